@@ -8,27 +8,167 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	ApiToken           string `envconfig:"API_TOKEN" validate:"required"`
-	ApiPort            int    `envconfig:"API_PORT"`
-	TLSCertFile        string `envconfig:"TLS_CERT_FILE"`
-	TLSKeyFile         string `envconfig:"TLS_KEY_FILE"`
-	EnableTLS          bool   `envconfig:"ENABLE_TLS"`
-	CacheRetentionDays int    `envconfig:"CACHE_RETENTION_DAYS"`
-	Environment        string `envconfig:"ENVIRONMENT"`
-	ContainerRuntime   string `envconfig:"CONTAINER_RUNTIME"`
-	ContainerNetwork   string `envconfig:"CONTAINER_NETWORK"`
-	LogFilePath        string `envconfig:"LOG_FILE_PATH"`
-	AWSRegion          string `envconfig:"AWS_REGION"`
-	AWSEndpointUrl     string `envconfig:"AWS_ENDPOINT_URL"`
-	AWSAccessKeyId     string `envconfig:"AWS_ACCESS_KEY_ID"`
-	AWSSecretAccessKey string `envconfig:"AWS_SECRET_ACCESS_KEY"`
-	AWSDefaultBucket   string `envconfig:"AWS_DEFAULT_BUCKET"`
+	ApiToken            string        `envconfig:"API_TOKEN" secret:"true" validate:"required_without=ApiTokensFile"`
+	ApiTokensFile       string        `envconfig:"API_TOKENS_FILE"`
+	ApiPort             int           `envconfig:"API_PORT"`
+	ApiListenAddresses  []string      `envconfig:"API_LISTEN_ADDRESSES"`
+	ApiMaxBodySize      int64         `envconfig:"API_MAX_REQUEST_BODY_SIZE" validate:"min=0"`
+	ApiHeaderTimeout    time.Duration `envconfig:"API_READ_HEADER_TIMEOUT"`
+	ApiIdleTimeout      time.Duration `envconfig:"API_IDLE_TIMEOUT"`
+	ApiTCPKeepAlive     time.Duration `envconfig:"API_TCP_KEEPALIVE"`
+	StreamKeepalive     time.Duration `envconfig:"API_STREAM_KEEPALIVE_INTERVAL"`
+	TLSCertFile         string        `envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile          string        `envconfig:"TLS_KEY_FILE"`
+	TLSClientCAFile     string        `envconfig:"TLS_CLIENT_CA_FILE"`
+	EnableTLS           bool          `envconfig:"ENABLE_TLS"`
+	CacheRetention      []string      `envconfig:"CACHE_RETENTION"`
+	CacheRetentionDays  int           `envconfig:"CACHE_RETENTION_DAYS" validate:"min=0"`
+	CacheMaxEntries     int           `envconfig:"CACHE_MAX_ENTRIES" validate:"min=0"`
+	CacheHalfLife       time.Duration `envconfig:"CACHE_ACTIVITY_HALF_LIFE"`
+	CacheCleanupPeriod  time.Duration `envconfig:"CACHE_CLEANUP_INTERVAL"`
+	CacheBackend        string        `envconfig:"CACHE_BACKEND" validate:"omitempty,oneof=memory file redis"`
+	CacheFilePath       string        `envconfig:"CACHE_FILE_PATH"`
+	CacheSnapshotPath   string        `envconfig:"CACHE_SNAPSHOT_PATH"`
+	CacheSaveInterval   time.Duration `envconfig:"CACHE_SNAPSHOT_INTERVAL"`
+	CacheRedisUrl       string        `envconfig:"CACHE_REDIS_URL" secret:"true" validate:"required_if=CacheBackend redis"`
+	CacheRedisPrefix    string        `envconfig:"CACHE_REDIS_KEY_PREFIX"`
+	Environment         string        `envconfig:"ENVIRONMENT"`
+	ContainerRuntime    string        `envconfig:"CONTAINER_RUNTIME"`
+	ContainerNetwork    string        `envconfig:"CONTAINER_NETWORK"`
+	RootlessMode        bool          `envconfig:"ROOTLESS_MODE"`
+	ContainerBackend    string        `envconfig:"CONTAINER_BACKEND" validate:"omitempty,oneof=docker"`
+	LogFilePath         string        `envconfig:"LOG_FILE_PATH"`
+	AWSRegion           string        `envconfig:"AWS_REGION"`
+	AWSEndpointUrl      string        `envconfig:"AWS_ENDPOINT_URL"`
+	AWSAccessKeyId      string        `envconfig:"AWS_ACCESS_KEY_ID" secret:"true"`
+	AWSSecretAccessKey  string        `envconfig:"AWS_SECRET_ACCESS_KEY" secret:"true"`
+	AWSDefaultBucket    string        `envconfig:"AWS_DEFAULT_BUCKET"`
+	StorageProvider     string        `envconfig:"OBJECT_STORAGE_PROVIDER" validate:"omitempty,oneof=s3 gcs azure"`
+	StorageBucket       string        `envconfig:"OBJECT_STORAGE_BUCKET"`
+	GCSEndpointUrl      string        `envconfig:"GCS_ENDPOINT_URL"`
+	GCSAccessKeyId      string        `envconfig:"GCS_HMAC_ACCESS_KEY_ID" secret:"true"`
+	GCSSecretAccessKey  string        `envconfig:"GCS_HMAC_SECRET" secret:"true"`
+	AzureEndpointUrl    string        `envconfig:"AZURE_STORAGE_ENDPOINT"`
+	AzureAccountName    string        `envconfig:"AZURE_STORAGE_ACCOUNT"`
+	AzureAccountKey     string        `envconfig:"AZURE_STORAGE_KEY" secret:"true"`
+	RegistryMirrors     []string      `envconfig:"REGISTRY_MIRRORS"`
+	PullThroughCacheUrl string        `envconfig:"PULL_THROUGH_CACHE_URL"`
+	OtelEndpoint        string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OtelServiceName     string        `envconfig:"OTEL_SERVICE_NAME"`
+	DrainOnSigterm      bool          `envconfig:"DRAIN_ON_SIGTERM"`
+	DrainTimeout        time.Duration `envconfig:"DRAIN_TIMEOUT"`
+	ProxyTokenSecret    string        `envconfig:"PROXY_TOKEN_SECRET" secret:"true"`
+	ProxyTokenTTL       time.Duration `envconfig:"PROXY_TOKEN_TTL"`
+	ProxyPublicUrl      string        `envconfig:"PROXY_PUBLIC_URL"`
+	PreviewDomain       string        `envconfig:"PREVIEW_DOMAIN" validate:"required_with=PreviewListenAddr PreviewTLSCertFile PreviewAcmeHook"`
+	PreviewUrlScheme    string        `envconfig:"PREVIEW_URL_SCHEME" validate:"omitempty,oneof=http https"`
+	PreviewListenAddr   string        `envconfig:"PREVIEW_LISTEN_ADDRESS"`
+	DebugListenAddr     string        `envconfig:"DEBUG_LISTEN_ADDRESS"`
+	PreviewTLSCertFile  string        `envconfig:"PREVIEW_TLS_CERT_FILE" validate:"required_with=PreviewTLSKeyFile,excluded_with=PreviewAcmeHook"`
+	PreviewTLSKeyFile   string        `envconfig:"PREVIEW_TLS_KEY_FILE" validate:"required_with=PreviewTLSCertFile"`
+	PreviewAcmeHook     string        `envconfig:"PREVIEW_ACME_DNS_HOOK"`
+	PreviewAcmeEmail    string        `envconfig:"PREVIEW_ACME_EMAIL"`
+	PreviewAcmeUrl      string        `envconfig:"PREVIEW_ACME_DIRECTORY_URL"`
+	PreviewAcmeDir      string        `envconfig:"PREVIEW_ACME_CACHE_DIR"`
+	ExposedPortsPath    string        `envconfig:"EXPOSED_PORTS_FILE_PATH"`
+	SshGatewayPort      int           `envconfig:"SSH_GATEWAY_PORT" validate:"min=0,max=65535"`
+	SshGatewayHost      string        `envconfig:"SSH_GATEWAY_PUBLIC_HOST"`
+	SshHostKeyPath      string        `envconfig:"SSH_GATEWAY_HOST_KEY_PATH"`
+	SshAccessPath       string        `envconfig:"SSH_ACCESS_FILE_PATH"`
+	SshAccessTTL        time.Duration `envconfig:"SSH_ACCESS_TTL"`
+	ProxyCacheSize      int64         `envconfig:"PROXY_CACHE_MAX_SIZE" validate:"min=0"`
+	ProxyCacheEntrySize int64         `envconfig:"PROXY_CACHE_MAX_ENTRY_SIZE" validate:"min=0"`
+	ProxyCacheDir       string        `envconfig:"PROXY_CACHE_DIR"`
+	ProxyStreamIdle     time.Duration `envconfig:"PROXY_STREAM_IDLE_TIMEOUT"`
+	DaemonVersion       string        `envconfig:"DAEMON_VERSION"`
+	DaemonChecksums     []string      `envconfig:"DAEMON_CHECKSUMS" validate:"required_with=DaemonVersion"`
+	DaemonCacheDir      string        `envconfig:"DAEMON_CACHE_DIR"`
+	WebhookUrl          string        `envconfig:"WEBHOOK_URL"`
+	WebhookSecret       string        `envconfig:"WEBHOOK_SECRET" secret:"true"`
+	WebhookMaxRetries   int           `envconfig:"WEBHOOK_MAX_RETRIES"`
+	TrivyPath           string        `envconfig:"TRIVY_PATH"`
+	CosignPath          string        `envconfig:"COSIGN_PATH"`
+	CosignPublicKeys    []string      `envconfig:"COSIGN_PUBLIC_KEYS"`
+	CosignIdentities    []string      `envconfig:"COSIGN_KEYLESS_IDENTITIES"`
+	NetworkIsolation    bool          `envconfig:"SANDBOX_NETWORK_ISOLATION"`
+	DiskSoftThreshold   int           `envconfig:"DISK_USAGE_SOFT_THRESHOLD" validate:"min=0,max=100"`
+	UsageInterval       time.Duration `envconfig:"USAGE_RECORD_INTERVAL"`
+	UsageRetention      time.Duration `envconfig:"USAGE_RECORD_RETENTION"`
+	UsagePushUrl        string        `envconfig:"USAGE_PUSH_URL"`
+	UsagePushSecret     string        `envconfig:"USAGE_PUSH_SECRET" secret:"true" validate:"required_with=UsagePushUrl"`
+	ControlPlaneUrl     string        `envconfig:"CONTROL_PLANE_URL"`
+	ControlPlaneToken   string        `envconfig:"CONTROL_PLANE_TOKEN" secret:"true" validate:"required_with=ControlPlaneUrl"`
+	RunnerName          string        `envconfig:"RUNNER_NAME"`
+	RunnerAddress       string        `envconfig:"RUNNER_ADDRESS" validate:"required_with=ControlPlaneUrl"`
+	RunnerLabels        []string      `envconfig:"RUNNER_LABELS"`
+	HeartbeatInterval   time.Duration `envconfig:"HEARTBEAT_INTERVAL"`
+	SnapshotGCInterval  time.Duration `envconfig:"SNAPSHOT_GC_INTERVAL"`
+	SnapshotGCMinAge    time.Duration `envconfig:"SNAPSHOT_GC_MIN_AGE"`
+	SnapshotGCKeepList  []string      `envconfig:"SNAPSHOT_GC_KEEP_LIST"`
+	SnapshotPinsPath    string        `envconfig:"SNAPSHOT_PINS_FILE_PATH"`
+	SandboxEnvDir       string        `envconfig:"SANDBOX_ENV_DIR"`
+	LifecycleHooksDir   string        `envconfig:"LIFECYCLE_HOOKS_DIR"`
+	SecurityProfilesDir string        `envconfig:"SECURITY_PROFILES_DIR"`
+	AppArmorParserPath  string        `envconfig:"APPARMOR_PARSER_PATH"`
+	PrivilegePolicy     string        `envconfig:"SANDBOX_PRIVILEGE_POLICY" validate:"omitempty,oneof=default opt-in forbidden"`
+	AllowedCapabilities []string      `envconfig:"SANDBOX_ALLOWED_CAPABILITIES"`
+	BuildLogInterval    time.Duration `envconfig:"BUILD_LOG_RETENTION_INTERVAL"`
+	BuildLogMaxSize     int64         `envconfig:"BUILD_LOG_MAX_SIZE" validate:"min=0"`
+	BuildLogMaxAge      time.Duration `envconfig:"BUILD_LOG_MAX_AGE"`
+	BuildLogMaxTotal    int64         `envconfig:"BUILD_LOG_MAX_TOTAL_SIZE" validate:"min=0"`
+	BuildLogArchive     bool          `envconfig:"BUILD_LOG_ARCHIVE"`
+	BuildLogPrefix      string        `envconfig:"BUILD_LOG_OBJECT_PREFIX"`
+	MaxConcurrentPulls  int           `envconfig:"MAX_CONCURRENT_PULLS" validate:"min=0"`
+	MaxQueuedPulls      int           `envconfig:"MAX_QUEUED_PULLS" validate:"min=0"`
+	CreateConcurrency   int           `envconfig:"MAX_CONCURRENT_CREATES" validate:"min=0"`
+	BackupConcurrency   int           `envconfig:"MAX_CONCURRENT_BACKUPS" validate:"min=0"`
+	ExecConcurrency     int           `envconfig:"MAX_CONCURRENT_EXECS" validate:"min=0"`
+	ParallelLayerPulls  int           `envconfig:"PARALLEL_LAYER_DOWNLOADS" validate:"min=0"`
+	RegistryRetries     int           `envconfig:"REGISTRY_RETRY_ATTEMPTS" validate:"min=0"`
+	RegistryBackoff     time.Duration `envconfig:"REGISTRY_RETRY_BACKOFF"`
+	RegistryMaxBackoff  time.Duration `envconfig:"REGISTRY_RETRY_MAX_BACKOFF"`
+	RateLimit           float64       `envconfig:"RATE_LIMIT" validate:"min=0"`
+	RateLimitBurst      int           `envconfig:"RATE_LIMIT_BURST" validate:"min=0"`
+	RateLimitRoutes     []string      `envconfig:"RATE_LIMIT_ROUTES"`
+	AuditLogPath        string        `envconfig:"AUDIT_LOG_PATH"`
+	AuditShipInterval   time.Duration `envconfig:"AUDIT_LOG_SHIP_INTERVAL"`
+	AuditObjectPrefix   string        `envconfig:"AUDIT_LOG_OBJECT_PREFIX"`
+	ApiRouteScopes      []string      `envconfig:"API_ROUTE_SCOPES"`
+	IdempotencyKeyTTL   time.Duration `envconfig:"IDEMPOTENCY_KEY_TTL"`
+	LogLevel            string        `envconfig:"LOG_LEVEL"`
+	LogModuleLevels     []string      `envconfig:"LOG_MODULE_LEVELS"`
+	LogShipSink         string        `envconfig:"LOG_SHIP_SINK" validate:"omitempty,oneof=loki syslog s3"`
+	LogShipLokiUrl      string        `envconfig:"LOG_SHIP_LOKI_URL" secret:"true" validate:"required_if=LogShipSink loki"`
+	LogShipLokiTenant   string        `envconfig:"LOG_SHIP_LOKI_TENANT"`
+	LogShipSyslogAddr   string        `envconfig:"LOG_SHIP_SYSLOG_ADDRESS"`
+	LogShipPrefix       string        `envconfig:"LOG_SHIP_OBJECT_PREFIX"`
+	LogShipBufferSize   int           `envconfig:"LOG_SHIP_BUFFER_SIZE" validate:"min=0"`
+	LogShipBatchSize    int           `envconfig:"LOG_SHIP_BATCH_SIZE" validate:"min=0"`
+	LogShipInterval     time.Duration `envconfig:"LOG_SHIP_FLUSH_INTERVAL"`
+	LogArchiveInterval  time.Duration `envconfig:"LOG_SHIP_ARCHIVE_INTERVAL"`
+	SandboxTTLWarning   time.Duration `envconfig:"SANDBOX_TTL_WARNING_LEAD_TIME"`
+	MaintenanceLeadTime time.Duration `envconfig:"MAINTENANCE_NOTIFY_LEAD_TIME"`
+	SandboxStopTimeout  time.Duration `envconfig:"SANDBOX_STOP_TIMEOUT"`
+	RestartLoopWindow   time.Duration `envconfig:"RESTART_CRASH_LOOP_WINDOW"`
+	VaultAddress        string        `envconfig:"VAULT_ADDR"`
+	VaultToken          string        `envconfig:"VAULT_TOKEN" secret:"true"`
+	VaultNamespace      string        `envconfig:"VAULT_NAMESPACE"`
+	VaultRenewInterval  time.Duration `envconfig:"VAULT_TOKEN_RENEW_INTERVAL"`
+	OrphanPolicy        string        `envconfig:"RECONCILE_ORPHAN_POLICY" validate:"omitempty,oneof=adopt remove ignore"`
+	ReconcileInterval   time.Duration `envconfig:"RECONCILE_INTERVAL"`
+	CleanupInterval     time.Duration `envconfig:"CLEANUP_INTERVAL"`
+	CleanupExitedAge    time.Duration `envconfig:"CLEANUP_EXITED_SANDBOX_MAX_AGE"`
+	CleanupVolumes      bool          `envconfig:"CLEANUP_DANGLING_VOLUMES"`
+	CleanupNetworks     bool          `envconfig:"CLEANUP_SANDBOX_NETWORKS"`
+	WarmPoolPath        string        `envconfig:"WARM_POOL_FILE_PATH"`
+	WarmPoolInterval    time.Duration `envconfig:"WARM_POOL_REFILL_INTERVAL"`
 }
 
 var DEFAULT_API_PORT int = 8080
@@ -40,23 +180,214 @@ func GetConfig() (*Config, error) {
 		return config, nil
 	}
 
-	config = &Config{}
+	loaded, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	config = loaded
+
+	return config, nil
+}
+
+// Load reads the configuration from the config file and the environment again,
+// the configuration returned by GetConfig is not changed
+func Load() (*Config, error) {
+	config := &Config{}
+
+	filePath := GetConfigFilePath()
+	if filePath != "" {
+		err := loadConfigFile(filePath, config)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	err := envconfig.Process("", config)
 	if err != nil {
 		return nil, err
 	}
 
+	err = resolveSecrets(config)
+	if err != nil {
+		return nil, err
+	}
+
 	var validate = validator.New()
 	err = validate.Struct(config)
 	if err != nil {
-		return nil, err
+		return nil, formatValidationError(err)
 	}
 
 	if config.ApiPort == 0 {
 		config.ApiPort = DEFAULT_API_PORT
 	}
 
+	if config.ApiHeaderTimeout == 0 {
+		config.ApiHeaderTimeout = 30 * time.Second
+	}
+
+	if config.StreamKeepalive == 0 {
+		config.StreamKeepalive = 30 * time.Second
+	}
+
+	if config.CacheBackend == "" {
+		config.CacheBackend = "memory"
+	}
+
+	// CACHE_RETENTION_DAYS is deprecated, it only ever covered destroyed sandboxes and CACHE_RETENTION takes precedence
+	if len(config.CacheRetention) == 0 && config.CacheRetentionDays > 0 {
+		config.CacheRetention = []string{fmt.Sprintf("destroyed=%dh", config.CacheRetentionDays*24)}
+	}
+
+	if config.StorageProvider == "" {
+		config.StorageProvider = "s3"
+	}
+
+	if config.StorageBucket == "" {
+		config.StorageBucket = config.AWSDefaultBucket
+	}
+
+	if config.DrainTimeout == 0 {
+		config.DrainTimeout = 30 * time.Minute
+	}
+
+	if config.ProxyTokenTTL == 0 {
+		config.ProxyTokenTTL = 24 * time.Hour
+	}
+
+	if config.DiskSoftThreshold == 0 {
+		config.DiskSoftThreshold = 90
+	}
+
+	if config.SnapshotGCMinAge == 0 {
+		config.SnapshotGCMinAge = 7 * 24 * time.Hour
+	}
+
+	if config.MaxConcurrentPulls == 0 {
+		config.MaxConcurrentPulls = 3
+	}
+
+	if config.RegistryRetries == 0 {
+		config.RegistryRetries = 3
+	}
+
+	if config.RegistryBackoff == 0 {
+		config.RegistryBackoff = 2 * time.Second
+	}
+
+	if config.RegistryMaxBackoff == 0 {
+		config.RegistryMaxBackoff = 30 * time.Second
+	}
+
+	if config.OtelServiceName == "" {
+		config.OtelServiceName = "daytona-runner"
+	}
+
+	if config.CacheFilePath == "" {
+		config.CacheFilePath = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "runner-cache.db")
+	}
+
+	if config.CacheRedisPrefix == "" {
+		config.CacheRedisPrefix = "daytona-runner:"
+	}
+
+	if config.CacheSaveInterval == 0 {
+		config.CacheSaveInterval = time.Minute
+	}
+
+	if config.SnapshotPinsPath == "" {
+		config.SnapshotPinsPath = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "snapshot-pins.json")
+	}
+
+	if config.PreviewUrlScheme == "" {
+		config.PreviewUrlScheme = "https"
+	}
+
+	if config.ExposedPortsPath == "" {
+		config.ExposedPortsPath = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "exposed-ports.json")
+	}
+
+	if config.PreviewAcmeDir == "" {
+		config.PreviewAcmeDir = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "preview-acme")
+	}
+
+	if config.SshHostKeyPath == "" {
+		config.SshHostKeyPath = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "ssh-host-key")
+	}
+
+	if config.SshAccessPath == "" {
+		config.SshAccessPath = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "ssh-access.json")
+	}
+
+	if config.SshAccessTTL == 0 {
+		config.SshAccessTTL = 24 * time.Hour
+	}
+
+	if config.DaemonCacheDir == "" {
+		config.DaemonCacheDir = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "daemon")
+	}
+
+	if config.ProxyCacheEntrySize == 0 {
+		config.ProxyCacheEntrySize = 8 * 1024 * 1024
+	}
+
+	if config.SandboxEnvDir == "" {
+		config.SandboxEnvDir = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "sandbox-env")
+	}
+
+	if config.LifecycleHooksDir == "" {
+		config.LifecycleHooksDir = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "lifecycle-hooks")
+	}
+
+	if config.SecurityProfilesDir == "" {
+		config.SecurityProfilesDir = filepath.Join(filepath.Dir(config.LogFilePath), "cache", "security-profiles")
+	}
+
+	if config.BuildLogInterval == 0 {
+		config.BuildLogInterval = time.Hour
+	}
+
+	if config.BuildLogMaxSize == 0 {
+		config.BuildLogMaxSize = 50 * 1024 * 1024
+	}
+
+	if config.BuildLogMaxAge == 0 {
+		config.BuildLogMaxAge = 30 * 24 * time.Hour
+	}
+
+	if config.BuildLogPrefix == "" {
+		config.BuildLogPrefix = "build-logs"
+	}
+
+	if config.AuditLogPath == "" {
+		config.AuditLogPath = filepath.Join(filepath.Dir(config.LogFilePath), "audit", "audit.jsonl")
+	}
+
+	if config.SandboxTTLWarning == 0 {
+		config.SandboxTTLWarning = 5 * time.Minute
+	}
+
+	if config.VaultRenewInterval == 0 {
+		config.VaultRenewInterval = time.Hour
+	}
+
+	if config.OrphanPolicy == "" {
+		config.OrphanPolicy = "adopt"
+	}
+
+	if config.ReconcileInterval == 0 {
+		config.ReconcileInterval = 5 * time.Minute
+	}
+
+	if config.AuditObjectPrefix == "" {
+		config.AuditObjectPrefix = "audit"
+	}
+
+	if config.WarmPoolInterval == 0 {
+		config.WarmPoolInterval = 30 * time.Second
+	}
+
 	return config, nil
 }
 
@@ -72,18 +403,79 @@ func GetEnvironment() string {
 	return config.Environment
 }
 
-func GetBuildLogFilePath(snapshotRef string) (string, error) {
-	buildId := snapshotRef
+// GetProxyPublicUrl returns the base URL clients reach the runner proxy at, empty if it is derived from requests
+func GetProxyPublicUrl() string {
+	return config.ProxyPublicUrl
+}
+
+// GetPreviewDomain returns the wildcard domain preview hostnames are below, empty if previews are disabled
+func GetPreviewDomain() string {
+	return config.PreviewDomain
+}
+
+func GetPreviewUrlScheme() string {
+	return config.PreviewUrlScheme
+}
+
+func GetSshGatewayPort() int {
+	return config.SshGatewayPort
+}
+
+// GetSshGatewayHost returns the host clients reach the SSH gateway at, empty if it is the host of the API
+func GetSshGatewayHost() string {
+	return config.SshGatewayHost
+}
+
+func GetSshAccessTTL() time.Duration {
+	return config.SshAccessTTL
+}
+
+// GetProxyStreamIdleTimeout returns how long proxied websockets and tunnels may go without traffic before they
+// are closed, 0 if they are never closed. Desktop ports are exempt.
+func GetProxyStreamIdleTimeout() time.Duration {
+	return config.ProxyStreamIdle
+}
+
+// GetStreamKeepaliveInterval returns how often idle event streams send a keepalive
+func GetStreamKeepaliveInterval() time.Duration {
+	return config.StreamKeepalive
+}
+
+// GetBuildLogDir returns the directory holding the build logs
+func GetBuildLogDir() (string, error) {
+	c, err := GetConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(c.LogFilePath), "builds"), nil
+}
+
+// GetBuildLogId returns the name of the build log of the snapshot below the build log directory
+func GetBuildLogId(snapshotRef string) string {
 	if colonIndex := strings.Index(snapshotRef, ":"); colonIndex != -1 {
-		buildId = snapshotRef[:colonIndex]
+		return snapshotRef[:colonIndex]
 	}
 
-	c, err := GetConfig()
+	return snapshotRef
+}
+
+// GetBuildLogPath returns the path of the build log without creating it
+func GetBuildLogPath(snapshotRef string) (string, error) {
+	dir, err := GetBuildLogDir()
 	if err != nil {
 		return "", err
 	}
 
-	logPath := filepath.Join(filepath.Dir(c.LogFilePath), "builds", buildId)
+	return filepath.Join(dir, GetBuildLogId(snapshotRef)), nil
+}
+
+// GetBuildLogFilePath returns the path of the build log and creates the log if it doesn't exist
+func GetBuildLogFilePath(snapshotRef string) (string, error) {
+	logPath, err := GetBuildLogPath(snapshotRef)
+	if err != nil {
+		return "", err
+	}
 
 	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
 		return "", fmt.Errorf("failed to create log directory: %w", err)
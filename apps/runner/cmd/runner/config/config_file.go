@@ -0,0 +1,210 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var configFilePath string
+
+// SetConfigFilePath overrides the CONFIG_FILE environment variable, it has to be called before GetConfig
+func SetConfigFilePath(path string) {
+	configFilePath = path
+}
+
+// GetConfigFilePath returns the config file the configuration is read from, empty when there is none
+func GetConfigFilePath() string {
+	if configFilePath != "" {
+		return configFilePath
+	}
+
+	return os.Getenv("CONFIG_FILE")
+}
+
+// loadConfigFile sets the fields of the config from a YAML or TOML file. Keys are the environment
+// variable names in any case, e.g. api_port, and environment variables override them.
+func loadConfigFile(path string, config *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := make(map[string]any)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		return fmt.Errorf("unsupported config file format %q, use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	fields := getConfigFields()
+
+	unknownKeys := make([]string, 0)
+	for key := range values {
+		if _, ok := fields[strings.ToUpper(key)]; !ok {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	if len(unknownKeys) > 0 {
+		slices.Sort(unknownKeys)
+		return fmt.Errorf("unknown keys in config file %s: %s", path, strings.Join(unknownKeys, ", "))
+	}
+
+	configValue := reflect.ValueOf(config).Elem()
+	for key, value := range values {
+		err = setConfigField(configValue.FieldByName(fields[strings.ToUpper(key)]), value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s in config file %s: %w", key, path, err)
+		}
+	}
+
+	return nil
+}
+
+// getConfigFields maps the environment variable names to the config field names
+func getConfigFields() map[string]string {
+	configType := reflect.TypeOf(Config{})
+
+	fields := make(map[string]string, configType.NumField())
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		fields[field.Tag.Get("envconfig")] = field.Name
+	}
+
+	return fields
+}
+
+func setConfigField(field reflect.Value, value any) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		raw, ok := value.(string)
+		if !ok {
+			return errors.New("expected a duration like 30s")
+		}
+
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		raw, ok := value.(string)
+		if !ok {
+			return errors.New("expected a string")
+		}
+		field.SetString(raw)
+	case reflect.Bool:
+		raw, ok := value.(bool)
+		if !ok {
+			return errors.New("expected true or false")
+		}
+		field.SetBool(raw)
+	case reflect.Int, reflect.Int64:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) {
+			return errors.New("expected an integer")
+		}
+		field.SetInt(int64(number))
+	case reflect.Float64:
+		number, ok := toFloat(value)
+		if !ok {
+			return errors.New("expected a number")
+		}
+		field.SetFloat(number)
+	case reflect.Slice:
+		list, ok := value.([]any)
+		if !ok {
+			return errors.New("expected a list")
+		}
+
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			switch item := item.(type) {
+			case string:
+				items = append(items, item)
+			case int, int64, float64, bool:
+				items = append(items, fmt.Sprint(item))
+			default:
+				return errors.New("expected a list of strings")
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		return number, err == nil
+	}
+
+	return 0, false
+}
+
+// formatValidationError names the environment variables of the invalid fields instead of the Go field names
+func formatValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	configType := reflect.TypeOf(Config{})
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		name := fieldError.Field()
+		field, ok := configType.FieldByName(fieldError.StructField())
+		if ok {
+			name = field.Tag.Get("envconfig")
+		}
+
+		message := fmt.Sprintf("%s failed the %s validation", name, fieldError.Tag())
+		if fieldError.Param() != "" {
+			// Params of cross field validations name another field
+			param := fieldError.Param()
+			if paramField, ok := configType.FieldByName(param); ok {
+				param = paramField.Tag.Get("envconfig")
+			}
+			message = fmt.Sprintf("%s failed the %s=%s validation", name, fieldError.Tag(), param)
+		}
+		messages = append(messages, message)
+	}
+
+	return fmt.Errorf("invalid configuration: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package config
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/joho/godotenv"
+)
+
+var (
+	envFileMutex sync.Mutex
+	// Variables set by the process environment always take precedence over the env file
+	processEnvKeys map[string]bool
+	envFileKeys    map[string]bool
+)
+
+// LoadEnvFile sets the variables of the env file that are not set by the process environment.
+// Loading the file again applies its changes, variables removed from it are unset.
+func LoadEnvFile(path string) error {
+	envFileMutex.Lock()
+	defer envFileMutex.Unlock()
+
+	if processEnvKeys == nil {
+		processEnvKeys = make(map[string]bool)
+		for _, variable := range os.Environ() {
+			key, _, _ := strings.Cut(variable, "=")
+			processEnvKeys[key] = true
+		}
+	}
+
+	values, err := godotenv.Read(path)
+	if err != nil {
+		return err
+	}
+
+	for key := range envFileKeys {
+		if _, ok := values[key]; !ok {
+			os.Unsetenv(key)
+		}
+	}
+
+	envFileKeys = make(map[string]bool, len(values))
+	for key, value := range values {
+		if processEnvKeys[key] {
+			continue
+		}
+
+		os.Setenv(key, value)
+		envFileKeys[key] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/secrets"
+)
+
+// resolveSecrets reads the fields tagged as secret from <VARIABLE>_FILE when the variable is not set,
+// then fetches the values referencing a Vault secret
+func resolveSecrets(config *Config) error {
+	configValue := reflect.ValueOf(config).Elem()
+	configType := configValue.Type()
+
+	secretFields := make([]reflect.Value, 0)
+	names := make([]string, 0)
+
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		if field.Tag.Get("secret") != "true" {
+			continue
+		}
+
+		name := field.Tag.Get("envconfig")
+		value := configValue.Field(i)
+
+		secretFile, fileSet := os.LookupEnv(name + "_FILE")
+		if value.String() == "" && fileSet {
+			content, err := os.ReadFile(secretFile)
+			if err != nil {
+				return fmt.Errorf("failed to read %s_FILE: %w", name, err)
+			}
+
+			value.SetString(strings.TrimRight(string(content), "\r\n"))
+		}
+
+		secretFields = append(secretFields, value)
+		names = append(names, name)
+	}
+
+	var vaultClient *secrets.VaultClient
+
+	for i, value := range secretFields {
+		secretPath, key, isReference, err := secrets.ParseVaultReference(value.String())
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", names[i], err)
+		}
+		if !isReference {
+			continue
+		}
+
+		if vaultClient == nil {
+			vaultClient, err = GetVaultClient(config)
+			if err != nil {
+				return fmt.Errorf("%s references a Vault secret: %w", names[i], err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		secret, err := vaultClient.ReadSecret(ctx, secretPath, key)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", names[i], err)
+		}
+
+		value.SetString(secret)
+	}
+
+	return nil
+}
+
+// GetVaultClient returns a client for the Vault configured by the config
+func GetVaultClient(config *Config) (*secrets.VaultClient, error) {
+	if config.VaultAddress == "" || config.VaultToken == "" {
+		return nil, errors.New("VAULT_ADDR and VAULT_TOKEN are required to read Vault secrets")
+	}
+
+	if strings.HasPrefix(config.VaultToken, secrets.VaultReferencePrefix) {
+		return nil, errors.New("VAULT_TOKEN can't reference a Vault secret")
+	}
+
+	return secrets.NewVaultClient(config.VaultAddress, config.VaultToken, config.VaultNamespace), nil
+}
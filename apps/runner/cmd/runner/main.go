@@ -5,10 +5,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	golog "log"
@@ -16,41 +21,146 @@ import (
 	"github.com/daytonaio/runner/cmd/runner/config"
 	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/api"
+	"github.com/daytonaio/runner/pkg/apitoken"
+	"github.com/daytonaio/runner/pkg/audit"
+	"github.com/daytonaio/runner/pkg/backend"
 	"github.com/daytonaio/runner/pkg/cache"
 	"github.com/daytonaio/runner/pkg/daemon"
 	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/idempotency"
+	"github.com/daytonaio/runner/pkg/lifecycle"
+	"github.com/daytonaio/runner/pkg/logging"
+	"github.com/daytonaio/runner/pkg/logship"
 	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
 	"github.com/daytonaio/runner/pkg/netrules"
+	"github.com/daytonaio/runner/pkg/previewtls"
+	"github.com/daytonaio/runner/pkg/proxycache"
+	"github.com/daytonaio/runner/pkg/proxytoken"
+	"github.com/daytonaio/runner/pkg/ratelimit"
 	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/sandboxenv"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+	"github.com/daytonaio/runner/pkg/securityprofile"
 	"github.com/daytonaio/runner/pkg/services"
+	"github.com/daytonaio/runner/pkg/sshgateway"
+	"github.com/daytonaio/runner/pkg/storage"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/daytonaio/runner/pkg/volumesync"
 	"github.com/docker/docker/client"
-	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
 
-	"github.com/rs/zerolog"
-	zlog "github.com/rs/zerolog/log"
-
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
 )
 
 func main() {
+	configFile := flag.String("config", "", "Path of a YAML or TOML config file, environment variables override its settings")
+	flag.Parse()
+
+	if *configFile != "" {
+		config.SetConfigFilePath(*configFile)
+	}
+
 	cfg, err := config.GetConfig()
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
+	// The log levels may come from the config file which is not read before main
+	if cfg.LogLevel != "" {
+		logLevel, err := logrus.ParseLevel(cfg.LogLevel)
+		if err == nil {
+			logging.SetLevel(logLevel)
+		}
+	}
+
+	moduleLevels, err := logging.ParseModuleLevels(cfg.LogModuleLevels)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	logging.SetModuleLevels(moduleLevels)
+
+	logShipper, err := logship.NewShipperFromConfig(cfg)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	logShipper.Start()
+	// Deferred first so the logs of the shutdown are shipped as well
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		logShipper.Close(ctx)
+	}()
+
+	var previewCertificates *previewtls.Manager
+	if cfg.PreviewTLSCertFile != "" {
+		previewCertificates, err = previewtls.NewFileManager(cfg.PreviewDomain, cfg.PreviewTLSCertFile, cfg.PreviewTLSKeyFile)
+	} else if cfg.PreviewAcmeHook != "" {
+		previewCertificates, err = previewtls.NewAcmeManager(previewtls.AcmeConfig{
+			Domain:       cfg.PreviewDomain,
+			DirectoryUrl: cfg.PreviewAcmeUrl,
+			Email:        cfg.PreviewAcmeEmail,
+			DNSHook:      cfg.PreviewAcmeHook,
+			CacheDir:     cfg.PreviewAcmeDir,
+		})
+	}
+	if err != nil {
+		log.Errorf("Failed to set up preview certificates: %v", err)
+		return
+	}
+
 	apiServer := api.NewApiServer(api.ApiServerConfig{
-		ApiPort:     cfg.ApiPort,
-		TLSCertFile: cfg.TLSCertFile,
-		TLSKeyFile:  cfg.TLSKeyFile,
-		EnableTLS:   cfg.EnableTLS,
+		ApiPort:              cfg.ApiPort,
+		ListenAddresses:      cfg.ApiListenAddresses,
+		TLSCertFile:          cfg.TLSCertFile,
+		TLSKeyFile:           cfg.TLSKeyFile,
+		TLSClientCAFile:      cfg.TLSClientCAFile,
+		EnableTLS:            cfg.EnableTLS,
+		MaxRequestBodySize:   cfg.ApiMaxBodySize,
+		ReadHeaderTimeout:    cfg.ApiHeaderTimeout,
+		IdleTimeout:          cfg.ApiIdleTimeout,
+		TCPKeepAlive:         cfg.ApiTCPKeepAlive,
+		PreviewListenAddress: cfg.PreviewListenAddr,
+		PreviewCertificates:  previewCertificates,
+		DebugListenAddress:   cfg.DebugListenAddr,
 	})
 
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), telemetry.TracingConfig{
+		Endpoint:    cfg.OtelEndpoint,
+		ServiceName: cfg.OtelServiceName,
+	})
 	if err != nil {
 		log.Error(err)
 		return
 	}
+	defer func() {
+		err := shutdownTracing(context.Background())
+		if err != nil {
+			log.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation(), client.WithTraceProvider(otel.GetTracerProvider()))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	info, err := cli.Info(context.Background())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	rootless := cfg.RootlessMode || docker.IsRootlessEngine(info)
+	if rootless {
+		log.Warn("Running against a rootless container engine, sandbox firewall rules and bandwidth limits are not enforced")
+	}
 
 	// Initialize net rules manager
 	persistent := cfg.Environment == "production"
@@ -60,6 +170,12 @@ func main() {
 		return
 	}
 
+	sandboxNetwork := sandboxnet.NewManager(sandboxnet.ManagerConfig{
+		ApiClient:       cli,
+		NetRulesManager: netRulesManager,
+		Isolation:       cfg.NetworkIsolation,
+	})
+
 	// Start Docker events monitor
 	monitor := docker.NewDockerMonitor(cli, netRulesManager)
 	go func() {
@@ -70,98 +186,600 @@ func main() {
 	}()
 	defer monitor.Stop()
 
-	runnerCache := cache.NewInMemoryRunnerCache(cache.InMemoryRunnerCacheConfig{
-		Cache:         make(map[string]*models.CacheData),
-		RetentionDays: cfg.CacheRetentionDays,
-	})
+	if cfg.CacheRetentionDays > 0 {
+		log.Warn("CACHE_RETENTION_DAYS is deprecated, use CACHE_RETENTION, e.g. destroyed=168h")
+	}
+
+	cacheRetention, err := cache.ParseRetention(cfg.CacheRetention)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	cacheEviction := cache.EvictionPolicy{
+		Retention:        cacheRetention,
+		MaxEntries:       cfg.CacheMaxEntries,
+		ActivityHalfLife: cfg.CacheHalfLife,
+		CleanupInterval:  cfg.CacheCleanupPeriod,
+	}
+
+	var runnerCache cache.IRunnerCache
+	// fileCacheConfig is set when the cache is persisted, the memory backend with a snapshot path only writes
+	// the changes on the snapshot interval
+	var fileCacheConfig *cache.FileRunnerCacheConfig
+	switch cfg.CacheBackend {
+	case "file":
+		fileCacheConfig = &cache.FileRunnerCacheConfig{
+			FilePath: cfg.CacheFilePath,
+			Eviction: cacheEviction,
+		}
+	case "redis":
+		redisCtx, redisCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		runnerCache, err = cache.NewRedisRunnerCache(redisCtx, cache.RedisRunnerCacheConfig{
+			Url:       cfg.CacheRedisUrl,
+			KeyPrefix: cfg.CacheRedisPrefix,
+			Retention: cacheRetention,
+		})
+		redisCancel()
+		if err != nil {
+			log.Error(err)
+			return
+		}
+	default:
+		if cfg.CacheSnapshotPath != "" {
+			fileCacheConfig = &cache.FileRunnerCacheConfig{
+				FilePath:      cfg.CacheSnapshotPath,
+				FlushInterval: cfg.CacheSaveInterval,
+				Eviction:      cacheEviction,
+			}
+		} else {
+			runnerCache = cache.NewInMemoryRunnerCache(cache.InMemoryRunnerCacheConfig{
+				Cache:    make(map[string]*models.CacheData),
+				Eviction: cacheEviction,
+			})
+		}
+	}
+
+	if fileCacheConfig != nil {
+		fileCache, err := cache.NewFileRunnerCache(*fileCacheConfig)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		// Runs after the API server stopped so the last changes are written
+		defer func() {
+			err := fileCache.Close()
+			if err != nil {
+				log.Errorf("Failed to close cache file: %v", err)
+			}
+		}()
+		runnerCache = fileCache
+	}
+
+	eventBroker := events.NewBroker()
+	runnerCache = cache.NewEventRunnerCache(runnerCache, eventBroker)
 
 	// Start cleanup job with a cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if previewCertificates != nil {
+		previewCertificates.Start(ctx)
+	}
+
+	if cfg.VaultAddress != "" && cfg.VaultToken != "" {
+		vaultClient, err := config.GetVaultClient(cfg)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		vaultClient.StartRenewal(ctx, cfg.VaultRenewInterval)
+	}
+
+	if cfg.WebhookUrl != "" {
+		webhookDispatcher, err := events.NewWebhookDispatcher(events.WebhookConfig{
+			Url:           cfg.WebhookUrl,
+			Secret:        cfg.WebhookSecret,
+			MaxRetries:    cfg.WebhookMaxRetries,
+			AllowInsecure: cfg.Environment == "development",
+		})
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		webhookDispatcher.Start(ctx, eventBroker)
+	}
+
 	runnerCache.Cleanup(ctx)
 
-	daemonPath, err := daemon.WriteStaticBinary("daemon-amd64")
+	sandboxNetwork.StartDomainRefresh(ctx)
+
+	pluginPath, err := daemon.WriteStaticBinary("daytona-computer-use")
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	pluginPath, err := daemon.WriteStaticBinary("daytona-computer-use")
+	var volumeSyncer *volumesync.Syncer
+	objectStore, err := storage.NewObjectStore(cfg)
+	if err == nil {
+		volumeSyncer, err = volumesync.NewSyncer(volumesync.SyncerConfig{
+			Store: objectStore,
+		})
+	}
+	if err != nil && !errors.Is(err, storage.ErrObjectStorageNotConfigured) {
+		log.Errorf("Volume sync disabled: %v", err)
+	}
+
+	var daemonPaths map[string]string
+	if cfg.DaemonVersion != "" {
+		if objectStore == nil {
+			log.Errorf("Fetching daemon %s requires object storage", cfg.DaemonVersion)
+			return
+		}
+		var checksums map[string]string
+		checksums, err = daemon.ParseChecksums(cfg.DaemonChecksums)
+		if err == nil {
+			daemonPaths, err = daemon.FetchDaemonBinaries(ctx, daemon.FetchConfig{
+				Store:     objectStore,
+				Bucket:    cfg.StorageBucket,
+				Version:   cfg.DaemonVersion,
+				Checksums: checksums,
+				CacheDir:  cfg.DaemonCacheDir,
+			})
+		}
+	} else {
+		daemonPaths, err = daemon.WriteDaemonBinaries()
+	}
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
+	// AppArmor profiles don't survive a reboot of the host
+	securityProfiles := securityprofile.NewStore(cfg.SecurityProfilesDir, cfg.AppArmorParserPath)
+	err = securityProfiles.LoadAppArmorProfiles(ctx)
+	if err != nil {
+		log.Errorf("Failed to load AppArmor profiles: %v", err)
+	}
+
 	dockerClient := docker.NewDockerClient(docker.DockerClientConfig{
 		ApiClient:             cli,
 		Cache:                 runnerCache,
-		LogWriter:             os.Stdout,
+		LogWriter:             io.MultiWriter(os.Stdout, logShipper.Writer(logship.SOURCE_OPERATION, nil)),
 		AWSRegion:             cfg.AWSRegion,
 		AWSEndpointUrl:        cfg.AWSEndpointUrl,
 		AWSAccessKeyId:        cfg.AWSAccessKeyId,
 		AWSSecretAccessKey:    cfg.AWSSecretAccessKey,
-		DaemonPath:            daemonPath,
+		DaemonPaths:           daemonPaths,
 		ComputerUsePluginPath: pluginPath,
 		NetRulesManager:       netRulesManager,
+		SandboxNetwork:        sandboxNetwork,
+		RegistryMirrors:       cfg.RegistryMirrors,
+		PullThroughCacheUrl:   cfg.PullThroughCacheUrl,
+		VolumeSyncer:          volumeSyncer,
+		Events:                eventBroker,
+		TrivyPath:             cfg.TrivyPath,
+		MaxConcurrentPulls:    cfg.MaxConcurrentPulls,
+		MaxQueuedPulls:        cfg.MaxQueuedPulls,
+		MaxConcurrentCreates:  cfg.CreateConcurrency,
+		MaxConcurrentBackups:  cfg.BackupConcurrency,
+		MaxConcurrentExecs:    cfg.ExecConcurrency,
+		Rootless:              rootless,
+		SandboxEnv:            sandboxenv.NewStore(cfg.SandboxEnvDir),
+		LifecycleHooks:        lifecycle.NewStore(cfg.LifecycleHooksDir),
+		SecurityProfiles:      securityProfiles,
+		LogShipper:            logShipper,
+		StopTimeout:           cfg.SandboxStopTimeout,
+		ParallelLayerPulls:    cfg.ParallelLayerPulls,
+		RegistryRetry: docker.RegistryRetryPolicy{
+			MaxAttempts:    cfg.RegistryRetries,
+			InitialBackoff: cfg.RegistryBackoff,
+			MaxBackoff:     cfg.RegistryMaxBackoff,
+		},
+		SignatureVerification: docker.SignatureVerificationConfig{
+			CosignPath:        cfg.CosignPath,
+			PublicKeys:        cfg.CosignPublicKeys,
+			KeylessIdentities: cfg.CosignIdentities,
+		},
+		PrivilegePolicy: docker.PrivilegePolicy{
+			Mode:                cfg.PrivilegePolicy,
+			AllowedCapabilities: cfg.AllowedCapabilities,
+		},
 	})
 
-	sandboxService := services.NewSandboxService(runnerCache, dockerClient)
+	containerBackend, err := backend.New(cfg.ContainerBackend, dockerClient)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	sandboxService := services.NewSandboxService(runnerCache, dockerClient, containerBackend)
+
+	reconcileService := services.NewReconcileService(services.ReconcileServiceConfig{
+		Cache:          runnerCache,
+		Docker:         dockerClient,
+		Backend:        containerBackend,
+		SandboxService: sandboxService,
+		OrphanPolicy:   enums.OrphanPolicy(cfg.OrphanPolicy),
+		Interval:       cfg.ReconcileInterval,
+	})
+	reconcileService.StartReconciliation(ctx)
 
 	metricsService := services.NewMetricsService(dockerClient, runnerCache)
 	metricsService.StartMetricsCollection(ctx)
 
-	_ = runner.GetInstance(&runner.RunnerInstanceConfig{
+	usageMeter, err := services.NewUsageMeter(services.UsageMeterConfig{
+		RecordInterval: cfg.UsageInterval,
+		Retention:      cfg.UsageRetention,
+		PushUrl:        cfg.UsagePushUrl,
+		PushSecret:     cfg.UsagePushSecret,
+		AllowInsecure:  cfg.Environment == "development",
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	usageMeter.StartPushing(ctx)
+
+	sandboxMetricsCollector := services.NewSandboxMetricsCollector(dockerClient, eventBroker, cfg.DiskSoftThreshold, usageMeter)
+	prometheus.MustRegister(sandboxMetricsCollector)
+	sandboxMetricsCollector.StartCollection(ctx)
+
+	drainService := services.NewDrainService()
+
+	idleService := services.NewIdleService(dockerClient, containerBackend)
+	idleService.StartIdleDetection(ctx)
+
+	daemonSupervisor := services.NewDaemonSupervisor(services.DaemonSupervisorConfig{
+		Cache:  runnerCache,
+		Docker: dockerClient,
+	})
+	daemonSupervisor.StartSupervision(ctx)
+
+	expiryService := services.NewExpiryService(services.ExpiryServiceConfig{
+		Cache:           runnerCache,
+		Backend:         containerBackend,
+		Events:          eventBroker,
+		WarningLeadTime: cfg.SandboxTTLWarning,
+	})
+	expiryService.StartExpiryScheduler(ctx)
+
+	restartService := services.NewRestartService(services.RestartServiceConfig{
 		Cache:           runnerCache,
 		Docker:          dockerClient,
-		SandboxService:  sandboxService,
-		MetricsService:  metricsService,
-		NetRulesManager: netRulesManager,
+		Events:          eventBroker,
+		CrashLoopWindow: cfg.RestartLoopWindow,
+	})
+	restartService.StartSupervision(ctx)
+
+	snapshotPins, err := services.NewSnapshotPins(cfg.SnapshotPinsPath)
+	if err != nil {
+		log.Errorf("Failed to load snapshot pins: %v", err)
+		return
+	}
+
+	snapshotGCService := services.NewSnapshotGCService(services.SnapshotGCServiceConfig{
+		Docker:   dockerClient,
+		Interval: cfg.SnapshotGCInterval,
+		MinAge:   cfg.SnapshotGCMinAge,
+		KeepList: cfg.SnapshotGCKeepList,
+		Pins:     snapshotPins,
+	})
+	snapshotGCService.StartGC(ctx)
+
+	prewarmService := services.NewPrewarmService(services.PrewarmServiceConfig{
+		Docker: dockerClient,
+		Pins:   snapshotPins,
+	})
+	prewarmService.StartPrewarm(ctx)
+
+	warmPoolTemplates, err := services.LoadWarmPoolTemplates(cfg.WarmPoolPath, cfg.NetworkIsolation)
+	if err != nil {
+		log.Errorf("Failed to load warm pool templates: %v", err)
+		return
+	}
+
+	warmPool := services.NewWarmPool(services.WarmPoolConfig{
+		Docker:    dockerClient,
+		Drain:     drainService,
+		Templates: warmPoolTemplates,
+		Interval:  cfg.WarmPoolInterval,
+	})
+	warmPool.StartRefill(ctx)
+
+	buildLogDir, err := config.GetBuildLogDir()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	buildLogService := services.NewBuildLogService(services.BuildLogServiceConfig{
+		Dir:          buildLogDir,
+		Interval:     cfg.BuildLogInterval,
+		MaxSize:      cfg.BuildLogMaxSize,
+		MaxAge:       cfg.BuildLogMaxAge,
+		MaxTotalSize: cfg.BuildLogMaxTotal,
+		Archive:      cfg.BuildLogArchive,
+		ObjectPrefix: cfg.BuildLogPrefix,
+	})
+	buildLogService.StartRetention(ctx)
+
+	cleanupService := services.NewCleanupService(services.CleanupServiceConfig{
+		Docker:   dockerClient,
+		Interval: cfg.CleanupInterval,
+		Policies: services.CleanupPolicies{
+			ExitedContainerMaxAge: cfg.CleanupExitedAge,
+			DanglingVolumes:       cfg.CleanupVolumes,
+			SandboxNetworks:       cfg.CleanupNetworks,
+		},
+	})
+	cleanupService.StartCleanup(ctx)
+
+	healthService := services.NewHealthService(runnerCache, dockerClient, drainService)
+
+	maintenanceService := services.NewMaintenanceService(services.MaintenanceServiceConfig{
+		Cache:          runnerCache,
+		Events:         eventBroker,
+		Drain:          drainService,
+		NotifyLeadTime: cfg.MaintenanceLeadTime,
+	})
+	maintenanceService.StartScheduler(ctx)
+
+	configuredLabels, err := services.ParseRunnerLabels(cfg.RunnerLabels)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	gpus, err := metricsService.GetGPUs(ctx)
+	if err != nil {
+		log.Warnf("Failed to list GPUs, the runner is labeled as having none: %v", err)
+	}
+	runnerLabels := services.NewRunnerLabels(configuredLabels, len(gpus) > 0)
+
+	if cfg.ControlPlaneUrl != "" {
+		registrationClient, err := services.NewRegistrationClient(services.RegistrationConfig{
+			ControlPlaneUrl:   cfg.ControlPlaneUrl,
+			Token:             cfg.ControlPlaneToken,
+			Name:              cfg.RunnerName,
+			Address:           cfg.RunnerAddress,
+			Labels:            runnerLabels,
+			HeartbeatInterval: cfg.HeartbeatInterval,
+			AllowInsecure:     cfg.Environment == "development",
+			Metrics:           metricsService,
+			Health:            healthService,
+			Drain:             drainService,
+		})
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		registrationClient.Start(ctx)
+	}
+
+	portService, err := services.NewPortService(services.PortServiceConfig{
+		FilePath: cfg.ExposedPortsPath,
+		Events:   eventBroker,
+	})
+	if err != nil {
+		log.Errorf("Failed to load exposed ports: %v", err)
+		return
+	}
+	portService.StartCleanup(ctx)
+
+	services.StartProxyMetricsCleanup(ctx, eventBroker)
+
+	var sshAccessService *services.SshAccessService
+	var sshGateway *sshgateway.Server
+	if cfg.SshGatewayPort > 0 {
+		sshAccessService, err = services.NewSshAccessService(services.SshAccessServiceConfig{
+			FilePath: cfg.SshAccessPath,
+			Events:   eventBroker,
+		})
+		if err != nil {
+			log.Errorf("Failed to load SSH accesses: %v", err)
+			return
+		}
+		sshAccessService.StartCleanup(ctx)
+
+		sshGateway, err = sshgateway.NewServer(sshgateway.ServerConfig{
+			ListenAddress: fmt.Sprintf(":%d", cfg.SshGatewayPort),
+			HostKeyPath:   cfg.SshHostKeyPath,
+			Docker:        dockerClient,
+			Access:        sshAccessService,
+		})
+		if err != nil {
+			log.Error(err)
+			return
+		}
+	}
+
+	var proxyCache *proxycache.Cache
+	if cfg.ProxyCacheSize > 0 {
+		proxyCache, err = proxycache.NewCache(proxycache.CacheConfig{
+			MaxSize:      cfg.ProxyCacheSize,
+			MaxEntrySize: cfg.ProxyCacheEntrySize,
+			Dir:          cfg.ProxyCacheDir,
+		})
+		if err != nil {
+			log.Errorf("Failed to create the proxy cache: %v", err)
+			return
+		}
+		proxyCache.StartCleanup(ctx, eventBroker)
+	}
+
+	var proxyTokenIssuer *proxytoken.Issuer
+	if cfg.ProxyTokenSecret != "" {
+		proxyTokenIssuer, err = proxytoken.NewIssuer(cfg.ProxyTokenSecret, cfg.ProxyTokenTTL)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+	}
+
+	rateLimiterConfig, err := ratelimit.ParseConfig(cfg.RateLimit, cfg.RateLimitBurst, cfg.RateLimitRoutes)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	rateLimiter := ratelimit.NewLimiter(rateLimiterConfig)
+	rateLimiter.StartCleanup(ctx)
+
+	auditLogger, err := audit.NewLogger(audit.LoggerConfig{
+		FilePath:     cfg.AuditLogPath,
+		ShipInterval: cfg.AuditShipInterval,
+		ObjectPrefix: cfg.AuditObjectPrefix,
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	auditLogger.StartShipping(ctx)
+
+	authenticator, err := apitoken.NewAuthenticator(cfg.ApiToken, cfg.ApiTokensFile)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	authenticator.StartWatching(ctx)
+
+	configReloadService := services.NewConfigReloadService(services.ConfigReloadServiceConfig{
+		EnvFilePath:    envFilePath,
+		ConfigFilePath: config.GetConfigFilePath(),
+		Authenticator:  authenticator,
+		Docker:         dockerClient,
+		RateLimiter:    rateLimiter,
+		SnapshotGC:     snapshotGCService,
+	})
+	configReloadService.StartWatching(ctx)
+
+	idempotencyStore := idempotency.NewStore(idempotency.StoreConfig{
+		TTL: cfg.IdempotencyKeyTTL,
+	})
+	idempotencyStore.StartCleanup(ctx)
+
+	operationService := services.NewOperationService(drainService)
+	operationService.StartCleanup(ctx)
+
+	migrationService := services.NewMigrationService(services.MigrationServiceConfig{
+		Backend:       containerBackend,
+		Ports:         portService,
+		Labels:        runnerLabels,
+		AllowInsecure: cfg.Environment == "development",
+	})
+
+	routeScopes, err := apitoken.ParsePolicy(cfg.ApiRouteScopes)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	_ = runner.GetInstance(&runner.RunnerInstanceConfig{
+		Cache:            runnerCache,
+		Docker:           dockerClient,
+		Backend:          containerBackend,
+		SandboxService:   sandboxService,
+		MetricsService:   metricsService,
+		HealthService:    healthService,
+		DrainService:     drainService,
+		IdleService:      idleService,
+		SnapshotGC:       snapshotGCService,
+		Prewarm:          prewarmService,
+		WarmPool:         warmPool,
+		BuildLogs:        buildLogService,
+		Ports:            portService,
+		SshAccess:        sshAccessService,
+		ProxyCache:       proxyCache,
+		CleanupService:   cleanupService,
+		Events:           eventBroker,
+		ProxyTokenIssuer: proxyTokenIssuer,
+		NetRulesManager:  netRulesManager,
+		SandboxNetwork:   sandboxNetwork,
+		RateLimiter:      rateLimiter,
+		AuditLogger:      auditLogger,
+		Authenticator:    authenticator,
+		AuthPolicy:       apitoken.NewPolicy(routeScopes),
+		Idempotency:      idempotencyStore,
+		Operations:       operationService,
+		Usage:            usageMeter,
+		Labels:           runnerLabels,
+		Maintenance:      maintenanceService,
+		Migration:        migrationService,
 	})
 
 	apiServerErrChan := make(chan error)
 
 	go func() {
-		log.Infof("Starting Daytona Runner on port %d", cfg.ApiPort)
+		if len(cfg.ApiListenAddresses) > 0 {
+			log.Infof("Starting Daytona Runner on %s", strings.Join(cfg.ApiListenAddresses, ", "))
+		} else {
+			log.Infof("Starting Daytona Runner on port %d", cfg.ApiPort)
+		}
 		apiServerErrChan <- apiServer.Start()
 	}()
 
+	if sshGateway != nil {
+		go func() {
+			log.Infof("Starting SSH gateway on port %d", cfg.SshGatewayPort)
+			apiServerErrChan <- sshGateway.Start()
+		}()
+	}
+
 	interruptChannel := make(chan os.Signal, 1)
-	signal.Notify(interruptChannel, os.Interrupt)
+	signal.Notify(interruptChannel, os.Interrupt, syscall.SIGTERM)
 
 	select {
 	case err := <-apiServerErrChan:
 		log.Error(err)
 		return
-	case <-interruptChannel:
+	case sig := <-interruptChannel:
+		if sig == syscall.SIGTERM && cfg.DrainOnSigterm {
+			drainService.Drain()
+
+			log.Infof("Waiting up to %s for in-flight operations to finish", cfg.DrainTimeout)
+
+			drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+			err := drainService.Wait(drainCtx)
+			drainCancel()
+			if err != nil {
+				log.Warnf("Drain timed out with %d operations in flight", drainService.InFlight())
+			}
+		}
+
 		log.Info("Shutting down Daytona Runner")
 		apiServer.Stop()
+		if sshGateway != nil {
+			sshGateway.Stop()
+		}
 	}
 }
 
+const envFilePath = ".env"
+
 func init() {
 	// Load .env file
-	err := godotenv.Load()
+	err := config.LoadEnvFile(envFilePath)
 	if err != nil {
 		log.Println("Warning: Error loading .env file:", err)
 		// Continue anyway, as environment variables might be set directly
 	}
 
-	logLevel := log.WarnLevel
+	logLevel := logrus.WarnLevel
 
 	logLevelEnv, logLevelSet := os.LookupEnv("LOG_LEVEL")
 
 	if logLevelSet {
 		var err error
-		logLevel, err = log.ParseLevel(logLevelEnv)
+		logLevel, err = logrus.ParseLevel(logLevelEnv)
 		if err != nil {
-			logLevel = log.WarnLevel
+			logLevel = logrus.WarnLevel
 		}
 	}
 
-	log.SetLevel(logLevel)
+	logging.SetLevel(logLevel)
 
-	log.SetOutput(os.Stdout)
+	logging.SetOutput(os.Stdout)
 
 	logFilePath, logFilePathSet := os.LookupEnv("LOG_FILE_PATH")
 	if logFilePathSet {
@@ -177,20 +795,9 @@ func init() {
 			os.Exit(1)
 		}
 
-		log.SetOutput(io.MultiWriter(os.Stdout, file))
-	}
-
-	zerologLevel, err := zerolog.ParseLevel(logLevel.String())
-	if err != nil {
-		zerologLevel = zerolog.ErrorLevel
+		logging.SetOutput(io.MultiWriter(os.Stdout, file))
 	}
 
-	zerolog.SetGlobalLevel(zerologLevel)
-	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	zlog.Logger = zlog.Output(zerolog.ConsoleWriter{
-		Out:        &util.DebugLogWriter{},
-		TimeFormat: time.RFC3339,
-	})
-
+	// Dependencies logging through the standard library, including the default slog logger, end up at debug level
 	golog.SetOutput(&util.DebugLogWriter{})
 }
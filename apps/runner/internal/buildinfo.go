@@ -0,0 +1,7 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package internal
+
+// Version is set on release builds with -ldflags "-X github.com/daytonaio/runner/internal.Version=<version>"
+var Version = "v0.0.0-dev"
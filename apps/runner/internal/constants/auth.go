@@ -8,3 +8,19 @@ const BEARER_AUTH_HEADER = "Bearer"
 const AUTHORIZATION_HEADER = "Authorization"
 
 const DAYTONA_AUTHORIZATION_HEADER = "X-Daytona-Authorization"
+
+const DAYTONA_PROXY_TOKEN_HEADER = "X-Daytona-Proxy-Token"
+
+const DAYTONA_PROXY_TOKEN_QUERY_PARAM = "DAYTONA_PROXY_TOKEN"
+
+// DAYTONA_PREVIEW_TOKEN_COOKIE keeps browsers authenticated on a preview hostname after the first request
+const DAYTONA_PREVIEW_TOKEN_COOKIE = "daytona_preview_token"
+
+// DAYTONA_ORGANIZATION_ID_HEADER identifies the organization a control plane request is made for
+const DAYTONA_ORGANIZATION_ID_HEADER = "X-Daytona-Organization-Id"
+
+// TOKEN_SUBJECT_CONTEXT_KEY holds the subject of the scoped token a request was authenticated with
+const TOKEN_SUBJECT_CONTEXT_KEY = "tokenSubject"
+
+// IDEMPOTENCY_KEY_HEADER makes retries of a mutating request return the response of the first request
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
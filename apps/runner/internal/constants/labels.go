@@ -0,0 +1,54 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package constants
+
+const ORGANIZATION_ID_LABEL = "daytona.organization_id"
+
+// IDLE_TIMEOUT_LABEL holds the idle timeout in minutes after which a running sandbox is stopped
+const IDLE_TIMEOUT_LABEL = "daytona.idle_timeout"
+
+// RESTART_POLICY_LABEL holds the JSON restart policy the runner restarts the sandbox by when it exits on its own
+const RESTART_POLICY_LABEL = "daytona.restart_policy"
+
+// COMPOSE_SANDBOX_LABEL marks the containers and network of a multi-container sandbox with its sandbox ID
+const COMPOSE_SANDBOX_LABEL = "daytona.compose_sandbox"
+
+// COMPOSE_SERVICE_LABEL holds the compose service name of a multi-container sandbox container
+const COMPOSE_SERVICE_LABEL = "daytona.compose_service"
+
+// COMPOSE_START_INDEX_LABEL holds the position of the service in dependency order so it can be started and stopped in order
+const COMPOSE_START_INDEX_LABEL = "daytona.compose_start_index"
+
+// SIDECAR_OF_LABEL marks a sidecar container with the ID of the sandbox it belongs to
+const SIDECAR_OF_LABEL = "daytona.sidecar_of"
+
+// STORAGE_QUOTA_LABEL holds the storage quota of the sandbox in GB so usage can be compared without inspecting it
+const STORAGE_QUOTA_LABEL = "daytona.storage_quota_gb"
+
+// SECURITY_PROFILE_LABEL holds the name of the security profile the sandbox was created with, profiles in use can't
+// be deleted
+const SECURITY_PROFILE_LABEL = "daytona.security_profile"
+
+// INGRESS_BANDWIDTH_LABEL and EGRESS_BANDWIDTH_LABEL hold the bandwidth limits of the sandbox in Mbit/s.
+// The limits are re-applied on every start since the container veth is recreated.
+const INGRESS_BANDWIDTH_LABEL = "daytona.ingress_bandwidth_mbps"
+const EGRESS_BANDWIDTH_LABEL = "daytona.egress_bandwidth_mbps"
+
+// RESTORED_VOLUME_SANDBOX_LABEL marks a volume restored from a backup with the ID of the sandbox it belongs to
+// and RESTORED_VOLUME_DESTINATION_LABEL holds the path it is mounted at when the sandbox is created
+const RESTORED_VOLUME_SANDBOX_LABEL = "daytona.restored_volume_of"
+const RESTORED_VOLUME_DESTINATION_LABEL = "daytona.restored_volume_destination"
+
+// SNAPSHOT_SOURCE_SANDBOX_LABEL, SNAPSHOT_SOURCE_IMAGE_LABEL and SNAPSHOT_CREATED_AT_LABEL record the lineage of a
+// snapshot created from a sandbox: the sandbox, the snapshot the sandbox was created from and when it was taken
+const SNAPSHOT_SOURCE_SANDBOX_LABEL = "daytona.snapshot.source_sandbox"
+const SNAPSHOT_SOURCE_IMAGE_LABEL = "daytona.snapshot.source_image"
+const SNAPSHOT_CREATED_AT_LABEL = "daytona.snapshot.created_at"
+
+// DEVCONTAINER_PORTS_LABEL holds the comma separated ports the devcontainer of the sandbox forwards, they are
+// exposed once the sandbox is created
+const DEVCONTAINER_PORTS_LABEL = "daytona.devcontainer.forward_ports"
+
+// DEVCONTAINER_VOLUME_SANDBOX_LABEL marks a volume mounted by the devcontainer of a sandbox with the sandbox ID
+const DEVCONTAINER_VOLUME_SANDBOX_LABEL = "daytona.devcontainer.volume_of"
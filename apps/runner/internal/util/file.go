@@ -0,0 +1,33 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package util
+
+import (
+	"os"
+)
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it over path once it is synced, so a
+// crash leaves either the previous or the new content and never a truncated file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmpFilePath := path + ".tmp"
+	file, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFilePath)
+		return err
+	}
+
+	return os.Rename(tmpFilePath, path)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package util
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileWatcher notifies about writes to a file through inotify
+type fileWatcher struct {
+	inotify *os.File
+	changes chan struct{}
+}
+
+func newFileWatcher(path string) (*fileWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_NONBLOCK | unix.IN_CLOEXEC)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	_, err = unix.InotifyAddWatch(fd, path, unix.IN_MODIFY|unix.IN_CLOSE_WRITE|unix.IN_MOVE_SELF|unix.IN_DELETE_SELF)
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("inotify_add_watch", err)
+	}
+
+	w := &fileWatcher{
+		// A non-blocking descriptor goes through the runtime poller, so Close unblocks the pending read
+		inotify: os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan struct{}, 1),
+	}
+
+	go w.watch()
+
+	return w, nil
+}
+
+// Changes receives after the file changed, changes in quick succession are coalesced
+func (w *fileWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *fileWatcher) Close() error {
+	return w.inotify.Close()
+}
+
+func (w *fileWatcher) watch() {
+	buffer := make([]byte, 4096)
+
+	for {
+		// The events themselves don't matter, the reader rereads the file on every change
+		_, err := w.inotify.Read(buffer)
+		if err != nil {
+			return
+		}
+
+		select {
+		case w.changes <- struct{}{}:
+		default:
+		}
+	}
+}
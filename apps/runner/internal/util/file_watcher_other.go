@@ -0,0 +1,30 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+//go:build !linux
+
+package util
+
+import (
+	"time"
+)
+
+const fileWatcherPollInterval = 250 * time.Millisecond
+
+// fileWatcher polls the file on platforms without inotify, the runner itself only runs on Linux
+type fileWatcher struct {
+	ticker *time.Ticker
+}
+
+func newFileWatcher(_ string) (*fileWatcher, error) {
+	return &fileWatcher{ticker: time.NewTicker(fileWatcherPollInterval)}, nil
+}
+
+func (w *fileWatcher) Changes() <-chan time.Time {
+	return w.ticker.C
+}
+
+func (w *fileWatcher) Close() error {
+	w.ticker.Stop()
+	return nil
+}
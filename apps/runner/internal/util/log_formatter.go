@@ -6,15 +6,15 @@ package util
 import (
 	"io"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
 )
 
 type LogFormatter struct {
-	TextFormatter    *log.TextFormatter
+	TextFormatter    *logrus.TextFormatter
 	ProcessLogWriter io.Writer
 }
 
-func (f *LogFormatter) Format(entry *log.Entry) ([]byte, error) {
+func (f *LogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	formatted, err := f.TextFormatter.Format(entry)
 	if err != nil {
 		return nil, err
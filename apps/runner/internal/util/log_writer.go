@@ -3,10 +3,6 @@
 
 package util
 
-import (
-	log "github.com/sirupsen/logrus"
-)
-
 type DebugLogWriter struct{}
 
 func (w *DebugLogWriter) Write(p []byte) (n int, err error) {
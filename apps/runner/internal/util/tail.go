@@ -0,0 +1,130 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package util
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+const tailChunkSize = 64 * 1024
+
+type TailOptions struct {
+	// Lines limits the output to the last lines of the file, 0 writes the whole file
+	Lines int
+	// Since skips the existing content if the file wasn't written to since then. Lines carry no
+	// timestamps, so a file written to since then is written as a whole.
+	Since time.Time
+	// Follow keeps writing what is appended to the file until Done is closed or the context is canceled
+	Follow bool
+	// Done is closed once nothing is appended to the file anymore, e.g. when the build writing it finished
+	Done <-chan struct{}
+}
+
+// TailFile writes the file to the writer and follows it if requested. Following is driven by file
+// change notifications, what was written when Done is closed is still written before returning.
+// A context canceled while following is not an error.
+func TailFile(ctx context.Context, file *os.File, writer io.Writer, options TailOptions) error {
+	var watcher *fileWatcher
+	if options.Follow {
+		// Watch before the first read so no write between the read and the watch is missed
+		var err error
+		watcher, err = newFileWatcher(file.Name())
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if !options.Since.IsZero() && info.ModTime().Before(options.Since) {
+		_, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+	} else if options.Lines > 0 {
+		offset, err := findLastLines(file, options.Lines)
+		if err != nil {
+			return err
+		}
+
+		_, err = file.Seek(offset, io.SeekStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.Copy(writer, file)
+	if err != nil || !options.Follow {
+		return err
+	}
+
+	for {
+		select {
+		case <-watcher.Changes():
+			_, err = io.Copy(writer, file)
+			if err != nil {
+				return err
+			}
+		case <-options.Done:
+			_, err = io.Copy(writer, file)
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// findLastLines returns the offset of the last lines of the file, a trailing newline doesn't start a line
+func findLastLines(file *os.File, lines int) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	end := info.Size()
+	if end == 0 {
+		return 0, nil
+	}
+
+	buffer := make([]byte, tailChunkSize)
+	position := end
+	newlines := 0
+	skippedTrailing := false
+
+	for position > 0 {
+		chunkSize := min(int64(tailChunkSize), position)
+		position -= chunkSize
+
+		chunk := buffer[:chunkSize]
+		_, err = file.ReadAt(chunk, position)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' {
+				continue
+			}
+
+			if !skippedTrailing && position+int64(i) == end-1 {
+				skippedTrailing = true
+				continue
+			}
+
+			newlines++
+			if newlines == lines {
+				return position + int64(i) + 1, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
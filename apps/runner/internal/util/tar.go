@@ -0,0 +1,175 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package util
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TarDirectory writes the contents of srcDir to w as a tar stream with paths relative to srcDir
+func TarDirectory(srcDir string, w io.Writer) error {
+	tarWriter := tar.NewWriter(w)
+
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", srcDir, err)
+	}
+
+	return tarWriter.Close()
+}
+
+// UntarToDirectory extracts the tar stream from r into destDir, rejecting entries and symlinks that escape it.
+// Entries are never written through symlinks, neither ones from the stream nor ones already in destDir.
+func UntarToDirectory(r io.Reader, destDir string) error {
+	tarReader := tar.NewReader(r)
+
+	destDir = filepath.Clean(destDir)
+	resolvedDestDir, err := resolvePath(destDir)
+	if err != nil {
+		return err
+	}
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar: %w", err)
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if !isWithinDirectory(destDir, target) {
+			return fmt.Errorf("invalid tar entry path: %s", header.Name)
+		}
+
+		// The lexical check above doesn't catch parents that are symlinks pointing out of destDir
+		resolvedParent, err := resolvePath(filepath.Dir(target))
+		if err != nil {
+			return err
+		}
+		if !isWithinDirectory(resolvedDestDir, resolvedParent) {
+			return fmt.Errorf("invalid tar entry path: %s escapes through a symlink", header.Name)
+		}
+
+		if target != destDir {
+			fileInfo, err := os.Lstat(target)
+			if err == nil && fileInfo.Mode()&os.ModeSymlink != 0 {
+				return fmt.Errorf("invalid tar entry path: %s is an existing symlink", header.Name)
+			}
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !isWithinDirectory(destDir, filepath.Join(filepath.Dir(target), header.Linkname)) {
+				return fmt.Errorf("invalid tar entry link: %s -> %s", header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, tarReader)
+			file.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isWithinDirectory(dir string, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator))
+}
+
+// resolvePath resolves the symlinks of the longest existing prefix of path and appends the rest of it.
+// Dangling symlinks are refused since their target can't be checked.
+func resolvePath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if _, err := os.Lstat(path); err == nil {
+		return "", fmt.Errorf("refusing to follow dangling symlink %s", path)
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+
+	resolvedParent, err := resolvePath(parent)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCacheEntries godoc
+//
+//	@Tags			cache
+//	@Summary		List cache entries
+//	@Description	List the entries of the runner cache, including destroyed sandboxes kept until their retention ends
+//	@Produce		json
+//	@Success		200	{array}		CacheEntryResponse
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
+//	@Failure		500	{object}	common.ErrorResponse
+//	@Router			/cache [get]
+//
+//	@id				ListCacheEntries
+func ListCacheEntries(ctx *gin.Context) {
+	runnerCache := runner.GetInstance(nil).Cache
+
+	sandboxIds := runnerCache.List(ctx.Request.Context())
+	sort.Strings(sandboxIds)
+
+	entries := make([]CacheEntryResponse, 0, len(sandboxIds))
+	for _, sandboxId := range sandboxIds {
+		entries = append(entries, toCacheEntryResponse(sandboxId, runnerCache.Get(ctx.Request.Context(), sandboxId)))
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// GetCacheEntry godoc
+//
+//	@Tags			cache
+//	@Summary		Get cache entry
+//	@Description	Get the cache entry of a sandbox as the runner stores it
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{object}	CacheEntryResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		403			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/cache/{sandboxId} [get]
+//
+//	@id				GetCacheEntry
+func GetCacheEntry(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runnerCache := runner.GetInstance(nil).Cache
+
+	// Get returns an unknown state for missing entries, only listed entries exist
+	if !slices.Contains(runnerCache.List(ctx.Request.Context()), sandboxId) {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("cache entry of sandbox %s not found", sandboxId)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCacheEntryResponse(sandboxId, runnerCache.Get(ctx.Request.Context(), sandboxId)))
+}
+
+// DeleteCacheEntry godoc
+//
+//	@Tags			cache
+//	@Summary		Delete cache entry
+//	@Description	Drop the cache entry of a sandbox, e.g. one stuck in a transitional state. The state is deduced from the container again on the next request or reconciliation.
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Cache entry deleted"
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		403			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/cache/{sandboxId} [delete]
+//
+//	@id				DeleteCacheEntry
+func DeleteCacheEntry(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runnerCache := runner.GetInstance(nil).Cache
+
+	if !slices.Contains(runnerCache.List(ctx.Request.Context()), sandboxId) {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("cache entry of sandbox %s not found", sandboxId)))
+		return
+	}
+
+	runnerCache.Delete(ctx.Request.Context(), sandboxId)
+
+	log.Infof("Deleted cache entry of sandbox %s", sandboxId)
+
+	ctx.JSON(http.StatusOK, "Cache entry deleted")
+}
+
+func toCacheEntryResponse(sandboxId string, data *models.CacheData) CacheEntryResponse {
+	return CacheEntryResponse{
+		SandboxId:         sandboxId,
+		State:             data.SandboxState,
+		BackupState:       data.BackupState,
+		BackupError:       data.BackupErrorReason,
+		DestructionTime:   data.DestructionTime,
+		Resources:         data.Resources,
+		Expiration:        data.Expiration,
+		PullQueuePosition: data.PullQueuePosition,
+		BackupProgress:    data.BackupProgress,
+		DaemonHealth:      data.DaemonHealth,
+		Repository:        data.Repository,
+	}
+}
+
+type CacheEntryResponse struct {
+	SandboxId         string                    `json:"sandboxId"`
+	State             enums.SandboxState        `json:"state"`
+	BackupState       enums.BackupState         `json:"backupState"`
+	BackupError       *string                   `json:"backupError,omitempty"`
+	DestructionTime   *time.Time                `json:"destructionTime,omitempty"` // When the entry of a destroyed sandbox is dropped
+	Resources         *models.SandboxResources  `json:"resources,omitempty"`
+	Expiration        *models.SandboxExpiration `json:"expiration,omitempty"`
+	PullQueuePosition *int                      `json:"pullQueuePosition,omitempty"`
+	BackupProgress    *models.BackupProgress    `json:"backupProgress,omitempty"`
+	DaemonHealth      enums.DaemonHealth        `json:"daemonHealth,omitempty"`
+	Repository        *models.RepositoryStatus  `json:"repository,omitempty"`
+} //	@name	CacheEntryResponse
@@ -0,0 +1,64 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// Cleanup 			godoc
+//
+//	@Summary		Clean up leftover resources
+//	@Description	Remove sandboxes that exited longer ago than the maximum age, sandbox volumes no container uses and per-sandbox networks without a sandbox. Policies not set in the request use the runner settings.
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CleanupRequestDTO	true	"Cleanup request"
+//	@Success		200		{object}	dto.CleanupResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/cleanup [post]
+//
+//	@id				Cleanup
+func Cleanup(ctx *gin.Context) {
+	var request dto.CleanupRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	cleanupService := runner.GetInstance(nil).CleanupService
+
+	policies := cleanupService.Policies()
+	if request.ExitedContainerMaxAge != "" {
+		policies.ExitedContainerMaxAge, err = time.ParseDuration(request.ExitedContainerMaxAge)
+		if err != nil || policies.ExitedContainerMaxAge < 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid exitedContainerMaxAge: %s", request.ExitedContainerMaxAge)))
+			return
+		}
+	}
+	if request.DanglingVolumes != nil {
+		policies.DanglingVolumes = *request.DanglingVolumes
+	}
+	if request.SandboxNetworks != nil {
+		policies.SandboxNetworks = *request.SandboxNetworks
+	}
+
+	result, err := cleanupService.Run(ctx.Request.Context(), policies, request.DryRun)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/daytonaio/common-go/pkg/errors"
 	"github.com/daytonaio/common-go/pkg/proxy"
 	"github.com/gin-gonic/gin"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/gorilla/websocket"
 )
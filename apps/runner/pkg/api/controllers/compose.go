@@ -0,0 +1,191 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCompose 			godoc
+//
+//	@Tags			compose
+//	@Summary		Create a multi-container sandbox
+//	@Description	Create a sandbox from a compose spec, all services share a dedicated network
+//	@Param			sandbox	body	dto.CreateComposeSandboxDTO	true	"Create compose sandbox"
+//	@Produce		json
+//	@Success		201	{object}	dto.ComposeSandboxInfoResponse
+//	@Failure		400	{object}	common.ErrorResponse
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		404	{object}	common.ErrorResponse
+//	@Failure		409	{object}	common.ErrorResponse
+//	@Failure		500	{object}	common.ErrorResponse
+//	@Router			/compose [post]
+//
+//	@id				CreateCompose
+func CreateCompose(ctx *gin.Context) {
+	var createComposeDto dto.CreateComposeSandboxDTO
+	err := ctx.ShouldBindJSON(&createComposeDto)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Backend.CreateCompose(ctx.Request.Context(), createComposeDto)
+	if err != nil {
+		runner.Cache.SetSandboxState(ctx, createComposeDto.Id, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("create_compose", string(common.PrometheusOperationStatusFailure)).Inc()
+		ctx.Error(err)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("create_compose", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	info, err := runner.Backend.GetComposeInfo(ctx.Request.Context(), createComposeDto.Id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, info)
+}
+
+// ComposeInfo godoc
+//
+//	@Tags			compose
+//	@Summary		Get multi-container sandbox info
+//	@Description	Get the aggregated state and the state of every service
+//	@Produce		json
+//	@Param			sandboxId	path		string							true	"Sandbox ID"
+//	@Success		200			{object}	dto.ComposeSandboxInfoResponse	"Sandbox info"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/compose/{sandboxId} [get]
+//
+//	@id				ComposeInfo
+func ComposeInfo(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	info, err := runner.Backend.GetComposeInfo(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+// StartCompose 			godoc
+//
+//	@Tags			compose
+//	@Summary		Start multi-container sandbox
+//	@Description	Start all services in dependency order
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Sandbox started"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/compose/{sandboxId}/start [post]
+//
+//	@id				StartCompose
+func StartCompose(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Backend.StartCompose(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("start_compose", string(common.PrometheusOperationStatusFailure)).Inc()
+		ctx.Error(err)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("start_compose", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	ctx.JSON(http.StatusOK, "Sandbox started")
+}
+
+// StopCompose 			godoc
+//
+//	@Tags			compose
+//	@Summary		Stop multi-container sandbox
+//	@Description	Stop all services in reverse dependency order
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Sandbox stopped"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/compose/{sandboxId}/stop [post]
+//
+//	@id				StopCompose
+func StopCompose(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Backend.StopCompose(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("stop_compose", string(common.PrometheusOperationStatusFailure)).Inc()
+		ctx.Error(err)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("stop_compose", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	ctx.JSON(http.StatusOK, "Sandbox stopped")
+}
+
+// DestroyCompose 			godoc
+//
+//	@Tags			compose
+//	@Summary		Destroy multi-container sandbox
+//	@Description	Remove all services and the sandbox network
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Sandbox destroyed"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/compose/{sandboxId}/destroy [post]
+//
+//	@id				DestroyCompose
+func DestroyCompose(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Backend.DestroyCompose(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("destroy_compose", string(common.PrometheusOperationStatusFailure)).Inc()
+		ctx.Error(err)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("destroy_compose", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	ctx.JSON(http.StatusOK, "Sandbox destroyed")
+}
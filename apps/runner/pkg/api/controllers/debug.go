@@ -0,0 +1,129 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// DumpGoroutines godoc
+//
+//	@Tags			debug
+//	@Summary		Dump goroutines
+//	@Description	Get the stack traces of all goroutines of the runner, with grouped=true goroutines with the same stack are counted instead of listed one by one
+//	@Produce		plain
+//	@Param			grouped	query		boolean	false	"Whether to group goroutines by their stack"
+//	@Success		200		{string}	string	"Goroutine stack traces"
+//	@Router			/debug/goroutines [get]
+//
+//	@id				DumpGoroutines
+func DumpGoroutines(ctx *gin.Context) {
+	// Level 2 prints the stacks like an unrecovered panic does, level 1 groups them with a count
+	debugLevel := 2
+	if ctx.Query("grouped") == "true" {
+		debugLevel = 1
+	}
+
+	var dump bytes.Buffer
+	err := pprof.Lookup("goroutine").WriteTo(&dump, debugLevel)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", dump.Bytes())
+}
+
+// GetLogLevels godoc
+//
+//	@Tags			debug
+//	@Summary		Get log levels
+//	@Description	Get the runner log level and the level every module logs at
+//	@Produce		json
+//	@Success		200	{object}	dto.LogLevelsDTO
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
+//	@Router			/debug/log-level [get]
+//
+//	@id				GetLogLevels
+func GetLogLevels(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, getLogLevels())
+}
+
+// SetLogLevel godoc
+//
+//	@Tags			debug
+//	@Summary		Set log level
+//	@Description	Change the log level of the runner or of a single module at runtime, with a duration the previous level is restored afterwards
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.SetLogLevelDTO	true	"Log level"
+//	@Success		200		{object}	dto.LogLevelsDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Router			/debug/log-level [post]
+//
+//	@id				SetLogLevel
+func SetLogLevel(ctx *gin.Context) {
+	var request dto.SetLogLevelDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	var level *logrus.Level
+	if request.Level != "" {
+		parsed, err := logrus.ParseLevel(request.Level)
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(err))
+			return
+		}
+		level = &parsed
+	}
+
+	var duration time.Duration
+	if request.Duration != "" {
+		duration, err = time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid duration: %s", request.Duration)))
+			return
+		}
+	}
+
+	err = logging.ChangeLevel(request.Module, level, duration)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(err))
+		return
+	}
+
+	log.Warnf("Log level of %s changed to %s", cmp.Or(request.Module, "the runner"), cmp.Or(request.Level, "the runner level"))
+
+	ctx.JSON(http.StatusOK, getLogLevels())
+}
+
+func getLogLevels() dto.LogLevelsDTO {
+	levels := dto.LogLevelsDTO{
+		Level:   logging.GetLevel().String(),
+		Modules: make(map[string]string),
+	}
+
+	for module, level := range logging.GetModuleLevels() {
+		levels.Modules[module] = level.String()
+	}
+
+	return levels
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// Drain 			godoc
+//
+//	@Summary		Drain runner
+//	@Description	Stop accepting new sandboxes and snapshot pulls while in-flight operations finish. Draining cannot be undone without restarting the runner.
+//	@Produce		json
+//	@Success		200	{object}	dto.DrainStatusResponseDTO
+//	@Router			/drain [post]
+//
+//	@id				Drain
+func Drain(ctx *gin.Context) {
+	drainService := runner.GetInstance(nil).DrainService
+
+	drainService.Drain()
+
+	ctx.JSON(http.StatusOK, dto.DrainStatusResponseDTO{
+		Draining: drainService.IsDraining(),
+		InFlight: drainService.InFlight(),
+	})
+}
+
+// DrainStatus 			godoc
+//
+//	@Summary		Drain status
+//	@Description	Get whether the runner is draining and how many operations are still in flight
+//	@Produce		json
+//	@Success		200	{object}	dto.DrainStatusResponseDTO
+//	@Router			/drain [get]
+//
+//	@id				DrainStatus
+func DrainStatus(ctx *gin.Context) {
+	drainService := runner.GetInstance(nil).DrainService
+
+	ctx.JSON(http.StatusOK, dto.DrainStatusResponseDTO{
+		Draining: drainService.IsDraining(),
+		InFlight: drainService.InFlight(),
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// StreamEvents 			godoc
+//
+//	@Tags			events
+//	@Summary		Stream runner events
+//	@Description	Streams sandbox state transitions and snapshot pull/build progress as server-sent events
+//	@Produce		text/event-stream
+//	@Param			sandboxId	query		string	false	"Only stream events for this sandbox"
+//	@Param			type		query		string	false	"Only stream events of this type"
+//	@Success		200			{object}	events.Event
+//	@Router			/events [get]
+//
+//	@id				StreamEvents
+func StreamEvents(ctx *gin.Context) {
+	sandboxId := ctx.Query("sandboxId")
+	eventType := ctx.Query("type")
+
+	eventChan, unsubscribe := runner.GetInstance(nil).Events.Subscribe()
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	keepalive := time.NewTicker(config.GetStreamKeepaliveInterval())
+	defer keepalive.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-eventChan:
+			if !ok {
+				return false
+			}
+			if matchesEventFilter(event, sandboxId, eventType) {
+				ctx.SSEvent(event.Type.String(), event)
+			}
+			return true
+		case <-keepalive.C:
+			// SSE comment lines keep idle proxies from closing the connection
+			_, err := w.Write([]byte(": keepalive\n\n"))
+			return err == nil
+		case <-ctx.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func matchesEventFilter(event events.Event, sandboxId string, eventType string) bool {
+	if sandboxId != "" && event.SandboxId != sandboxId {
+		return false
+	}
+
+	return eventType == "" || event.Type.String() == eventType
+}
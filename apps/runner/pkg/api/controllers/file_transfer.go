@@ -0,0 +1,113 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadFile godoc
+//
+//	@Tags			sandbox
+//	@Summary		Upload a file to the sandbox
+//	@Description	Stream the request body to a file in the sandbox
+//	@Accept			application/octet-stream
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			path		query		string	true	"Absolute destination file path"
+//	@Param			mode		query		string	false	"Octal file mode"	default(0644)
+//	@Success		200			{string}	string	"File uploaded"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/files/upload [post]
+//
+//	@id				UploadFile
+func UploadFile(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	destPath := ctx.Query("path")
+	if destPath == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("path parameter is required")))
+		return
+	}
+
+	mode, err := strconv.ParseInt(ctx.DefaultQuery("mode", "0644"), 8, 64)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid file mode: %w", err)))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Docker.UploadFile(ctx.Request.Context(), sandboxId, destPath, ctx.Request.Body, ctx.Request.ContentLength, mode)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "File uploaded")
+}
+
+// DownloadFile godoc
+//
+//	@Tags			sandbox
+//	@Summary		Download a file from the sandbox
+//	@Description	Stream a file from the sandbox, directories are returned as a tar archive
+//	@Produce		application/octet-stream
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			path		query		string	true	"Absolute source path"
+//	@Success		200			{file}		binary	"File content"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/files/download [get]
+//
+//	@id				DownloadFile
+func DownloadFile(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	srcPath := ctx.Query("path")
+	if srcPath == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("path parameter is required")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	content, stat, err := runner.Docker.DownloadFile(ctx.Request.Context(), sandboxId, srcPath)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer content.Close()
+
+	if stat.Mode.IsDir() {
+		ctx.Header("Content-Type", "application/x-tar")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stat.Name+".tar"))
+	} else {
+		ctx.Header("Content-Type", "application/octet-stream")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stat.Name))
+		ctx.Header("Content-Length", strconv.FormatInt(stat.Size, 10))
+	}
+
+	ctx.Status(http.StatusOK)
+
+	_, err = io.Copy(ctx.Writer, content)
+	if err != nil {
+		log.Errorf("Error streaming file %s from sandbox %s: %v", srcPath, sandboxId, err)
+	}
+}
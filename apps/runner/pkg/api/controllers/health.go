@@ -3,8 +3,13 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,3 +28,57 @@ func HealthCheck(ctx *gin.Context) {
 		"version": "0.0.1",
 	})
 }
+
+// HealthStatus 			godoc
+//
+//	@Summary		Component health status
+//	@Description	Health of the runner components following the gRPC health checking protocol semantics. An empty service reports the overall runner health.
+//	@Produce		json
+//	@Param			service	query		string	false	"Component to check (runner, docker, cache, proxy)"
+//	@Success		200		{object}	dto.HealthStatusResponseDTO
+//	@Failure		404		{object}	dto.HealthStatusResponseDTO
+//	@Failure		503		{object}	dto.HealthStatusResponseDTO
+//	@Router			/health [get]
+//
+//	@id				HealthStatus
+func HealthStatus(ctx *gin.Context) {
+	service := ctx.Query("service")
+
+	components := runner.GetInstance(nil).HealthService.Check(ctx.Request.Context())
+
+	var status services.ComponentHealth
+	if service == "" {
+		status = services.Overall(components)
+	} else {
+		health, ok := components[service]
+		if !ok {
+			health = services.ComponentHealth{
+				Status:  enums.HealthStatusServiceUnknown,
+				Message: fmt.Sprintf("unknown service %s", service),
+			}
+		}
+		status = health
+		components = map[string]services.ComponentHealth{service: health}
+	}
+
+	response := dto.HealthStatusResponseDTO{
+		Status:     status.Status.String(),
+		Components: make(map[string]dto.ComponentHealthDTO, len(components)),
+	}
+	for name, health := range components {
+		response.Components[name] = dto.ComponentHealthDTO{
+			Status:  health.Status.String(),
+			Message: health.Message,
+		}
+	}
+
+	statusCode := http.StatusOK
+	switch status.Status {
+	case enums.HealthStatusNotServing:
+		statusCode = http.StatusServiceUnavailable
+	case enums.HealthStatusServiceUnknown:
+		statusCode = http.StatusNotFound
+	}
+
+	ctx.JSON(statusCode, response)
+}
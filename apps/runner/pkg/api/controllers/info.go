@@ -14,7 +14,7 @@ import (
 // RunnerInfo 			godoc
 //
 //	@Summary		Runner info
-//	@Description	Runner info with system metrics
+//	@Description	Runner info with system metrics, host capacity, available GPUs and the runner labels
 //	@Produce		json
 //	@Success		200	{object}	dto.RunnerInfoResponseDTO
 //	@Router			/info [get]
@@ -37,8 +37,47 @@ func RunnerInfo(ctx *gin.Context) {
 		CurrentSnapshotCount:         snapshotCount,
 	}
 
+	var capacity *dto.RunnerCapacity
+	hostCapacity, err := runnerInstance.MetricsService.GetHostCapacity(ctx.Request.Context())
+	if err != nil {
+		log.Warnf("Failed to get host capacity: %v", err)
+	} else {
+		capacity = &dto.RunnerCapacity{
+			TotalCpu:        hostCapacity.TotalCpu,
+			TotalMemoryGiB:  hostCapacity.TotalMemoryGiB,
+			TotalDiskGiB:    hostCapacity.TotalDiskGiB,
+			DockerVersion:   hostCapacity.DockerVersion,
+			DefaultRuntime:  hostCapacity.DefaultRuntime,
+			Runtimes:        hostCapacity.Runtimes,
+			Architecture:    hostCapacity.Architecture,
+			OperatingSystem: hostCapacity.OperatingSystem,
+			KernelVersion:   hostCapacity.KernelVersion,
+			Rootless:        hostCapacity.Rootless,
+			UsernsRemap:     hostCapacity.UsernsRemap,
+		}
+	}
+
+	gpus, err := runnerInstance.MetricsService.GetGPUs(ctx.Request.Context())
+	if err != nil {
+		log.Warnf("Failed to list GPUs: %v", err)
+	}
+
+	gpuDtos := make([]dto.GpuInfoDTO, 0, len(gpus))
+	for _, gpu := range gpus {
+		gpuDtos = append(gpuDtos, dto.GpuInfoDTO{
+			Index:     gpu.Index,
+			Uuid:      gpu.Uuid,
+			Name:      gpu.Name,
+			MemoryMiB: gpu.MemoryMiB,
+			Allocated: gpu.Allocated,
+		})
+	}
+
 	response := dto.RunnerInfoResponseDTO{
-		Metrics: metrics,
+		Metrics:  metrics,
+		Capacity: capacity,
+		Gpus:     gpuDtos,
+		Labels:   runnerInstance.Labels.Get(),
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -0,0 +1,47 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// GetLifecycleHookLogs godoc
+//
+//	@Tags			sandbox
+//	@Summary		Get lifecycle hook logs
+//	@Description	Get the output of the lifecycle hooks the sandbox ran so far
+//	@Produce		plain
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Hook logs"
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/hooks/logs [get]
+//
+//	@id				GetLifecycleHookLogs
+func GetLifecycleHookLogs(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var logs bytes.Buffer
+	err := runner.GetInstance(nil).Docker.GetLifecycleHookLogs(sandboxId, &logs)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			ctx.Error(common.NewNotFoundError(fmt.Errorf("sandbox %s has not run any lifecycle hooks", sandboxId)))
+			return
+		}
+		ctx.Error(fmt.Errorf("failed to read lifecycle hook logs of sandbox %s: %w", sandboxId, err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", logs.Bytes())
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleMaintenance godoc
+//
+//	@Tags			maintenance
+//	@Summary		Schedule maintenance
+//	@Description	Schedule the maintenance window of the runner, replacing the scheduled one. New sandboxes are refused during the window and the sandboxes are notified with an event ahead of it.
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.ScheduleMaintenanceDTO	true	"Maintenance window"
+//	@Success		200		{object}	dto.MaintenanceWindowDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Router			/maintenance [post]
+//
+//	@id				ScheduleMaintenance
+func ScheduleMaintenance(ctx *gin.Context) {
+	var request dto.ScheduleMaintenanceDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, request.StartsAt)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid startsAt: %w", err)))
+		return
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, request.EndsAt)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid endsAt: %w", err)))
+		return
+	}
+
+	var notifyBefore time.Duration
+	if request.NotifyBefore != "" {
+		notifyBefore, err = time.ParseDuration(request.NotifyBefore)
+		if err != nil || notifyBefore <= 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid notifyBefore: %s", request.NotifyBefore)))
+			return
+		}
+	}
+
+	maintenance := runner.GetInstance(nil).Maintenance
+
+	err = maintenance.Schedule(ctx.Request.Context(), services.MaintenanceWindow{
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		Reason:       request.Reason,
+		Drain:        request.Drain,
+		NotifyBefore: notifyBefore,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toMaintenanceWindowDTO(maintenance.GetWindow()))
+}
+
+// GetMaintenance godoc
+//
+//	@Tags			maintenance
+//	@Summary		Get maintenance
+//	@Description	Get the scheduled maintenance window of the runner
+//	@Produce		json
+//	@Success		200	{object}	dto.MaintenanceWindowDTO
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
+//	@Failure		404	{object}	common.ErrorResponse
+//	@Router			/maintenance [get]
+//
+//	@id				GetMaintenance
+func GetMaintenance(ctx *gin.Context) {
+	window := runner.GetInstance(nil).Maintenance.GetWindow()
+	if window == nil {
+		ctx.Error(common.NewNotFoundError(errors.New("no maintenance window scheduled")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toMaintenanceWindowDTO(window))
+}
+
+// CancelMaintenance godoc
+//
+//	@Tags			maintenance
+//	@Summary		Cancel maintenance
+//	@Description	Cancel the scheduled maintenance window, notified sandboxes get a cancellation event. A drain started by the window is not undone.
+//	@Produce		json
+//	@Success		200	{string}	string	"Maintenance cancelled"
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
+//	@Failure		404	{object}	common.ErrorResponse
+//	@Router			/maintenance [delete]
+//
+//	@id				CancelMaintenance
+func CancelMaintenance(ctx *gin.Context) {
+	if !runner.GetInstance(nil).Maintenance.Cancel(ctx.Request.Context()) {
+		ctx.Error(common.NewNotFoundError(errors.New("no maintenance window scheduled")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Maintenance cancelled")
+}
+
+func toMaintenanceWindowDTO(window *services.MaintenanceWindow) dto.MaintenanceWindowDTO {
+	now := time.Now()
+
+	return dto.MaintenanceWindowDTO{
+		StartsAt:   window.StartsAt.UTC().Format(time.RFC3339),
+		EndsAt:     window.EndsAt.UTC().Format(time.RFC3339),
+		Reason:     window.Reason,
+		Drain:      window.Drain,
+		InProgress: !now.Before(window.StartsAt) && now.Before(window.EndsAt),
+		Notified:   window.Notified,
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// MigrateSandbox godoc
+//
+//	@Tags			sandbox
+//	@Summary		Migrate a sandbox to another runner
+//	@Description	Export the sandbox to object storage in the background and, with a destination, hand it over to the destination runner and destroy it on this runner. Live migrations checkpoint the running processes so they continue on the destination.
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			request		body		dto.MigrateSandboxDTO	true	"Migrate sandbox"
+//	@Success		202			{object}	dto.OperationDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/migrate [post]
+//
+//	@id				MigrateSandbox
+func MigrateSandbox(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.MigrateSandboxDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	startOperation(ctx, enums.OperationTypeMigrate, sandboxId, func(ctx context.Context) (string, error) {
+		return runner.GetInstance(nil).Migration.Migrate(ctx, sandboxId, request)
+	})
+}
+
+// ReceiveMigration godoc
+//
+//	@Tags			sandbox
+//	@Summary		Take over a migrated sandbox
+//	@Description	Import a sandbox another runner exported for migration in the background, create it on this runner and expose its ports again
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			request		body		dto.ReceiveMigrationDTO	true	"Receive migration"
+//	@Success		202			{object}	dto.OperationDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Failure		503			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/migrate/receive [post]
+//
+//	@id				ReceiveMigration
+func ReceiveMigration(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.ReceiveMigrationDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	startOperation(ctx, enums.OperationTypeReceiveMigration, sandboxId, func(ctx context.Context) (string, error) {
+		return runner.GetInstance(nil).Migration.Receive(ctx, sandboxId, request)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOperationWaitTimeout = 30 * time.Second
+	maxOperationWaitTimeout     = 5 * time.Minute
+)
+
+// GetOperation godoc
+//
+//	@Tags			operations
+//	@Summary		Get operation
+//	@Description	Get the state of an operation started with async=true, finished operations are kept for an hour
+//	@Produce		json
+//	@Param			operationId	path		string	true	"Operation ID"
+//	@Success		200			{object}	dto.OperationDTO
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/operations/{operationId} [get]
+//
+//	@id				GetOperation
+func GetOperation(ctx *gin.Context) {
+	operation, err := runner.GetInstance(nil).Operations.Get(ctx.Param("operationId"), ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER))
+	respondWithOperation(ctx, http.StatusOK, operation, err)
+}
+
+// WaitOperation godoc
+//
+//	@Tags			operations
+//	@Summary		Wait for operation
+//	@Description	Wait until the operation finished or the timeout elapsed and get its state, the state is running if it timed out
+//	@Produce		json
+//	@Param			operationId	path		string	true	"Operation ID"
+//	@Param			timeout		query		string	false	"Duration like 30s to wait for at most, defaults to 30s and is at most 5m"
+//	@Success		200			{object}	dto.OperationDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/operations/{operationId}/wait [get]
+//
+//	@id				WaitOperation
+func WaitOperation(ctx *gin.Context) {
+	timeout := defaultOperationWaitTimeout
+	if value := ctx.Query("timeout"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 || parsed > maxOperationWaitTimeout {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid timeout: %s", value)))
+			return
+		}
+		timeout = parsed
+	}
+
+	operation, err := runner.GetInstance(nil).Operations.Wait(ctx.Request.Context(), ctx.Param("operationId"), ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER), timeout)
+	respondWithOperation(ctx, http.StatusOK, operation, err)
+}
+
+// CancelOperation godoc
+//
+//	@Tags			operations
+//	@Summary		Cancel operation
+//	@Description	Cancel a running operation, its state changes to cancelled once the work in progress was aborted
+//	@Produce		json
+//	@Param			operationId	path		string	true	"Operation ID"
+//	@Success		200			{object}	dto.OperationDTO
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/operations/{operationId}/cancel [post]
+//
+//	@id				CancelOperation
+func CancelOperation(ctx *gin.Context) {
+	operation, err := runner.GetInstance(nil).Operations.Cancel(ctx.Param("operationId"), ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER))
+	respondWithOperation(ctx, http.StatusOK, operation, err)
+}
+
+// startOperation responds with the operation running the work in the background
+func startOperation(ctx *gin.Context, operationType enums.OperationType, target string, run func(ctx context.Context) (string, error)) {
+	operation, err := runner.GetInstance(nil).Operations.Start(ctx.Request.Context(), operationType, target, ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER), run)
+	respondWithOperation(ctx, http.StatusAccepted, operation, err)
+}
+
+func respondWithOperation(ctx *gin.Context, status int, operation dto.OperationDTO, err error) {
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(status, operation)
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	proxy "github.com/daytonaio/common-go/pkg/proxy"
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ExposePort godoc
+//
+//	@Tags			sandbox
+//	@Summary		Expose a sandbox port
+//	@Description	Route a port of the sandbox through the runner proxy without recreating the sandbox, exposing an exposed port again changes its access level and desktop flag
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string				true	"Sandbox ID"
+//	@Param			request		body		dto.ExposePortDTO	true	"Expose port request"
+//	@Success		201			{object}	dto.ExposedPortDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/ports [post]
+//
+//	@id				ExposePort
+func ExposePort(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.ExposePortDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	// Sandbox ports other than the daemon port are only reachable through the network namespace of a rootless engine
+	if runner.Docker.Rootless() {
+		ctx.Error(common.NewBadRequestError(errors.New("exposing ports is not supported with a rootless container engine")))
+		return
+	}
+
+	_, err = runner.Docker.ContainerInspect(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(common.NewNotFoundError(err))
+		return
+	}
+
+	accessLevel := enums.PreviewAccessLevelAuthenticated
+	if request.AccessLevel != "" {
+		accessLevel = enums.PreviewAccessLevel(request.AccessLevel)
+	}
+
+	exposed, err := runner.Ports.Expose(sandboxId, request.Port, accessLevel, request.Desktop)
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to expose port %d of sandbox %s: %w", request.Port, sandboxId, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, getExposedPortDto(ctx, sandboxId, exposed))
+}
+
+// ListExposedPorts godoc
+//
+//	@Tags			sandbox
+//	@Summary		List exposed sandbox ports
+//	@Description	List the ports of the sandbox routed through the runner proxy
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{array}		dto.ExposedPortDTO
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/ports [get]
+//
+//	@id				ListExposedPorts
+func ListExposedPorts(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	result := []dto.ExposedPortDTO{}
+	for _, exposed := range runner.GetInstance(nil).Ports.List(sandboxId) {
+		result = append(result, getExposedPortDto(ctx, sandboxId, exposed))
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// UnexposePort godoc
+//
+//	@Tags			sandbox
+//	@Summary		Unexpose a sandbox port
+//	@Description	Stop routing the port of the sandbox through the runner proxy
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			port		path		int		true	"Sandbox port"
+//	@Success		200			{string}	string	"Port unexposed"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/ports/{port} [delete]
+//
+//	@id				UnexposePort
+func UnexposePort(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	port, err := parsePortParam(ctx)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(err))
+		return
+	}
+
+	unexposed, err := runner.GetInstance(nil).Ports.Unexpose(sandboxId, port)
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to unexpose port %d of sandbox %s: %w", port, sandboxId, err))
+		return
+	}
+
+	if !unexposed {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("port %d of sandbox %s is not exposed", port, sandboxId)))
+		return
+	}
+
+	// Cached responses are served before the port is checked, they must not outlive the port
+	if proxyCache := runner.GetInstance(nil).ProxyCache; proxyCache != nil {
+		proxyCache.RemovePort(sandboxId, strconv.Itoa(port))
+	}
+
+	ctx.JSON(http.StatusOK, "Port unexposed")
+}
+
+// ProxyPortRequest forwards requests to an exposed port of the sandbox
+//
+//	@Tags			toolbox
+//	@Summary		Proxy requests to an exposed sandbox port
+//	@Description	Forwards the request to the exposed port of the sandbox container
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			port		path		int		true	"Sandbox port"
+//	@Param			path		path		string	true	"Path to forward"
+//	@Success		200			{object}	any		"Proxied response"
+//	@Failure		400			{object}	string	"Bad request"
+//	@Failure		401			{object}	string	"Unauthorized"
+//	@Failure		404			{object}	string	"Port not exposed or sandbox container not found"
+//	@Failure		500			{object}	string	"Internal server error"
+//	@Router			/sandboxes/{sandboxId}/ports/{port}/{path} [get]
+//	@Router			/sandboxes/{sandboxId}/ports/{port}/{path} [post]
+//	@Router			/sandboxes/{sandboxId}/ports/{port}/{path} [delete]
+func ProxyPortRequest(ctx *gin.Context) {
+	if isWebSocketUpgrade(ctx.Request) {
+		target, _, err := getPortProxyTarget(ctx)
+		if err != nil {
+			// Error already sent to the context
+			return
+		}
+
+		port, _ := parsePortParam(ctx)
+		proxyWebSocket(ctx, target, getPortStreamOptions(ctx.Param("sandboxId"), port))
+		return
+	}
+
+	proxy.NewProxyRequestHandler(getPortProxyTarget)(ctx)
+}
+
+func getPortProxyTarget(ctx *gin.Context) (*url.URL, map[string]string, error) {
+	port, err := parsePortParam(ctx)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(err))
+		return nil, nil, err
+	}
+
+	sandboxId := ctx.Param("sandboxId")
+	if !runner.GetInstance(nil).Ports.IsExposed(sandboxId, port) {
+		err = fmt.Errorf("port %d of sandbox %s is not exposed", port, sandboxId)
+		ctx.Error(common.NewNotFoundError(err))
+		return nil, nil, err
+	}
+
+	containerIP, err := getSandboxIP(ctx)
+	if err != nil {
+		// Error already sent to the context
+		return nil, nil, err
+	}
+
+	path := ctx.Param("path")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return &url.URL{Scheme: "http", Host: fmt.Sprintf("%s:%d", containerIP, port), Path: path}, nil, nil
+}
+
+func parsePortParam(ctx *gin.Context) (int, error) {
+	port, err := strconv.Atoi(ctx.Param("port"))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, errors.New("port must be between 1 and 65535")
+	}
+
+	return port, nil
+}
+
+// getExposedPortDto builds the address of the port from the configured public URL, or from the address
+// the request reached the runner at
+func getExposedPortDto(ctx *gin.Context, sandboxId string, exposed services.ExposedPort) dto.ExposedPortDTO {
+	baseUrl := strings.TrimSuffix(config.GetProxyPublicUrl(), "/")
+	if baseUrl == "" {
+		scheme := "http"
+		if ctx.Request.TLS != nil {
+			scheme = "https"
+		}
+		baseUrl = fmt.Sprintf("%s://%s", scheme, ctx.Request.Host)
+	}
+
+	exposedPort := dto.ExposedPortDTO{
+		Port:        exposed.Port,
+		Url:         fmt.Sprintf("%s/sandboxes/%s/ports/%d/", baseUrl, sandboxId, exposed.Port),
+		AccessLevel: exposed.AccessLevel.String(),
+		Desktop:     exposed.Desktop,
+		ExposedAt:   exposed.ExposedAt,
+	}
+
+	if domain := config.GetPreviewDomain(); domain != "" {
+		exposedPort.PreviewUrl = fmt.Sprintf("%s://%s/", config.GetPreviewUrlScheme(), services.GetPreviewHost(sandboxId, exposed.Port, domain))
+	}
+
+	return exposedPort
+}
+
+// exposeDevcontainerPorts exposes the ports the devcontainer of the sandbox forwards, ports exposed before keep
+// their access level. Failures are logged since the sandbox itself was created.
+func exposeDevcontainerPorts(ctx context.Context, sandboxId string) {
+	runner := runner.GetInstance(nil)
+
+	ports, err := runner.Docker.GetDevcontainerPorts(ctx, sandboxId)
+	if err != nil {
+		log.Warnf("Failed to get the devcontainer ports of sandbox %s: %v", sandboxId, err)
+		return
+	}
+
+	if len(ports) > 0 && runner.Docker.Rootless() {
+		log.Warnf("Devcontainer ports of sandbox %s are not exposed, exposing ports is not supported with a rootless container engine", sandboxId)
+		return
+	}
+
+	for _, port := range ports {
+		if runner.Ports.IsExposed(sandboxId, port) {
+			continue
+		}
+
+		_, err = runner.Ports.Expose(sandboxId, port, enums.PreviewAccessLevelAuthenticated, false)
+		if err != nil {
+			log.Warnf("Failed to expose devcontainer port %d of sandbox %s: %v", port, sandboxId, err)
+		}
+	}
+}
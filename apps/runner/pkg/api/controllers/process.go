@@ -0,0 +1,87 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ListSandboxProcesses godoc
+//
+//	@Tags			sandbox
+//	@Summary		List sandbox processes
+//	@Description	List the processes of a running sandbox with their host PIDs, the parent PIDs make up the process tree
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{object}	dto.ListSandboxProcessesResponseDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/processes [get]
+//
+//	@id				ListSandboxProcesses
+func ListSandboxProcesses(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	processes, err := runner.GetInstance(nil).Docker.ListProcesses(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, processes)
+}
+
+// SignalProcess godoc
+//
+//	@Tags			sandbox
+//	@Summary		Signal a sandbox process
+//	@Description	Send a signal to a process of the sandbox by its host PID, e.g. to kill a runaway process
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			pid			path		int						true	"Host PID of the process"
+//	@Param			signal		body		dto.SignalProcessDTO	true	"Signal"
+//	@Success		200			{string}	string					"Signal sent"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/processes/{pid}/signal [post]
+//
+//	@id				SignalProcess
+func SignalProcess(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	pid, err := strconv.Atoi(ctx.Param("pid"))
+	if err != nil || pid <= 0 {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid pid: %s", ctx.Param("pid"))))
+		return
+	}
+
+	var request dto.SignalProcessDTO
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	err = runner.GetInstance(nil).Docker.SignalProcess(ctx.Request.Context(), sandboxId, pid, request.Signal)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Signal sent")
+}
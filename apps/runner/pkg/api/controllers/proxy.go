@@ -6,6 +6,7 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
@@ -13,9 +14,15 @@ import (
 	proxy "github.com/daytonaio/common-go/pkg/proxy"
 	"github.com/daytonaio/runner/pkg/common"
 	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/docker/docker/api/types"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
+// Toolbox routes running a command in the sandbox, they count towards the exec limit of the runner
+var execPathRegex = regexp.MustCompile(`^/process/(execute|session/[^/]+/exec)$`)
+
 // ProxyRequest handles proxying requests to a sandbox's container
 //
 //	@Tags			toolbox
@@ -28,11 +35,21 @@ import (
 //	@Failure		401			{object}	string	"Unauthorized"
 //	@Failure		404			{object}	string	"Sandbox container not found"
 //	@Failure		409			{object}	string	"Sandbox container conflict"
+//	@Failure		429			{object}	common.ErrorResponse	"Too many concurrent execs"
 //	@Failure		500			{object}	string	"Internal server error"
 //	@Router			/sandboxes/{sandboxId}/toolbox/{path} [get]
 //	@Router			/sandboxes/{sandboxId}/toolbox/{path} [post]
 //	@Router			/sandboxes/{sandboxId}/toolbox/{path} [delete]
 func ProxyRequest(ctx *gin.Context) {
+	if ctx.Request.Method == http.MethodPost && execPathRegex.MatchString(ctx.Param("path")) {
+		release, err := runner.GetInstance(nil).Docker.AcquireExec()
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		defer release()
+	}
+
 	if regexp.MustCompile(`^/process/session/.+/command/.+/logs$`).MatchString(ctx.Param("path")) {
 		if ctx.Query("follow") == "true" {
 			ProxyCommandLogsStream(ctx)
@@ -40,23 +57,74 @@ func ProxyRequest(ctx *gin.Context) {
 		}
 	}
 
+	if isWebSocketUpgrade(ctx.Request) {
+		target, _, err := getProxyTarget(ctx)
+		if err != nil {
+			// Error already sent to the context
+			return
+		}
+
+		proxyWebSocket(ctx, target, getStreamOptions())
+		return
+	}
+
 	proxy.NewProxyRequestHandler(getProxyTarget)(ctx)
 }
 
 func getProxyTarget(ctx *gin.Context) (*url.URL, map[string]string, error) {
-	runner := runner.GetInstance(nil)
+	daemonAddress, err := getSandboxDaemonAddress(ctx)
+	if err != nil {
+		// Error already sent to the context
+		return nil, nil, err
+	}
 
-	sandboxId := ctx.Param("sandboxId")
-	if sandboxId == "" {
-		ctx.Error(common.NewBadRequestError(errors.New("sandbox ID is required")))
-		return nil, nil, errors.New("sandbox ID is required")
+	// Build the target URL
+	targetURL := fmt.Sprintf("http://%s", daemonAddress)
+
+	// Get the wildcard path and normalize it
+	path := ctx.Param("path")
+
+	// Ensure path always has a leading slash but not duplicate slashes
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
 	}
 
-	// Get container details
-	container, err := runner.Docker.ContainerInspect(ctx.Request.Context(), sandboxId)
+	// Create the complete target URL with path
+	target, err := url.Parse(fmt.Sprintf("%s%s", targetURL, path))
 	if err != nil {
-		ctx.Error(common.NewNotFoundError(fmt.Errorf("sandbox container not found: %w", err)))
-		return nil, nil, fmt.Errorf("sandbox container not found: %w", err)
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("failed to parse target URL: %w", err)))
+		return nil, nil, fmt.Errorf("failed to parse target URL: %w", err)
+	}
+
+	// Continue the runner trace in the sandbox daemon
+	otel.GetTextMapPropagator().Inject(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+	return target, nil, nil
+}
+
+// getSandboxDaemonAddress resolves the address of the daemon of the sandbox referenced by the sandboxId path parameter
+func getSandboxDaemonAddress(ctx *gin.Context) (string, error) {
+	container, err := inspectSandbox(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	daemonAddress, err := runner.GetInstance(nil).Docker.GetDaemonAddress(container)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(err))
+		return "", err
+	}
+
+	return daemonAddress, nil
+}
+
+// getSandboxIP resolves the IP address of the sandbox container referenced by the sandboxId path parameter
+func getSandboxIP(ctx *gin.Context) (string, error) {
+	container, err := inspectSandbox(ctx)
+	if err != nil {
+		return "", err
 	}
 
 	var containerIP string
@@ -68,28 +136,27 @@ func getProxyTarget(ctx *gin.Context) (*url.URL, map[string]string, error) {
 	if containerIP == "" {
 		message := "no IP address found. Is the Sandbox started?"
 		ctx.Error(common.NewBadRequestError(errors.New(message)))
-		return nil, nil, errors.New(message)
+		return "", errors.New(message)
 	}
 
-	// Build the target URL
-	targetURL := fmt.Sprintf("http://%s:2280", containerIP)
+	return containerIP, nil
+}
 
-	// Get the wildcard path and normalize it
-	path := ctx.Param("path")
+// inspectSandbox inspects the sandbox container referenced by the sandboxId path parameter
+func inspectSandbox(ctx *gin.Context) (*types.ContainerJSON, error) {
+	runner := runner.GetInstance(nil)
 
-	// Ensure path always has a leading slash but not duplicate slashes
-	if path == "" {
-		path = "/"
-	} else if !strings.HasPrefix(path, "/") {
-		path = "/" + path
+	sandboxId := ctx.Param("sandboxId")
+	if sandboxId == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("sandbox ID is required")))
+		return nil, errors.New("sandbox ID is required")
 	}
 
-	// Create the complete target URL with path
-	target, err := url.Parse(fmt.Sprintf("%s%s", targetURL, path))
+	container, err := runner.Docker.ContainerInspect(ctx.Request.Context(), sandboxId)
 	if err != nil {
-		ctx.Error(common.NewBadRequestError(fmt.Errorf("failed to parse target URL: %w", err)))
-		return nil, nil, fmt.Errorf("failed to parse target URL: %w", err)
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("sandbox container not found: %w", err)))
+		return nil, fmt.Errorf("sandbox container not found: %w", err)
 	}
 
-	return target, nil, nil
+	return &container, nil
 }
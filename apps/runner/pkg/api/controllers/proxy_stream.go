@@ -0,0 +1,190 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/runner"
+)
+
+const (
+	streamBufferSize = 32 * 1024
+	// Framebuffer updates are large bursts, a bigger buffer moves them in fewer writes
+	desktopStreamBufferSize = 256 * 1024
+	// A desktop viewer that doesn't read for this long is gone, waiting on it only stalls the sandbox side
+	desktopStreamWriteTimeout = time.Minute
+	// Desktop sessions can be silent for a long time, keepalives stop load balancers and NATs from dropping them
+	desktopStreamKeepAlive = 15 * time.Second
+	// Idle detection only needs to hear about long lived streams every now and then
+	streamActivityInterval = 30 * time.Second
+)
+
+type streamOptions struct {
+	// idleTimeout closes the stream once neither side sent anything for this long, 0 disables it
+	idleTimeout time.Duration
+	// writeTimeout closes the stream once a side doesn't accept data for this long, 0 disables it.
+	// Until then a side that can't keep up blocks reading from the other side, nothing is buffered.
+	writeTimeout time.Duration
+	bufferSize   int
+	keepAlive    time.Duration
+	// onActivity is called at most every streamActivityInterval while data flows
+	onActivity func()
+}
+
+func getStreamOptions() streamOptions {
+	return streamOptions{
+		idleTimeout: config.GetProxyStreamIdleTimeout(),
+		bufferSize:  streamBufferSize,
+	}
+}
+
+// getPortStreamOptions disables the idle timeout of desktop ports and keeps their sandbox active while the
+// session streams
+func getPortStreamOptions(sandboxId string, port int) streamOptions {
+	options := getStreamOptions()
+
+	runner := runner.GetInstance(nil)
+	exposed, ok := runner.Ports.Get(sandboxId, port)
+	if !ok || !exposed.Desktop {
+		return options
+	}
+
+	options.idleTimeout = 0
+	options.writeTimeout = desktopStreamWriteTimeout
+	options.bufferSize = desktopStreamBufferSize
+	options.keepAlive = desktopStreamKeepAlive
+	if idleService := runner.IdleService; idleService != nil {
+		options.onActivity = func() {
+			idleService.RecordActivity(sandboxId)
+		}
+	}
+
+	return options
+}
+
+// pipeConnections copies raw bytes in both directions until either side closes
+func pipeConnections(clientConn, backendConn net.Conn, options streamOptions) error {
+	if options.keepAlive > 0 {
+		setKeepAlive(clientConn, options.keepAlive)
+		setKeepAlive(backendConn, options.keepAlive)
+	}
+
+	stream := &stream{options: options}
+	stream.lastActivity.Store(time.Now().UnixNano())
+
+	errChan := make(chan error, 2)
+	go func() {
+		errChan <- stream.copy(backendConn, clientConn)
+	}()
+	go func() {
+		errChan <- stream.copy(clientConn, backendConn)
+	}()
+
+	err := <-errChan
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
+
+type stream struct {
+	options      streamOptions
+	lastActivity atomic.Int64
+	lastReported atomic.Int64
+}
+
+// copy moves data from src to dst with a single buffer so a slow dst holds back src instead of growing memory
+func (s *stream) copy(dst, src net.Conn) error {
+	if s.options.idleTimeout == 0 && s.options.writeTimeout == 0 && s.options.onActivity == nil {
+		_, err := io.CopyBuffer(dst, src, make([]byte, s.options.bufferSize))
+		return err
+	}
+
+	buffer := make([]byte, s.options.bufferSize)
+	for {
+		if s.options.idleTimeout > 0 {
+			err := src.SetReadDeadline(time.Now().Add(s.options.idleTimeout))
+			if err != nil {
+				return err
+			}
+		}
+
+		n, err := src.Read(buffer)
+		if n > 0 {
+			s.recordActivity()
+
+			if s.options.writeTimeout > 0 {
+				deadlineErr := dst.SetWriteDeadline(time.Now().Add(s.options.writeTimeout))
+				if deadlineErr != nil {
+					return deadlineErr
+				}
+			}
+
+			_, writeErr := dst.Write(buffer[:n])
+			if writeErr != nil {
+				return writeErr
+			}
+		}
+
+		if err != nil {
+			// The other direction may have carried traffic in the meantime
+			if errors.Is(err, os.ErrDeadlineExceeded) && s.idleFor() < s.options.idleTimeout {
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *stream) recordActivity() {
+	now := time.Now()
+	s.lastActivity.Store(now.UnixNano())
+
+	if s.options.onActivity == nil {
+		return
+	}
+
+	lastReported := s.lastReported.Load()
+	if now.Sub(time.Unix(0, lastReported)) < streamActivityInterval {
+		return
+	}
+	if s.lastReported.CompareAndSwap(lastReported, now.UnixNano()) {
+		s.options.onActivity()
+	}
+}
+
+func (s *stream) idleFor() time.Duration {
+	return time.Since(time.Unix(0, s.lastActivity.Load()))
+}
+
+func setKeepAlive(conn net.Conn, period time.Duration) {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		// TLS connections wrap the TCP connection
+		netConn, ok := conn.(interface{ NetConn() net.Conn })
+		if !ok {
+			return
+		}
+		tcpConn, ok = netConn.NetConn().(*net.TCPConn)
+		if !ok {
+			return
+		}
+	}
+
+	tcpConn.SetKeepAlive(true)
+	tcpConn.SetKeepAlivePeriod(period)
+	// Input events are tiny, they must not wait for more data to be sent along
+	tcpConn.SetNoDelay(true)
+}
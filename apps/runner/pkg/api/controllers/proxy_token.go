@@ -0,0 +1,58 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// RefreshProxyToken godoc
+//
+//	@Tags			sandbox
+//	@Summary		Refresh sandbox proxy token
+//	@Description	Issue a token granting access to the sandbox toolbox proxy only, previously issued tokens of the sandbox are revoked
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{object}	dto.ProxyTokenResponseDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/proxy-token [post]
+//
+//	@id				RefreshProxyToken
+func RefreshProxyToken(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	if runner.ProxyTokenIssuer == nil {
+		ctx.Error(common.NewBadRequestError(errors.New("proxy tokens are not enabled on this runner")))
+		return
+	}
+
+	_, err := runner.Docker.ContainerInspect(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(common.NewNotFoundError(err))
+		return
+	}
+
+	token, expiresAt, err := runner.ProxyTokenIssuer.Refresh(sandboxId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ProxyTokenResponseDTO{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+const tcpTunnelProtocol = "tcp"
+
+// TunnelTCP opens a raw TCP tunnel to a port inside the sandbox
+//
+//	@Tags			toolbox
+//	@Summary		Open a TCP tunnel to a sandbox port
+//	@Description	Upgrades the connection (Upgrade: tcp) and forwards raw bytes to the given port of the sandbox container
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			port		path		int		true	"Sandbox port"
+//	@Success		101			{string}	string	"Switching protocols"
+//	@Failure		400			{object}	string	"Bad request"
+//	@Failure		401			{object}	string	"Unauthorized"
+//	@Failure		404			{object}	string	"Sandbox container not found"
+//	@Failure		409			{object}	string	"Sandbox container conflict"
+//	@Failure		500			{object}	string	"Internal server error"
+//	@Failure		502			{object}	string	"Sandbox port unreachable"
+//	@Router			/sandboxes/{sandboxId}/tunnel/{port} [get]
+//
+//	@id				TunnelTCP
+func TunnelTCP(ctx *gin.Context) {
+	if !strings.EqualFold(ctx.GetHeader("Upgrade"), tcpTunnelProtocol) {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("expected Upgrade: %s header", tcpTunnelProtocol)))
+		return
+	}
+
+	port, err := strconv.Atoi(ctx.Param("port"))
+	if err != nil || port < 1 || port > 65535 {
+		ctx.Error(common.NewBadRequestError(errors.New("port must be between 1 and 65535")))
+		return
+	}
+
+	// Sandbox ports other than the daemon port are only reachable through the network namespace of a rootless engine
+	if runner.GetInstance(nil).Docker.Rootless() {
+		ctx.Error(common.NewBadRequestError(errors.New("TCP tunnels are not supported with a rootless container engine")))
+		return
+	}
+
+	containerIP, err := getSandboxIP(ctx)
+	if err != nil {
+		// Error already sent to the context
+		return
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	backendConn, err := dialer.DialContext(ctx.Request.Context(), "tcp", net.JoinHostPort(containerIP, strconv.Itoa(port)))
+	if err != nil {
+		ctx.Error(common.NewCustomError(http.StatusBadGateway, fmt.Sprintf("failed to connect to sandbox port %d: %v", port, err), "BAD_GATEWAY"))
+		return
+	}
+	defer backendConn.Close()
+
+	clientConn, clientBuf, err := ctx.Writer.Hijack()
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to hijack connection: %w", err))
+		return
+	}
+	defer clientConn.Close()
+
+	_, err = fmt.Fprintf(clientConn, "HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n", tcpTunnelProtocol)
+	if err != nil {
+		log.Debugf("Failed to write tunnel upgrade response: %v", err)
+		return
+	}
+
+	// Forward anything the client sent right after the upgrade request that was already buffered
+	if buffered := clientBuf.Reader.Buffered(); buffered > 0 {
+		_, err = io.CopyN(backendConn, clientBuf, int64(buffered))
+		if err != nil {
+			log.Debugf("Failed to forward buffered tunnel data: %v", err)
+			return
+		}
+	}
+
+	err = pipeConnections(clientConn, backendConn, getPortStreamOptions(ctx.Param("sandboxId"), port))
+	if err != nil {
+		log.Debugf("TCP tunnel to sandbox %s port %d closed: %v", ctx.Param("sandboxId"), port, err)
+	}
+}
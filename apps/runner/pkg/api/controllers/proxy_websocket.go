@@ -0,0 +1,73 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+func isWebSocketUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(req.Header.Get("Connection")), "upgrade")
+}
+
+// proxyWebSocket forwards the upgrade request to the target and then copies raw bytes in both
+// directions between the hijacked client connection and the sandbox connection until either side closes
+func proxyWebSocket(ctx *gin.Context, target *url.URL, options streamOptions) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	backendConn, err := dialer.DialContext(ctx.Request.Context(), "tcp", target.Host)
+	if err != nil {
+		ctx.Error(common.NewCustomError(http.StatusBadGateway, fmt.Sprintf("failed to connect to sandbox: %v", err), "BAD_GATEWAY"))
+		return
+	}
+	defer backendConn.Close()
+
+	// The connection outlives the request context once hijacked
+	outReq := ctx.Request.Clone(context.Background())
+	outReq.URL = &url.URL{
+		Scheme:   target.Scheme,
+		Host:     target.Host,
+		Path:     target.Path,
+		RawQuery: ctx.Request.URL.RawQuery,
+	}
+	outReq.Host = target.Host
+	outReq.RequestURI = ""
+
+	err = outReq.Write(backendConn)
+	if err != nil {
+		ctx.Error(common.NewCustomError(http.StatusBadGateway, fmt.Sprintf("failed to forward upgrade request: %v", err), "BAD_GATEWAY"))
+		return
+	}
+
+	clientConn, clientBuf, err := ctx.Writer.Hijack()
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to hijack connection: %w", err))
+		return
+	}
+	defer clientConn.Close()
+
+	// Forward anything the client sent right after the upgrade request that was already buffered
+	if buffered := clientBuf.Reader.Buffered(); buffered > 0 {
+		_, err = io.CopyN(backendConn, clientBuf, int64(buffered))
+		if err != nil {
+			log.Debugf("Failed to forward buffered websocket data: %v", err)
+			return
+		}
+	}
+
+	err = pipeConnections(clientConn, backendConn, options)
+	if err != nil {
+		log.Debugf("WebSocket proxy connection closed: %v", err)
+	}
+}
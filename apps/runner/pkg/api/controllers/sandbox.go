@@ -4,13 +4,22 @@
 package controllers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/daytonaio/runner/pkg/api/dto"
 	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models"
 	"github.com/daytonaio/runner/pkg/models/enums"
 	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+	"github.com/daytonaio/runner/pkg/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,14 +27,20 @@ import (
 //
 //	@Tags			sandbox
 //	@Summary		Create a sandbox
-//	@Description	Create a sandbox
-//	@Param			sandbox	body	dto.CreateSandboxDTO	true	"Create sandbox"
+//	@Description	Create a sandbox, with async=true the sandbox is created in the background and the operation is returned
+//	@Param			sandbox			body	dto.CreateSandboxDTO	true	"Create sandbox"
+//	@Param			async			query	boolean					false	"Whether to create the sandbox in the background"
+//	@Param			Idempotency-Key	header	string					false	"Key to replay the response of a retried request"
 //	@Produce		json
 //	@Success		201	{string}	containerId
+//	@Success		202	{object}	dto.OperationDTO
 //	@Failure		400	{object}	common.ErrorResponse
 //	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
 //	@Failure		404	{object}	common.ErrorResponse
 //	@Failure		409	{object}	common.ErrorResponse
+//	@Failure		422	{object}	common.ErrorResponse
+//	@Failure		429	{object}	common.ErrorResponse
 //	@Failure		500	{object}	common.ErrorResponse
 //	@Router			/sandboxes [post]
 //
@@ -38,19 +53,103 @@ func Create(ctx *gin.Context) {
 		return
 	}
 
-	runner := runner.GetInstance(nil)
+	// Refused before the sandbox is known to the runner so the control plane can place it elsewhere
+	err = runner.GetInstance(nil).Labels.Admit(createSandboxDto)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
-	containerId, err := runner.Docker.Create(ctx.Request.Context(), createSandboxDto)
+	if ctx.Query("async") == "true" {
+		startOperation(ctx, enums.OperationTypeCreate, createSandboxDto.Id, func(ctx context.Context) (string, error) {
+			return createSandbox(ctx, createSandboxDto)
+		})
+		return
+	}
+
+	containerId, err := createSandbox(ctx.Request.Context(), createSandboxDto)
 	if err != nil {
-		runner.Cache.SetSandboxState(ctx, createSandboxDto.Id, enums.SandboxStateError)
-		common.ContainerOperationCount.WithLabelValues("create", string(common.PrometheusOperationStatusFailure)).Inc()
 		ctx.Error(err)
 		return
 	}
 
+	ctx.JSON(http.StatusCreated, containerId)
+}
+
+func createSandbox(ctx context.Context, createSandboxDto dto.CreateSandboxDTO) (string, error) {
+	runner := runner.GetInstance(nil)
+
+	// Matching creates are served from the warm pool when it has a sandbox ready
+	containerId, err := runner.WarmPool.Claim(ctx, createSandboxDto)
+	if err == nil && containerId == "" {
+		containerId, err = runner.Backend.Create(ctx, createSandboxDto)
+	}
+	if err != nil {
+		// Cancelled and refused creates are rolled back instead
+		if ctx.Err() == nil && !common.IsResourceExhaustedError(err) {
+			runner.Cache.SetSandboxState(ctx, createSandboxDto.Id, enums.SandboxStateError)
+		}
+		common.ContainerOperationCount.WithLabelValues("create", string(common.PrometheusOperationStatusFailure)).Inc()
+		return "", err
+	}
+
 	common.ContainerOperationCount.WithLabelValues("create", string(common.PrometheusOperationStatusSuccess)).Inc()
 
-	ctx.JSON(http.StatusCreated, containerId)
+	if createSandboxDto.Devcontainer != nil {
+		exposeDevcontainerPorts(ctx, containerId)
+	}
+
+	return containerId, nil
+}
+
+// ListSandboxes godoc
+//
+//	@Tags			sandbox
+//	@Summary		List sandboxes
+//	@Description	List the sandboxes known to the runner cache and the sandbox containers, cached states are reconciled with the containers
+//	@Produce		json
+//	@Param			state		query		[]string	false	"Filter by state"						collectionFormat(multi)
+//	@Param			label		query		[]string	false	"Filter by label (key or key=value)"	collectionFormat(multi)
+//	@Param			snapshot	query		string		false	"Filter by snapshot"
+//	@Param			page		query		int			false	"Page number, starting at 1"
+//	@Param			limit		query		int			false	"Page size, defaults to 100 and is at most 1000"
+//	@Success		200			{object}	dto.ListSandboxesResponseDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes [get]
+//
+//	@id				ListSandboxes
+func ListSandboxes(ctx *gin.Context) {
+	filter := services.SandboxListFilter{
+		Labels:   ctx.QueryArray("label"),
+		Snapshot: ctx.Query("snapshot"),
+	}
+
+	for _, state := range ctx.QueryArray("state") {
+		filter.States = append(filter.States, enums.SandboxState(state))
+	}
+
+	var err error
+	filter.Page, err = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid page: %w", err)))
+		return
+	}
+
+	filter.Limit, err = strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(services.DefaultSandboxListLimit)))
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid limit: %w", err)))
+		return
+	}
+
+	sandboxes, err := runner.GetInstance(nil).SandboxService.ListSandboxes(ctx.Request.Context(), filter)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sandboxes)
 }
 
 // Destroy 			godoc
@@ -74,7 +173,7 @@ func Destroy(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err := runner.Docker.Destroy(ctx.Request.Context(), sandboxId)
+	err := runner.Backend.Destroy(ctx.Request.Context(), sandboxId)
 	if err != nil {
 		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
 		common.ContainerOperationCount.WithLabelValues("destroy", string(common.PrometheusOperationStatusFailure)).Inc()
@@ -93,14 +192,16 @@ func Destroy(ctx *gin.Context) {
 //	@Summary		Create sandbox backup
 //	@Description	Create sandbox backup
 //	@Produce		json
-//	@Param			sandboxId	path		string				true	"Sandbox ID"
-//	@Param			sandbox		body		dto.CreateBackupDTO	true	"Create backup"
-//	@Success		201			{string}	string				"Backup started"
-//	@Failure		400			{object}	common.ErrorResponse
-//	@Failure		401			{object}	common.ErrorResponse
-//	@Failure		404			{object}	common.ErrorResponse
-//	@Failure		409			{object}	common.ErrorResponse
-//	@Failure		500			{object}	common.ErrorResponse
+//	@Param			sandboxId		path		string				true	"Sandbox ID"
+//	@Param			sandbox			body		dto.CreateBackupDTO	true	"Create backup"
+//	@Param			Idempotency-Key	header		string				false	"Key to replay the response of a retried request"
+//	@Success		201				{string}	string				"Backup started"
+//	@Failure		400				{object}	common.ErrorResponse
+//	@Failure		401				{object}	common.ErrorResponse
+//	@Failure		404				{object}	common.ErrorResponse
+//	@Failure		409				{object}	common.ErrorResponse
+//	@Failure		429				{object}	common.ErrorResponse
+//	@Failure		500				{object}	common.ErrorResponse
 //	@Router			/sandboxes/{sandboxId}/backup [post]
 //
 //	@id				CreateBackup
@@ -116,9 +217,12 @@ func CreateBackup(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err = runner.Docker.StartBackupCreate(ctx.Request.Context(), sandboxId, createBackupDTO)
+	err = runner.Backend.StartBackupCreate(ctx.Request.Context(), sandboxId, createBackupDTO)
 	if err != nil {
-		runner.Cache.SetBackupState(ctx, sandboxId, enums.BackupStateFailed, err)
+		// A refused backup never started, the state of the previous one stays
+		if !common.IsResourceExhaustedError(err) {
+			runner.Cache.SetBackupState(ctx, sandboxId, enums.BackupStateFailed, err)
+		}
 		ctx.Error(err)
 		return
 	}
@@ -126,11 +230,175 @@ func CreateBackup(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, "Backup started")
 }
 
+// CreateSnapshotFromSandbox godoc
+//
+//	@Tags			sandbox
+//	@Summary		Create a snapshot from a sandbox
+//	@Description	Commit the sandbox filesystem and config as a snapshot recording its lineage, optionally squashed and pushed
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string								true	"Sandbox ID"
+//	@Param			request		body		dto.CreateSnapshotFromSandboxDTO	true	"Create snapshot request"
+//	@Success		201			{object}	dto.CreateSnapshotFromSandboxResponseDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/snapshot [post]
+//
+//	@id				CreateSnapshotFromSandbox
+func CreateSnapshotFromSandbox(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.CreateSnapshotFromSandboxDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	if !strings.Contains(request.Snapshot, ":") || strings.HasSuffix(request.Snapshot, ":") {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshot name must include a valid tag")))
+		return
+	}
+
+	if request.Push && request.Registry == nil {
+		ctx.Error(common.NewBadRequestError(errors.New("registry is required when pushing the snapshot")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	id, err := runner.Backend.CreateSnapshotFromSandbox(ctx.Request.Context(), sandboxId, request.Snapshot, request.Squash)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := dto.CreateSnapshotFromSandboxResponseDTO{
+		Snapshot: request.Snapshot,
+		Id:       id,
+	}
+
+	if request.Push {
+		tag := fmt.Sprintf("%s/%s", request.Registry.Url, request.Snapshot)
+		if request.Registry.Project != nil && *request.Registry.Project != "" {
+			tag = fmt.Sprintf("%s/%s/%s", request.Registry.Url, *request.Registry.Project, request.Snapshot)
+		}
+
+		err = runner.Backend.TagImage(ctx.Request.Context(), request.Snapshot, tag)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		err = runner.Backend.PushImage(ctx.Request.Context(), tag, request.Registry)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		response.PushedTo = tag
+	}
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// CloneSandbox godoc
+//
+//	@Tags			sandbox
+//	@Summary		Clone a sandbox
+//	@Description	Commit the sandbox, copy its volumes and create a new sandbox from the result
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string				true	"Sandbox ID"
+//	@Param			request		body		dto.CloneSandboxDTO	true	"Clone sandbox request"
+//	@Success		201			{string}	containerId
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/clone [post]
+//
+//	@id				CloneSandbox
+func CloneSandbox(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.CloneSandboxDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	if request.Snapshot != "" && (!strings.Contains(request.Snapshot, ":") || strings.HasSuffix(request.Snapshot, ":")) {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshot name must include a valid tag")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	containerId, err := runner.Backend.CloneSandbox(ctx.Request.Context(), sandboxId, request)
+	if err != nil {
+		common.ContainerOperationCount.WithLabelValues("clone", string(common.PrometheusOperationStatusFailure)).Inc()
+		ctx.Error(err)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("clone", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	ctx.JSON(http.StatusCreated, containerId)
+}
+
+// RestoreBackup godoc
+//
+//	@Tags			sandbox
+//	@Summary		Restore sandbox backup
+//	@Description	Restore the snapshot and volumes of a backup exported to object storage so the sandbox can be created on this runner
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			sandbox		body		dto.RestoreBackupDTO	true	"Restore backup"
+//	@Success		201			{string}	string					"Backup restore started"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		429			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/backup/restore [post]
+//
+//	@id				RestoreBackup
+func RestoreBackup(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var restoreBackupDTO dto.RestoreBackupDTO
+	err := ctx.ShouldBindJSON(&restoreBackupDTO)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Backend.StartBackupRestore(ctx.Request.Context(), sandboxId, restoreBackupDTO)
+	if err != nil {
+		if !common.IsResourceExhaustedError(err) {
+			runner.Cache.SetBackupState(ctx, sandboxId, enums.BackupStateFailed, err)
+		}
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, "Backup restore started")
+}
+
 // Resize 			godoc
 //
 //	@Tags			sandbox
 //	@Summary		Resize sandbox
-//	@Description	Resize sandbox
+//	@Description	Update CPU, memory and swap limits of the sandbox without recreating it
 //	@Produce		json
 //	@Param			sandboxId	path		string					true	"Sandbox ID"
 //	@Param			sandbox		body		dto.ResizeSandboxDTO	true	"Resize sandbox"
@@ -155,7 +423,7 @@ func Resize(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err = runner.Docker.Resize(ctx.Request.Context(), sandboxId, resizeDto)
+	err = runner.Backend.Resize(ctx.Request.Context(), sandboxId, resizeDto)
 	if err != nil {
 		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
 		ctx.Error(err)
@@ -198,22 +466,16 @@ func UpdateNetworkSettings(ctx *gin.Context) {
 		ctx.Error(common.NewInvalidBodyRequestError(err))
 		return
 	}
-	containerShortId := info.ID[:12]
 
 	// Return error if container does not have an IP address
-	if info.NetworkSettings.IPAddress == "" {
+	if sandboxnet.GetContainerIP(info.NetworkSettings.Networks) == "" {
 		ctx.Error(common.NewInvalidBodyRequestError(errors.New("sandbox does not have an IP address")))
 		return
 	}
 
-	if updateNetworkSettingsDto.NetworkBlockAll != nil && *updateNetworkSettingsDto.NetworkBlockAll {
-		err = runner.NetRulesManager.SetNetWorkRules(containerShortId, info.NetworkSettings.IPAddress, "")
-		if err != nil {
-			ctx.Error(common.NewInvalidBodyRequestError(err))
-			return
-		}
-	} else if updateNetworkSettingsDto.NetworkAllowList != nil {
-		err = runner.NetRulesManager.SetNetWorkRules(containerShortId, info.NetworkSettings.IPAddress, *updateNetworkSettingsDto.NetworkAllowList)
+	egressPolicy := docker.GetEgressPolicy(updateNetworkSettingsDto.NetworkBlockAll, updateNetworkSettingsDto.NetworkAllowList, updateNetworkSettingsDto.EgressPolicy)
+	if egressPolicy != nil {
+		err = runner.SandboxNetwork.ApplyEgressPolicy(ctx.Request.Context(), sandboxId, *egressPolicy)
 		if err != nil {
 			ctx.Error(common.NewInvalidBodyRequestError(err))
 			return
@@ -279,13 +541,16 @@ func Start(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err := runner.Docker.Start(ctx.Request.Context(), sandboxId)
+	err := runner.Backend.Start(ctx.Request.Context(), sandboxId)
 	if err != nil {
 		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("start", string(common.PrometheusOperationStatusFailure)).Inc()
 		ctx.Error(err)
 		return
 	}
 
+	common.ContainerOperationCount.WithLabelValues("start", string(common.PrometheusOperationStatusSuccess)).Inc()
+
 	ctx.JSON(http.StatusOK, "Sandbox started")
 }
 
@@ -293,10 +558,12 @@ func Start(ctx *gin.Context) {
 //
 //	@Tags			sandbox
 //	@Summary		Stop sandbox
-//	@Description	Stop sandbox
+//	@Description	Stop sandbox with SIGTERM and kill it if it doesn't exit within the grace period
+//	@Accept			json
 //	@Produce		json
-//	@Param			sandboxId	path		string	true	"Sandbox ID"
-//	@Success		200			{string}	string	"Sandbox stopped"
+//	@Param			sandboxId	path		string				true	"Sandbox ID"
+//	@Param			stop		body		dto.StopSandboxDTO	false	"Stop options"
+//	@Success		200			{object}	dto.StopSandboxResponseDTO
 //	@Failure		400			{object}	common.ErrorResponse
 //	@Failure		401			{object}	common.ErrorResponse
 //	@Failure		404			{object}	common.ErrorResponse
@@ -306,18 +573,174 @@ func Start(ctx *gin.Context) {
 //
 //	@id				Stop
 func Stop(ctx *gin.Context) {
+	// The body is optional, a stop without one uses the stop timeout of the runner
+	var stopDto dto.StopSandboxDTO
+	err := ctx.ShouldBindJSON(&stopDto)
+	if err != nil && !errors.Is(err, io.EOF) {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
 	sandboxId := ctx.Param("sandboxId")
 
 	runner := runner.GetInstance(nil)
 
-	err := runner.Docker.Stop(ctx.Request.Context(), sandboxId)
+	result, err := runner.Backend.Stop(ctx.Request.Context(), sandboxId, stopDto)
 	if err != nil {
 		runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		common.ContainerOperationCount.WithLabelValues("stop", string(common.PrometheusOperationStatusFailure)).Inc()
 		ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, "Sandbox stopped")
+	common.ContainerOperationCount.WithLabelValues("stop", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Pause 			godoc
+//
+//	@Tags			sandbox
+//	@Summary		Pause sandbox
+//	@Description	Pause all processes in the sandbox without stopping it
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Sandbox paused"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/pause [post]
+//
+//	@id				Pause
+func Pause(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Backend.Pause(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		if !common.IsConflictError(err) {
+			runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		}
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Sandbox paused")
+}
+
+// Resume 			godoc
+//
+//	@Tags			sandbox
+//	@Summary		Resume sandbox
+//	@Description	Resume a paused sandbox
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{string}	string	"Sandbox resumed"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/resume [post]
+//
+//	@id				Resume
+func Resume(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Backend.Resume(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		if !common.IsConflictError(err) {
+			runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		}
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Sandbox resumed")
+}
+
+// Checkpoint 			godoc
+//
+//	@Tags			sandbox
+//	@Summary		Checkpoint sandbox
+//	@Description	Persist the sandbox process state to object storage using CRIU (experimental)
+//	@Produce		json
+//	@Param			sandboxId	path		string						true	"Sandbox ID"
+//	@Param			checkpoint	body		dto.CheckpointSandboxDTO	true	"Checkpoint sandbox"
+//	@Success		200			{string}	string						"Sandbox checkpointed"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/checkpoint [post]
+//
+//	@id				Checkpoint
+func Checkpoint(ctx *gin.Context) {
+	var checkpointDto dto.CheckpointSandboxDTO
+	err := ctx.ShouldBindJSON(&checkpointDto)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Backend.Checkpoint(ctx.Request.Context(), sandboxId, checkpointDto)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Sandbox checkpointed")
+}
+
+// RestoreCheckpoint 	godoc
+//
+//	@Tags			sandbox
+//	@Summary		Restore sandbox from checkpoint
+//	@Description	Start a stopped sandbox from a checkpoint stored in object storage (experimental)
+//	@Produce		json
+//	@Param			sandboxId	path		string						true	"Sandbox ID"
+//	@Param			checkpoint	body		dto.RestoreCheckpointDTO	true	"Restore checkpoint"
+//	@Success		200			{string}	string						"Sandbox restored"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/checkpoint/restore [post]
+//
+//	@id				RestoreCheckpoint
+func RestoreCheckpoint(ctx *gin.Context) {
+	var restoreDto dto.RestoreCheckpointDTO
+	err := ctx.ShouldBindJSON(&restoreDto)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Backend.RestoreCheckpoint(ctx.Request.Context(), sandboxId, restoreDto)
+	if err != nil {
+		if !common.IsConflictError(err) && !common.IsBadRequestError(err) {
+			runner.Cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		}
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Sandbox restored")
 }
 
 // Info godoc
@@ -343,17 +766,46 @@ func Info(ctx *gin.Context) {
 
 	info := runner.SandboxService.GetSandboxStatesInfo(ctx.Request.Context(), sandboxId)
 
+	daemonHealth := info.DaemonHealth
+	if daemonHealth == "" {
+		daemonHealth = enums.DaemonHealthUnknown
+	}
+
+	warnings, err := runner.Docker.GetSandboxWarnings(sandboxId)
+	if err != nil {
+		log.Warnf("Failed to read warnings of sandbox %s: %v", sandboxId, err)
+	}
+
+	// Ignore err because the runtime is only known while the sandbox has a container
+	containerRuntime, _ := runner.Backend.GetSandboxRuntime(ctx.Request.Context(), sandboxId)
+
 	ctx.JSON(http.StatusOK, SandboxInfoResponse{
-		State:       info.SandboxState,
-		BackupState: info.BackupState,
-		BackupError: info.BackupErrorReason,
+		State:             info.SandboxState,
+		BackupState:       info.BackupState,
+		BackupError:       info.BackupErrorReason,
+		Resources:         info.Resources,
+		PullQueuePosition: info.PullQueuePosition,
+		BackupProgress:    info.BackupProgress,
+		Expiration:        info.Expiration,
+		Runtime:           containerRuntime,
+		DaemonHealth:      daemonHealth,
+		Warnings:          warnings,
+		Repository:        info.Repository,
 	})
 }
 
 type SandboxInfoResponse struct {
-	State       enums.SandboxState `json:"state"`
-	BackupState enums.BackupState  `json:"backupState"`
-	BackupError *string            `json:"backupError,omitempty"`
+	State             enums.SandboxState        `json:"state"`
+	BackupState       enums.BackupState         `json:"backupState"`
+	BackupError       *string                   `json:"backupError,omitempty"`
+	Resources         *models.SandboxResources  `json:"resources,omitempty"`
+	PullQueuePosition *int                      `json:"pullQueuePosition,omitempty"` // Position in the runner pull queue while the snapshot pull waits for a free slot
+	BackupProgress    *models.BackupProgress    `json:"backupProgress,omitempty"`    // Phase and transferred bytes of a running object storage backup or restore
+	Expiration        *models.SandboxExpiration `json:"expiration,omitempty"`        // When and how a sandbox created with a TTL expires
+	Runtime           string                    `json:"runtime,omitempty"`           // OCI runtime the sandbox container runs under
+	DaemonHealth      enums.DaemonHealth        `json:"daemonHealth"`                // Result of the last probe of the sandbox daemon, UNKNOWN unless the sandbox is started
+	Warnings          []dto.SandboxWarningDTO   `json:"warnings,omitempty"`          // Latest failures of lifecycle hooks with the WARN failure policy
+	Repository        *models.RepositoryStatus  `json:"repository,omitempty"`        // Progress or outcome of the clone of the repository the sandbox was created with
 } //	@name	SandboxInfoResponse
 
 // RemoveDestroyed godoc
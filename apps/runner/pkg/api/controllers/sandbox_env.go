@@ -0,0 +1,118 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSandboxEnv godoc
+//
+//	@Tags			sandbox
+//	@Summary		Get sandbox env
+//	@Description	Get the environment variables set on the sandbox after it was created and the names of its secrets
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Success		200			{object}	dto.SandboxEnvDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/env [get]
+//
+//	@id				GetSandboxEnv
+func GetSandboxEnv(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	runner := runner.GetInstance(nil)
+
+	env, err := runner.Docker.GetSandboxEnv(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, env)
+}
+
+// UpdateSandboxEnv godoc
+//
+//	@Tags			sandbox
+//	@Summary		Update sandbox env
+//	@Description	Set or remove environment variables of the sandbox, they are applied on the next start
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			request		body		dto.UpdateSandboxEnvDTO	true	"Update env request"
+//	@Success		200			{object}	dto.SandboxEnvDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/env [post]
+//
+//	@id				UpdateSandboxEnv
+func UpdateSandboxEnv(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.UpdateSandboxEnvDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	env, err := runner.Docker.UpdateSandboxEnv(ctx.Request.Context(), sandboxId, request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, env)
+}
+
+// UpdateSandboxSecrets godoc
+//
+//	@Tags			sandbox
+//	@Summary		Update sandbox secrets
+//	@Description	Set or remove secrets of the sandbox. Secrets are files in a tmpfs instead of environment variables so they don't show up when the container is inspected, a running sandbox gets them right away.
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string						true	"Sandbox ID"
+//	@Param			request		body		dto.UpdateSandboxSecretsDTO	true	"Update secrets request"
+//	@Success		200			{object}	dto.SandboxEnvDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/secrets [post]
+//
+//	@id				UpdateSandboxSecrets
+func UpdateSandboxSecrets(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.UpdateSandboxSecretsDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	env, err := runner.Docker.UpdateSandboxSecrets(ctx.Request.Context(), sandboxId, request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, env)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSandboxLogs godoc
+//
+//	@Tags			sandbox
+//	@Summary		Get sandbox logs
+//	@Description	Stream the output of the sandbox container itself, stdout and stderr are merged in the order they were written
+//	@Produce		plain
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			follow		query		boolean	false	"Whether to follow the log output"
+//	@Param			since		query		string	false	"RFC3339 timestamp or duration like 10m, only logs written after it are returned"
+//	@Param			until		query		string	false	"RFC3339 timestamp or duration like 10m, only logs written before it are returned"
+//	@Param			tail		query		string	false	"Number of lines from the end of the logs to return, defaults to all"
+//	@Param			stdout		query		boolean	false	"Whether to return stdout, defaults to true"
+//	@Param			stderr		query		boolean	false	"Whether to return stderr, defaults to true"
+//	@Param			timestamps	query		boolean	false	"Whether to prefix every line with its timestamp"
+//	@Success		200			{string}	string	"Sandbox logs stream"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/logs [get]
+//
+//	@id				GetSandboxLogs
+func GetSandboxLogs(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	options := docker.SandboxLogsOptions{
+		Follow:     ctx.Query("follow") == "true",
+		Tail:       -1,
+		Stdout:     ctx.DefaultQuery("stdout", "true") == "true",
+		Stderr:     ctx.DefaultQuery("stderr", "true") == "true",
+		Timestamps: ctx.Query("timestamps") == "true",
+	}
+
+	if !options.Stdout && !options.Stderr {
+		ctx.Error(common.NewBadRequestError(errors.New("at least one of stdout and stderr must be selected")))
+		return
+	}
+
+	if value := ctx.Query("tail"); value != "" && value != "all" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid tail: %s", value)))
+			return
+		}
+		options.Tail = parsed
+	}
+
+	if value := ctx.Query("since"); value != "" {
+		since, err := parseSince(value)
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(err))
+			return
+		}
+		options.Since = since
+	}
+
+	if value := ctx.Query("until"); value != "" {
+		until, err := parseSince(value)
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid until: %s", value)))
+			return
+		}
+		options.Until = until
+	}
+
+	if !options.Since.IsZero() && !options.Until.IsZero() && !options.Until.After(options.Since) {
+		ctx.Error(common.NewBadRequestError(errors.New("until must be after since")))
+		return
+	}
+
+	writer := io.Writer(ctx.Writer)
+	if options.Follow {
+		flusher, ok := ctx.Writer.(http.Flusher)
+		if !ok {
+			ctx.Error(common.NewCustomError(http.StatusInternalServerError, "Streaming not supported", "STREAMING_NOT_SUPPORTED"))
+			return
+		}
+		writer = &flushWriter{writer: ctx.Writer, flusher: flusher}
+	}
+
+	ctx.Header("Content-Type", "text/plain; charset=utf-8")
+
+	// Errors before any output, e.g. a missing sandbox, still get their status
+	err := runner.GetInstance(nil).Docker.GetSandboxLogs(ctx.Request.Context(), sandboxId, options, writer, writer)
+	if err != nil {
+		if ctx.Writer.Written() {
+			log.Errorf("Error streaming logs of sandbox %s: %v", sandboxId, err)
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Error(err)
+	}
+}
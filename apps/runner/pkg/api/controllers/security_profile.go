@@ -0,0 +1,96 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadSecurityProfile godoc
+//
+//	@Tags			security
+//	@Summary		Upload security profile
+//	@Description	Store a seccomp and AppArmor profile under a name sandboxes reference on create. The AppArmor profile is loaded into the kernel of the runner right away. Profiles can't be replaced, delete the profile first.
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.UploadSecurityProfileDTO	true	"Security profile"
+//	@Success		201		{object}	dto.SecurityProfileDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/security-profiles [post]
+//
+//	@id				UploadSecurityProfile
+func UploadSecurityProfile(ctx *gin.Context) {
+	var request dto.UploadSecurityProfileDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	profile, err := runner.GetInstance(nil).Docker.UploadSecurityProfile(ctx.Request.Context(), request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, profile)
+}
+
+// ListSecurityProfiles godoc
+//
+//	@Tags			security
+//	@Summary		List security profiles
+//	@Description	List the security profiles uploaded to the runner
+//	@Produce		json
+//	@Success		200	{array}		dto.SecurityProfileDTO
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		403	{object}	common.ErrorResponse
+//	@Failure		500	{object}	common.ErrorResponse
+//	@Router			/security-profiles [get]
+//
+//	@id				ListSecurityProfiles
+func ListSecurityProfiles(ctx *gin.Context) {
+	profiles, err := runner.GetInstance(nil).Docker.ListSecurityProfiles()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profiles)
+}
+
+// DeleteSecurityProfile godoc
+//
+//	@Tags			security
+//	@Summary		Delete security profile
+//	@Description	Delete a security profile and unload its AppArmor profile. Profiles sandboxes were created with can't be deleted until the sandboxes are destroyed.
+//	@Produce		json
+//	@Param			name	path		string	true	"Security profile name"
+//	@Success		200		{string}	string	"Security profile deleted"
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/security-profiles/{name} [delete]
+//
+//	@id				DeleteSecurityProfile
+func DeleteSecurityProfile(ctx *gin.Context) {
+	err := runner.GetInstance(nil).Docker.DeleteSecurityProfile(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Security profile deleted")
+}
@@ -4,35 +4,43 @@
 package controllers
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/api/dto"
 	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
 	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/services"
 	"github.com/gin-gonic/gin"
-	log "github.com/sirupsen/logrus"
 )
 
 // PullSnapshot godoc
 //
 //	@Tags			snapshots
 //	@Summary		Pull a snapshot
-//	@Description	Pull a snapshot from a registry
-//	@Param			request	body		dto.PullSnapshotRequestDTO	true	"Pull snapshot"
-//	@Success		200		{string}	string						"Snapshot successfully pulled"
-//	@Failure		400		{object}	common.ErrorResponse
-//	@Failure		401		{object}	common.ErrorResponse
-//	@Failure		404		{object}	common.ErrorResponse
-//	@Failure		409		{object}	common.ErrorResponse
-//	@Failure		500		{object}	common.ErrorResponse
+//	@Description	Pull a snapshot from a registry, with async=true the snapshot is pulled in the background and the operation is returned
+//	@Param			request			body		dto.PullSnapshotRequestDTO	true	"Pull snapshot"
+//	@Param			async			query		boolean						false	"Whether to pull the snapshot in the background"
+//	@Param			Idempotency-Key	header		string						false	"Key to replay the response of a retried request"
+//	@Success		200				{string}	string						"Snapshot successfully pulled"
+//	@Success		202				{object}	dto.OperationDTO
+//	@Failure		400				{object}	common.ErrorResponse
+//	@Failure		401				{object}	common.ErrorResponse
+//	@Failure		404				{object}	common.ErrorResponse
+//	@Failure		409				{object}	common.ErrorResponse
+//	@Failure		429				{object}	common.ErrorResponse
+//	@Failure		500				{object}	common.ErrorResponse
 //
 //	@Router			/snapshots/pull [post]
 //
@@ -47,7 +55,14 @@ func PullSnapshot(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err = runner.Docker.PullImage(ctx.Request.Context(), request.Snapshot, request.Registry)
+	if ctx.Query("async") == "true" {
+		startOperation(ctx, enums.OperationTypePull, request.Snapshot, func(ctx context.Context) (string, error) {
+			return "", runner.Backend.PullImage(ctx, request.Snapshot, request.Registry, request.Platform)
+		})
+		return
+	}
+
+	err = runner.Backend.PullImage(ctx.Request.Context(), request.Snapshot, request.Registry, request.Platform)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -60,14 +75,17 @@ func PullSnapshot(ctx *gin.Context) {
 //
 //	@Tags			snapshots
 //	@Summary		Build a snapshot
-//	@Description	Build a snapshot from a Dockerfile and context hashes
-//	@Param			request	body		dto.BuildSnapshotRequestDTO	true	"Build snapshot request"
-//	@Success		200		{string}	string						"Snapshot successfully built"
-//	@Failure		400		{object}	common.ErrorResponse
-//	@Failure		401		{object}	common.ErrorResponse
-//	@Failure		404		{object}	common.ErrorResponse
-//	@Failure		409		{object}	common.ErrorResponse
-//	@Failure		500		{object}	common.ErrorResponse
+//	@Description	Build a snapshot from a Dockerfile and context hashes, with async=true the snapshot is built in the background and the operation is returned
+//	@Param			request			body		dto.BuildSnapshotRequestDTO	true	"Build snapshot request"
+//	@Param			async			query		boolean						false	"Whether to build the snapshot in the background"
+//	@Param			Idempotency-Key	header		string						false	"Key to replay the response of a retried request"
+//	@Success		200				{string}	string						"Snapshot successfully built"
+//	@Success		202				{object}	dto.OperationDTO
+//	@Failure		400				{object}	common.ErrorResponse
+//	@Failure		401				{object}	common.ErrorResponse
+//	@Failure		404				{object}	common.ErrorResponse
+//	@Failure		409				{object}	common.ErrorResponse
+//	@Failure		500				{object}	common.ErrorResponse
 //
 //	@Router			/snapshots/build [post]
 //
@@ -85,39 +103,132 @@ func BuildSnapshot(ctx *gin.Context) {
 		return
 	}
 
-	runner := runner.GetInstance(nil)
+	if ctx.Query("async") == "true" {
+		startOperation(ctx, enums.OperationTypeBuild, request.Snapshot, func(ctx context.Context) (string, error) {
+			return "", buildSnapshot(ctx, request)
+		})
+		return
+	}
 
-	err = runner.Docker.BuildImage(ctx.Request.Context(), request)
+	err = buildSnapshot(ctx.Request.Context(), request)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
+	ctx.JSON(http.StatusOK, "Snapshot built successfully")
+}
+
+// buildSnapshot builds and tags the snapshot and pushes it to the internal registry if requested
+func buildSnapshot(ctx context.Context, request dto.BuildSnapshotRequestDTO) error {
+	runner := runner.GetInstance(nil)
+	defer runner.BuildLogs.Archive(request.Snapshot[:strings.LastIndex(request.Snapshot, ":")])
+
+	err := runner.Backend.BuildImage(ctx, request)
+	if err != nil {
+		return err
+	}
+
 	tag := request.Snapshot
 
 	if request.PushToInternalRegistry {
 		if request.Registry.Project == nil {
-			ctx.Error(common.NewBadRequestError(errors.New("project is required when pushing to internal registry")))
-			return
+			return common.NewBadRequestError(errors.New("project is required when pushing to internal registry"))
 		}
 		tag = fmt.Sprintf("%s/%s/%s", request.Registry.Url, *request.Registry.Project, request.Snapshot)
 	}
 
-	err = runner.Docker.TagImage(ctx.Request.Context(), request.Snapshot, tag)
+	err = runner.Backend.TagImage(ctx, request.Snapshot, tag)
+	if err != nil {
+		return err
+	}
+
+	if request.PushToInternalRegistry {
+		return runner.Backend.PushImage(ctx, tag, request.Registry)
+	}
+
+	return nil
+}
+
+// PushSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Push a snapshot
+//	@Description	Push a local snapshot to a registry
+//	@Param			request	body		dto.PushSnapshotRequestDTO	true	"Push snapshot"
+//	@Success		200		{string}	string						"Snapshot successfully pushed"
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//
+//	@Router			/snapshots/push [post]
+//
+//	@id				PushSnapshot
+func PushSnapshot(ctx *gin.Context) {
+	var request dto.PushSnapshotRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	if !strings.Contains(request.Snapshot, ":") || strings.HasSuffix(request.Snapshot, ":") {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshot name must include a valid tag")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	exists, err := runner.Backend.ImageExists(ctx.Request.Context(), request.Snapshot, true)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	if request.PushToInternalRegistry {
-		err = runner.Docker.PushImage(ctx.Request.Context(), tag, request.Registry)
-		if err != nil {
-			ctx.Error(err)
-			return
-		}
+	if !exists {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("snapshot %s not found locally", request.Snapshot)))
+		return
 	}
 
-	ctx.JSON(http.StatusOK, "Snapshot built successfully")
+	defer runner.BuildLogs.Archive(request.Snapshot[:strings.LastIndex(request.Snapshot, ":")])
+
+	tag := fmt.Sprintf("%s/%s", request.Registry.Url, request.Snapshot)
+	if request.Registry.Project != nil && *request.Registry.Project != "" {
+		tag = fmt.Sprintf("%s/%s/%s", request.Registry.Url, *request.Registry.Project, request.Snapshot)
+	}
+
+	// Sandboxes may be created from a snapshot pulled under the registry tag, such a tag is left in place
+	tagExists, err := runner.Backend.ImageExists(ctx.Request.Context(), tag, true)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = runner.Backend.TagImage(ctx.Request.Context(), request.Snapshot, tag)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// The registry tag is only needed for the push, removing it leaves the snapshot itself untouched
+	if !tagExists {
+		defer func() {
+			err := runner.Backend.RemoveImage(context.WithoutCancel(ctx.Request.Context()), tag, false)
+			if err != nil {
+				log.Warnf("Failed to remove tag %s of snapshot %s: %v", tag, request.Snapshot, err)
+			}
+		}()
+	}
+
+	err = runner.Backend.PushSnapshot(ctx.Request.Context(), request.Snapshot, tag, &request.Registry)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Snapshot pushed successfully")
 }
 
 // SnapshotExists godoc
@@ -126,7 +237,8 @@ func BuildSnapshot(ctx *gin.Context) {
 //	@Summary		Check if a snapshot exists
 //	@Description	Check if a specified snapshot exists locally
 //	@Produce		json
-//	@Param			snapshot	query		string	true	"Snapshot name and tag"	example:"nginx:latest"
+//	@Param			snapshot	query		string	true	"Snapshot name and tag"							example:"nginx:latest"
+//	@Param			platform	query		string	false	"Only report snapshots built for this platform"	example:"linux/arm64"
 //	@Success		200			{object}	SnapshotExistsResponse
 //	@Failure		400			{object}	common.ErrorResponse
 //	@Failure		401			{object}	common.ErrorResponse
@@ -145,7 +257,18 @@ func SnapshotExists(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	exists, err := runner.Docker.ImageExists(ctx.Request.Context(), snapshot, false)
+	var exists bool
+	var err error
+	if platform := ctx.Query("platform"); platform != "" {
+		targetPlatform, parseErr := docker.ParsePlatform(platform)
+		if parseErr != nil {
+			ctx.Error(parseErr)
+			return
+		}
+		exists, err = runner.Docker.ImageExistsForPlatform(ctx.Request.Context(), snapshot, false, targetPlatform)
+	} else {
+		exists, err = runner.Backend.ImageExists(ctx.Request.Context(), snapshot, false)
+	}
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -156,19 +279,54 @@ func SnapshotExists(ctx *gin.Context) {
 	})
 }
 
-// RemoveSnapshot godoc
+// SnapshotInfo godoc
 //
 //	@Tags			snapshots
-//	@Summary		Remove a snapshot
-//	@Description	Remove a specified snapshot from the local system
+//	@Summary		Get snapshot info
+//	@Description	Get the size and stored platform of a local snapshot
 //	@Produce		json
 //	@Param			snapshot	query		string	true	"Snapshot name and tag"	example:"nginx:latest"
-//	@Success		200			{string}	string	"Snapshot successfully removed"
+//	@Success		200			{object}	dto.SnapshotInfoResponse
 //	@Failure		400			{object}	common.ErrorResponse
 //	@Failure		401			{object}	common.ErrorResponse
 //	@Failure		404			{object}	common.ErrorResponse
 //	@Failure		409			{object}	common.ErrorResponse
 //	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/snapshots/info [get]
+//
+//	@id				SnapshotInfo
+func SnapshotInfo(ctx *gin.Context) {
+	snapshot := ctx.Query("snapshot")
+	if snapshot == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshot parameter is required")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	info, err := runner.Backend.GetImageInfo(ctx.Request.Context(), snapshot)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+// RemoveSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Remove a snapshot
+//	@Description	Remove a specified snapshot from the local system
+//	@Produce		json
+//	@Param			snapshot		query		string	true	"Snapshot name and tag"	example:"nginx:latest"
+//	@Param			Idempotency-Key	header		string	false	"Key to replay the response of a retried request"
+//	@Success		200				{string}	string	"Snapshot successfully removed"
+//	@Failure		400				{object}	common.ErrorResponse
+//	@Failure		401				{object}	common.ErrorResponse
+//	@Failure		404				{object}	common.ErrorResponse
+//	@Failure		409				{object}	common.ErrorResponse
+//	@Failure		500				{object}	common.ErrorResponse
 //	@Router			/snapshots/remove [post]
 //
 //	@id				RemoveSnapshot
@@ -181,7 +339,7 @@ func RemoveSnapshot(ctx *gin.Context) {
 
 	runner := runner.GetInstance(nil)
 
-	err := runner.Docker.RemoveImage(ctx.Request.Context(), snapshot, true)
+	err := runner.Backend.RemoveImage(ctx.Request.Context(), snapshot, true)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -190,10 +348,147 @@ func RemoveSnapshot(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, "Snapshot removed successfully")
 }
 
+// PruneSnapshots godoc
+//
+//	@Tags			snapshots
+//	@Summary		Prune unused snapshots
+//	@Description	Remove snapshots that are not used by any sandbox, are older than the minimum age and are not on the keep list
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.PruneSnapshotsRequestDTO	true	"Prune snapshots request"
+//	@Success		200		{object}	dto.PruneSnapshotsResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/prune [post]
+//
+//	@id				PruneSnapshots
+func PruneSnapshots(ctx *gin.Context) {
+	var request dto.PruneSnapshotsRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	minAge := runner.SnapshotGC.MinAge()
+	if request.MinAge != "" {
+		minAge, err = time.ParseDuration(request.MinAge)
+		if err != nil || minAge < 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid minAge: %s", request.MinAge)))
+			return
+		}
+	}
+
+	result, err := runner.SnapshotGC.Prune(ctx.Request.Context(), minAge, request.DryRun)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// PrewarmSnapshots godoc
+//
+//	@Tags			snapshots
+//	@Summary		Prewarm snapshots
+//	@Description	Pin the snapshots so garbage collection keeps them and pull them in the background
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.PrewarmSnapshotsRequestDTO	true	"Prewarm snapshots request"
+//	@Success		202		{object}	dto.PrewarmSnapshotsResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/prewarm [post]
+//
+//	@id				PrewarmSnapshots
+func PrewarmSnapshots(ctx *gin.Context) {
+	var request dto.PrewarmSnapshotsRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	statuses, err := runner.Prewarm.Prewarm(request.Snapshots, request.Registry, request.Platform)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, dto.PrewarmSnapshotsResponseDTO{Snapshots: statuses})
+}
+
+// GetPrewarmStatus godoc
+//
+//	@Tags			snapshots
+//	@Summary		Get prewarm status
+//	@Description	Get the progress of the snapshot prewarms and the pinned snapshots
+//	@Produce		json
+//	@Success		200	{object}	dto.PrewarmSnapshotsResponseDTO
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		500	{object}	common.ErrorResponse
+//	@Router			/snapshots/prewarm [get]
+//
+//	@id				GetPrewarmStatus
+func GetPrewarmStatus(ctx *gin.Context) {
+	runner := runner.GetInstance(nil)
+
+	ctx.JSON(http.StatusOK, dto.PrewarmSnapshotsResponseDTO{Snapshots: runner.Prewarm.Status()})
+}
+
+// UnpinSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Unpin a snapshot
+//	@Description	Make a pinned snapshot eligible for garbage collection again
+//	@Accept			json
+//	@Param			request	body		dto.UnpinSnapshotRequestDTO	true	"Unpin snapshot request"
+//	@Success		200		{string}	string						"Snapshot unpinned"
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/unpin [post]
+//
+//	@id				UnpinSnapshot
+func UnpinSnapshot(ctx *gin.Context) {
+	var request dto.UnpinSnapshotRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	unpinned, err := runner.Prewarm.Unpin(request.Snapshot)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if !unpinned {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("snapshot %s is not pinned", request.Snapshot)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Snapshot unpinned")
+}
+
 type SnapshotExistsResponse struct {
 	Exists bool `json:"exists" example:"true"`
 } //	@name	SnapshotExistsResponse
 
+// BUILD_STATUS_TRAILER carries the build status at the end of followed build logs
+const BUILD_STATUS_TRAILER = "X-Daytona-Build-Status"
+
 // GetBuildLogs godoc
 //
 //	@Tags			snapshots
@@ -201,6 +496,8 @@ type SnapshotExistsResponse struct {
 //	@Description	Stream build logs
 //	@Param			snapshotRef	query		string	true	"Snapshot ID or snapshot ref without the tag"
 //	@Param			follow		query		boolean	false	"Whether to follow the log output"
+//	@Param			tail		query		string	false	"Number of lines from the end of the log to return, defaults to all"
+//	@Param			since		query		string	false	"RFC3339 timestamp or duration like 10m, existing output is skipped if the log wasn't written to since"
 //	@Success		200			{string}	string	"Build logs stream"
 //	@Failure		400			{object}	common.ErrorResponse
 //	@Failure		401			{object}	common.ErrorResponse
@@ -219,6 +516,50 @@ func GetBuildLogs(ctx *gin.Context) {
 
 	follow := ctx.Query("follow") == "true"
 
+	tail := 0
+	if value := ctx.Query("tail"); value != "" && value != "all" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid tail: %s", value)))
+			return
+		}
+		tail = parsed
+	}
+
+	var since time.Time
+	if value := ctx.Query("since"); value != "" {
+		parsed, err := parseSince(value)
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(err))
+			return
+		}
+		since = parsed
+	}
+
+	runner := runner.GetInstance(nil)
+
+	// Logs removed by retention are served from the archive, archived logs are complete so there is nothing to follow
+	localPath, err := config.GetBuildLogPath(snapshotRef)
+	if err == nil {
+		if _, statErr := os.Stat(localPath); os.IsNotExist(statErr) {
+			archived, err := runner.BuildLogs.OpenArchived(ctx.Request.Context(), snapshotRef)
+			if err == nil {
+				defer archived.Close()
+
+				ctx.Header("Content-Type", "application/octet-stream")
+				_, err = io.Copy(ctx.Writer, archived)
+				if err != nil {
+					log.Errorf("Error writing archived build logs: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, services.ErrBuildLogNotArchived) {
+				log.Debugf("Archived build logs of %s not available: %v", snapshotRef, err)
+			}
+		}
+	}
+
 	logFilePath, err := config.GetBuildLogFilePath(snapshotRef)
 	if err != nil {
 		ctx.Error(common.NewCustomError(http.StatusInternalServerError, err.Error(), "INTERNAL_SERVER_ERROR"))
@@ -239,63 +580,139 @@ func GetBuildLogs(ctx *gin.Context) {
 	}
 	defer file.Close()
 
-	// If not following, just return the entire file content
-	if !follow {
-		_, err = io.Copy(ctx.Writer, file)
-		if err != nil {
-			ctx.Error(common.NewCustomError(http.StatusInternalServerError, err.Error(), "INTERNAL_SERVER_ERROR"))
-		}
+	flusher, ok := ctx.Writer.(http.Flusher)
+	if follow && !ok {
+		ctx.Error(common.NewCustomError(http.StatusInternalServerError, "Streaming not supported", "STREAMING_NOT_SUPPORTED"))
 		return
 	}
 
-	reader := bufio.NewReader(file)
-	runner := runner.GetInstance(nil)
-
-	checkSnapshotRef := snapshotRef
+	done := make(chan struct{})
+	// Written by the waiting goroutine before it closes done
+	var buildStatus dto.SnapshotBuildStatusDTO
 
-	// Fixed tag for instances where we are not looking for an entry with snapshot ID
-	if strings.HasPrefix(snapshotRef, "daytona") {
-		checkSnapshotRef = snapshotRef + ":daytona"
-	}
+	if follow {
+		status, tracked := runner.Backend.GetBuildStatus(snapshotRef)
+		finished := tracked && status.Status != enums.BuildStatusBuilding.String()
 
-	flusher, ok := ctx.Writer.(http.Flusher)
-	if !ok {
-		ctx.Error(common.NewCustomError(http.StatusInternalServerError, "Streaming not supported", "STREAMING_NOT_SUPPORTED"))
-		return
-	}
+		if !tracked {
+			checkSnapshotRef := snapshotRef
 
-	go func() {
-		for {
-			line, err := reader.ReadBytes('\n')
-			if err != nil && err != io.EOF {
-				log.Errorf("Error reading log file: %v", err)
-				break
+			// Fixed tag for instances where we are not looking for an entry with snapshot ID
+			if strings.HasPrefix(snapshotRef, "daytona") {
+				checkSnapshotRef = snapshotRef + ":daytona"
 			}
 
-			if len(line) > 0 {
-				_, writeErr := ctx.Writer.Write(line)
-				if writeErr != nil {
-					log.Errorf("Error writing to response: %v", writeErr)
-					break
-				}
-				flusher.Flush()
+			// Built before the runner started
+			exists, err := runner.Backend.ImageExists(ctx.Request.Context(), checkSnapshotRef, false)
+			if err != nil {
+				ctx.Error(err)
+				return
 			}
+			finished = exists
 		}
-	}()
 
-	for {
-		exists, err := runner.Docker.ImageExists(ctx.Request.Context(), checkSnapshotRef, false)
-		if err != nil {
-			log.Errorf("Error checking build status: %v", err)
-			break
+		if finished {
+			buildStatus = status
+			close(done)
+		} else {
+			go func() {
+				// A build that hasn't started yet is waited for as well
+				buildStatus, _ = runner.Backend.WaitForBuild(ctx.Request.Context(), snapshotRef)
+				close(done)
+			}()
 		}
 
-		if exists {
-			// If snapshot exists, build is complete, allow time for the last logs to be written and break the loop
-			time.Sleep(1 * time.Second)
-			break
+		ctx.Header("Trailer", BUILD_STATUS_TRAILER)
+	}
+
+	writer := io.Writer(ctx.Writer)
+	if follow {
+		writer = &flushWriter{writer: ctx.Writer, flusher: flusher}
+	}
+
+	err = util.TailFile(ctx.Request.Context(), file, writer, util.TailOptions{
+		Lines:  tail,
+		Since:  since,
+		Follow: follow,
+		Done:   done,
+	})
+	if err != nil {
+		log.Errorf("Error streaming build logs: %v", err)
+		return
+	}
+
+	if !follow || ctx.Request.Context().Err() != nil {
+		return
+	}
+
+	// The response status is already sent, a failed build is reported at the end of the stream
+	if buildStatus.Status == enums.BuildStatusFailed.String() {
+		_, err = writer.Write([]byte(fmt.Sprintf("Build failed: %s\n", buildStatus.Error)))
+		if err != nil {
+			log.Errorf("Error streaming build logs: %v", err)
 		}
+	}
+	if buildStatus.Status != "" {
+		ctx.Writer.Header().Set(BUILD_STATUS_TRAILER, buildStatus.Status)
+	}
+}
 
-		time.Sleep(250 * time.Millisecond)
+// GetSnapshotBuildStatus godoc
+//
+//	@Tags			snapshots
+//	@Summary		Get the build status of a snapshot
+//	@Description	Get the status of the latest build or pull of the snapshot since the runner started
+//	@Produce		json
+//	@Param			snapshotRef	query		string	true	"Snapshot name with tag, or snapshot ID or ref without the tag for builds"
+//	@Success		200			{object}	dto.SnapshotBuildStatusDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/snapshots/build/status [get]
+//
+//	@id				GetSnapshotBuildStatus
+func GetSnapshotBuildStatus(ctx *gin.Context) {
+	snapshotRef := ctx.Query("snapshotRef")
+	if snapshotRef == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshotRef parameter is required")))
+		return
 	}
+
+	runner := runner.GetInstance(nil)
+
+	status, ok := runner.Backend.GetBuildStatus(snapshotRef)
+	if !ok {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("no build or pull of %s since the runner started", snapshotRef)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// parseSince accepts an RFC3339 timestamp or a duration relative to now
+func parseSince(value string) (time.Time, error) {
+	since, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return since, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid since: %s", value)
+	}
+
+	return time.Now().Add(-duration), nil
+}
+
+// flushWriter flushes every write so followed logs reach the client as they are written
+type flushWriter struct {
+	writer  io.Writer
+	flusher http.Flusher
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.flusher.Flush()
+	return n, err
 }
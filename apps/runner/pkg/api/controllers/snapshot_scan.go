@@ -0,0 +1,49 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ScanSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Scan a snapshot for vulnerabilities
+//	@Description	Run Trivy against a local snapshot and return its vulnerability report
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.ScanSnapshotRequestDTO	true	"Scan snapshot request"
+//	@Success		200		{object}	dto.ScanSnapshotResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/scan [post]
+//
+//	@id				ScanSnapshot
+func ScanSnapshot(ctx *gin.Context) {
+	var request dto.ScanSnapshotRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	report, err := runner.Docker.ScanImage(ctx.Request.Context(), request.Snapshot)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Export a snapshot
+//	@Description	Stream a local snapshot as an image tarball
+//	@Produce		application/x-tar
+//	@Param			snapshot	query		string	true	"Snapshot name and tag"	example:"nginx:latest"
+//	@Success		200			{file}		binary	"Snapshot tarball"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/snapshots/export [get]
+//
+//	@id				ExportSnapshot
+func ExportSnapshot(ctx *gin.Context) {
+	snapshot := ctx.Query("snapshot")
+	if snapshot == "" {
+		ctx.Error(common.NewBadRequestError(errors.New("snapshot parameter is required")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	reader, err := runner.Docker.ExportImage(ctx.Request.Context(), snapshot)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	fileName := strings.NewReplacer("/", "_", ":", "_").Replace(snapshot) + ".tar"
+
+	ctx.Header("Content-Type", "application/x-tar")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Status(http.StatusOK)
+
+	_, err = io.Copy(ctx.Writer, reader)
+	if err != nil {
+		log.Errorf("Error streaming snapshot %s: %v", snapshot, err)
+	}
+}
+
+// ExportSnapshotToStorage godoc
+//
+//	@Tags			snapshots
+//	@Summary		Export a snapshot to object storage
+//	@Description	Upload a local snapshot as an image tarball to the runner object storage
+//	@Param			request	body		dto.ExportSnapshotRequestDTO	true	"Export snapshot"
+//	@Success		200		{string}	string							"Snapshot successfully exported"
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/export [post]
+//
+//	@id				ExportSnapshotToStorage
+func ExportSnapshotToStorage(ctx *gin.Context) {
+	var request dto.ExportSnapshotRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	err = runner.Docker.ExportImageToStorage(ctx.Request.Context(), request.Snapshot, request.ObjectPath)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Snapshot exported successfully")
+}
+
+// ImportSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Import a snapshot
+//	@Description	Load a snapshot from an image tarball streamed in the request body
+//	@Accept			application/x-tar
+//	@Produce		json
+//	@Success		200	{object}	dto.ImportSnapshotResponseDTO
+//	@Failure		400	{object}	common.ErrorResponse
+//	@Failure		401	{object}	common.ErrorResponse
+//	@Failure		404	{object}	common.ErrorResponse
+//	@Failure		409	{object}	common.ErrorResponse
+//	@Failure		500	{object}	common.ErrorResponse
+//	@Router			/snapshots/import [post]
+//
+//	@id				ImportSnapshot
+func ImportSnapshot(ctx *gin.Context) {
+	runner := runner.GetInstance(nil)
+
+	snapshots, err := runner.Docker.ImportImage(ctx.Request.Context(), ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ImportSnapshotResponseDTO{
+		Snapshots: snapshots,
+	})
+}
+
+// ImportRemoteSnapshot godoc
+//
+//	@Tags			snapshots
+//	@Summary		Import a snapshot from a remote tarball
+//	@Description	Load a snapshot from an image tarball in the runner object storage or at an HTTP(S) URL
+//	@Produce		json
+//	@Param			request	body		dto.ImportSnapshotRequestDTO	true	"Import snapshot"
+//	@Success		200		{object}	dto.ImportSnapshotResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/snapshots/import/remote [post]
+//
+//	@id				ImportRemoteSnapshot
+func ImportRemoteSnapshot(ctx *gin.Context) {
+	var request dto.ImportSnapshotRequestDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	if (request.ObjectPath == "") == (request.Url == "") {
+		ctx.Error(common.NewBadRequestError(errors.New("exactly one of objectPath or url is required")))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	var snapshots []string
+	if request.ObjectPath != "" {
+		snapshots, err = runner.Docker.ImportImageFromStorage(ctx.Request.Context(), request.ObjectPath)
+	} else {
+		snapshots, err = runner.Docker.ImportImageFromUrl(ctx.Request.Context(), request.Url)
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ImportSnapshotResponseDTO{
+		Snapshots: snapshots,
+	})
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/ssh"
+)
+
+// CreateSshAccess godoc
+//
+//	@Tags			sandbox
+//	@Summary		Create SSH access
+//	@Description	Allow a public key to open SSH sessions into the sandbox through the SSH gateway of the runner until the access expires. A key pair is generated if no public key is passed, its private key is only returned once.
+//	@Accept			json
+//	@Produce		json
+//	@Param			sandboxId	path		string					true	"Sandbox ID"
+//	@Param			request		body		dto.CreateSshAccessDTO	true	"Create SSH access request"
+//	@Success		201			{object}	dto.SshAccessDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/ssh-access [post]
+//
+//	@id				CreateSshAccess
+func CreateSshAccess(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+
+	var request dto.CreateSshAccessDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	if runner.SshAccess == nil {
+		ctx.Error(common.NewBadRequestError(errors.New("the SSH gateway is not enabled on this runner")))
+		return
+	}
+
+	_, err = runner.Docker.ContainerInspect(ctx.Request.Context(), sandboxId)
+	if err != nil {
+		ctx.Error(common.NewNotFoundError(err))
+		return
+	}
+
+	var publicKey ssh.PublicKey
+	var privateKeyPem string
+	if request.PublicKey != "" {
+		publicKey, _, _, _, err = ssh.ParseAuthorizedKey([]byte(request.PublicKey))
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid public key: %w", err)))
+			return
+		}
+	} else {
+		publicKey, privateKeyPem, err = generateSshKeyPair(sandboxId)
+		if err != nil {
+			ctx.Error(fmt.Errorf("failed to generate SSH key pair: %w", err))
+			return
+		}
+	}
+
+	ttl := config.GetSshAccessTTL()
+	if request.ExpiresInMinutes > 0 {
+		ttl = time.Duration(request.ExpiresInMinutes) * time.Minute
+	}
+
+	access, err := runner.SshAccess.Create(sandboxId, publicKey, ttl)
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to create SSH access to sandbox %s: %w", sandboxId, err))
+		return
+	}
+
+	host := config.GetSshGatewayHost()
+	if host == "" {
+		host = ctx.Request.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+	}
+	port := config.GetSshGatewayPort()
+
+	ctx.JSON(http.StatusCreated, dto.SshAccessDTO{
+		Id:          access.Id,
+		Fingerprint: access.Fingerprint,
+		Username:    sandboxId,
+		Host:        host,
+		Port:        port,
+		PrivateKey:  privateKeyPem,
+		Command:     fmt.Sprintf("ssh -p %d %s@%s", port, sandboxId, host),
+		ExpiresAt:   access.ExpiresAt,
+	})
+}
+
+// RevokeSshAccess godoc
+//
+//	@Tags			sandbox
+//	@Summary		Revoke SSH access
+//	@Description	Stop accepting the key of the SSH access, open sessions are not closed
+//	@Produce		json
+//	@Param			sandboxId	path		string	true	"Sandbox ID"
+//	@Param			accessId	path		string	true	"SSH access ID"
+//	@Success		200			{string}	string	"SSH access revoked"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/sandboxes/{sandboxId}/ssh-access/{accessId} [delete]
+//
+//	@id				RevokeSshAccess
+func RevokeSshAccess(ctx *gin.Context) {
+	sandboxId := ctx.Param("sandboxId")
+	accessId := ctx.Param("accessId")
+
+	runner := runner.GetInstance(nil)
+
+	if runner.SshAccess == nil {
+		ctx.Error(common.NewBadRequestError(errors.New("the SSH gateway is not enabled on this runner")))
+		return
+	}
+
+	revoked, err := runner.SshAccess.Revoke(sandboxId, accessId)
+	if err != nil {
+		ctx.Error(fmt.Errorf("failed to revoke SSH access %s of sandbox %s: %w", accessId, sandboxId, err))
+		return
+	}
+
+	if !revoked {
+		ctx.Error(common.NewNotFoundError(fmt.Errorf("SSH access %s of sandbox %s not found", accessId, sandboxId)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "SSH access revoked")
+}
+
+func generateSshKeyPair(comment string) (ssh.PublicKey, string, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, comment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sshPublicKey, string(pem.EncodeToMemory(block)), nil
+}
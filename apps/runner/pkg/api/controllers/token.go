@@ -0,0 +1,63 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/apitoken"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateScopedToken godoc
+//
+//	@Tags			tokens
+//	@Summary		Create a scoped token
+//	@Description	Issue a token limited to the given scopes, e.g. a read only token for log viewers
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	body		dto.CreateScopedTokenDTO	true	"Scoped token"
+//	@Success		201		{object}	dto.ScopedTokenResponseDTO
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		403		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/tokens [post]
+//
+//	@id				CreateScopedToken
+func CreateScopedToken(ctx *gin.Context) {
+	var request dto.CreateScopedTokenDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	claims := apitoken.Claims{
+		Subject: request.Subject,
+		Scopes:  request.Scopes,
+	}
+
+	var expiresAt *time.Time
+	if request.Ttl > 0 {
+		expiry := time.Now().Add(time.Duration(request.Ttl) * time.Second)
+		claims.ExpiresAt = expiry.Unix()
+		expiresAt = &expiry
+	}
+
+	token, err := runner.GetInstance(nil).Authenticator.Issue(claims)
+	if err != nil {
+		ctx.Error(common.NewBadRequestError(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ScopedTokenResponseDTO{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
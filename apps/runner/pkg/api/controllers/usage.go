@@ -0,0 +1,65 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultUsageRecordsLimit = 1000
+	maxUsageRecordsLimit     = 10000
+)
+
+// GetUsageRecords godoc
+//
+//	@Tags			usage
+//	@Summary		Get usage records
+//	@Description	Get the usage records of the sandboxes closed after the given sequence, records are kept for 24 hours by default
+//	@Produce		json
+//	@Param			after		query		integer	false	"Sequence of the last record already received, the cursor of the previous response"
+//	@Param			sandboxId	query		string	false	"Only return the records of this sandbox"
+//	@Param			limit		query		integer	false	"Maximum number of records to return, defaults to 1000 and is at most 10000"
+//	@Success		200			{object}	dto.UsageRecordsDTO
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/usage [get]
+//
+//	@id				GetUsageRecords
+func GetUsageRecords(ctx *gin.Context) {
+	var after uint64
+	if value := ctx.Query("after"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid after: %s", value)))
+			return
+		}
+		after = parsed
+	}
+
+	limit := defaultUsageRecordsLimit
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxUsageRecordsLimit {
+			ctx.Error(common.NewBadRequestError(fmt.Errorf("invalid limit: %s", value)))
+			return
+		}
+		limit = parsed
+	}
+
+	records, cursor := runner.GetInstance(nil).Usage.GetRecords(after, ctx.Query("sandboxId"), limit)
+
+	ctx.JSON(http.StatusOK, dto.UsageRecordsDTO{
+		Records: records,
+		Cursor:  cursor,
+	})
+}
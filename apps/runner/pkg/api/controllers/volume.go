@@ -0,0 +1,147 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateVolume godoc
+//
+//	@Tags			volumes
+//	@Summary		Create a volume
+//	@Description	Create a Docker volume on the runner
+//	@Produce		json
+//	@Param			volume	body		dto.CreateVolumeDTO	true	"Create volume"
+//	@Success		201		{object}	dto.VolumeInfoResponse
+//	@Failure		400		{object}	common.ErrorResponse
+//	@Failure		401		{object}	common.ErrorResponse
+//	@Failure		404		{object}	common.ErrorResponse
+//	@Failure		409		{object}	common.ErrorResponse
+//	@Failure		500		{object}	common.ErrorResponse
+//	@Router			/volumes [post]
+//
+//	@id				CreateVolume
+func CreateVolume(ctx *gin.Context) {
+	var createVolumeDto dto.CreateVolumeDTO
+	err := ctx.ShouldBindJSON(&createVolumeDto)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	volume, err := runner.Docker.CreateVolume(ctx.Request.Context(), createVolumeDto)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, volume)
+}
+
+// ListVolumes godoc
+//
+//	@Tags			volumes
+//	@Summary		List volumes
+//	@Description	List Docker volumes on the runner
+//	@Produce		json
+//	@Param			name		query		string		false	"Filter by volume name"
+//	@Param			driver		query		string		false	"Filter by volume driver"
+//	@Param			label		query		[]string	false	"Filter by label (key or key=value)"	collectionFormat(multi)
+//	@Param			dangling	query		bool		false	"Filter volumes not referenced by any container"
+//	@Success		200			{array}		dto.VolumeInfoResponse
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/volumes [get]
+//
+//	@id				ListVolumes
+func ListVolumes(ctx *gin.Context) {
+	volumeFilters := filters.NewArgs()
+	for _, key := range []string{"name", "driver", "dangling"} {
+		if value := ctx.Query(key); value != "" {
+			volumeFilters.Add(key, value)
+		}
+	}
+	for _, label := range ctx.QueryArray("label") {
+		volumeFilters.Add("label", label)
+	}
+
+	runner := runner.GetInstance(nil)
+
+	volumes, err := runner.Docker.ListVolumes(ctx.Request.Context(), volumeFilters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, volumes)
+}
+
+// InspectVolume godoc
+//
+//	@Tags			volumes
+//	@Summary		Inspect a volume
+//	@Description	Get volume details including disk usage and the sandboxes using it
+//	@Produce		json
+//	@Param			volumeName	path		string	true	"Volume name"
+//	@Success		200			{object}	dto.VolumeInfoResponse
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/volumes/{volumeName} [get]
+//
+//	@id				InspectVolume
+func InspectVolume(ctx *gin.Context) {
+	volumeName := ctx.Param("volumeName")
+
+	runner := runner.GetInstance(nil)
+
+	volume, err := runner.Docker.InspectVolume(ctx.Request.Context(), volumeName)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, volume)
+}
+
+// RemoveVolume godoc
+//
+//	@Tags			volumes
+//	@Summary		Remove a volume
+//	@Description	Remove a volume, fails if any sandbox still uses it
+//	@Produce		json
+//	@Param			volumeName	path		string	true	"Volume name"
+//	@Success		200			{string}	string	"Volume removed"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/volumes/{volumeName} [delete]
+//
+//	@id				RemoveVolume
+func RemoveVolume(ctx *gin.Context) {
+	volumeName := ctx.Param("volumeName")
+
+	runner := runner.GetInstance(nil)
+
+	err := runner.Docker.RemoveVolume(ctx.Request.Context(), volumeName)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Volume removed")
+}
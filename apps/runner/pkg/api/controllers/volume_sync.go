@@ -0,0 +1,102 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// SyncVolume godoc
+//
+//	@Tags			volumes
+//	@Summary		Sync a volume
+//	@Description	Start syncing a materialized volume with its S3 bucket, progress is reported by the sync status endpoint
+//	@Produce		json
+//	@Param			volumeName	path		string				true	"Volume ID"
+//	@Param			request		body		dto.SyncVolumeDTO	true	"Sync volume"
+//	@Success		202			{string}	string				"Volume sync started"
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		404			{object}	common.ErrorResponse
+//	@Failure		409			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/volumes/{volumeName}/sync [post]
+//
+//	@id				SyncVolume
+func SyncVolume(ctx *gin.Context) {
+	volumeId := ctx.Param("volumeName")
+
+	var request dto.SyncVolumeDTO
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(common.NewInvalidBodyRequestError(err))
+		return
+	}
+
+	runner := runner.GetInstance(nil)
+
+	// Fail fast when sync is not configured instead of reporting it through the status
+	_, err = runner.Docker.GetVolumeSyncStatus(volumeId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	go func() {
+		err := runner.Docker.SyncVolume(context.Background(), volumeId, enums.VolumeSyncDirection(request.Direction))
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	ctx.JSON(http.StatusAccepted, "Volume sync started")
+}
+
+// GetVolumeSyncStatus godoc
+//
+//	@Tags			volumes
+//	@Summary		Get volume sync status
+//	@Description	Get the progress of the last sync of a materialized volume
+//	@Produce		json
+//	@Param			volumeName	path		string	true	"Volume ID"
+//	@Success		200			{object}	dto.VolumeSyncStatusResponse
+//	@Failure		400			{object}	common.ErrorResponse
+//	@Failure		401			{object}	common.ErrorResponse
+//	@Failure		500			{object}	common.ErrorResponse
+//	@Router			/volumes/{volumeName}/sync [get]
+//
+//	@id				GetVolumeSyncStatus
+func GetVolumeSyncStatus(ctx *gin.Context) {
+	volumeId := ctx.Param("volumeName")
+
+	runner := runner.GetInstance(nil)
+
+	status, err := runner.Docker.GetVolumeSyncStatus(volumeId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := dto.VolumeSyncStatusResponse{
+		State:            status.State.String(),
+		Direction:        string(status.Direction),
+		TotalObjects:     status.TotalObjects,
+		SyncedObjects:    status.SyncedObjects,
+		TransferredBytes: status.TransferredBytes,
+		Error:            status.Error,
+		FinishedAt:       status.FinishedAt,
+	}
+	if !status.StartedAt.IsZero() {
+		response.StartedAt = &status.StartedAt
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
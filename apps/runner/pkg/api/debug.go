@@ -0,0 +1,25 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package api
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// getDebugHandler serves the pprof profiles and the expvar variables. It has no authentication, the debug listener
+// must only be reachable by operators, e.g. on localhost or a unix socket.
+func getDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	return mux
+}
@@ -36,57 +36,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/info": {
+        "/cache": {
             "get": {
-                "description": "Runner info with system metrics",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Runner info",
-                "operationId": "RunnerInfo",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/RunnerInfoResponseDTO"
-                        }
-                    }
-                }
-            }
-        },
-        "/sandboxes": {
-            "post": {
-                "description": "Create a sandbox",
+                "description": "List the entries of the runner cache, including destroyed sandboxes kept until their retention ends",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
-                ],
-                "summary": "Create a sandbox",
-                "operationId": "Create",
-                "parameters": [
-                    {
-                        "description": "Create sandbox",
-                        "name": "sandbox",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateSandboxDTO"
-                        }
-                    }
+                    "cache"
                 ],
+                "summary": "List cache entries",
+                "operationId": "ListCacheEntries",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CacheEntryResponse"
+                            }
                         }
                     },
                     "401": {
@@ -95,14 +63,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -116,17 +78,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}": {
+        "/cache/{sandboxId}": {
             "get": {
-                "description": "Get sandbox info",
+                "description": "Get the cache entry of a sandbox as the runner stores it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "cache"
                 ],
-                "summary": "Get sandbox info",
-                "operationId": "Info",
+                "summary": "Get cache entry",
+                "operationId": "GetCacheEntry",
                 "parameters": [
                     {
                         "type": "string",
@@ -138,15 +100,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sandbox info",
-                        "schema": {
-                            "$ref": "#/definitions/SandboxInfoResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/CacheEntryResponse"
                         }
                     },
                     "401": {
@@ -155,14 +111,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -176,15 +132,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Remove a sandbox that has been previously destroyed",
+                "description": "Drop the cache entry of a sandbox, e.g. one stuck in a transitional state. The state is deduced from the container again on the next request or reconciliation.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "cache"
                 ],
-                "summary": "Remove a destroyed sandbox",
-                "operationId": "RemoveDestroyed",
+                "summary": "Delete cache entry",
+                "operationId": "DeleteCacheEntry",
                 "parameters": [
                     {
                         "type": "string",
@@ -196,31 +152,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sandbox removed",
+                        "description": "Cache entry deleted",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -234,40 +184,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/backup": {
+        "/cleanup": {
             "post": {
-                "description": "Create sandbox backup",
-                "produces": [
+                "description": "Remove sandboxes that exited longer ago than the maximum age, sandbox volumes no container uses and per-sandbox networks without a sandbox. Policies not set in the request use the runner settings.",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "sandbox"
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Create sandbox backup",
-                "operationId": "CreateBackup",
+                "summary": "Clean up leftover resources",
+                "operationId": "Cleanup",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sandbox ID",
-                        "name": "sandboxId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Create backup",
-                        "name": "sandbox",
+                        "description": "Cleanup request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateBackupDTO"
+                            "$ref": "#/definitions/CleanupRequestDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Backup started",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/CleanupResponseDTO"
                         }
                     },
                     "400": {
@@ -282,14 +225,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -303,31 +240,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/destroy": {
+        "/compose": {
             "post": {
-                "description": "Destroy sandbox",
+                "description": "Create a sandbox from a compose spec, all services share a dedicated network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "compose"
                 ],
-                "summary": "Destroy sandbox",
-                "operationId": "Destroy",
+                "summary": "Create a multi-container sandbox",
+                "operationId": "CreateCompose",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sandbox ID",
-                        "name": "sandboxId",
-                        "in": "path",
-                        "required": true
+                        "description": "Create compose sandbox",
+                        "name": "sandbox",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateComposeSandboxDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Sandbox destroyed",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ComposeSandboxInfoResponse"
                         }
                     },
                     "400": {
@@ -363,17 +302,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/network-settings": {
+        "/compose/{sandboxId}": {
             "get": {
-                "description": "Get sandbox network settings",
+                "description": "Get the aggregated state and the state of every service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "compose"
                 ],
-                "summary": "Get sandbox network settings",
-                "operationId": "GetNetworkSettings",
+                "summary": "Get multi-container sandbox info",
+                "operationId": "ComposeInfo",
                 "parameters": [
                     {
                         "type": "string",
@@ -385,9 +324,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Network settings",
+                        "description": "Sandbox info",
                         "schema": {
-                            "$ref": "#/definitions/UpdateNetworkSettingsDTO"
+                            "$ref": "#/definitions/ComposeSandboxInfoResponse"
                         }
                     },
                     "400": {
@@ -421,17 +360,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/compose/{sandboxId}/destroy": {
             "post": {
-                "description": "Update sandbox network settings",
+                "description": "Remove all services and the sandbox network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "compose"
                 ],
-                "summary": "Update sandbox network settings",
-                "operationId": "UpdateNetworkSettings",
+                "summary": "Destroy multi-container sandbox",
+                "operationId": "DestroyCompose",
                 "parameters": [
                     {
                         "type": "string",
@@ -439,20 +380,11 @@ const docTemplate = `{
                         "name": "sandboxId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update network settings",
-                        "name": "sandbox",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateNetworkSettingsDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Network settings updated",
+                        "description": "Sandbox destroyed",
                         "schema": {
                             "type": "string"
                         }
@@ -490,17 +422,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/resize": {
+        "/compose/{sandboxId}/start": {
             "post": {
-                "description": "Resize sandbox",
+                "description": "Start all services in dependency order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "compose"
                 ],
-                "summary": "Resize sandbox",
-                "operationId": "Resize",
+                "summary": "Start multi-container sandbox",
+                "operationId": "StartCompose",
                 "parameters": [
                     {
                         "type": "string",
@@ -508,20 +440,11 @@ const docTemplate = `{
                         "name": "sandboxId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Resize sandbox",
-                        "name": "sandbox",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ResizeSandboxDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sandbox resized",
+                        "description": "Sandbox started",
                         "schema": {
                             "type": "string"
                         }
@@ -559,17 +482,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/start": {
+        "/compose/{sandboxId}/stop": {
             "post": {
-                "description": "Start sandbox",
+                "description": "Stop all services in reverse dependency order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sandbox"
+                    "compose"
                 ],
-                "summary": "Start sandbox",
-                "operationId": "Start",
+                "summary": "Stop multi-container sandbox",
+                "operationId": "StopCompose",
                 "parameters": [
                     {
                         "type": "string",
@@ -581,7 +504,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sandbox started",
+                        "description": "Sandbox stopped",
                         "schema": {
                             "type": "string"
                         }
@@ -619,37 +542,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/sandboxes/{sandboxId}/stop": {
-            "post": {
-                "description": "Stop sandbox",
+        "/debug/goroutines": {
+            "get": {
+                "description": "Get the stack traces of all goroutines of the runner, with grouped=true goroutines with the same stack are counted instead of listed one by one",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "sandbox"
+                    "debug"
                 ],
-                "summary": "Stop sandbox",
-                "operationId": "Stop",
+                "summary": "Dump goroutines",
+                "operationId": "DumpGoroutines",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sandbox ID",
-                        "name": "sandboxId",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Whether to group goroutines by their stack",
+                        "name": "grouped",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sandbox stopped",
+                        "description": "Goroutine stack traces",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    }
+                }
+            }
+        },
+        "/debug/log-level": {
+            "get": {
+                "description": "Get the runner log level and the level every module logs at",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "debug"
+                ],
+                "summary": "Get log levels",
+                "operationId": "GetLogLevels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/LogLevelsDTO"
                         }
                     },
                     "401": {
@@ -658,230 +595,258 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/sandboxes/{sandboxId}/toolbox/{path}": {
-            "get": {
-                "description": "Forwards the request to the specified sandbox's container",
+            },
+            "post": {
+                "description": "Change the log level of the runner or of a single module at runtime, with a duration the previous level is restored afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "toolbox"
+                    "debug"
                 ],
-                "summary": "Proxy requests to the sandbox toolbox",
+                "summary": "Set log level",
+                "operationId": "SetLogLevel",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sandbox ID",
-                        "name": "sandboxId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Path to forward",
-                        "name": "path",
-                        "in": "path",
-                        "required": true
+                        "description": "Log level",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetLogLevelDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Proxied response",
-                        "schema": {}
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LogLevelsDTO"
+                        }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Sandbox container not found",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sandbox container conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    }
+                }
+            }
+        },
+        "/drain": {
+            "get": {
+                "description": "Get whether the runner is draining and how many operations are still in flight",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Drain status",
+                "operationId": "DrainStatus",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/DrainStatusResponseDTO"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Forwards the request to the specified sandbox's container",
+                "description": "Stop accepting new sandboxes and snapshot pulls while in-flight operations finish. Draining cannot be undone without restarting the runner.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Drain runner",
+                "operationId": "Drain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DrainStatusResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Streams sandbox state transitions and snapshot pull/build progress as server-sent events",
+                "produces": [
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "toolbox"
+                    "events"
                 ],
-                "summary": "Proxy requests to the sandbox toolbox",
+                "summary": "Stream runner events",
+                "operationId": "StreamEvents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sandbox ID",
+                        "description": "Only stream events for this sandbox",
                         "name": "sandboxId",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Path to forward",
-                        "name": "path",
-                        "in": "path",
-                        "required": true
+                        "description": "Only stream events of this type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Proxied response",
-                        "schema": {}
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/Event"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Health of the runner components following the gRPC health checking protocol semantics. An empty service reports the overall runner health.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Component health status",
+                "operationId": "HealthStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Component to check (runner, docker, cache, proxy)",
+                        "name": "service",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/HealthStatusResponseDTO"
                         }
                     },
                     "404": {
-                        "description": "Sandbox container not found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/HealthStatusResponseDTO"
                         }
                     },
-                    "409": {
-                        "description": "Sandbox container conflict",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/HealthStatusResponseDTO"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    }
+                }
+            }
+        },
+        "/info": {
+            "get": {
+                "description": "Runner info with system metrics, host capacity, available GPUs and the runner labels",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Runner info",
+                "operationId": "RunnerInfo",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/RunnerInfoResponseDTO"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Forwards the request to the specified sandbox's container",
-                "tags": [
-                    "toolbox"
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "Get the scheduled maintenance window of the runner",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Proxy requests to the sandbox toolbox",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Sandbox ID",
-                        "name": "sandboxId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Path to forward",
-                        "name": "path",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "maintenance"
                 ],
+                "summary": "Get maintenance",
+                "operationId": "GetMaintenance",
                 "responses": {
                     "200": {
-                        "description": "Proxied response",
-                        "schema": {}
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/MaintenanceWindowDTO"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Sandbox container not found",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sandbox container conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/snapshots/build": {
+            },
             "post": {
-                "description": "Build a snapshot from a Dockerfile and context hashes",
+                "description": "Schedule the maintenance window of the runner, replacing the scheduled one. New sandboxes are refused during the window and the sandboxes are notified with an event ahead of it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "snapshots"
+                    "maintenance"
                 ],
-                "summary": "Build a snapshot",
-                "operationId": "BuildSnapshot",
+                "summary": "Schedule maintenance",
+                "operationId": "ScheduleMaintenance",
                 "parameters": [
                     {
-                        "description": "Build snapshot request",
+                        "description": "Maintenance window",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/BuildSnapshotRequestDTO"
+                            "$ref": "#/definitions/ScheduleMaintenanceDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Snapshot successfully built",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/MaintenanceWindowDTO"
                         }
                     },
                     "400": {
@@ -896,20 +861,45 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel the scheduled maintenance window, notified sandboxes get a cancellation event. A drain started by the window is not undone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Cancel maintenance",
+                "operationId": "CancelMaintenance",
+                "responses": {
+                    "200": {
+                        "description": "Maintenance cancelled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -917,23 +907,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/exists": {
+        "/operations/{operationId}": {
             "get": {
-                "description": "Check if a specified snapshot exists locally",
+                "description": "Get the state of an operation started with async=true, finished operations are kept for an hour",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "snapshots"
+                    "operations"
                 ],
-                "summary": "Check if a snapshot exists",
-                "operationId": "SnapshotExists",
+                "summary": "Get operation",
+                "operationId": "GetOperation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Snapshot name and tag",
-                        "name": "snapshot",
-                        "in": "query",
+                        "description": "Operation ID",
+                        "name": "operationId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -941,13 +931,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SnapshotExistsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/OperationDTO"
                         }
                     },
                     "401": {
@@ -962,12 +946,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -977,34 +955,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/logs": {
-            "get": {
-                "description": "Stream build logs",
+        "/operations/{operationId}/cancel": {
+            "post": {
+                "description": "Cancel a running operation, its state changes to cancelled once the work in progress was aborted",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "snapshots"
+                    "operations"
                 ],
-                "summary": "Get build logs",
-                "operationId": "GetBuildLogs",
+                "summary": "Cancel operation",
+                "operationId": "CancelOperation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Snapshot ID or snapshot ref without the tag",
-                        "name": "snapshotRef",
-                        "in": "query",
+                        "description": "Operation ID",
+                        "name": "operationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/{operationId}/wait": {
+            "get": {
+                "description": "Wait until the operation finished or the timeout elapsed and get its state, the state is running if it timed out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operations"
+                ],
+                "summary": "Wait for operation",
+                "operationId": "WaitOperation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "operationId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Whether to follow the log output",
-                        "name": "follow",
+                        "type": "string",
+                        "description": "Duration like 30s to wait for at most, defaults to 30s and is at most 5m",
+                        "name": "timeout",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Build logs stream",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/OperationDTO"
                         }
                     },
                     "400": {
@@ -1034,32 +1069,130 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/pull": {
+        "/sandboxes": {
+            "get": {
+                "description": "List the sandboxes known to the runner cache and the sandbox containers, cached states are reconciled with the containers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "List sandboxes",
+                "operationId": "ListSandboxes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by label (key or key=value)",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by snapshot",
+                        "name": "snapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 100 and is at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSandboxesResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Pull a snapshot from a registry",
+                "description": "Create a sandbox, with async=true the sandbox is created in the background and the operation is returned",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "snapshots"
+                    "sandbox"
                 ],
-                "summary": "Pull a snapshot",
-                "operationId": "PullSnapshot",
+                "summary": "Create a sandbox",
+                "operationId": "Create",
                 "parameters": [
                     {
-                        "description": "Pull snapshot",
-                        "name": "request",
+                        "description": "Create sandbox",
+                        "name": "sandbox",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/PullSnapshotRequestDTO"
+                            "$ref": "#/definitions/CreateSandboxDTO"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to create the sandbox in the background",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to replay the response of a retried request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Snapshot successfully pulled",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -1072,6 +1205,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1084,6 +1223,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1093,29 +1244,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/remove": {
-            "post": {
-                "description": "Remove a specified snapshot from the local system",
+        "/sandboxes/{sandboxId}": {
+            "get": {
+                "description": "Get sandbox info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "snapshots"
+                    "sandbox"
                 ],
-                "summary": "Remove a snapshot",
-                "operationId": "RemoveSnapshot",
+                "summary": "Get sandbox info",
+                "operationId": "Info",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Snapshot name and tag",
-                        "name": "snapshot",
-                        "in": "query",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Snapshot successfully removed",
+                        "description": "Sandbox info",
+                        "schema": {
+                            "$ref": "#/definitions/SandboxInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a sandbox that has been previously destroyed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Remove a destroyed sandbox",
+                "operationId": "RemoveDestroyed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox removed",
                         "schema": {
                             "type": "string"
                         }
@@ -1152,67 +1361,6072 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "BuildSnapshotRequestDTO": {
-            "type": "object",
-            "required": [
-                "dockerfile",
-                "organizationId"
-            ],
-            "properties": {
-                "context": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "dockerfile": {
-                    "type": "string"
-                },
-                "organizationId": {
-                    "type": "string"
-                },
-                "pushToInternalRegistry": {
-                    "type": "boolean"
+        },
+        "/sandboxes/{sandboxId}/backup": {
+            "post": {
+                "description": "Create sandbox backup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Create sandbox backup",
+                "operationId": "CreateBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create backup",
+                        "name": "sandbox",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateBackupDTO"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to replay the response of a retried request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Backup started",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/backup/restore": {
+            "post": {
+                "description": "Restore the snapshot and volumes of a backup exported to object storage so the sandbox can be created on this runner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Restore sandbox backup",
+                "operationId": "RestoreBackup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Restore backup",
+                        "name": "sandbox",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RestoreBackupDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Backup restore started",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/checkpoint": {
+            "post": {
+                "description": "Persist the sandbox process state to object storage using CRIU (experimental)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Checkpoint sandbox",
+                "operationId": "Checkpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checkpoint sandbox",
+                        "name": "checkpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CheckpointSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox checkpointed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/checkpoint/restore": {
+            "post": {
+                "description": "Start a stopped sandbox from a checkpoint stored in object storage (experimental)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Restore sandbox from checkpoint",
+                "operationId": "RestoreCheckpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Restore checkpoint",
+                        "name": "checkpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RestoreCheckpointDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox restored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/clone": {
+            "post": {
+                "description": "Commit the sandbox, copy its volumes and create a new sandbox from the result",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Clone a sandbox",
+                "operationId": "CloneSandbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone sandbox request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CloneSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/destroy": {
+            "post": {
+                "description": "Destroy sandbox",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Destroy sandbox",
+                "operationId": "Destroy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox destroyed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/env": {
+            "get": {
+                "description": "Get the environment variables set on the sandbox after it was created and the names of its secrets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Get sandbox env",
+                "operationId": "GetSandboxEnv",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SandboxEnvDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Set or remove environment variables of the sandbox, they are applied on the next start",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Update sandbox env",
+                "operationId": "UpdateSandboxEnv",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update env request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSandboxEnvDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SandboxEnvDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/files/download": {
+            "get": {
+                "description": "Stream a file from the sandbox, directories are returned as a tar archive",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Download a file from the sandbox",
+                "operationId": "DownloadFile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute source path",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/files/upload": {
+            "post": {
+                "description": "Stream the request body to a file in the sandbox",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Upload a file to the sandbox",
+                "operationId": "UploadFile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute destination file path",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0644",
+                        "description": "Octal file mode",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File uploaded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/hooks/logs": {
+            "get": {
+                "description": "Get the output of the lifecycle hooks the sandbox ran so far",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Get lifecycle hook logs",
+                "operationId": "GetLifecycleHookLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hook logs",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/logs": {
+            "get": {
+                "description": "Stream the output of the sandbox container itself, stdout and stderr are merged in the order they were written",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Get sandbox logs",
+                "operationId": "GetSandboxLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to follow the log output",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp or duration like 10m, only logs written after it are returned",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp or duration like 10m, only logs written before it are returned",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of lines from the end of the logs to return, defaults to all",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to return stdout, defaults to true",
+                        "name": "stdout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to return stderr, defaults to true",
+                        "name": "stderr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to prefix every line with its timestamp",
+                        "name": "timestamps",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox logs stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/migrate": {
+            "post": {
+                "description": "Export the sandbox to object storage in the background and, with a destination, hand it over to the destination runner and destroy it on this runner. Live migrations checkpoint the running processes so they continue on the destination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Migrate a sandbox to another runner",
+                "operationId": "MigrateSandbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Migrate sandbox",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MigrateSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/migrate/receive": {
+            "post": {
+                "description": "Import a sandbox another runner exported for migration in the background, create it on this runner and expose its ports again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Take over a migrated sandbox",
+                "operationId": "ReceiveMigration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Receive migration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReceiveMigrationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/network-settings": {
+            "get": {
+                "description": "Get sandbox network settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Get sandbox network settings",
+                "operationId": "GetNetworkSettings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Network settings",
+                        "schema": {
+                            "$ref": "#/definitions/UpdateNetworkSettingsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Update sandbox network settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Update sandbox network settings",
+                "operationId": "UpdateNetworkSettings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update network settings",
+                        "name": "sandbox",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateNetworkSettingsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Network settings updated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/pause": {
+            "post": {
+                "description": "Pause all processes in the sandbox without stopping it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Pause sandbox",
+                "operationId": "Pause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox paused",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/ports": {
+            "get": {
+                "description": "List the ports of the sandbox routed through the runner proxy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "List exposed sandbox ports",
+                "operationId": "ListExposedPorts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ExposedPortDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Route a port of the sandbox through the runner proxy without recreating the sandbox, exposing an exposed port again changes its access level and desktop flag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Expose a sandbox port",
+                "operationId": "ExposePort",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Expose port request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ExposePortDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ExposedPortDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/ports/{port}": {
+            "delete": {
+                "description": "Stop routing the port of the sandbox through the runner proxy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Unexpose a sandbox port",
+                "operationId": "UnexposePort",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sandbox port",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Port unexposed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/ports/{port}/{path}": {
+            "get": {
+                "description": "Forwards the request to the exposed port of the sandbox container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to an exposed sandbox port",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sandbox port",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {}
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Port not exposed or sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Forwards the request to the exposed port of the sandbox container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to an exposed sandbox port",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sandbox port",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {}
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Port not exposed or sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Forwards the request to the exposed port of the sandbox container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to an exposed sandbox port",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sandbox port",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {}
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Port not exposed or sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/processes": {
+            "get": {
+                "description": "List the processes of a running sandbox with their host PIDs, the parent PIDs make up the process tree",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "List sandbox processes",
+                "operationId": "ListSandboxProcesses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSandboxProcessesResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/processes/{pid}/signal": {
+            "post": {
+                "description": "Send a signal to a process of the sandbox by its host PID, e.g. to kill a runaway process",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Signal a sandbox process",
+                "operationId": "SignalProcess",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Host PID of the process",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signal",
+                        "name": "signal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SignalProcessDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signal sent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/proxy-token": {
+            "post": {
+                "description": "Issue a token granting access to the sandbox toolbox proxy only, previously issued tokens of the sandbox are revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Refresh sandbox proxy token",
+                "operationId": "RefreshProxyToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProxyTokenResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/resize": {
+            "post": {
+                "description": "Update CPU, memory and swap limits of the sandbox without recreating it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Resize sandbox",
+                "operationId": "Resize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resize sandbox",
+                        "name": "sandbox",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResizeSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox resized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/resume": {
+            "post": {
+                "description": "Resume a paused sandbox",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Resume sandbox",
+                "operationId": "Resume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox resumed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/secrets": {
+            "post": {
+                "description": "Set or remove secrets of the sandbox. Secrets are files in a tmpfs instead of environment variables so they don't show up when the container is inspected, a running sandbox gets them right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Update sandbox secrets",
+                "operationId": "UpdateSandboxSecrets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update secrets request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSandboxSecretsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SandboxEnvDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/snapshot": {
+            "post": {
+                "description": "Commit the sandbox filesystem and config as a snapshot recording its lineage, optionally squashed and pushed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Create a snapshot from a sandbox",
+                "operationId": "CreateSnapshotFromSandbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create snapshot request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSnapshotFromSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CreateSnapshotFromSandboxResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/ssh-access": {
+            "post": {
+                "description": "Allow a public key to open SSH sessions into the sandbox through the SSH gateway of the runner until the access expires. A key pair is generated if no public key is passed, its private key is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Create SSH access",
+                "operationId": "CreateSshAccess",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create SSH access request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSshAccessDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SshAccessDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/ssh-access/{accessId}": {
+            "delete": {
+                "description": "Stop accepting the key of the SSH access, open sessions are not closed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Revoke SSH access",
+                "operationId": "RevokeSshAccess",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SSH access ID",
+                        "name": "accessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SSH access revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/start": {
+            "post": {
+                "description": "Start sandbox",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Start sandbox",
+                "operationId": "Start",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sandbox started",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/stop": {
+            "post": {
+                "description": "Stop sandbox with SIGTERM and kill it if it doesn't exit within the grace period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sandbox"
+                ],
+                "summary": "Stop sandbox",
+                "operationId": "Stop",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stop options",
+                        "name": "stop",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/StopSandboxDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/StopSandboxResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/toolbox/{path}": {
+            "get": {
+                "description": "Forwards the request to the specified sandbox's container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to the sandbox toolbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sandbox container conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many concurrent execs",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Forwards the request to the specified sandbox's container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to the sandbox toolbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sandbox container conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many concurrent execs",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Forwards the request to the specified sandbox's container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Proxy requests to the sandbox toolbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path to forward",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Proxied response",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sandbox container conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many concurrent execs",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandboxes/{sandboxId}/tunnel/{port}": {
+            "get": {
+                "description": "Upgrades the connection (Upgrade: tcp) and forwards raw bytes to the given port of the sandbox container",
+                "tags": [
+                    "toolbox"
+                ],
+                "summary": "Open a TCP tunnel to a sandbox port",
+                "operationId": "TunnelTCP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox ID",
+                        "name": "sandboxId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sandbox port",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Sandbox container not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sandbox container conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Sandbox port unreachable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/security-profiles": {
+            "get": {
+                "description": "List the security profiles uploaded to the runner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "security"
+                ],
+                "summary": "List security profiles",
+                "operationId": "ListSecurityProfiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SecurityProfileDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Store a seccomp and AppArmor profile under a name sandboxes reference on create. The AppArmor profile is loaded into the kernel of the runner right away. Profiles can't be replaced, delete the profile first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "security"
+                ],
+                "summary": "Upload security profile",
+                "operationId": "UploadSecurityProfile",
+                "parameters": [
+                    {
+                        "description": "Security profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UploadSecurityProfileDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SecurityProfileDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/security-profiles/{name}": {
+            "delete": {
+                "description": "Delete a security profile and unload its AppArmor profile. Profiles sandboxes were created with can't be deleted until the sandboxes are destroyed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "security"
+                ],
+                "summary": "Delete security profile",
+                "operationId": "DeleteSecurityProfile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Security profile name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Security profile deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/build": {
+            "post": {
+                "description": "Build a snapshot from a Dockerfile and context hashes, with async=true the snapshot is built in the background and the operation is returned",
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Build a snapshot",
+                "operationId": "BuildSnapshot",
+                "parameters": [
+                    {
+                        "description": "Build snapshot request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/BuildSnapshotRequestDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to build the snapshot in the background",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to replay the response of a retried request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot successfully built",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/build/status": {
+            "get": {
+                "description": "Get the status of the latest build or pull of the snapshot since the runner started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Get the build status of a snapshot",
+                "operationId": "GetSnapshotBuildStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot name with tag, or snapshot ID or ref without the tag for builds",
+                        "name": "snapshotRef",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SnapshotBuildStatusDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/exists": {
+            "get": {
+                "description": "Check if a specified snapshot exists locally",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Check if a snapshot exists",
+                "operationId": "SnapshotExists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot name and tag",
+                        "name": "snapshot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only report snapshots built for this platform",
+                        "name": "platform",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SnapshotExistsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/export": {
+            "get": {
+                "description": "Stream a local snapshot as an image tarball",
+                "produces": [
+                    "application/x-tar"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Export a snapshot",
+                "operationId": "ExportSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot name and tag",
+                        "name": "snapshot",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot tarball",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a local snapshot as an image tarball to the runner object storage",
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Export a snapshot to object storage",
+                "operationId": "ExportSnapshotToStorage",
+                "parameters": [
+                    {
+                        "description": "Export snapshot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ExportSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot successfully exported",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/import": {
+            "post": {
+                "description": "Load a snapshot from an image tarball streamed in the request body",
+                "consumes": [
+                    "application/x-tar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Import a snapshot",
+                "operationId": "ImportSnapshot",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ImportSnapshotResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/import/remote": {
+            "post": {
+                "description": "Load a snapshot from an image tarball in the runner object storage or at an HTTP(S) URL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Import a snapshot from a remote tarball",
+                "operationId": "ImportRemoteSnapshot",
+                "parameters": [
+                    {
+                        "description": "Import snapshot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ImportSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ImportSnapshotResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/info": {
+            "get": {
+                "description": "Get the size and stored platform of a local snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Get snapshot info",
+                "operationId": "SnapshotInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot name and tag",
+                        "name": "snapshot",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SnapshotInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/logs": {
+            "get": {
+                "description": "Stream build logs",
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Get build logs",
+                "operationId": "GetBuildLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID or snapshot ref without the tag",
+                        "name": "snapshotRef",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to follow the log output",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of lines from the end of the log to return, defaults to all",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp or duration like 10m, existing output is skipped if the log wasn't written to since",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Build logs stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/prewarm": {
+            "get": {
+                "description": "Get the progress of the snapshot prewarms and the pinned snapshots",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Get prewarm status",
+                "operationId": "GetPrewarmStatus",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PrewarmSnapshotsResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Pin the snapshots so garbage collection keeps them and pull them in the background",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Prewarm snapshots",
+                "operationId": "PrewarmSnapshots",
+                "parameters": [
+                    {
+                        "description": "Prewarm snapshots request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PrewarmSnapshotsRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/PrewarmSnapshotsResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/prune": {
+            "post": {
+                "description": "Remove snapshots that are not used by any sandbox, are older than the minimum age and are not on the keep list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Prune unused snapshots",
+                "operationId": "PruneSnapshots",
+                "parameters": [
+                    {
+                        "description": "Prune snapshots request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PruneSnapshotsRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PruneSnapshotsResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/pull": {
+            "post": {
+                "description": "Pull a snapshot from a registry, with async=true the snapshot is pulled in the background and the operation is returned",
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Pull a snapshot",
+                "operationId": "PullSnapshot",
+                "parameters": [
+                    {
+                        "description": "Pull snapshot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PullSnapshotRequestDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to pull the snapshot in the background",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to replay the response of a retried request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot successfully pulled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/OperationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/push": {
+            "post": {
+                "description": "Push a local snapshot to a registry",
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Push a snapshot",
+                "operationId": "PushSnapshot",
+                "parameters": [
+                    {
+                        "description": "Push snapshot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PushSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot successfully pushed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/remove": {
+            "post": {
+                "description": "Remove a specified snapshot from the local system",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Remove a snapshot",
+                "operationId": "RemoveSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot name and tag",
+                        "name": "snapshot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to replay the response of a retried request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot successfully removed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/scan": {
+            "post": {
+                "description": "Run Trivy against a local snapshot and return its vulnerability report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Scan a snapshot for vulnerabilities",
+                "operationId": "ScanSnapshot",
+                "parameters": [
+                    {
+                        "description": "Scan snapshot request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ScanSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ScanSnapshotResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/unpin": {
+            "post": {
+                "description": "Make a pinned snapshot eligible for garbage collection again",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "snapshots"
+                ],
+                "summary": "Unpin a snapshot",
+                "operationId": "UnpinSnapshot",
+                "parameters": [
+                    {
+                        "description": "Unpin snapshot request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UnpinSnapshotRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot unpinned",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "post": {
+                "description": "Issue a token limited to the given scopes, e.g. a read only token for log viewers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Create a scoped token",
+                "operationId": "CreateScopedToken",
+                "parameters": [
+                    {
+                        "description": "Scoped token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateScopedTokenDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ScopedTokenResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "description": "Get the usage records of the sandboxes closed after the given sequence, records are kept for 24 hours by default",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Get usage records",
+                "operationId": "GetUsageRecords",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sequence of the last record already received, the cursor of the previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return the records of this sandbox",
+                        "name": "sandboxId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of records to return, defaults to 1000 and is at most 10000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UsageRecordsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volumes": {
+            "get": {
+                "description": "List Docker volumes on the runner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "List volumes",
+                "operationId": "ListVolumes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by volume name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by volume driver",
+                        "name": "driver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by label (key or key=value)",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter volumes not referenced by any container",
+                        "name": "dangling",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/VolumeInfoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a Docker volume on the runner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "Create a volume",
+                "operationId": "CreateVolume",
+                "parameters": [
+                    {
+                        "description": "Create volume",
+                        "name": "volume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateVolumeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/VolumeInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volumes/{volumeName}": {
+            "get": {
+                "description": "Get volume details including disk usage and the sandboxes using it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "Inspect a volume",
+                "operationId": "InspectVolume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Volume name",
+                        "name": "volumeName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/VolumeInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a volume, fails if any sandbox still uses it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "Remove a volume",
+                "operationId": "RemoveVolume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Volume name",
+                        "name": "volumeName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Volume removed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volumes/{volumeName}/sync": {
+            "get": {
+                "description": "Get the progress of the last sync of a materialized volume",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "Get volume sync status",
+                "operationId": "GetVolumeSyncStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/VolumeSyncStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start syncing a materialized volume with its S3 bucket, progress is reported by the sync status endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volumes"
+                ],
+                "summary": "Sync a volume",
+                "operationId": "SyncVolume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Volume ID",
+                        "name": "volumeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sync volume",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SyncVolumeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Volume sync started",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "BuildCacheDTO": {
+            "type": "object",
+            "required": [
+                "ref"
+            ],
+            "properties": {
+                "export": {
+                    "description": "Push the updated cache after the build",
+                    "type": "boolean"
+                },
+                "ref": {
+                    "description": "Registry reference storing the cache, e.g. registry.example.com/cache/devcontainer:buildcache",
+                    "type": "string"
+                },
+                "registry": {
+                    "description": "Credentials for the cache registry",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RegistryDTO"
+                        }
+                    ]
+                }
+            }
+        },
+        "BuildSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "dockerfile",
+                "organizationId"
+            ],
+            "properties": {
+                "cache": {
+                    "description": "Build with BuildKit using a registry layer cache",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/BuildCacheDTO"
+                        }
+                    ]
+                },
+                "context": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dockerfile": {
+                    "type": "string"
+                },
+                "organizationId": {
+                    "type": "string"
+                },
+                "pushToInternalRegistry": {
+                    "type": "boolean"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "snapshot": {
+                    "description": "Snapshot ID and tag or the build's hash",
+                    "type": "string"
+                }
+            }
+        },
+        "CacheEntryResponse": {
+            "type": "object",
+            "properties": {
+                "backupError": {
+                    "type": "string"
+                },
+                "backupProgress": {
+                    "$ref": "#/definitions/models.BackupProgress"
+                },
+                "backupState": {
+                    "$ref": "#/definitions/enums.BackupState"
+                },
+                "daemonHealth": {
+                    "$ref": "#/definitions/enums.DaemonHealth"
+                },
+                "destructionTime": {
+                    "description": "When the entry of a destroyed sandbox is dropped",
+                    "type": "string"
+                },
+                "expiration": {
+                    "$ref": "#/definitions/models.SandboxExpiration"
+                },
+                "pullQueuePosition": {
+                    "type": "integer"
+                },
+                "repository": {
+                    "$ref": "#/definitions/models.RepositoryStatus"
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.SandboxResources"
+                },
+                "sandboxId": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/enums.SandboxState"
+                }
+            }
+        },
+        "CheckpointSandboxDTO": {
+            "type": "object",
+            "required": [
+                "checkpointId"
+            ],
+            "properties": {
+                "checkpointId": {
+                    "type": "string"
+                },
+                "exit": {
+                    "description": "Stop the sandbox after the checkpoint is taken",
+                    "type": "boolean"
+                }
+            }
+        },
+        "CleanupRequestDTO": {
+            "type": "object",
+            "properties": {
+                "danglingVolumes": {
+                    "description": "Remove sandbox volumes no container uses, defaults to the runner setting",
+                    "type": "boolean"
+                },
+                "dryRun": {
+                    "description": "Report what would be removed without removing anything",
+                    "type": "boolean"
+                },
+                "exitedContainerMaxAge": {
+                    "description": "Remove sandboxes exited longer ago than this duration, e.g. 48h, defaults to the runner setting",
+                    "type": "string"
+                },
+                "sandboxNetworks": {
+                    "description": "Remove per-sandbox networks without a sandbox, defaults to the runner setting",
+                    "type": "boolean"
+                }
+            }
+        },
+        "CleanupResponseDTO": {
+            "type": "object",
+            "required": [
+                "containers",
+                "dryRun",
+                "networks",
+                "volumes"
+            ],
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "CloneSandboxDTO": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "copyEnv": {
+                    "description": "Keep the environment variables and secrets of the source, otherwise variables are reset to the snapshot values",
+                    "type": "boolean"
+                },
+                "copyLabels": {
+                    "description": "Keep the idle timeout and bandwidth limits of the source",
+                    "type": "boolean"
+                },
+                "env": {
+                    "description": "Set on the clone after the copied variables",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "description": "Name and tag of the snapshot the clone is created from, defaults to daytona-clone:\u003cid\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "ComponentHealthDTO": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SERVING"
+                }
+            }
+        },
+        "ComposeSandboxInfoResponse": {
+            "type": "object",
+            "required": [
+                "services",
+                "state"
+            ],
+            "properties": {
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ComposeServiceDTO"
+                    }
+                },
+                "state": {
+                    "description": "Aggregated state of all services",
+                    "type": "string"
+                }
+            }
+        },
+        "ComposeServiceDTO": {
+            "type": "object",
+            "required": [
+                "containerId",
+                "image",
+                "name",
+                "state"
+            ],
+            "properties": {
+                "containerId": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateBackupDTO": {
+            "type": "object",
+            "required": [
+                "snapshot"
+            ],
+            "properties": {
+                "baseObjectPath": {
+                    "description": "Prefix of an earlier object storage backup, only the snapshot layers missing from its backup chain are uploaded",
+                    "type": "string"
+                },
+                "objectPath": {
+                    "description": "Object storage prefix to export the snapshot and volumes to instead of pushing to the registry",
+                    "type": "string"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "snapshot": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateComposeSandboxDTO": {
+            "type": "object",
+            "required": [
+                "compose",
+                "id",
+                "userId"
+            ],
+            "properties": {
+                "compose": {
+                    "description": "Compose file (YAML) describing the sandbox services",
+                    "type": "string"
+                },
+                "cpuQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "egressPolicy": {
+                    "description": "Takes precedence over networkBlockAll and networkAllowList",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/EgressPolicyDTO"
+                        }
+                    ]
+                },
+                "env": {
+                    "description": "Added to the environment of every service",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "memoryQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "networkAllowList": {
+                    "type": "string"
+                },
+                "networkBlockAll": {
+                    "type": "boolean"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "security": {
+                    "$ref": "#/definitions/SandboxSecurityDTO"
+                },
+                "storageQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSandboxDTO": {
+            "type": "object",
+            "required": [
+                "id",
+                "osUser",
+                "userId"
+            ],
+            "properties": {
+                "cpuQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "devcontainer": {
+                    "description": "Takes precedence over snapshot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/DevcontainerDTO"
+                        }
+                    ]
+                },
+                "egressBandwidthMbps": {
+                    "description": "Limit on traffic out of the sandbox, 0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "egressPolicy": {
+                    "description": "Takes precedence over networkBlockAll and networkAllowList",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/EgressPolicyDTO"
+                        }
+                    ]
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "env": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "fromVolumeId": {
+                    "type": "string"
+                },
+                "gpuDeviceIds": {
+                    "description": "Takes precedence over gpuQuota when set",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gpuQuota": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "hooks": {
+                    "$ref": "#/definitions/SandboxHooksDTO"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idleTimeoutMinutes": {
+                    "description": "Stop the sandbox after this many idle minutes, 0 disables auto-stop",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "ingressBandwidthMbps": {
+                    "description": "Limit on traffic into the sandbox, 0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "memoryQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "networkAllowList": {
+                    "type": "string"
+                },
+                "networkBlockAll": {
+                    "type": "boolean"
+                },
+                "osUser": {
+                    "type": "string"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "repository": {
+                    "description": "Cloned into the sandbox before its first start",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RepositoryDTO"
+                        }
+                    ]
+                },
+                "requiredLabels": {
+                    "description": "Runner labels the sandbox must be placed on, an empty value only requires the label to be present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "restartPolicy": {
+                    "$ref": "#/definitions/RestartPolicyDTO"
+                },
+                "runtime": {
+                    "description": "OCI runtime of the sandbox, must be available on the runner, defaults to the runner setting",
+                    "type": "string",
+                    "enum": [
+                        "runc",
+                        "runsc",
+                        "kata"
+                    ]
+                },
+                "scanSeverityThreshold": {
+                    "description": "Refuse to create the sandbox if the snapshot has vulnerabilities at or above this severity",
+                    "type": "string",
+                    "enum": [
+                        "LOW",
+                        "MEDIUM",
+                        "HIGH",
+                        "CRITICAL"
+                    ]
+                },
+                "secrets": {
+                    "description": "Written to files in /run/daytona/secrets instead of the container env, keyed by file name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "security": {
+                    "$ref": "#/definitions/SandboxSecurityDTO"
+                },
+                "sidecars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SidecarDTO"
+                    }
+                },
+                "snapshot": {
+                    "description": "Resolved from the devcontainer when one is set",
+                    "type": "string"
+                },
+                "storageQuota": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "ttlAction": {
+                    "description": "Defaults to DESTROY",
+                    "type": "string",
+                    "enum": [
+                        "STOP",
+                        "DESTROY"
+                    ]
+                },
+                "ttlMinutes": {
+                    "description": "Apply the TTL action this many minutes after creation, 0 disables the TTL",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolumeDTO"
+                    }
+                }
+            }
+        },
+        "CreateScopedTokenDTO": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "subject": {
+                    "description": "Subject names the holder of the token in audit logs",
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "Ttl in seconds, tokens without a ttl stay valid until the API token signing them is removed",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "CreateSnapshotFromSandboxDTO": {
+            "type": "object",
+            "required": [
+                "snapshot"
+            ],
+            "properties": {
+                "push": {
+                    "description": "Push the snapshot to the registry",
+                    "type": "boolean"
+                },
+                "registry": {
+                    "description": "Required when pushing",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RegistryDTO"
+                        }
+                    ]
+                },
+                "snapshot": {
+                    "description": "Snapshot name and tag",
+                    "type": "string"
+                },
+                "squash": {
+                    "description": "Flatten the snapshot into a single layer",
+                    "type": "boolean"
+                }
+            }
+        },
+        "CreateSnapshotFromSandboxResponseDTO": {
+            "type": "object",
+            "required": [
+                "id",
+                "snapshot"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "pushedTo": {
+                    "description": "Registry reference the snapshot was pushed to",
+                    "type": "string"
+                },
+                "snapshot": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSshAccessDTO": {
+            "type": "object",
+            "properties": {
+                "expiresInMinutes": {
+                    "description": "ExpiresInMinutes defaults to the SSH access TTL of the runner",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "publicKey": {
+                    "description": "PublicKey in the authorized_keys format, a key pair is generated if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateVolumeDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "driver": {
+                    "type": "string"
+                },
+                "driverOpts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "DevcontainerDTO": {
+            "type": "object",
+            "properties": {
+                "authToken": {
+                    "description": "Token for HTTPS access to a private repository",
+                    "type": "string"
+                },
+                "config": {
+                    "description": "Content of the devcontainer.json, takes precedence over the file in the repository",
+                    "type": "string"
+                },
+                "configPath": {
+                    "description": "Relative to the repository root, defaults to .devcontainer/devcontainer.json or .devcontainer.json",
+                    "type": "string"
+                },
+                "ref": {
+                    "description": "Branch or tag, defaults to the default branch",
+                    "type": "string"
+                },
+                "repositoryUrl": {
+                    "description": "Cloned to resolve the config and the build context",
+                    "type": "string"
+                }
+            }
+        },
+        "DrainStatusResponseDTO": {
+            "type": "object",
+            "required": [
+                "draining",
+                "inFlight"
+            ],
+            "properties": {
+                "draining": {
+                    "type": "boolean"
+                },
+                "inFlight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EgressPolicyDTO": {
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "cidrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "ALLOW_ALL",
+                        "DENY_ALL",
+                        "ALLOWLIST"
+                    ]
+                }
+            }
+        },
+        "ErrorResponse": {
+            "description": "Error response",
+            "type": "object",
+            "required": [
+                "code",
+                "message",
+                "method",
+                "path",
+                "statusCode",
+                "timestamp"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BAD_REQUEST"
+                },
+                "details": {
+                    "description": "Details of the failure, e.g. the image it concerns",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bad request"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/resource"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-01-01T12:00:00Z"
+                }
+            }
+        },
+        "Event": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "sandboxId": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/enums.EventType"
+                }
+            }
+        },
+        "ExportSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "objectPath",
+                "snapshot"
+            ],
+            "properties": {
+                "objectPath": {
+                    "description": "Destination path in the runner object storage bucket",
+                    "type": "string"
+                },
+                "snapshot": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExposePortDTO": {
+            "type": "object",
+            "required": [
+                "port"
+            ],
+            "properties": {
+                "accessLevel": {
+                    "description": "Who can reach the preview URL, defaults to AUTHENTICATED",
+                    "type": "string",
+                    "enum": [
+                        "PUBLIC",
+                        "AUTHENTICATED",
+                        "OWNER"
+                    ]
+                },
+                "desktop": {
+                    "description": "Marks a long lived desktop session (VNC, RDP over websocket), its streams are never closed for being idle",
+                    "type": "boolean"
+                },
+                "port": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                }
+            }
+        },
+        "ExposedPortDTO": {
+            "type": "object",
+            "required": [
+                "accessLevel",
+                "exposedAt",
+                "port",
+                "url"
+            ],
+            "properties": {
+                "accessLevel": {
+                    "type": "string"
+                },
+                "desktop": {
+                    "type": "boolean"
+                },
+                "exposedAt": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "previewUrl": {
+                    "description": "Address of the port on its own hostname, set if the runner has a preview domain",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Externally reachable address of the port through the runner proxy",
+                    "type": "string"
+                }
+            }
+        },
+        "GpuInfoDTO": {
+            "type": "object",
+            "properties": {
+                "allocated": {
+                    "type": "boolean"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "memoryMiB": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "HealthStatusResponseDTO": {
+            "type": "object",
+            "required": [
+                "components",
+                "status"
+            ],
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/ComponentHealthDTO"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SERVING"
+                }
+            }
+        },
+        "HookDTO": {
+            "type": "object",
+            "required": [
+                "command"
+            ],
+            "properties": {
+                "command": {
+                    "description": "Run with sh -c",
+                    "type": "string"
+                },
+                "failurePolicy": {
+                    "description": "Defaults to WARN",
+                    "type": "string",
+                    "enum": [
+                        "IGNORE",
+                        "WARN",
+                        "FAIL"
+                    ]
+                },
+                "timeoutSeconds": {
+                    "description": "Defaults to 10 minutes",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "user": {
+                    "description": "Defaults to the user of the container",
+                    "type": "string"
+                }
+            }
+        },
+        "ImportSnapshotRequestDTO": {
+            "type": "object",
+            "properties": {
+                "objectPath": {
+                    "description": "Tarball path in the runner object storage bucket",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "HTTP(S) URL of the tarball, e.g. a presigned S3 URL",
+                    "type": "string"
+                }
+            }
+        },
+        "ImportSnapshotResponseDTO": {
+            "type": "object",
+            "required": [
+                "snapshots"
+            ],
+            "properties": {
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ListSandboxProcessesResponseDTO": {
+            "type": "object",
+            "required": [
+                "processes"
+            ],
+            "properties": {
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SandboxProcessDTO"
+                    }
+                }
+            }
+        },
+        "ListSandboxesResponseDTO": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SandboxSummaryDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LogLevelsDTO": {
+            "type": "object",
+            "required": [
+                "level",
+                "modules"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "modules": {
+                    "description": "Level every module logs at",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "MaintenanceWindowDTO": {
+            "type": "object",
+            "required": [
+                "endsAt",
+                "startsAt"
+            ],
+            "properties": {
+                "drain": {
+                    "type": "boolean"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "inProgress": {
+                    "description": "New sandboxes are refused",
+                    "type": "boolean"
+                },
+                "notified": {
+                    "description": "The sandboxes were notified of the window",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "MigrateSandboxDTO": {
+            "type": "object",
+            "required": [
+                "objectPath"
+            ],
+            "properties": {
+                "destination": {
+                    "description": "Hand the sandbox over to this runner and destroy it here, otherwise the sandbox is only exported and left stopped",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/MigrationDestinationDTO"
+                        }
+                    ]
+                },
+                "live": {
+                    "description": "Checkpoint the processes of the running sandbox so they continue on the destination, requires CRIU on both runners",
+                    "type": "boolean"
+                },
+                "objectPath": {
+                    "description": "Object storage prefix the sandbox is exported to, the destination runner imports it from there",
+                    "type": "string"
+                }
+            }
+        },
+        "MigrationDestinationDTO": {
+            "type": "object",
+            "required": [
+                "token",
+                "url"
+            ],
+            "properties": {
+                "token": {
+                    "description": "API token with the write scope on the destination runner",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Base URL of the API of the destination runner",
+                    "type": "string"
+                }
+            }
+        },
+        "OperationDTO": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "state",
+                "target",
+                "type"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorCode": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "Container ID of created sandboxes",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "running, succeeded, failed or cancelled",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Sandbox ID or snapshot the operation is for",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "create, pull, build, migrate or receive_migration",
+                    "type": "string"
+                }
+            }
+        },
+        "PrewarmSnapshotStatusDTO": {
+            "type": "object",
+            "required": [
+                "snapshot"
+            ],
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "pinned": {
+                    "type": "boolean"
+                },
+                "snapshot": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "STARTED, COMPLETED or FAILED, empty for a pin that wasn't prewarmed since the runner started",
+                    "type": "string"
+                }
+            }
+        },
+        "PrewarmSnapshotsRequestDTO": {
+            "type": "object",
+            "required": [
+                "snapshots"
+            ],
+            "properties": {
+                "platform": {
+                    "description": "os/arch[/variant], defaults to the runner platform",
+                    "type": "string"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "snapshots": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "PrewarmSnapshotsResponseDTO": {
+            "type": "object",
+            "required": [
+                "snapshots"
+            ],
+            "properties": {
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PrewarmSnapshotStatusDTO"
+                    }
+                }
+            }
+        },
+        "ProxyTokenResponseDTO": {
+            "type": "object",
+            "required": [
+                "expiresAt",
+                "token"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "PruneSnapshotsRequestDTO": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "Report what would be removed without removing anything",
+                    "type": "boolean"
+                },
+                "minAge": {
+                    "description": "Only remove snapshots last pulled or tagged longer ago than this duration, e.g. 72h, defaults to the runner setting",
+                    "type": "string"
+                }
+            }
+        },
+        "PruneSnapshotsResponseDTO": {
+            "type": "object",
+            "required": [
+                "reclaimedBytes",
+                "removed"
+            ],
+            "properties": {
+                "reclaimedBytes": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "PullSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "snapshot"
+            ],
+            "properties": {
+                "platform": {
+                    "description": "os/arch[/variant], defaults to the runner platform",
+                    "type": "string"
+                },
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "snapshot": {
+                    "type": "string"
+                }
+            }
+        },
+        "PushSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "registry",
+                "snapshot"
+            ],
+            "properties": {
+                "registry": {
+                    "$ref": "#/definitions/RegistryDTO"
+                },
+                "snapshot": {
+                    "description": "Local snapshot name and tag",
+                    "type": "string"
+                }
+            }
+        },
+        "ReceiveMigrationDTO": {
+            "type": "object",
+            "required": [
+                "objectPath"
+            ],
+            "properties": {
+                "objectPath": {
+                    "description": "Object storage prefix the source runner exported the sandbox to",
+                    "type": "string"
+                }
+            }
+        },
+        "RegistryDTO": {
+            "type": "object",
+            "required": [
+                "password",
+                "url",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "RepositoryDTO": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "authToken": {
+                    "description": "Token for HTTPS access to a private repository",
+                    "type": "string"
+                },
+                "depth": {
+                    "description": "Number of commits to fetch, 0 fetches the whole history",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "filter": {
+                    "description": "Partial clone filter, omitted objects are fetched on demand",
+                    "type": "string",
+                    "enum": [
+                        "blob:none",
+                        "tree:0"
+                    ]
+                },
+                "ref": {
+                    "description": "Branch, tag or commit, defaults to the default branch",
+                    "type": "string"
+                },
+                "sparsePaths": {
+                    "description": "Only these directories are checked out, along with the files at the root",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "targetPath": {
+                    "description": "Defaults to /workspaces/\u003crepository name\u003e",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ResizeSandboxDTO": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "gpu": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "memory": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "swap": {
+                    "description": "Swap in GB on top of memory, 0 disables swap",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "RestartPolicyDTO": {
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "backoffSeconds": {
+                    "description": "Delay before the first restart in the window, doubled for every further one up to 5 minutes, defaults to 1",
+                    "type": "integer",
+                    "maximum": 300,
+                    "minimum": 0
+                },
+                "maxRestarts": {
+                    "description": "Restarts within the crash loop window before the sandbox is parked in the error state, defaults to 5",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "mode": {
+                    "description": "on-failure restarts after a non-zero exit code only",
+                    "type": "string",
+                    "enum": [
+                        "never",
+                        "on-failure",
+                        "always"
+                    ]
+                }
+            }
+        },
+        "RestoreBackupDTO": {
+            "type": "object",
+            "required": [
+                "objectPath"
+            ],
+            "properties": {
+                "hostUserNamespace": {
+                    "description": "Restore the volumes for a sandbox in the host user namespace so their files keep their owners on runners that remap users",
+                    "type": "boolean"
+                },
+                "objectPath": {
+                    "description": "Object storage prefix the backup was exported to",
+                    "type": "string"
+                }
+            }
+        },
+        "RestoreCheckpointDTO": {
+            "type": "object",
+            "required": [
+                "checkpointId"
+            ],
+            "properties": {
+                "checkpointId": {
+                    "type": "string"
+                }
+            }
+        },
+        "RunnerCapacity": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "defaultRuntime": {
+                    "type": "string"
+                },
+                "dockerVersion": {
+                    "type": "string"
+                },
+                "kernelVersion": {
+                    "type": "string"
+                },
+                "operatingSystem": {
+                    "type": "string"
+                },
+                "rootless": {
+                    "description": "The engine is rootless Docker or Podman, TCP tunnels are unavailable and resource limits depend on delegated cgroup controllers",
+                    "type": "boolean"
+                },
+                "runtimes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalCpu": {
+                    "type": "integer"
+                },
+                "totalDiskGiB": {
+                    "type": "integer"
+                },
+                "totalMemoryGiB": {
+                    "type": "integer"
+                },
+                "usernsRemap": {
+                    "description": "The engine remaps users, sandboxes run unprivileged unless they use the host user namespace",
+                    "type": "boolean"
+                }
+            }
+        },
+        "RunnerInfoResponseDTO": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "$ref": "#/definitions/RunnerCapacity"
+                },
+                "gpus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GpuInfoDTO"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metrics": {
+                    "$ref": "#/definitions/RunnerMetrics"
+                }
+            }
+        },
+        "RunnerMetrics": {
+            "type": "object",
+            "properties": {
+                "currentAllocatedCpu": {
+                    "type": "integer"
+                },
+                "currentAllocatedDiskGiB": {
+                    "type": "integer"
+                },
+                "currentAllocatedMemoryGiB": {
+                    "type": "integer"
+                },
+                "currentCpuUsagePercentage": {
+                    "type": "number"
+                },
+                "currentDiskUsagePercentage": {
+                    "type": "number"
+                },
+                "currentMemoryUsagePercentage": {
+                    "type": "number"
+                },
+                "currentSnapshotCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SandboxEnvDTO": {
+            "type": "object",
+            "properties": {
+                "env": {
+                    "description": "Variables the daemon and the processes it starts get from the next start on",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "secrets": {
+                    "description": "Names of the secret files in /run/daytona/secrets",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "SandboxHooksDTO": {
+            "type": "object",
+            "properties": {
+                "postCreate": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookDTO"
+                    }
+                },
+                "postStart": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookDTO"
+                    }
+                },
+                "preStop": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookDTO"
+                    }
+                }
+            }
+        },
+        "SandboxInfoResponse": {
+            "type": "object",
+            "properties": {
+                "backupError": {
+                    "type": "string"
+                },
+                "backupProgress": {
+                    "description": "Phase and transferred bytes of a running object storage backup or restore",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BackupProgress"
+                        }
+                    ]
+                },
+                "backupState": {
+                    "$ref": "#/definitions/enums.BackupState"
+                },
+                "daemonHealth": {
+                    "description": "Result of the last probe of the sandbox daemon, UNKNOWN unless the sandbox is started",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.DaemonHealth"
+                        }
+                    ]
+                },
+                "expiration": {
+                    "description": "When and how a sandbox created with a TTL expires",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SandboxExpiration"
+                        }
+                    ]
+                },
+                "pullQueuePosition": {
+                    "description": "Position in the runner pull queue while the snapshot pull waits for a free slot",
+                    "type": "integer"
+                },
+                "repository": {
+                    "description": "Progress or outcome of the clone of the repository the sandbox was created with",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RepositoryStatus"
+                        }
+                    ]
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.SandboxResources"
+                },
+                "runtime": {
+                    "description": "OCI runtime the sandbox container runs under",
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/enums.SandboxState"
+                },
+                "warnings": {
+                    "description": "Latest failures of lifecycle hooks with the WARN failure policy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SandboxWarningDTO"
+                    }
+                }
+            }
+        },
+        "SandboxProcessDTO": {
+            "type": "object",
+            "required": [
+                "command",
+                "elapsed",
+                "pid",
+                "ppid",
+                "state",
+                "user"
+            ],
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "elapsed": {
+                    "description": "Time since the process started, in [[dd-]hh:]mm:ss",
+                    "type": "string"
+                },
+                "pid": {
+                    "description": "PID on the host, signals are sent by it",
+                    "type": "integer"
+                },
+                "ppid": {
+                    "description": "Parent PID on the host, processes with a parent outside of the sandbox are its roots",
+                    "type": "integer"
+                },
+                "rssKiB": {
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "ps process state codes, e.g. S for sleeping or Z for zombie",
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "SandboxSecurityDTO": {
+            "type": "object",
+            "properties": {
+                "capAdd": {
+                    "description": "Linux capabilities added to the default set, e.g. NET_ADMIN, the runner policy may restrict them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capDrop": {
+                    "description": "Linux capabilities dropped from the default set, e.g. ALL",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hostUserNamespace": {
+                    "description": "Opt out of the user namespace remapping of the runner, root in the sandbox is root on the host. Remapped sandboxes are never privileged.",
+                    "type": "boolean"
+                },
+                "maskedPaths": {
+                    "description": "Paths hidden from the sandbox processes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "noNewPrivileges": {
+                    "description": "Keep the sandbox processes from gaining privileges, e.g. through setuid binaries like sudo",
+                    "type": "boolean"
+                },
+                "privileged": {
+                    "description": "Run the sandbox privileged or not, the privilege policy of the runner decides when unset and may forbid it",
+                    "type": "boolean"
+                },
+                "profile": {
+                    "description": "Name of a security profile uploaded to the runner, its seccomp and AppArmor profiles are applied to the sandbox",
+                    "type": "string"
+                },
+                "readOnlyRootfs": {
+                    "description": "Mount the root filesystem read-only, the sandbox can only write to volumes and tmpfs mounts",
+                    "type": "boolean"
+                },
+                "readonlyPaths": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tmpfs": {
+                    "description": "Writable in-memory mounts, e.g. /tmp for sandboxes with a read-only root filesystem",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TmpfsMountDTO"
+                    }
+                }
+            }
+        },
+        "SandboxSummaryDTO": {
+            "type": "object",
+            "required": [
+                "id",
+                "state"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
                 },
-                "registry": {
-                    "$ref": "#/definitions/RegistryDTO"
+                "hasContainer": {
+                    "description": "HasContainer is false for sandboxes only known to the cache, e.g. destroyed ones or ones pulling their snapshot",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
                 "snapshot": {
-                    "description": "Snapshot ID and tag or the build's hash",
+                    "type": "string"
+                },
+                "state": {
                     "type": "string"
                 }
             }
         },
-        "CreateBackupDTO": {
+        "SandboxWarningDTO": {
             "type": "object",
             "required": [
-                "registry",
-                "snapshot"
+                "command",
+                "createdAt",
+                "message",
+                "stage"
             ],
             "properties": {
-                "registry": {
-                    "$ref": "#/definitions/RegistryDTO"
+                "command": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
                 },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "ScanSnapshotRequestDTO": {
+            "type": "object",
+            "required": [
+                "snapshot"
+            ],
+            "properties": {
                 "snapshot": {
                     "type": "string"
                 }
             }
         },
-        "CreateSandboxDTO": {
+        "ScanSnapshotResponseDTO": {
             "type": "object",
             "required": [
-                "id",
-                "osUser",
                 "snapshot",
-                "userId"
+                "summary",
+                "vulnerabilities"
             ],
             "properties": {
-                "cpuQuota": {
-                    "type": "integer",
-                    "minimum": 1
+                "snapshot": {
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "Vulnerability count per severity",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "vulnerabilities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/VulnerabilityDTO"
+                    }
+                }
+            }
+        },
+        "ScheduleMaintenanceDTO": {
+            "type": "object",
+            "required": [
+                "endsAt",
+                "startsAt"
+            ],
+            "properties": {
+                "drain": {
+                    "description": "Drain the runner once the window starts, draining lasts until the runner restarts",
+                    "type": "boolean"
+                },
+                "endsAt": {
+                    "description": "RFC3339",
+                    "type": "string",
+                    "example": "2025-01-01T04:00:00Z"
+                },
+                "notifyBefore": {
+                    "description": "How long before the window the sandboxes are notified, defaults to the runner setting",
+                    "type": "string",
+                    "example": "15m"
+                },
+                "reason": {
+                    "description": "Passed on to the sandbox notifications",
+                    "type": "string"
+                },
+                "startsAt": {
+                    "description": "RFC3339",
+                    "type": "string",
+                    "example": "2025-01-01T02:00:00Z"
+                }
+            }
+        },
+        "ScopedTokenResponseDTO": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "SecurityProfileDTO": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "name"
+            ],
+            "properties": {
+                "appArmorProfile": {
+                    "description": "Name of the AppArmor profile the sandboxes are confined by",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seccomp": {
+                    "description": "Whether the profile has a seccomp profile",
+                    "type": "boolean"
+                }
+            }
+        },
+        "SetLogLevelDTO": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "Duration like 15m after which the previous level is restored, the level is kept if empty",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "Empty resets the module to the runner level",
+                    "type": "string",
+                    "enum": [
+                        "trace",
+                        "debug",
+                        "info",
+                        "warn",
+                        "warning",
+                        "error",
+                        "fatal",
+                        "panic"
+                    ]
+                },
+                "module": {
+                    "description": "Module to set the level of, the runner level is set if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "SidecarDTO": {
+            "type": "object",
+            "required": [
+                "image",
+                "name"
+            ],
+            "properties": {
+                "cmd": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "entrypoint": {
                     "type": "array",
@@ -1226,206 +7440,496 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "fromVolumeId": {
+                "image": {
                     "type": "string"
                 },
-                "gpuQuota": {
-                    "type": "integer",
-                    "minimum": 0
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "SignalProcessDTO": {
+            "type": "object",
+            "required": [
+                "signal"
+            ],
+            "properties": {
+                "signal": {
+                    "type": "string",
+                    "enum": [
+                        "SIGTERM",
+                        "SIGKILL",
+                        "SIGINT",
+                        "SIGHUP",
+                        "SIGQUIT",
+                        "SIGUSR1",
+                        "SIGUSR2",
+                        "SIGSTOP",
+                        "SIGCONT"
+                    ]
+                }
+            }
+        },
+        "SnapshotBuildStatusDTO": {
+            "type": "object",
+            "required": [
+                "operation",
+                "snapshot",
+                "startedAt",
+                "status"
+            ],
+            "properties": {
+                "error": {
+                    "type": "string"
                 },
-                "id": {
+                "errorCode": {
+                    "description": "Error code of the failure, e.g. IMAGE_NOT_FOUND",
                     "type": "string"
                 },
-                "memoryQuota": {
-                    "type": "integer",
-                    "minimum": 1
+                "finishedAt": {
+                    "type": "string"
                 },
-                "networkAllowList": {
+                "operation": {
+                    "description": "build or pull",
                     "type": "string"
                 },
-                "networkBlockAll": {
-                    "type": "boolean"
+                "snapshot": {
+                    "type": "string"
                 },
-                "osUser": {
+                "startedAt": {
                     "type": "string"
                 },
-                "registry": {
-                    "$ref": "#/definitions/RegistryDTO"
+                "status": {
+                    "description": "BUILDING, SUCCEEDED or FAILED",
+                    "type": "string"
+                }
+            }
+        },
+        "SnapshotExistsResponse": {
+            "type": "object",
+            "properties": {
+                "exists": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SnapshotInfoResponse": {
+            "type": "object",
+            "required": [
+                "architecture",
+                "id",
+                "name",
+                "os",
+                "platform",
+                "sizeGB"
+            ],
+            "properties": {
+                "architecture": {
+                    "type": "string"
                 },
-                "snapshot": {
+                "created": {
                     "type": "string"
                 },
-                "storageQuota": {
-                    "type": "integer",
-                    "minimum": 1
+                "id": {
+                    "type": "string"
                 },
-                "userId": {
+                "name": {
                     "type": "string"
                 },
-                "volumes": {
+                "os": {
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "os/arch[/variant] of the stored image",
+                    "type": "string"
+                },
+                "repoDigests": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.VolumeDTO"
+                        "type": "string"
                     }
+                },
+                "sizeGB": {
+                    "type": "number"
+                },
+                "variant": {
+                    "type": "string"
                 }
             }
         },
-        "ErrorResponse": {
-            "description": "Error response",
+        "SshAccessDTO": {
             "type": "object",
             "required": [
-                "code",
-                "message",
-                "method",
-                "path",
-                "statusCode",
-                "timestamp"
+                "command",
+                "expiresAt",
+                "fingerprint",
+                "host",
+                "id",
+                "port",
+                "username"
             ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "BAD_REQUEST"
+                "command": {
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Bad request"
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "privateKey": {
+                    "description": "PrivateKey is only set if the runner generated the key pair, it is not stored by the runner",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "StopSandboxDTO": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "description": "Kill the sandbox right away, without the grace period and the pre-stop hooks",
+                    "type": "boolean"
+                },
+                "timeoutSeconds": {
+                    "description": "Grace period between SIGTERM and SIGKILL, defaults to the stop timeout of the runner",
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                }
+            }
+        },
+        "StopSandboxResponseDTO": {
+            "type": "object",
+            "required": [
+                "method"
+            ],
+            "properties": {
+                "durationMs": {
+                    "description": "Time from the first signal until the container stopped",
+                    "type": "integer"
+                },
+                "exitCode": {
+                    "type": "integer"
                 },
                 "method": {
+                    "description": "graceful if the sandbox exited within the grace period, killed if it was killed after it, forced if it was killed right away or not_running",
+                    "type": "string"
+                }
+            }
+        },
+        "SyncVolumeDTO": {
+            "type": "object",
+            "required": [
+                "direction"
+            ],
+            "properties": {
+                "direction": {
                     "type": "string",
-                    "example": "GET"
+                    "enum": [
+                        "pull",
+                        "push"
+                    ]
+                }
+            }
+        },
+        "TmpfsMountDTO": {
+            "type": "object",
+            "required": [
+                "path"
+            ],
+            "properties": {
+                "exec": {
+                    "description": "Allow running files from the mount, mounts are noexec by default",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "Octal permissions of the mount root, defaults to 1777",
+                    "type": "string"
                 },
                 "path": {
-                    "type": "string",
-                    "example": "/api/resource"
+                    "type": "string"
                 },
-                "statusCode": {
+                "sizeMiB": {
+                    "description": "Counted against the memory of the sandbox, defaults to 64 MiB",
                     "type": "integer",
-                    "example": 400
-                },
-                "timestamp": {
-                    "type": "string",
-                    "example": "2023-01-01T12:00:00Z"
+                    "minimum": 0
                 }
             }
         },
-        "PullSnapshotRequestDTO": {
+        "UnpinSnapshotRequestDTO": {
             "type": "object",
             "required": [
                 "snapshot"
             ],
             "properties": {
-                "registry": {
-                    "$ref": "#/definitions/RegistryDTO"
-                },
                 "snapshot": {
                     "type": "string"
                 }
             }
         },
-        "RegistryDTO": {
+        "UpdateNetworkSettingsDTO": {
             "type": "object",
-            "required": [
-                "password",
-                "url",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "egressPolicy": {
+                    "$ref": "#/definitions/EgressPolicyDTO"
                 },
-                "project": {
-                    "type": "string"
-                },
-                "url": {
+                "networkAllowList": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "networkBlockAll": {
+                    "type": "boolean"
                 }
             }
         },
-        "ResizeSandboxDTO": {
+        "UpdateSandboxEnvDTO": {
             "type": "object",
             "properties": {
-                "cpu": {
-                    "type": "integer",
-                    "minimum": 1
+                "env": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "gpu": {
-                    "type": "integer",
-                    "minimum": 0
+                "remove": {
+                    "description": "Names of variables set earlier to drop",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "UpdateSandboxSecretsDTO": {
+            "type": "object",
+            "properties": {
+                "remove": {
+                    "description": "Names of secrets set earlier to drop",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "memory": {
-                    "type": "integer",
-                    "minimum": 1
+                "secrets": {
+                    "description": "Keyed by file name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "RunnerInfoResponseDTO": {
+        "UploadSecurityProfileDTO": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "metrics": {
-                    "$ref": "#/definitions/RunnerMetrics"
+                "appArmor": {
+                    "description": "AppArmor profile source, it is loaded into the kernel of the runner under the name it declares",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seccomp": {
+                    "description": "Seccomp profile in the JSON format of Docker",
+                    "type": "string"
                 }
             }
         },
-        "RunnerMetrics": {
+        "UsageRecordDTO": {
             "type": "object",
+            "required": [
+                "cpuSeconds",
+                "diskByteHours",
+                "diskBytes",
+                "memoryByteHours",
+                "networkRxBytes",
+                "networkTxBytes",
+                "periodEnd",
+                "periodStart",
+                "sandboxId",
+                "sequence"
+            ],
             "properties": {
-                "currentAllocatedCpu": {
+                "cpuSeconds": {
+                    "type": "number"
+                },
+                "diskByteHours": {
+                    "type": "number"
+                },
+                "diskBytes": {
+                    "description": "Peak size of the writable layer in the period",
                     "type": "integer"
                 },
-                "currentAllocatedDiskGiB": {
+                "memoryByteHours": {
+                    "description": "Memory in use excluding page cache, integrated over the period",
+                    "type": "number"
+                },
+                "networkRxBytes": {
                     "type": "integer"
                 },
-                "currentAllocatedMemoryGiB": {
+                "networkTxBytes": {
                     "type": "integer"
                 },
-                "currentCpuUsagePercentage": {
-                    "type": "number"
+                "organizationId": {
+                    "type": "string"
                 },
-                "currentDiskUsagePercentage": {
-                    "type": "number"
+                "periodEnd": {
+                    "type": "string"
                 },
-                "currentMemoryUsagePercentage": {
-                    "type": "number"
+                "periodStart": {
+                    "type": "string"
                 },
-                "currentSnapshotCount": {
+                "sandboxId": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "Increases with every record, records are returned in its order",
                     "type": "integer"
                 }
             }
         },
-        "SandboxInfoResponse": {
+        "UsageRecordsDTO": {
             "type": "object",
+            "required": [
+                "cursor",
+                "records"
+            ],
             "properties": {
-                "backupError": {
+                "cursor": {
+                    "description": "Sequence of the last record, pass it as after to get the following records",
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UsageRecordDTO"
+                    }
+                }
+            }
+        },
+        "VolumeInfoResponse": {
+            "type": "object",
+            "required": [
+                "driver",
+                "mountpoint",
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "backupState": {
-                    "$ref": "#/definitions/enums.BackupState"
+                "driver": {
+                    "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/enums.SandboxState"
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mountpoint": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sandboxes": {
+                    "description": "Sandboxes currently using the volume, only set on inspect",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "description": "Size in bytes, -1 if the driver does not report usage",
+                    "type": "integer"
                 }
             }
         },
-        "SnapshotExistsResponse": {
+        "VolumeSyncStatusResponse": {
             "type": "object",
+            "required": [
+                "state"
+            ],
             "properties": {
-                "exists": {
-                    "type": "boolean",
-                    "example": true
+                "direction": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "syncedObjects": {
+                    "type": "integer"
+                },
+                "totalObjects": {
+                    "type": "integer"
+                },
+                "transferredBytes": {
+                    "type": "integer"
                 }
             }
         },
-        "UpdateNetworkSettingsDTO": {
+        "VulnerabilityDTO": {
             "type": "object",
+            "required": [
+                "id",
+                "installedVersion",
+                "pkgName",
+                "severity",
+                "target"
+            ],
             "properties": {
-                "networkAllowList": {
+                "fixedVersion": {
                     "type": "string"
                 },
-                "networkBlockAll": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
+                },
+                "installedVersion": {
+                    "type": "string"
+                },
+                "pkgName": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
@@ -1435,11 +7939,32 @@ const docTemplate = `{
                 "mountPath": {
                     "type": "string"
                 },
+                "sync": {
+                    "description": "Materialize the volume on the runner before start and sync changes back on stop instead of mounting it",
+                    "type": "boolean"
+                },
                 "volumeId": {
                     "type": "string"
                 }
             }
         },
+        "enums.BackupPhase": {
+            "type": "string",
+            "enum": [
+                "COMMITTING",
+                "EXPORTING_SNAPSHOT",
+                "EXPORTING_VOLUMES",
+                "RESTORING_SNAPSHOT",
+                "RESTORING_VOLUMES"
+            ],
+            "x-enum-varnames": [
+                "BackupPhaseCommitting",
+                "BackupPhaseExportingSnapshot",
+                "BackupPhaseExportingVolumes",
+                "BackupPhaseRestoringSnapshot",
+                "BackupPhaseRestoringVolumes"
+            ]
+        },
         "enums.BackupState": {
             "type": "string",
             "enum": [
@@ -1457,6 +7982,80 @@ const docTemplate = `{
                 "BackupStateFailed"
             ]
         },
+        "enums.DaemonHealth": {
+            "type": "string",
+            "enum": [
+                "UNKNOWN",
+                "HEALTHY",
+                "UNHEALTHY",
+                "RESTARTING"
+            ],
+            "x-enum-varnames": [
+                "DaemonHealthUnknown",
+                "DaemonHealthHealthy",
+                "DaemonHealthUnhealthy",
+                "DaemonHealthRestarting"
+            ]
+        },
+        "enums.EventType": {
+            "type": "string",
+            "enum": [
+                "sandbox.state_changed",
+                "backup.state_changed",
+                "snapshot.pull",
+                "snapshot.build",
+                "sandbox.disk_usage_exceeded",
+                "sandbox.expiring",
+                "sandbox.expired",
+                "sandbox.daemon_health_changed",
+                "sandbox.warning",
+                "sandbox.restarted",
+                "sandbox.crash_loop",
+                "sandbox.maintenance_scheduled",
+                "sandbox.maintenance_cancelled"
+            ],
+            "x-enum-varnames": [
+                "EventTypeSandboxStateChanged",
+                "EventTypeBackupStateChanged",
+                "EventTypeSnapshotPull",
+                "EventTypeSnapshotBuild",
+                "EventTypeSandboxDiskUsage",
+                "EventTypeSandboxExpiring",
+                "EventTypeSandboxExpired",
+                "EventTypeDaemonHealthChanged",
+                "EventTypeSandboxWarning",
+                "EventTypeSandboxRestarted",
+                "EventTypeSandboxCrashLoop",
+                "EventTypeSandboxMaintenance",
+                "EventTypeMaintenanceCancel"
+            ]
+        },
+        "enums.ExpiryAction": {
+            "type": "string",
+            "enum": [
+                "STOP",
+                "DESTROY"
+            ],
+            "x-enum-varnames": [
+                "ExpiryActionStop",
+                "ExpiryActionDestroy"
+            ]
+        },
+        "enums.RepositoryPhase": {
+            "type": "string",
+            "enum": [
+                "CLONING",
+                "COPYING",
+                "READY",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "RepositoryPhaseCloning",
+                "RepositoryPhaseCopying",
+                "RepositoryPhaseReady",
+                "RepositoryPhaseFailed"
+            ]
+        },
         "enums.SandboxState": {
             "type": "string",
             "enum": [
@@ -1469,6 +8068,9 @@ const docTemplate = `{
                 "starting",
                 "stopping",
                 "resizing",
+                "pausing",
+                "paused",
+                "resuming",
                 "error",
                 "unknown",
                 "pulling_snapshot"
@@ -1483,10 +8085,74 @@ const docTemplate = `{
                 "SandboxStateStarting",
                 "SandboxStateStopping",
                 "SandboxStateResizing",
+                "SandboxStatePausing",
+                "SandboxStatePaused",
+                "SandboxStateResuming",
                 "SandboxStateError",
                 "SandboxStateUnknown",
                 "SandboxStatePullingSnapshot"
             ]
+        },
+        "models.BackupProgress": {
+            "type": "object",
+            "properties": {
+                "bytesTransferred": {
+                    "type": "integer"
+                },
+                "phase": {
+                    "$ref": "#/definitions/enums.BackupPhase"
+                }
+            }
+        },
+        "models.RepositoryStatus": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "phase": {
+                    "$ref": "#/definitions/enums.RepositoryPhase"
+                },
+                "step": {
+                    "description": "Step is the git step the clone is at, e.g. Receiving objects, and Percent the progress of the step",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SandboxExpiration": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/enums.ExpiryAction"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "warned": {
+                    "description": "Warned is set once the expiry warning event was published",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.SandboxResources": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "type": "integer"
+                },
+                "memory": {
+                    "type": "integer"
+                },
+                "swap": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -4,6 +4,14 @@
 package dto
 
 type CreateBackupDTO struct {
-	Registry RegistryDTO `json:"registry" validate:"required"`
-	Snapshot string      `json:"snapshot" validate:"required"`
+	Registry   *RegistryDTO `json:"registry,omitempty" validate:"required_without=ObjectPath"`
+	Snapshot   string       `json:"snapshot" validate:"required"`
+	ObjectPath string       `json:"objectPath,omitempty"` // Object storage prefix to export the snapshot and volumes to instead of pushing to the registry
+	// Prefix of an earlier object storage backup, only the snapshot layers missing from its backup chain are uploaded
+	BaseObjectPath string `json:"baseObjectPath,omitempty" validate:"excluded_without=ObjectPath"`
 } //	@name	CreateBackupDTO
+
+type RestoreBackupDTO struct {
+	ObjectPath        string `json:"objectPath" validate:"required"` // Object storage prefix the backup was exported to
+	HostUserNamespace bool   `json:"hostUserNamespace,omitempty"`    // Restore the volumes for a sandbox in the host user namespace so their files keep their owners on runners that remap users
+} //	@name	RestoreBackupDTO
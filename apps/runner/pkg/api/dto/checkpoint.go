@@ -0,0 +1,13 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type CheckpointSandboxDTO struct {
+	CheckpointId string `json:"checkpointId" validate:"required"`
+	Exit         bool   `json:"exit"` // Stop the sandbox after the checkpoint is taken
+} //	@name	CheckpointSandboxDTO
+
+type RestoreCheckpointDTO struct {
+	CheckpointId string `json:"checkpointId" validate:"required"`
+} //	@name	RestoreCheckpointDTO
@@ -0,0 +1,18 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type CleanupRequestDTO struct {
+	ExitedContainerMaxAge string `json:"exitedContainerMaxAge,omitempty"` // Remove sandboxes exited longer ago than this duration, e.g. 48h, defaults to the runner setting
+	DanglingVolumes       *bool  `json:"danglingVolumes,omitempty"`       // Remove sandbox volumes no container uses, defaults to the runner setting
+	SandboxNetworks       *bool  `json:"sandboxNetworks,omitempty"`       // Remove per-sandbox networks without a sandbox, defaults to the runner setting
+	DryRun                bool   `json:"dryRun,omitempty"`                // Report what would be removed without removing anything
+} //	@name	CleanupRequestDTO
+
+type CleanupResponseDTO struct {
+	Containers []string `json:"containers" validate:"required"`
+	Volumes    []string `json:"volumes" validate:"required"`
+	Networks   []string `json:"networks" validate:"required"`
+	DryRun     bool     `json:"dryRun" validate:"required"`
+} //	@name	CleanupResponseDTO
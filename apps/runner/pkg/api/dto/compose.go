@@ -0,0 +1,33 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+// CreateComposeSandboxDTO describes a multi-container sandbox. The quotas, the network settings and the security
+// options apply to every service, like they apply to a single-container sandbox.
+type CreateComposeSandboxDTO struct {
+	Id               string              `json:"id" validate:"required"`
+	UserId           string              `json:"userId" validate:"required"`
+	Compose          string              `json:"compose" validate:"required"` // Compose file (YAML) describing the sandbox services
+	Env              map[string]string   `json:"env,omitempty"`               // Added to the environment of every service
+	Registry         *RegistryDTO        `json:"registry,omitempty"`
+	CpuQuota         int64               `json:"cpuQuota" validate:"min=1"`
+	MemoryQuota      int64               `json:"memoryQuota" validate:"min=1"`
+	StorageQuota     int64               `json:"storageQuota" validate:"min=1"`
+	NetworkBlockAll  *bool               `json:"networkBlockAll,omitempty"`
+	NetworkAllowList *string             `json:"networkAllowList,omitempty"`
+	EgressPolicy     *EgressPolicyDTO    `json:"egressPolicy,omitempty"` // Takes precedence over networkBlockAll and networkAllowList
+	Security         *SandboxSecurityDTO `json:"security,omitempty"`
+} //	@name	CreateComposeSandboxDTO
+
+type ComposeServiceDTO struct {
+	Name        string `json:"name" validate:"required"`
+	ContainerId string `json:"containerId" validate:"required"`
+	Image       string `json:"image" validate:"required"`
+	State       string `json:"state" validate:"required"`
+} //	@name	ComposeServiceDTO
+
+type ComposeSandboxInfoResponse struct {
+	State    string              `json:"state" validate:"required"` // Aggregated state of all services
+	Services []ComposeServiceDTO `json:"services" validate:"required"`
+} //	@name	ComposeSandboxInfoResponse
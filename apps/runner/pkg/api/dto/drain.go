@@ -0,0 +1,9 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type DrainStatusResponseDTO struct {
+	Draining bool  `json:"draining" validate:"required"`
+	InFlight int64 `json:"inFlight" validate:"required"`
+} //	@name	DrainStatusResponseDTO
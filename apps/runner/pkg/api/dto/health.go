@@ -0,0 +1,14 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type ComponentHealthDTO struct {
+	Status  string `json:"status" example:"SERVING" validate:"required"`
+	Message string `json:"message,omitempty"`
+} //	@name	ComponentHealthDTO
+
+type HealthStatusResponseDTO struct {
+	Status     string                        `json:"status" example:"SERVING" validate:"required"`
+	Components map[string]ComponentHealthDTO `json:"components" validate:"required"`
+} //	@name	HealthStatusResponseDTO
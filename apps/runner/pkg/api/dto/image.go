@@ -6,13 +6,128 @@ package dto
 type PullSnapshotRequestDTO struct {
 	Snapshot string       `json:"snapshot" validate:"required"`
 	Registry *RegistryDTO `json:"registry,omitempty"`
+	Platform string       `json:"platform,omitempty"` // os/arch[/variant], defaults to the runner platform
 } //	@name	PullSnapshotRequestDTO
 
 type BuildSnapshotRequestDTO struct {
-	Snapshot               string       `json:"snapshot,omitempty"` // Snapshot ID and tag or the build's hash
-	Registry               *RegistryDTO `json:"registry,omitempty"`
-	Dockerfile             string       `json:"dockerfile" validate:"required"`
-	OrganizationId         string       `json:"organizationId" validate:"required"`
-	Context                []string     `json:"context"`
-	PushToInternalRegistry bool         `json:"pushToInternalRegistry"`
+	Snapshot               string         `json:"snapshot,omitempty"` // Snapshot ID and tag or the build's hash
+	Registry               *RegistryDTO   `json:"registry,omitempty"`
+	Dockerfile             string         `json:"dockerfile" validate:"required"`
+	OrganizationId         string         `json:"organizationId" validate:"required"`
+	Context                []string       `json:"context"`
+	PushToInternalRegistry bool           `json:"pushToInternalRegistry"`
+	Cache                  *BuildCacheDTO `json:"cache,omitempty"` // Build with BuildKit using a registry layer cache
 } //	@name	BuildSnapshotRequestDTO
+
+type BuildCacheDTO struct {
+	Ref      string       `json:"ref" validate:"required"` // Registry reference storing the cache, e.g. registry.example.com/cache/devcontainer:buildcache
+	Registry *RegistryDTO `json:"registry,omitempty"`      // Credentials for the cache registry
+	Export   bool         `json:"export"`                  // Push the updated cache after the build
+} //	@name	BuildCacheDTO
+
+type PushSnapshotRequestDTO struct {
+	Snapshot string      `json:"snapshot" validate:"required"` // Local snapshot name and tag
+	Registry RegistryDTO `json:"registry" validate:"required"`
+} //	@name	PushSnapshotRequestDTO
+
+type ExportSnapshotRequestDTO struct {
+	Snapshot   string `json:"snapshot" validate:"required"`
+	ObjectPath string `json:"objectPath" validate:"required"` // Destination path in the runner object storage bucket
+} //	@name	ExportSnapshotRequestDTO
+
+type ImportSnapshotRequestDTO struct {
+	ObjectPath string `json:"objectPath,omitempty"` // Tarball path in the runner object storage bucket
+	Url        string `json:"url,omitempty"`        // HTTP(S) URL of the tarball, e.g. a presigned S3 URL
+} //	@name	ImportSnapshotRequestDTO
+
+type ImportSnapshotResponseDTO struct {
+	Snapshots []string `json:"snapshots" validate:"required"`
+} //	@name	ImportSnapshotResponseDTO
+
+type ScanSnapshotRequestDTO struct {
+	Snapshot string `json:"snapshot" validate:"required"`
+} //	@name	ScanSnapshotRequestDTO
+
+type VulnerabilityDTO struct {
+	Id               string `json:"id" validate:"required"`
+	Target           string `json:"target" validate:"required"`
+	PkgName          string `json:"pkgName" validate:"required"`
+	InstalledVersion string `json:"installedVersion" validate:"required"`
+	FixedVersion     string `json:"fixedVersion,omitempty"`
+	Severity         string `json:"severity" validate:"required"`
+	Title            string `json:"title,omitempty"`
+} //	@name	VulnerabilityDTO
+
+type ScanSnapshotResponseDTO struct {
+	Snapshot        string             `json:"snapshot" validate:"required"`
+	Vulnerabilities []VulnerabilityDTO `json:"vulnerabilities" validate:"required"`
+	Summary         map[string]int     `json:"summary" validate:"required"` // Vulnerability count per severity
+} //	@name	ScanSnapshotResponseDTO
+
+type SnapshotInfoResponse struct {
+	Name         string   `json:"name" validate:"required"`
+	Id           string   `json:"id" validate:"required"`
+	SizeGB       float64  `json:"sizeGB" validate:"required"`
+	Os           string   `json:"os" validate:"required"`
+	Architecture string   `json:"architecture" validate:"required"`
+	Variant      string   `json:"variant,omitempty"`
+	Platform     string   `json:"platform" validate:"required"` // os/arch[/variant] of the stored image
+	RepoDigests  []string `json:"repoDigests"`
+	Created      string   `json:"created"`
+} //	@name	SnapshotInfoResponse
+
+type PruneSnapshotsRequestDTO struct {
+	MinAge string `json:"minAge,omitempty"` // Only remove snapshots last pulled or tagged longer ago than this duration, e.g. 72h, defaults to the runner setting
+	DryRun bool   `json:"dryRun,omitempty"` // Report what would be removed without removing anything
+} //	@name	PruneSnapshotsRequestDTO
+
+type PruneSnapshotsResponseDTO struct {
+	Removed        []string `json:"removed" validate:"required"`
+	ReclaimedBytes int64    `json:"reclaimedBytes" validate:"required"`
+} //	@name	PruneSnapshotsResponseDTO
+
+type PrewarmSnapshotsRequestDTO struct {
+	Snapshots []string     `json:"snapshots" validate:"required,min=1"`
+	Registry  *RegistryDTO `json:"registry,omitempty"`
+	Platform  string       `json:"platform,omitempty"` // os/arch[/variant], defaults to the runner platform
+} //	@name	PrewarmSnapshotsRequestDTO
+
+type PrewarmSnapshotStatusDTO struct {
+	Snapshot    string `json:"snapshot" validate:"required"`
+	State       string `json:"state,omitempty"` // STARTED, COMPLETED or FAILED, empty for a pin that wasn't prewarmed since the runner started
+	Pinned      bool   `json:"pinned"`
+	Error       string `json:"error,omitempty"`
+	StartedAt   string `json:"startedAt,omitempty"`
+	CompletedAt string `json:"completedAt,omitempty"`
+} //	@name	PrewarmSnapshotStatusDTO
+
+type PrewarmSnapshotsResponseDTO struct {
+	Snapshots []PrewarmSnapshotStatusDTO `json:"snapshots" validate:"required"`
+} //	@name	PrewarmSnapshotsResponseDTO
+
+type UnpinSnapshotRequestDTO struct {
+	Snapshot string `json:"snapshot" validate:"required"`
+} //	@name	UnpinSnapshotRequestDTO
+
+type SnapshotBuildStatusDTO struct {
+	Snapshot   string `json:"snapshot" validate:"required"`
+	Operation  string `json:"operation" validate:"required"` // build or pull
+	Status     string `json:"status" validate:"required"`    // BUILDING, SUCCEEDED or FAILED
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"errorCode,omitempty"` // Error code of the failure, e.g. IMAGE_NOT_FOUND
+	StartedAt  string `json:"startedAt" validate:"required"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+} //	@name	SnapshotBuildStatusDTO
+
+type CreateSnapshotFromSandboxDTO struct {
+	Snapshot string       `json:"snapshot" validate:"required"` // Snapshot name and tag
+	Squash   bool         `json:"squash,omitempty"`             // Flatten the snapshot into a single layer
+	Push     bool         `json:"push,omitempty"`               // Push the snapshot to the registry
+	Registry *RegistryDTO `json:"registry,omitempty"`           // Required when pushing
+} //	@name	CreateSnapshotFromSandboxDTO
+
+type CreateSnapshotFromSandboxResponseDTO struct {
+	Snapshot string `json:"snapshot" validate:"required"`
+	Id       string `json:"id" validate:"required"`
+	PushedTo string `json:"pushedTo,omitempty"` // Registry reference the snapshot was pushed to
+} //	@name	CreateSnapshotFromSandboxResponseDTO
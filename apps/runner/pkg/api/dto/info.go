@@ -13,6 +13,31 @@ type RunnerMetrics struct {
 	CurrentSnapshotCount         int     `json:"currentSnapshotCount"`
 } //	@name	RunnerMetrics
 
+type GpuInfoDTO struct {
+	Index     int    `json:"index"`
+	Uuid      string `json:"uuid"`
+	Name      string `json:"name"`
+	MemoryMiB int64  `json:"memoryMiB"`
+	Allocated bool   `json:"allocated"`
+} //	@name	GpuInfoDTO
+
+type RunnerCapacity struct {
+	TotalCpu        int64    `json:"totalCpu"`
+	TotalMemoryGiB  int64    `json:"totalMemoryGiB"`
+	TotalDiskGiB    int64    `json:"totalDiskGiB"`
+	DockerVersion   string   `json:"dockerVersion"`
+	DefaultRuntime  string   `json:"defaultRuntime"`
+	Runtimes        []string `json:"runtimes"`
+	Architecture    string   `json:"architecture"`
+	OperatingSystem string   `json:"operatingSystem"`
+	KernelVersion   string   `json:"kernelVersion"`
+	Rootless        bool     `json:"rootless"`    // The engine is rootless Docker or Podman, TCP tunnels are unavailable and resource limits depend on delegated cgroup controllers
+	UsernsRemap     bool     `json:"usernsRemap"` // The engine remaps users, sandboxes run unprivileged unless they use the host user namespace
+} //	@name	RunnerCapacity
+
 type RunnerInfoResponseDTO struct {
-	Metrics *RunnerMetrics `json:"metrics,omitempty"`
+	Metrics  *RunnerMetrics    `json:"metrics,omitempty"`
+	Capacity *RunnerCapacity   `json:"capacity,omitempty"`
+	Gpus     []GpuInfoDTO      `json:"gpus"`
+	Labels   map[string]string `json:"labels,omitempty"`
 } //	@name	RunnerInfoResponseDTO
@@ -0,0 +1,15 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type SetLogLevelDTO struct {
+	Level    string `json:"level,omitempty" validate:"omitempty,oneof=trace debug info warn warning error fatal panic"` // Empty resets the module to the runner level
+	Module   string `json:"module,omitempty"`                                                                           // Module to set the level of, the runner level is set if empty
+	Duration string `json:"duration,omitempty"`                                                                         // Duration like 15m after which the previous level is restored, the level is kept if empty
+} //	@name	SetLogLevelDTO
+
+type LogLevelsDTO struct {
+	Level   string            `json:"level" validate:"required"`
+	Modules map[string]string `json:"modules" validate:"required"` // Level every module logs at
+} //	@name	LogLevelsDTO
@@ -0,0 +1,21 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type ScheduleMaintenanceDTO struct {
+	StartsAt     string `json:"startsAt" validate:"required" example:"2025-01-01T02:00:00Z"` // RFC3339
+	EndsAt       string `json:"endsAt" validate:"required" example:"2025-01-01T04:00:00Z"`   // RFC3339
+	Reason       string `json:"reason,omitempty"`                                            // Passed on to the sandbox notifications
+	Drain        bool   `json:"drain,omitempty"`                                             // Drain the runner once the window starts, draining lasts until the runner restarts
+	NotifyBefore string `json:"notifyBefore,omitempty" example:"15m"`                        // How long before the window the sandboxes are notified, defaults to the runner setting
+} //	@name	ScheduleMaintenanceDTO
+
+type MaintenanceWindowDTO struct {
+	StartsAt   string `json:"startsAt" validate:"required"`
+	EndsAt     string `json:"endsAt" validate:"required"`
+	Reason     string `json:"reason,omitempty"`
+	Drain      bool   `json:"drain"`
+	InProgress bool   `json:"inProgress"` // New sandboxes are refused
+	Notified   bool   `json:"notified"`   // The sandboxes were notified of the window
+} //	@name	MaintenanceWindowDTO
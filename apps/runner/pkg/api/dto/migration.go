@@ -0,0 +1,19 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type MigrateSandboxDTO struct {
+	ObjectPath  string                   `json:"objectPath" validate:"required"` // Object storage prefix the sandbox is exported to, the destination runner imports it from there
+	Live        bool                     `json:"live"`                           // Checkpoint the processes of the running sandbox so they continue on the destination, requires CRIU on both runners
+	Destination *MigrationDestinationDTO `json:"destination,omitempty"`          // Hand the sandbox over to this runner and destroy it here, otherwise the sandbox is only exported and left stopped
+} //	@name	MigrateSandboxDTO
+
+type MigrationDestinationDTO struct {
+	Url   string `json:"url" validate:"required,url"` // Base URL of the API of the destination runner
+	Token string `json:"token" validate:"required"`   // API token with the write scope on the destination runner
+} //	@name	MigrationDestinationDTO
+
+type ReceiveMigrationDTO struct {
+	ObjectPath string `json:"objectPath" validate:"required"` // Object storage prefix the source runner exported the sandbox to
+} //	@name	ReceiveMigrationDTO
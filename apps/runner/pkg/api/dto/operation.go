@@ -0,0 +1,16 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type OperationDTO struct {
+	Id         string `json:"id" validate:"required"`
+	Type       string `json:"type" validate:"required"`   // create, pull, build, migrate or receive_migration
+	State      string `json:"state" validate:"required"`  // running, succeeded, failed or cancelled
+	Target     string `json:"target" validate:"required"` // Sandbox ID or snapshot the operation is for
+	Result     string `json:"result,omitempty"`           // Container ID of created sandboxes
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"errorCode,omitempty"`
+	CreatedAt  string `json:"createdAt" validate:"required"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+} //	@name	OperationDTO
@@ -0,0 +1,21 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+import "time"
+
+type ExposePortDTO struct {
+	Port        int    `json:"port" validate:"required,min=1,max=65535"`
+	AccessLevel string `json:"accessLevel,omitempty" validate:"omitempty,oneof=PUBLIC AUTHENTICATED OWNER"` // Who can reach the preview URL, defaults to AUTHENTICATED
+	Desktop     bool   `json:"desktop,omitempty"`                                                           // Marks a long lived desktop session (VNC, RDP over websocket), its streams are never closed for being idle
+} //	@name	ExposePortDTO
+
+type ExposedPortDTO struct {
+	Port        int       `json:"port" validate:"required"`
+	Url         string    `json:"url" validate:"required"` // Externally reachable address of the port through the runner proxy
+	PreviewUrl  string    `json:"previewUrl,omitempty"`    // Address of the port on its own hostname, set if the runner has a preview domain
+	AccessLevel string    `json:"accessLevel" validate:"required"`
+	Desktop     bool      `json:"desktop"`
+	ExposedAt   time.Time `json:"exposedAt" validate:"required"`
+} //	@name	ExposedPortDTO
@@ -0,0 +1,22 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type SandboxProcessDTO struct {
+	Pid     int    `json:"pid" validate:"required"`  // PID on the host, signals are sent by it
+	Ppid    int    `json:"ppid" validate:"required"` // Parent PID on the host, processes with a parent outside of the sandbox are its roots
+	User    string `json:"user" validate:"required"`
+	State   string `json:"state" validate:"required"`   // ps process state codes, e.g. S for sleeping or Z for zombie
+	Elapsed string `json:"elapsed" validate:"required"` // Time since the process started, in [[dd-]hh:]mm:ss
+	RssKiB  int64  `json:"rssKiB"`
+	Command string `json:"command" validate:"required"`
+} //	@name	SandboxProcessDTO
+
+type ListSandboxProcessesResponseDTO struct {
+	Processes []SandboxProcessDTO `json:"processes" validate:"required"`
+} //	@name	ListSandboxProcessesResponseDTO
+
+type SignalProcessDTO struct {
+	Signal string `json:"signal" validate:"required,oneof=SIGTERM SIGKILL SIGINT SIGHUP SIGQUIT SIGUSR1 SIGUSR2 SIGSTOP SIGCONT"`
+} //	@name	SignalProcessDTO
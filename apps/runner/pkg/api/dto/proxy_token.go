@@ -0,0 +1,11 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+import "time"
+
+type ProxyTokenResponseDTO struct {
+	Token     string    `json:"token" validate:"required"`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required"`
+} //	@name	ProxyTokenResponseDTO
@@ -0,0 +1,31 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type RunnerRegistrationDTO struct {
+	Name     string            `json:"name" validate:"required"`
+	Address  string            `json:"address" validate:"required"` // URL the control plane reaches the runner API at
+	Version  string            `json:"version" validate:"required"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Capacity *RunnerCapacity   `json:"capacity,omitempty"`
+	Gpus     []GpuInfoDTO      `json:"gpus"`
+} //	@name	RunnerRegistrationDTO
+
+type RunnerRegistrationResponseDTO struct {
+	Id string `json:"id" validate:"required"`
+	// HeartbeatIntervalSeconds overrides the heartbeat interval of the runner if set
+	HeartbeatIntervalSeconds int `json:"heartbeatIntervalSeconds,omitempty"`
+} //	@name	RunnerRegistrationResponseDTO
+
+type RunnerHeartbeatDTO struct {
+	Id         string                        `json:"id" validate:"required"`
+	Version    string                        `json:"version" validate:"required"`
+	Status     string                        `json:"status" example:"SERVING" validate:"required"`
+	Components map[string]ComponentHealthDTO `json:"components" validate:"required"`
+	Draining   bool                          `json:"draining"`
+	InFlight   int64                         `json:"inFlight"` // Operations the runner is processing
+	Labels     map[string]string             `json:"labels,omitempty"`
+	Metrics    *RunnerMetrics                `json:"metrics,omitempty"`
+	SentAt     string                        `json:"sentAt" validate:"required"`
+} //	@name	RunnerHeartbeatDTO
@@ -3,31 +3,170 @@
 
 package dto
 
+import "time"
+
 type CreateSandboxDTO struct {
-	Id               string            `json:"id" validate:"required"`
-	FromVolumeId     string            `json:"fromVolumeId,omitempty"`
-	UserId           string            `json:"userId" validate:"required"`
-	Snapshot         string            `json:"snapshot" validate:"required"`
-	OsUser           string            `json:"osUser" validate:"required"`
-	CpuQuota         int64             `json:"cpuQuota" validate:"min=1"`
-	GpuQuota         int64             `json:"gpuQuota" validate:"min=0"`
-	MemoryQuota      int64             `json:"memoryQuota" validate:"min=1"`
-	StorageQuota     int64             `json:"storageQuota" validate:"min=1"`
-	Env              map[string]string `json:"env,omitempty"`
-	Registry         *RegistryDTO      `json:"registry,omitempty"`
-	Entrypoint       []string          `json:"entrypoint,omitempty"`
-	Volumes          []VolumeDTO       `json:"volumes,omitempty"`
-	NetworkBlockAll  *bool             `json:"networkBlockAll,omitempty"`
-	NetworkAllowList *string           `json:"networkAllowList,omitempty"`
+	Id                    string              `json:"id" validate:"required"`
+	FromVolumeId          string              `json:"fromVolumeId,omitempty"`
+	UserId                string              `json:"userId" validate:"required"`
+	Snapshot              string              `json:"snapshot" validate:"required_without=Devcontainer"` // Resolved from the devcontainer when one is set
+	OsUser                string              `json:"osUser" validate:"required"`
+	CpuQuota              int64               `json:"cpuQuota" validate:"min=1"`
+	GpuQuota              int64               `json:"gpuQuota" validate:"min=0"`
+	GpuDeviceIds          []string            `json:"gpuDeviceIds,omitempty"` // Takes precedence over gpuQuota when set
+	MemoryQuota           int64               `json:"memoryQuota" validate:"min=1"`
+	StorageQuota          int64               `json:"storageQuota" validate:"min=1"`
+	Env                   map[string]string   `json:"env,omitempty"`
+	Registry              *RegistryDTO        `json:"registry,omitempty"`
+	Entrypoint            []string            `json:"entrypoint,omitempty"`
+	Volumes               []VolumeDTO         `json:"volumes,omitempty"`
+	NetworkBlockAll       *bool               `json:"networkBlockAll,omitempty"`
+	NetworkAllowList      *string             `json:"networkAllowList,omitempty"`
+	EgressPolicy          *EgressPolicyDTO    `json:"egressPolicy,omitempty"`                          // Takes precedence over networkBlockAll and networkAllowList
+	IdleTimeoutMinutes    int                 `json:"idleTimeoutMinutes,omitempty" validate:"min=0"`   // Stop the sandbox after this many idle minutes, 0 disables auto-stop
+	IngressBandwidthMbps  int64               `json:"ingressBandwidthMbps,omitempty" validate:"min=0"` // Limit on traffic into the sandbox, 0 means unlimited
+	EgressBandwidthMbps   int64               `json:"egressBandwidthMbps,omitempty" validate:"min=0"`  // Limit on traffic out of the sandbox, 0 means unlimited
+	Sidecars              []SidecarDTO        `json:"sidecars,omitempty" validate:"dive"`
+	ScanSeverityThreshold string              `json:"scanSeverityThreshold,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH CRITICAL"` // Refuse to create the sandbox if the snapshot has vulnerabilities at or above this severity
+	TtlMinutes            int                 `json:"ttlMinutes,omitempty" validate:"min=0"`                                               // Apply the TTL action this many minutes after creation, 0 disables the TTL
+	TtlAction             string              `json:"ttlAction,omitempty" validate:"omitempty,oneof=STOP DESTROY"`                         // Defaults to DESTROY
+	Runtime               string              `json:"runtime,omitempty" validate:"omitempty,oneof=runc runsc kata"`                        // OCI runtime of the sandbox, must be available on the runner, defaults to the runner setting
+	Secrets               map[string]string   `json:"secrets,omitempty"`                                                                   // Written to files in /run/daytona/secrets instead of the container env, keyed by file name
+	Hooks                 *SandboxHooksDTO    `json:"hooks,omitempty"`
+	Devcontainer          *DevcontainerDTO    `json:"devcontainer,omitempty"` // Takes precedence over snapshot
+	Repository            *RepositoryDTO      `json:"repository,omitempty"`   // Cloned into the sandbox before its first start
+	RestartPolicy         *RestartPolicyDTO   `json:"restartPolicy,omitempty"`
+	RequiredLabels        map[string]string   `json:"requiredLabels,omitempty"` // Runner labels the sandbox must be placed on, an empty value only requires the label to be present
+	Security              *SandboxSecurityDTO `json:"security,omitempty"`
 } //	@name	CreateSandboxDTO
 
+// RestartPolicyDTO makes the runner restart the sandbox when it exits on its own. Restarts go through the runner
+// instead of the Docker restart policy so they show up as state changes.
+type RestartPolicyDTO struct {
+	Mode           string `json:"mode" validate:"required,oneof=never on-failure always"` // on-failure restarts after a non-zero exit code only
+	MaxRestarts    int    `json:"maxRestarts,omitempty" validate:"min=0"`                 // Restarts within the crash loop window before the sandbox is parked in the error state, defaults to 5
+	BackoffSeconds int    `json:"backoffSeconds,omitempty" validate:"min=0,max=300"`      // Delay before the first restart in the window, doubled for every further one up to 5 minutes, defaults to 1
+} //	@name	RestartPolicyDTO
+
+// RepositoryDTO is a git repository cloned by the runner and copied into the sandbox, the clone runs while the
+// snapshot is pulled
+type RepositoryDTO struct {
+	Url         string   `json:"url" validate:"required,startswith=https://"`
+	Ref         string   `json:"ref,omitempty"`                                                // Branch, tag or commit, defaults to the default branch
+	AuthToken   string   `json:"authToken,omitempty"`                                          // Token for HTTPS access to a private repository
+	TargetPath  string   `json:"targetPath,omitempty" validate:"omitempty,startswith=/"`       // Defaults to /workspaces/<repository name>
+	Depth       int      `json:"depth,omitempty" validate:"min=0"`                             // Number of commits to fetch, 0 fetches the whole history
+	Filter      string   `json:"filter,omitempty" validate:"omitempty,oneof=blob:none tree:0"` // Partial clone filter, omitted objects are fetched on demand
+	SparsePaths []string `json:"sparsePaths,omitempty"`                                        // Only these directories are checked out, along with the files at the root
+} //	@name	RepositoryDTO
+
+// DevcontainerDTO points at a devcontainer.json, either inline or in a git repository. The runner builds or pulls
+// the image it describes, installs its features and maps its lifecycle commands to hooks.
+type DevcontainerDTO struct {
+	Config        string `json:"config,omitempty" validate:"required_without=RepositoryUrl"` // Content of the devcontainer.json, takes precedence over the file in the repository
+	RepositoryUrl string `json:"repositoryUrl,omitempty"`                                    // Cloned to resolve the config and the build context
+	Ref           string `json:"ref,omitempty"`                                              // Branch or tag, defaults to the default branch
+	ConfigPath    string `json:"configPath,omitempty"`                                       // Relative to the repository root, defaults to .devcontainer/devcontainer.json or .devcontainer.json
+	AuthToken     string `json:"authToken,omitempty"`                                        // Token for HTTPS access to a private repository
+} //	@name	DevcontainerDTO
+
+// SandboxHooksDTO holds the commands run in the sandbox at lifecycle transitions, in order. Post create hooks
+// run once before the post start hooks of the first start.
+type SandboxHooksDTO struct {
+	PostCreate []HookDTO `json:"postCreate,omitempty" validate:"dive"`
+	PostStart  []HookDTO `json:"postStart,omitempty" validate:"dive"`
+	PreStop    []HookDTO `json:"preStop,omitempty" validate:"dive"`
+} //	@name	SandboxHooksDTO
+
+type HookDTO struct {
+	Command        string `json:"command" validate:"required"`                                         // Run with sh -c
+	User           string `json:"user,omitempty"`                                                      // Defaults to the user of the container
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty" validate:"min=0"`                           // Defaults to 10 minutes
+	FailurePolicy  string `json:"failurePolicy,omitempty" validate:"omitempty,oneof=IGNORE WARN FAIL"` // Defaults to WARN
+} //	@name	HookDTO
+
+type SandboxWarningDTO struct {
+	Stage     string    `json:"stage" validate:"required"`
+	Command   string    `json:"command" validate:"required"`
+	Message   string    `json:"message" validate:"required"`
+	CreatedAt time.Time `json:"createdAt" validate:"required"`
+} //	@name	SandboxWarningDTO
+
+// SidecarDTO describes an additional container that shares the sandbox network namespace and lifecycle
+type SidecarDTO struct {
+	Name       string            `json:"name" validate:"required,alphanum"`
+	Image      string            `json:"image" validate:"required"`
+	Entrypoint []string          `json:"entrypoint,omitempty"`
+	Cmd        []string          `json:"cmd,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+} //	@name	SidecarDTO
+
 type ResizeSandboxDTO struct {
 	Cpu    int64 `json:"cpu" validate:"min=1"`
 	Gpu    int64 `json:"gpu" validate:"min=0"`
 	Memory int64 `json:"memory" validate:"min=1"`
+	Swap   int64 `json:"swap" validate:"min=0"` // Swap in GB on top of memory, 0 disables swap
 } //	@name	ResizeSandboxDTO
 
+type StopSandboxDTO struct {
+	TimeoutSeconds int  `json:"timeoutSeconds,omitempty" validate:"min=0,max=3600"` // Grace period between SIGTERM and SIGKILL, defaults to the stop timeout of the runner
+	Force          bool `json:"force,omitempty"`                                    // Kill the sandbox right away, without the grace period and the pre-stop hooks
+} //	@name	StopSandboxDTO
+
+type StopSandboxResponseDTO struct {
+	Method     string `json:"method" validate:"required"` // graceful if the sandbox exited within the grace period, killed if it was killed after it, forced if it was killed right away or not_running
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"` // Time from the first signal until the container stopped
+} //	@name	StopSandboxResponseDTO
+
 type UpdateNetworkSettingsDTO struct {
-	NetworkBlockAll  *bool   `json:"networkBlockAll,omitempty"`
-	NetworkAllowList *string `json:"networkAllowList,omitempty"`
+	NetworkBlockAll  *bool            `json:"networkBlockAll,omitempty"`
+	NetworkAllowList *string          `json:"networkAllowList,omitempty"`
+	EgressPolicy     *EgressPolicyDTO `json:"egressPolicy,omitempty"`
 } //	@name	UpdateNetworkSettingsDTO
+
+type EgressPolicyDTO struct {
+	Mode    string   `json:"mode" validate:"required,oneof=ALLOW_ALL DENY_ALL ALLOWLIST"`
+	Cidrs   []string `json:"cidrs,omitempty" validate:"omitempty,dive,cidrv4"`
+	Domains []string `json:"domains,omitempty" validate:"omitempty,dive,fqdn"`
+} //	@name	EgressPolicyDTO
+
+type SandboxSummaryDTO struct {
+	Id        string            `json:"id" validate:"required"`
+	State     string            `json:"state" validate:"required"`
+	Snapshot  string            `json:"snapshot,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt *time.Time        `json:"createdAt,omitempty"`
+	// HasContainer is false for sandboxes only known to the cache, e.g. destroyed ones or ones pulling their snapshot
+	HasContainer bool `json:"hasContainer"`
+} //	@name	SandboxSummaryDTO
+
+type ListSandboxesResponseDTO struct {
+	Items []SandboxSummaryDTO `json:"items" validate:"required"`
+	Total int                 `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+} //	@name	ListSandboxesResponseDTO
+
+type CloneSandboxDTO struct {
+	Id         string            `json:"id" validate:"required"`
+	Snapshot   string            `json:"snapshot,omitempty"`   // Name and tag of the snapshot the clone is created from, defaults to daytona-clone:<id>
+	CopyEnv    bool              `json:"copyEnv,omitempty"`    // Keep the environment variables and secrets of the source, otherwise variables are reset to the snapshot values
+	CopyLabels bool              `json:"copyLabels,omitempty"` // Keep the idle timeout and bandwidth limits of the source
+	Env        map[string]string `json:"env,omitempty"`        // Set on the clone after the copied variables
+} //	@name	CloneSandboxDTO
+
+type UpdateSandboxEnvDTO struct {
+	Env    map[string]string `json:"env,omitempty"`
+	Remove []string          `json:"remove,omitempty"` // Names of variables set earlier to drop
+} //	@name	UpdateSandboxEnvDTO
+
+type UpdateSandboxSecretsDTO struct {
+	Secrets map[string]string `json:"secrets,omitempty"` // Keyed by file name
+	Remove  []string          `json:"remove,omitempty"`  // Names of secrets set earlier to drop
+} //	@name	UpdateSandboxSecretsDTO
+
+type SandboxEnvDTO struct {
+	Env     map[string]string `json:"env"`     // Variables the daemon and the processes it starts get from the next start on
+	Secrets []string          `json:"secrets"` // Names of the secret files in /run/daytona/secrets
+} //	@name	SandboxEnvDTO
@@ -0,0 +1,40 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+// SandboxSecurityDTO hardens the sandbox container, e.g. to apply the sandbox profile an organization policy requires.
+// Masked and read-only paths are added to the Docker defaults and take the sandbox out of privileged mode, Docker
+// ignores them for privileged containers, the same goes for security profiles, capabilities and no new privileges.
+type SandboxSecurityDTO struct {
+	ReadOnlyRootfs    bool            `json:"readOnlyRootfs,omitempty"`                           // Mount the root filesystem read-only, the sandbox can only write to volumes and tmpfs mounts
+	Tmpfs             []TmpfsMountDTO `json:"tmpfs,omitempty" validate:"dive"`                    // Writable in-memory mounts, e.g. /tmp for sandboxes with a read-only root filesystem
+	MaskedPaths       []string        `json:"maskedPaths,omitempty" validate:"dive,startswith=/"` // Paths hidden from the sandbox processes
+	ReadonlyPaths     []string        `json:"readonlyPaths,omitempty" validate:"dive,startswith=/"`
+	Profile           string          `json:"profile,omitempty"`           // Name of a security profile uploaded to the runner, its seccomp and AppArmor profiles are applied to the sandbox
+	CapAdd            []string        `json:"capAdd,omitempty"`            // Linux capabilities added to the default set, e.g. NET_ADMIN, the runner policy may restrict them
+	CapDrop           []string        `json:"capDrop,omitempty"`           // Linux capabilities dropped from the default set, e.g. ALL
+	NoNewPrivileges   bool            `json:"noNewPrivileges,omitempty"`   // Keep the sandbox processes from gaining privileges, e.g. through setuid binaries like sudo
+	Privileged        *bool           `json:"privileged,omitempty"`        // Run the sandbox privileged or not, the privilege policy of the runner decides when unset and may forbid it
+	HostUserNamespace bool            `json:"hostUserNamespace,omitempty"` // Opt out of the user namespace remapping of the runner, root in the sandbox is root on the host. Remapped sandboxes are never privileged.
+} //	@name	SandboxSecurityDTO
+
+type TmpfsMountDTO struct {
+	Path    string `json:"path" validate:"required,startswith=/"`
+	SizeMiB int64  `json:"sizeMiB,omitempty" validate:"min=0"` // Counted against the memory of the sandbox, defaults to 64 MiB
+	Mode    string `json:"mode,omitempty"`                     // Octal permissions of the mount root, defaults to 1777
+	Exec    bool   `json:"exec,omitempty"`                     // Allow running files from the mount, mounts are noexec by default
+} //	@name	TmpfsMountDTO
+
+type UploadSecurityProfileDTO struct {
+	Name     string `json:"name" validate:"required"`
+	Seccomp  string `json:"seccomp,omitempty"`  // Seccomp profile in the JSON format of Docker
+	AppArmor string `json:"appArmor,omitempty"` // AppArmor profile source, it is loaded into the kernel of the runner under the name it declares
+} //	@name	UploadSecurityProfileDTO
+
+type SecurityProfileDTO struct {
+	Name            string `json:"name" validate:"required"`
+	Seccomp         bool   `json:"seccomp"`                   // Whether the profile has a seccomp profile
+	AppArmorProfile string `json:"appArmorProfile,omitempty"` // Name of the AppArmor profile the sandboxes are confined by
+	CreatedAt       string `json:"createdAt" validate:"required"`
+} //	@name	SecurityProfileDTO
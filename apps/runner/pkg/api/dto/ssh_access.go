@@ -0,0 +1,25 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+import "time"
+
+type CreateSshAccessDTO struct {
+	// PublicKey in the authorized_keys format, a key pair is generated if empty
+	PublicKey string `json:"publicKey,omitempty"`
+	// ExpiresInMinutes defaults to the SSH access TTL of the runner
+	ExpiresInMinutes int `json:"expiresInMinutes,omitempty" validate:"omitempty,min=1"`
+} //	@name	CreateSshAccessDTO
+
+type SshAccessDTO struct {
+	Id          string `json:"id" validate:"required"`
+	Fingerprint string `json:"fingerprint" validate:"required"`
+	Username    string `json:"username" validate:"required"`
+	Host        string `json:"host" validate:"required"`
+	Port        int    `json:"port" validate:"required"`
+	// PrivateKey is only set if the runner generated the key pair, it is not stored by the runner
+	PrivateKey string    `json:"privateKey,omitempty"`
+	Command    string    `json:"command" validate:"required"`
+	ExpiresAt  time.Time `json:"expiresAt" validate:"required"`
+} //	@name	SshAccessDTO
@@ -0,0 +1,19 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+import "time"
+
+type CreateScopedTokenDTO struct {
+	// Subject names the holder of the token in audit logs
+	Subject string   `json:"subject,omitempty"`
+	Scopes  []string `json:"scopes" validate:"required,min=1,dive,oneof=read write toolbox admin"`
+	// Ttl in seconds, tokens without a ttl stay valid until the API token signing them is removed
+	Ttl int64 `json:"ttl,omitempty" validate:"min=0"`
+} //	@name	CreateScopedTokenDTO
+
+type ScopedTokenResponseDTO struct {
+	Token     string     `json:"token" validate:"required"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+} //	@name	ScopedTokenResponseDTO
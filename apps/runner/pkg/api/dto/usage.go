@@ -0,0 +1,23 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package dto
+
+type UsageRecordDTO struct {
+	Sequence        uint64  `json:"sequence" validate:"required"` // Increases with every record, records are returned in its order
+	SandboxId       string  `json:"sandboxId" validate:"required"`
+	OrganizationId  string  `json:"organizationId,omitempty"`
+	PeriodStart     string  `json:"periodStart" validate:"required"`
+	PeriodEnd       string  `json:"periodEnd" validate:"required"`
+	CpuSeconds      float64 `json:"cpuSeconds" validate:"required"`
+	MemoryByteHours float64 `json:"memoryByteHours" validate:"required"` // Memory in use excluding page cache, integrated over the period
+	DiskBytes       int64   `json:"diskBytes" validate:"required"`       // Peak size of the writable layer in the period
+	DiskByteHours   float64 `json:"diskByteHours" validate:"required"`
+	NetworkRxBytes  int64   `json:"networkRxBytes" validate:"required"`
+	NetworkTxBytes  int64   `json:"networkTxBytes" validate:"required"`
+} //	@name	UsageRecordDTO
+
+type UsageRecordsDTO struct {
+	Records []UsageRecordDTO `json:"records" validate:"required"`
+	Cursor  uint64           `json:"cursor" validate:"required"` // Sequence of the last record, pass it as after to get the following records
+} //	@name	UsageRecordsDTO
@@ -3,7 +3,46 @@
 
 package dto
 
+import "time"
+
 type VolumeDTO struct {
 	VolumeId  string `json:"volumeId"`
 	MountPath string `json:"mountPath"`
+	// Materialize the volume on the runner before start and sync changes back on stop instead of mounting it
+	Sync bool `json:"sync,omitempty"`
 }
+
+type CreateVolumeDTO struct {
+	Name       string            `json:"name" validate:"required"`
+	Driver     string            `json:"driver,omitempty"`
+	DriverOpts map[string]string `json:"driverOpts,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+} //	@name	CreateVolumeDTO
+
+type VolumeInfoResponse struct {
+	Name       string            `json:"name" validate:"required"`
+	Driver     string            `json:"driver" validate:"required"`
+	Mountpoint string            `json:"mountpoint" validate:"required"`
+	CreatedAt  string            `json:"createdAt,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Options    map[string]string `json:"options,omitempty"`
+	// Size in bytes, -1 if the driver does not report usage
+	Size int64 `json:"size"`
+	// Sandboxes currently using the volume, only set on inspect
+	Sandboxes []string `json:"sandboxes,omitempty"`
+} //	@name	VolumeInfoResponse
+
+type SyncVolumeDTO struct {
+	Direction string `json:"direction" validate:"required,oneof=pull push"`
+} //	@name	SyncVolumeDTO
+
+type VolumeSyncStatusResponse struct {
+	State            string     `json:"state" validate:"required"`
+	Direction        string     `json:"direction,omitempty"`
+	TotalObjects     int        `json:"totalObjects"`
+	SyncedObjects    int        `json:"syncedObjects"`
+	TransferredBytes int64      `json:"transferredBytes"`
+	Error            string     `json:"error,omitempty"`
+	StartedAt        *time.Time `json:"startedAt,omitempty"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+} //	@name	VolumeSyncStatusResponse
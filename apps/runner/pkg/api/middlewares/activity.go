@@ -0,0 +1,26 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityMiddleware records proxied traffic as sandbox activity for idle detection
+func ActivityMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sandboxId := ctx.Param("sandboxId")
+		idleService := runner.GetInstance(nil).IdleService
+		if sandboxId == "" || idleService == nil {
+			ctx.Next()
+			return
+		}
+
+		idleService.RecordActivity(sandboxId)
+		ctx.Next()
+		// Long lived connections (websockets, tunnels) count as activity until they close
+		idleService.RecordActivity(sandboxId)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/audit"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// Bodies larger than this are not inspected for the audit summary
+const maxAuditedBodySize = 1024 * 1024
+
+// AuditMiddleware records every mutating request, including the ones refused by authentication.
+// Reads and toolbox proxy traffic are not audited.
+func AuditMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		auditLogger := runner.GetInstance(nil).AuditLogger
+		if auditLogger == nil || ctx.Request.Method == http.MethodGet || strings.Contains(ctx.FullPath(), "/toolbox/") {
+			ctx.Next()
+			return
+		}
+
+		startTime := time.Now()
+
+		entry := audit.Entry{
+			Time:           startTime,
+			OrganizationId: ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER),
+			ClientIp:       ctx.ClientIP(),
+			Method:         ctx.Request.Method,
+			Route:          ctx.FullPath(),
+			Path:           ctx.Request.URL.Path,
+			SandboxId:      ctx.Param("sandboxId"),
+			Snapshot:       ctx.Query("snapshot"),
+		}
+
+		if len(ctx.Params) > 0 {
+			entry.Params = make(map[string]string, len(ctx.Params))
+			for _, param := range ctx.Params {
+				entry.Params[param.Key] = param.Value
+			}
+		}
+
+		body := peekJSONBody(ctx)
+		for field := range body {
+			entry.BodyFields = append(entry.BodyFields, field)
+		}
+		slices.Sort(entry.BodyFields)
+
+		if entry.SandboxId == "" {
+			entry.SandboxId = getStringField(body, "id")
+		}
+		if entry.Snapshot == "" {
+			entry.Snapshot = getStringField(body, "snapshot")
+		}
+
+		ctx.Next()
+
+		entry.TokenSubject = ctx.GetString(constants.TOKEN_SUBJECT_CONTEXT_KEY)
+		entry.Status = ctx.Writer.Status()
+		if err := ctx.Errors.Last(); err != nil {
+			entry.Error = err.Error()
+		}
+		entry.DurationMs = time.Since(startTime).Milliseconds()
+
+		auditLogger.Log(entry)
+	}
+}
+
+// peekJSONBody decodes a JSON object body and puts the body back for the handler
+func peekJSONBody(ctx *gin.Context) map[string]json.RawMessage {
+	if ctx.Request.Body == nil || ctx.Request.ContentLength > maxAuditedBodySize || !strings.HasPrefix(ctx.ContentType(), "application/json") {
+		return nil
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxAuditedBodySize+1))
+	ctx.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), ctx.Request.Body))
+	if err != nil || len(raw) > maxAuditedBodySize {
+		return nil
+	}
+
+	var body map[string]json.RawMessage
+	if json.Unmarshal(raw, &body) != nil {
+		return nil
+	}
+
+	return body
+}
+
+func getStringField(body map[string]json.RawMessage, field string) string {
+	var value string
+	if json.Unmarshal(body[field], &value) != nil {
+		return ""
+	}
+
+	return value
+}
@@ -5,23 +5,28 @@ package middlewares
 
 import (
 	"errors"
-	"os"
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/daytonaio/runner/internal/constants"
 	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
 	"github.com/gin-gonic/gin"
 )
 
+// AuthMiddleware accepts the runner API token or a scoped token holding the scope the route requires
 func AuthMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		// The header the runner token came in is removed so proxied requests never carry it to the sandbox,
+		// an Authorization header next to the Daytona one is meant for the sandbox and is kept
 		authHeader := ctx.GetHeader(constants.DAYTONA_AUTHORIZATION_HEADER)
+		ctx.Request.Header.Del(constants.DAYTONA_AUTHORIZATION_HEADER)
 		if authHeader == "" {
 			authHeader = ctx.GetHeader(constants.AUTHORIZATION_HEADER)
+			ctx.Request.Header.Del(constants.AUTHORIZATION_HEADER)
 		}
 
-		ctx.Request.Header.Del(constants.DAYTONA_AUTHORIZATION_HEADER)
-
 		if authHeader == "" {
 			ctx.Error(common.NewUnauthorizedError(errors.New("authorization header required")))
 			ctx.Abort()
@@ -36,14 +41,26 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		token := parts[1]
-		// Compare with API token from environment variable
-		if token != os.Getenv("API_TOKEN") {
-			ctx.Error(common.NewUnauthorizedError(errors.New("invalid token")))
+		runner := runner.GetInstance(nil)
+
+		claims, err := runner.Authenticator.Authenticate(parts[1])
+		if err != nil {
+			ctx.Error(common.NewUnauthorizedError(err))
+			ctx.Abort()
+			return
+		}
+
+		requiredScope := runner.AuthPolicy.RequiredScope(ctx.Request.Method, ctx.FullPath())
+		if !claims.HasScope(requiredScope) {
+			ctx.Error(common.NewCustomError(http.StatusForbidden, fmt.Sprintf("token is missing the %s scope", requiredScope), "PERMISSION_DENIED"))
 			ctx.Abort()
 			return
 		}
 
+		if claims.Subject != "" {
+			ctx.Set(constants.TOKEN_SUBJECT_CONTEXT_KEY, claims.Subject)
+		}
+
 		// Authentication successful, continue to the next handler
 		ctx.Next()
 	}
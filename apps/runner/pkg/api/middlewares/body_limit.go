@@ -0,0 +1,28 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware fails reads of request bodies larger than maxSize bytes, 0 means unlimited
+func BodyLimitMiddleware(maxSize int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if maxSize > 0 && ctx.Request.Body != nil {
+			if ctx.Request.ContentLength > maxSize {
+				ctx.Error(common.NewCustomError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body is larger than %d bytes", maxSize), "REQUEST_TOO_LARGE"))
+				ctx.Abort()
+				return
+			}
+			ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize)
+		}
+
+		ctx.Next()
+	}
+}
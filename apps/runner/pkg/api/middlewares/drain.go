@@ -0,0 +1,57 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// Routes that start new work on the runner and are refused while draining or during maintenance
+var drainRefusedRoutes = map[string]bool{
+	http.MethodPost + " /sandboxes":        true,
+	http.MethodPost + " /compose":          true,
+	http.MethodPost + " /snapshots/pull":   true,
+	http.MethodPost + " /snapshots/build":  true,
+	http.MethodPost + " /snapshots/import": true,
+	// Sandboxes migrated to a draining runner would have to be migrated again
+	http.MethodPost + " /sandboxes/:sandboxId/migrate/receive": true,
+}
+
+// DrainMiddleware refuses new work while the runner drains or is in maintenance and tracks mutating operations
+// so shutdown can wait for them. Reads and toolbox proxy traffic are long lived and not tracked.
+func DrainMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodGet || strings.Contains(ctx.FullPath(), "/toolbox/") {
+			ctx.Next()
+			return
+		}
+
+		drainService := runner.GetInstance(nil).DrainService
+
+		if drainRefusedRoutes[ctx.Request.Method+" "+ctx.FullPath()] {
+			if drainService.IsDraining() {
+				ctx.Error(common.NewCustomError(http.StatusServiceUnavailable, "runner is draining", "RUNNER_DRAINING"))
+				ctx.Abort()
+				return
+			}
+
+			err := runner.GetInstance(nil).Maintenance.Admit()
+			if err != nil {
+				ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+		}
+
+		done := drainService.StartOperation()
+		defer done()
+
+		ctx.Next()
+	}
+}
@@ -4,16 +4,15 @@
 package middlewares
 
 import (
-	"fmt"
+	"math"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/common"
-	"github.com/docker/docker/errdefs"
 	"github.com/gin-gonic/gin"
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
 )
 
 func ErrorMiddleware() gin.HandlerFunc {
@@ -25,7 +24,7 @@ func ErrorMiddleware() gin.HandlerFunc {
 			var errorResponse common.ErrorResponse
 			err := errs.Last()
 
-			switch e := err.Err.(type) {
+			switch e := common.MapDockerError(err.Err).(type) {
 			case *common.CustomError:
 				errorResponse = common.ErrorResponse{
 					StatusCode: e.StatusCode,
@@ -34,12 +33,13 @@ func ErrorMiddleware() gin.HandlerFunc {
 					Timestamp:  time.Now(),
 					Path:       ctx.Request.URL.Path,
 					Method:     ctx.Request.Method,
+					Details:    e.Details,
 				}
 			case *common.NotFoundError:
 				errorResponse = common.ErrorResponse{
 					StatusCode: http.StatusNotFound,
 					Message:    err.Err.Error(),
-					Code:       "NOT_FOUND",
+					Code:       common.ErrorCodeNotFound,
 					Timestamp:  time.Now(),
 					Path:       ctx.Request.URL.Path,
 					Method:     ctx.Request.Method,
@@ -66,7 +66,7 @@ func ErrorMiddleware() gin.HandlerFunc {
 				errorResponse = common.ErrorResponse{
 					StatusCode: http.StatusConflict,
 					Message:    err.Err.Error(),
-					Code:       "CONFLICT",
+					Code:       common.ErrorCodeConflict,
 					Timestamp:  time.Now(),
 					Path:       ctx.Request.URL.Path,
 					Method:     ctx.Request.Method,
@@ -75,22 +75,43 @@ func ErrorMiddleware() gin.HandlerFunc {
 				errorResponse = common.ErrorResponse{
 					StatusCode: http.StatusBadRequest,
 					Message:    util.ExtractErrorPart(err.Err.Error()),
-					Code:       "BAD_REQUEST",
+					Code:       common.ErrorCodeBadRequest,
 					Timestamp:  time.Now(),
 					Path:       ctx.Request.URL.Path,
 					Method:     ctx.Request.Method,
 				}
+			case *common.ResourceExhaustedError:
+				retryAfter := strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds())))
+				ctx.Header("Retry-After", retryAfter)
+				errorResponse = common.ErrorResponse{
+					StatusCode: http.StatusTooManyRequests,
+					Message:    err.Err.Error(),
+					Code:       common.ErrorCodeResourceExhausted,
+					Timestamp:  time.Now(),
+					Path:       ctx.Request.URL.Path,
+					Method:     ctx.Request.Method,
+					Details: map[string]string{
+						"retryAfterSeconds": retryAfter,
+					},
+				}
 			default:
-				errorResponse = handlePossibleDockerError(ctx, err.Err)
+				errorResponse = common.ErrorResponse{
+					StatusCode: http.StatusInternalServerError,
+					Message:    err.Err.Error(),
+					Code:       common.ErrorCodeInternal,
+					Timestamp:  time.Now(),
+					Path:       ctx.Request.URL.Path,
+					Method:     ctx.Request.Method,
+				}
 			}
 
 			if errorResponse.StatusCode == http.StatusInternalServerError {
-				log.WithError(err).WithFields(log.Fields{
+				log.WithError(err).WithFields(logrus.Fields{
 					"path":   ctx.Request.URL.Path,
 					"method": ctx.Request.Method,
 				}).Error("Internal Server Error")
 			} else {
-				log.WithFields(log.Fields{
+				log.WithFields(logrus.Fields{
 					"method": ctx.Request.Method,
 					"URI":    ctx.Request.URL.Path,
 					"status": errorResponse.StatusCode,
@@ -104,63 +125,3 @@ func ErrorMiddleware() gin.HandlerFunc {
 		}
 	}
 }
-
-func handlePossibleDockerError(ctx *gin.Context, err error) common.ErrorResponse {
-	if errdefs.IsNotFound(err) {
-		return common.ErrorResponse{
-			StatusCode: http.StatusNotFound,
-			Message:    fmt.Sprintf("resource not found: %s", err.Error()),
-			Code:       "NOT_FOUND",
-			Timestamp:  time.Now(),
-			Path:       ctx.Request.URL.Path,
-			Method:     ctx.Request.Method,
-		}
-	} else if errdefs.IsUnauthorized(err) || strings.Contains(err.Error(), "unauthorized") {
-		return common.ErrorResponse{
-			StatusCode: http.StatusUnauthorized,
-			Message:    fmt.Sprintf("unauthorized: %s", err.Error()),
-			Code:       "UNAUTHORIZED",
-			Timestamp:  time.Now(),
-			Path:       ctx.Request.URL.Path,
-			Method:     ctx.Request.Method,
-		}
-	} else if errdefs.IsConflict(err) {
-		return common.ErrorResponse{
-			StatusCode: http.StatusConflict,
-			Message:    fmt.Sprintf("conflict: %s", err.Error()),
-			Code:       "CONFLICT",
-			Timestamp:  time.Now(),
-			Path:       ctx.Request.URL.Path,
-			Method:     ctx.Request.Method,
-		}
-	} else if errdefs.IsInvalidParameter(err) {
-		return common.ErrorResponse{
-			StatusCode: http.StatusBadRequest,
-			Message:    fmt.Sprintf("bad request: %s", err.Error()),
-			Code:       "BAD_REQUEST",
-			Timestamp:  time.Now(),
-			Path:       ctx.Request.URL.Path,
-			Method:     ctx.Request.Method,
-		}
-	} else if errdefs.IsSystem(err) {
-		if strings.Contains(err.Error(), "unable to find user") {
-			return common.ErrorResponse{
-				StatusCode: http.StatusBadRequest,
-				Message:    util.ExtractErrorPart(err.Error()),
-				Code:       "BAD_REQUEST",
-				Timestamp:  time.Now(),
-				Path:       ctx.Request.URL.Path,
-				Method:     ctx.Request.Method,
-			}
-		}
-	}
-
-	return common.ErrorResponse{
-		StatusCode: http.StatusInternalServerError,
-		Message:    err.Error(),
-		Code:       "INTERNAL_SERVER_ERROR",
-		Timestamp:  time.Now(),
-		Path:       ctx.Request.URL.Path,
-		Method:     ctx.Request.Method,
-	}
-}
@@ -0,0 +1,118 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/idempotency"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	// Bodies larger than this are not part of the request fingerprint
+	maxFingerprintedBodySize = 1024 * 1024
+	maxIdempotencyKeyLength  = 255
+)
+
+// IdempotencyMiddleware replays the response of a successful mutating request for retries with the same idempotency
+// key, a retry of a request that is still running waits for it. Failed requests are not stored so their retries
+// run the request again. Keys are scoped to the organization.
+func IdempotencyMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		store := runner.GetInstance(nil).Idempotency
+		key := ctx.GetHeader(constants.IDEMPOTENCY_KEY_HEADER)
+		if store == nil || key == "" || ctx.Request.Method == http.MethodGet || strings.Contains(ctx.FullPath(), "/toolbox/") {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.Error(common.NewBadRequestError(errors.New("idempotency key is too long")))
+			ctx.Abort()
+			return
+		}
+
+		scopedKey := ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER) + " " + key
+
+		response, finish, err := store.Begin(ctx.Request.Context(), scopedKey, getRequestFingerprint(ctx))
+		if err != nil {
+			if errors.Is(err, idempotency.ErrKeyReused) {
+				err = common.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "IDEMPOTENCY_KEY_REUSED")
+			}
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		if response != nil {
+			common.IdempotentReplayCount.WithLabelValues(ctx.Request.Method + " " + ctx.FullPath()).Inc()
+			writeIdempotentResponse(ctx, response)
+			ctx.Abort()
+			return
+		}
+
+		writer := &cacheWriter{ResponseWriter: ctx.Writer, limit: store.MaxResponseSize()}
+		ctx.Writer = writer
+
+		// A panicking handler doesn't leave the key claimed
+		stored := false
+		defer func() {
+			if !stored {
+				finish(nil)
+			}
+		}()
+
+		ctx.Next()
+
+		ctx.Writer = writer.ResponseWriter
+		if writer.overflow || len(ctx.Errors) > 0 || writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		stored = true
+		finish(&idempotency.Response{
+			Status: writer.Status(),
+			Header: writer.Header().Clone(),
+			Body:   bytes.Clone(writer.body.Bytes()),
+		})
+	}
+}
+
+func writeIdempotentResponse(ctx *gin.Context, response *idempotency.Response) {
+	header := ctx.Writer.Header()
+	for key, values := range response.Header {
+		header[key] = values
+	}
+	header.Set(idempotencyReplayedHeader, "true")
+
+	ctx.Status(response.Status)
+	_, _ = ctx.Writer.Write(response.Body)
+}
+
+// getRequestFingerprint hashes the method, path and body, only the method and path of large bodies are hashed
+func getRequestFingerprint(ctx *gin.Context) string {
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n"))
+
+	if ctx.Request.Body != nil && ctx.Request.ContentLength >= 0 && ctx.Request.ContentLength <= maxFingerprintedBodySize {
+		raw, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxFingerprintedBodySize+1))
+		ctx.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), ctx.Request.Body))
+		if err == nil && len(raw) <= maxFingerprintedBodySize {
+			hash.Write(raw)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
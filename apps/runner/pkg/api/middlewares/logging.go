@@ -8,7 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus"
 )
 
 var ignoreLoggingPaths = map[string]bool{}
@@ -17,10 +17,13 @@ func LoggingMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		startTime := time.Now()
 		ctx.Next()
+		if ctx.GetBool(proxyAccessLoggedKey) {
+			return
+		}
 		endTime := time.Now()
 		latencyTime := endTime.Sub(startTime)
 
-		fields := log.Fields{
+		fields := logrus.Fields{
 			"method":  ctx.Request.Method,
 			"URI":     ctx.Request.RequestURI,
 			"status":  ctx.Writer.Status(),
@@ -0,0 +1,27 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// MigrationRedirectMiddleware redirects proxy requests for sandboxes migrated away from this runner to the
+// destination runner until the control plane routes them there itself
+func MigrationRedirectMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		destination, ok := runner.GetInstance(nil).Migration.GetForward(ctx.Param("sandboxId"))
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		ctx.Redirect(http.StatusTemporaryRedirect, strings.TrimSuffix(destination, "/")+ctx.Request.URL.RequestURI())
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/daytonaio/runner/pkg/services"
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewHostMiddleware sets the sandboxId and port params from the preview hostname of the request
+func PreviewHostMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sandboxId, port, ok := services.ParsePreviewHost(ctx.Request.Host, config.GetPreviewDomain())
+		if !ok {
+			ctx.Error(common.NewNotFoundError(fmt.Errorf("%s is not a preview hostname", ctx.Request.Host)))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Params = append(ctx.Params,
+			gin.Param{Key: "sandboxId", Value: sandboxId},
+			gin.Param{Key: "port", Value: strconv.Itoa(port)},
+		)
+
+		ctx.Next()
+	}
+}
+
+// PreviewAuthMiddleware enforces the access level of the previewed port. Browsers can't set headers on
+// navigation, so a proxy token passed as a query param is moved to a cookie scoped to the preview hostname.
+func PreviewAuthMiddleware() gin.HandlerFunc {
+	apiTokenAuth := AuthMiddleware()
+
+	return func(ctx *gin.Context) {
+		sandboxId := ctx.Param("sandboxId")
+		port, _ := strconv.Atoi(ctx.Param("port"))
+
+		runner := runner.GetInstance(nil)
+
+		exposed, ok := runner.Ports.Get(sandboxId, port)
+		if !ok {
+			ctx.Error(common.NewNotFoundError(fmt.Errorf("port %d of sandbox %s is not exposed", port, sandboxId)))
+			ctx.Abort()
+			return
+		}
+
+		token, fromQuery := getPreviewToken(ctx)
+
+		if exposed.AccessLevel == enums.PreviewAccessLevelPublic {
+			ctx.Next()
+			return
+		}
+
+		if token == "" {
+			if exposed.AccessLevel == enums.PreviewAccessLevelOwner {
+				ctx.Error(common.NewUnauthorizedError(errors.New("a proxy token of the sandbox is required")))
+				ctx.Abort()
+				return
+			}
+
+			apiTokenAuth(ctx)
+			return
+		}
+
+		if runner.ProxyTokenIssuer == nil {
+			ctx.Error(common.NewUnauthorizedError(errors.New("proxy tokens are not enabled on this runner")))
+			ctx.Abort()
+			return
+		}
+
+		err := runner.ProxyTokenIssuer.Validate(token, sandboxId)
+		if err != nil {
+			ctx.Error(common.NewUnauthorizedError(err))
+			ctx.Abort()
+			return
+		}
+
+		if fromQuery {
+			http.SetCookie(ctx.Writer, &http.Cookie{
+				Name:     constants.DAYTONA_PREVIEW_TOKEN_COOKIE,
+				Value:    token,
+				Path:     "/",
+				HttpOnly: true,
+				Secure:   config.GetPreviewUrlScheme() == "https",
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		ctx.Next()
+	}
+}
+
+// getPreviewToken returns the proxy token from the header, query param or cookie and removes it from the
+// request so it never reaches the sandbox
+func getPreviewToken(ctx *gin.Context) (string, bool) {
+	token := ctx.GetHeader(constants.DAYTONA_PROXY_TOKEN_HEADER)
+	ctx.Request.Header.Del(constants.DAYTONA_PROXY_TOKEN_HEADER)
+
+	query := ctx.Request.URL.Query()
+	queryToken := query.Get(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM)
+	if query.Has(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM) {
+		query.Del(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM)
+		ctx.Request.URL.RawQuery = query.Encode()
+	}
+
+	var cookieToken string
+	cookies := ctx.Request.Cookies()
+	ctx.Request.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name == constants.DAYTONA_PREVIEW_TOKEN_COOKIE {
+			cookieToken = cookie.Value
+			continue
+		}
+		ctx.Request.AddCookie(cookie)
+	}
+
+	if token != "" {
+		return token, false
+	}
+	if queryToken != "" {
+		return queryToken, true
+	}
+
+	return cookieToken, false
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/gin-gonic/gin"
+
+	"github.com/sirupsen/logrus"
+)
+
+// proxyAccessLoggedKey keeps LoggingMiddleware from logging proxied requests a second time
+const proxyAccessLoggedKey = "proxyAccessLogged"
+
+// ProxyAccessMiddleware writes an access log entry and records metrics for every request proxied to a sandbox.
+// It runs after the authentication so unauthenticated requests can't create metric series for made up sandboxes.
+func ProxyAccessMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(proxyAccessLoggedKey, true)
+
+		startTime := time.Now()
+		ctx.Next()
+		latency := time.Since(startTime)
+
+		sandboxId := ctx.Param("sandboxId")
+		port := getProxyAccessPort(ctx)
+		status := ctx.Writer.Status()
+
+		// Size is -1 when nothing was written, hijacked websocket and tunnel connections aren't counted
+		bytes := max(ctx.Writer.Size(), 0)
+
+		log.WithFields(logrus.Fields{
+			"method":    ctx.Request.Method,
+			"host":      ctx.Request.Host,
+			"path":      ctx.Request.URL.Path,
+			"sandboxId": sandboxId,
+			"port":      port,
+			"status":    status,
+			"bytes":     bytes,
+			"latency":   latency,
+		}).Info("PROXY REQUEST")
+
+		if sandboxId == "" {
+			return
+		}
+
+		common.ProxyRequestDuration.WithLabelValues(sandboxId, port, ctx.Request.Method, fmt.Sprintf("%dxx", status/100)).Observe(latency.Seconds())
+		common.ProxyResponseSize.WithLabelValues(sandboxId, port).Observe(float64(bytes))
+	}
+}
+
+func getProxyAccessPort(ctx *gin.Context) string {
+	if port := ctx.Param("port"); port != "" {
+		return port
+	}
+
+	if strings.Contains(ctx.FullPath(), "/toolbox/") {
+		return "toolbox"
+	}
+
+	return ""
+}
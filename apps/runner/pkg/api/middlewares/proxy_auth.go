@@ -0,0 +1,55 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// ProxyAuthMiddleware accepts a proxy token scoped to the requested sandbox in place of the runner API token.
+// Requests without a proxy token fall back to the API token authentication.
+func ProxyAuthMiddleware() gin.HandlerFunc {
+	apiTokenAuth := AuthMiddleware()
+
+	return func(ctx *gin.Context) {
+		token := ctx.GetHeader(constants.DAYTONA_PROXY_TOKEN_HEADER)
+		if token == "" {
+			token = ctx.Query(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM)
+		}
+
+		if token == "" {
+			apiTokenAuth(ctx)
+			return
+		}
+
+		// Never forward the token to the sandbox
+		ctx.Request.Header.Del(constants.DAYTONA_PROXY_TOKEN_HEADER)
+		query := ctx.Request.URL.Query()
+		if query.Has(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM) {
+			query.Del(constants.DAYTONA_PROXY_TOKEN_QUERY_PARAM)
+			ctx.Request.URL.RawQuery = query.Encode()
+		}
+
+		issuer := runner.GetInstance(nil).ProxyTokenIssuer
+		if issuer == nil {
+			ctx.Error(common.NewUnauthorizedError(errors.New("proxy tokens are not enabled on this runner")))
+			ctx.Abort()
+			return
+		}
+
+		err := issuer.Validate(token, ctx.Param("sandboxId"))
+		if err != nil {
+			ctx.Error(common.NewUnauthorizedError(err))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/proxycache"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+const cacheStatusHeader = "X-Daytona-Cache"
+
+// ProxyCacheMiddleware serves GET requests to sandbox ports from the proxy cache and stores the responses the
+// sandbox marks cacheable. It must run after the authentication, cached responses are shared by every caller
+// allowed to reach the port.
+func ProxyCacheMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		proxyCache := runner.GetInstance(nil).ProxyCache
+		if proxyCache == nil || !proxycache.IsCacheableRequest(ctx.Request) {
+			ctx.Next()
+			return
+		}
+
+		key := proxycache.Key(ctx.Param("sandboxId"), ctx.Param("port"), ctx.Request)
+
+		if !proxycache.SkipsLookup(ctx.Request) {
+			entry, body, ok := proxyCache.Get(key)
+			if ok {
+				defer body.Close()
+				common.ProxyCacheRequestCount.WithLabelValues("hit").Inc()
+				writeCachedResponse(ctx, entry, body)
+				ctx.Abort()
+				return
+			}
+		}
+
+		common.ProxyCacheRequestCount.WithLabelValues("miss").Inc()
+
+		writer := &cacheWriter{ResponseWriter: ctx.Writer, limit: proxyCache.MaxEntrySize()}
+		ctx.Writer = writer
+		ctx.Header(cacheStatusHeader, "MISS")
+
+		ctx.Next()
+
+		ctx.Writer = writer.ResponseWriter
+		if writer.overflow || len(ctx.Errors) > 0 {
+			return
+		}
+
+		now := time.Now()
+		header := writer.Header().Clone()
+		header.Del(cacheStatusHeader)
+
+		freshness := proxycache.Freshness(ctx.Request, writer.Status(), header, now)
+		if freshness <= 0 {
+			return
+		}
+
+		proxyCache.Put(key, proxycache.Entry{
+			Status:    writer.Status(),
+			Header:    header,
+			StoredAt:  now,
+			ExpiresAt: now.Add(freshness),
+		}, writer.body.Bytes())
+	}
+}
+
+func writeCachedResponse(ctx *gin.Context, entry proxycache.Entry, body io.Reader) {
+	header := ctx.Writer.Header()
+	for key, values := range entry.Header {
+		header[key] = values
+	}
+
+	age := int(time.Since(entry.StoredAt).Seconds())
+	if storedAge, err := strconv.Atoi(entry.Header.Get("Age")); err == nil {
+		age += storedAge
+	}
+	header.Set("Age", strconv.Itoa(age))
+	header.Set(cacheStatusHeader, "HIT")
+
+	etag := entry.Header.Get("ETag")
+	if etag != "" && ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		ctx.Writer.WriteHeaderNow()
+		return
+	}
+
+	ctx.Status(entry.Status)
+	_, err := io.Copy(ctx.Writer, body)
+	if err != nil {
+		log.Debugf("Failed to write cached response: %v", err)
+	}
+}
+
+// cacheWriter keeps a copy of the response body until it grows beyond the limit
+type cacheWriter struct {
+	gin.ResponseWriter
+	limit    int64
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.record(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheWriter) record(data []byte) {
+	if w.overflow {
+		return
+	}
+
+	if int64(w.body.Len()+len(data)) > w.limit {
+		w.overflow = true
+		w.body = bytes.Buffer{}
+		return
+	}
+
+	w.body.Write(data)
+}
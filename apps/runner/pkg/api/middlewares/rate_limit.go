@@ -0,0 +1,46 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/runner"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware limits the requests of every organization, or of the client address for requests
+// without an organization. Toolbox proxy traffic belongs to sandbox users and is not limited.
+func RateLimitMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rateLimiter := runner.GetInstance(nil).RateLimiter
+		if strings.Contains(ctx.FullPath(), "/toolbox/") {
+			ctx.Next()
+			return
+		}
+
+		caller := ctx.GetHeader(constants.DAYTONA_ORGANIZATION_ID_HEADER)
+		if caller == "" {
+			caller = ctx.ClientIP()
+		}
+
+		route := ctx.Request.Method + " " + ctx.FullPath()
+
+		allowed, retryAfter := rateLimiter.Allow(caller, route)
+		if !allowed {
+			common.RateLimitedRequestCount.WithLabelValues(route).Inc()
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			ctx.Error(common.NewCustomError(http.StatusTooManyRequests, "rate limit exceeded", "RESOURCE_EXHAUSTED"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package middlewares
+
+import (
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware names the request span after the matched route once gin has resolved it
+// and records handler errors on the span
+func TracingMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		span := trace.SpanFromContext(ctx.Request.Context())
+		if !span.IsRecording() {
+			return
+		}
+
+		if route := ctx.FullPath(); route != "" {
+			span.SetName(ctx.Request.Method + " " + route)
+		}
+
+		if sandboxId := ctx.Param("sandboxId"); sandboxId != "" {
+			span.SetAttributes(telemetry.SandboxIdAttribute(sandboxId))
+		}
+
+		if len(ctx.Errors) > 0 {
+			telemetry.RecordError(span, ctx.Errors.Last().Err)
+		}
+	}
+}
@@ -16,48 +16,94 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/daytonaio/runner/cmd/runner/config"
 	"github.com/daytonaio/runner/pkg/api/controllers"
 	"github.com/daytonaio/runner/pkg/api/docs"
 	"github.com/daytonaio/runner/pkg/api/middlewares"
+	"github.com/daytonaio/runner/pkg/previewtls"
+	"github.com/daytonaio/runner/pkg/services"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
-	log "github.com/sirupsen/logrus"
 
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type ApiServerConfig struct {
-	ApiPort     int
-	TLSCertFile string
-	TLSKeyFile  string
-	EnableTLS   bool
+	ApiPort int
+	// ListenAddresses are TCP addresses or unix:// socket paths, the server listens on ApiPort if empty
+	ListenAddresses []string
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+	EnableTLS       bool
+	// MaxRequestBodySize limits control plane request bodies in bytes, 0 means unlimited. Toolbox proxy requests are not limited.
+	MaxRequestBodySize int64
+	ReadHeaderTimeout  time.Duration
+	// IdleTimeout closes keep-alive connections that have been idle for this long
+	IdleTimeout time.Duration
+	// TCPKeepAlive is the keep-alive probe period of TCP connections, 0 uses the Go default and negative disables probes
+	TCPKeepAlive time.Duration
+	// PreviewListenAddress serves only preview hostnames, with TLS if PreviewCertificates is set
+	PreviewListenAddress string
+	PreviewCertificates  *previewtls.Manager
+	// DebugListenAddress serves pprof and expvar without authentication, disabled if empty
+	DebugListenAddress string
 }
 
 func NewApiServer(config ApiServerConfig) *ApiServer {
+	listenAddresses := config.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{fmt.Sprintf(":%d", config.ApiPort)}
+	}
+
 	return &ApiServer{
-		apiPort:     config.ApiPort,
-		tlsCertFile: config.TLSCertFile,
-		tlsKeyFile:  config.TLSKeyFile,
-		enableTLS:   config.EnableTLS,
+		listenAddresses: listenAddresses,
+		tlsCertFile:     config.TLSCertFile,
+		tlsKeyFile:      config.TLSKeyFile,
+		tlsClientCAFile: config.TLSClientCAFile,
+		enableTLS:       config.EnableTLS,
+		maxBodySize:     config.MaxRequestBodySize,
+		headerTimeout:   config.ReadHeaderTimeout,
+		idleTimeout:     config.IdleTimeout,
+		tcpKeepAlive:    config.TCPKeepAlive,
+		previewAddress:  config.PreviewListenAddress,
+		previewCerts:    config.PreviewCertificates,
+		debugAddress:    config.DebugListenAddress,
 	}
 }
 
 type ApiServer struct {
-	apiPort     int
-	tlsCertFile string
-	tlsKeyFile  string
-	enableTLS   bool
-	httpServer  *http.Server
-	router      *gin.Engine
+	listenAddresses []string
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
+	enableTLS       bool
+	maxBodySize     int64
+	headerTimeout   time.Duration
+	idleTimeout     time.Duration
+	tcpKeepAlive    time.Duration
+	previewAddress  string
+	previewCerts    *previewtls.Manager
+	debugAddress    string
+	httpServer      *http.Server
+	previewServer   *http.Server
+	debugServer     *http.Server
+	router          *gin.Engine
+	previewRouter   *gin.Engine
 }
 
 func (a *ApiServer) Start() error {
@@ -65,11 +111,6 @@ func (a *ApiServer) Start() error {
 	docs.SwaggerInfo.Title = "Daytona Runner API"
 	docs.SwaggerInfo.BasePath = "/"
 
-	_, err := net.Dial("tcp", fmt.Sprintf(":%d", a.apiPort))
-	if err == nil {
-		return fmt.Errorf("cannot start API server, port %d is already in use", a.apiPort)
-	}
-
 	binding.Validator = new(DefaultValidator)
 
 	a.router = gin.New()
@@ -80,18 +121,25 @@ func (a *ApiServer) Start() error {
 		gin.SetMode(gin.DebugMode)
 	}
 
+	a.router.Use(middlewares.TracingMiddleware())
 	a.router.Use(middlewares.LoggingMiddleware())
 	a.router.Use(middlewares.ErrorMiddleware())
 
 	public := a.router.Group("/")
 	public.GET("", controllers.HealthCheck)
+	public.GET("/health", controllers.HealthStatus)
 
 	if config.GetEnvironment() == "development" {
 		public.GET("/api/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 	}
 
 	protected := a.router.Group("/")
+	protected.Use(middlewares.BodyLimitMiddleware(a.maxBodySize))
+	protected.Use(middlewares.AuditMiddleware())
 	protected.Use(middlewares.AuthMiddleware())
+	protected.Use(middlewares.RateLimitMiddleware())
+	protected.Use(middlewares.DrainMiddleware())
+	protected.Use(middlewares.IdempotencyMiddleware())
 
 	metricsController := public.Group("/metrics")
 	{
@@ -103,60 +151,391 @@ func (a *ApiServer) Start() error {
 		infoController.GET("", controllers.RunnerInfo)
 	}
 
+	eventsController := protected.Group("/events")
+	{
+		eventsController.GET("", controllers.StreamEvents)
+	}
+
+	drainController := protected.Group("/drain")
+	{
+		drainController.GET("", controllers.DrainStatus)
+		drainController.POST("", controllers.Drain)
+	}
+
+	maintenanceController := protected.Group("/maintenance")
+	{
+		maintenanceController.GET("", controllers.GetMaintenance)
+		maintenanceController.POST("", controllers.ScheduleMaintenance)
+		maintenanceController.DELETE("", controllers.CancelMaintenance)
+	}
+
+	cleanupController := protected.Group("/cleanup")
+	{
+		cleanupController.POST("", controllers.Cleanup)
+	}
+
+	cacheController := protected.Group("/cache")
+	{
+		cacheController.GET("", controllers.ListCacheEntries)
+		cacheController.GET("/:sandboxId", controllers.GetCacheEntry)
+		cacheController.DELETE("/:sandboxId", controllers.DeleteCacheEntry)
+	}
+
+	securityProfileController := protected.Group("/security-profiles")
+	{
+		securityProfileController.GET("", controllers.ListSecurityProfiles)
+		securityProfileController.POST("", controllers.UploadSecurityProfile)
+		securityProfileController.DELETE("/:name", controllers.DeleteSecurityProfile)
+	}
+
+	tokenController := protected.Group("/tokens")
+	{
+		tokenController.POST("", controllers.CreateScopedToken)
+	}
+
+	operationController := protected.Group("/operations")
+	{
+		operationController.GET("/:operationId", controllers.GetOperation)
+		operationController.GET("/:operationId/wait", controllers.WaitOperation)
+		operationController.POST("/:operationId/cancel", controllers.CancelOperation)
+	}
+
+	usageController := protected.Group("/usage")
+	{
+		usageController.GET("", controllers.GetUsageRecords)
+	}
+
+	debugController := protected.Group("/debug")
+	{
+		debugController.GET("/goroutines", controllers.DumpGoroutines)
+		debugController.GET("/log-level", controllers.GetLogLevels)
+		debugController.POST("/log-level", controllers.SetLogLevel)
+	}
+
 	sandboxController := protected.Group("/sandboxes")
 	{
 		sandboxController.POST("", controllers.Create)
+		sandboxController.GET("", controllers.ListSandboxes)
 		sandboxController.GET("/:sandboxId", controllers.Info)
 		sandboxController.POST("/:sandboxId/destroy", controllers.Destroy)
 		sandboxController.POST("/:sandboxId/start", controllers.Start)
 		sandboxController.POST("/:sandboxId/stop", controllers.Stop)
+		sandboxController.POST("/:sandboxId/pause", controllers.Pause)
+		sandboxController.POST("/:sandboxId/resume", controllers.Resume)
+		sandboxController.POST("/:sandboxId/checkpoint", controllers.Checkpoint)
+		sandboxController.POST("/:sandboxId/checkpoint/restore", controllers.RestoreCheckpoint)
 		sandboxController.POST("/:sandboxId/backup", controllers.CreateBackup)
+		sandboxController.POST("/:sandboxId/backup/restore", controllers.RestoreBackup)
+		sandboxController.POST("/:sandboxId/snapshot", controllers.CreateSnapshotFromSandbox)
+		sandboxController.POST("/:sandboxId/clone", controllers.CloneSandbox)
+		sandboxController.POST("/:sandboxId/migrate", controllers.MigrateSandbox)
+		sandboxController.POST("/:sandboxId/migrate/receive", controllers.ReceiveMigration)
 		sandboxController.POST("/:sandboxId/resize", controllers.Resize)
 		sandboxController.DELETE("/:sandboxId", controllers.RemoveDestroyed)
 		sandboxController.POST("/:sandboxId/network-settings", controllers.UpdateNetworkSettings)
+		sandboxController.GET("/:sandboxId/env", controllers.GetSandboxEnv)
+		sandboxController.POST("/:sandboxId/env", controllers.UpdateSandboxEnv)
+		sandboxController.POST("/:sandboxId/secrets", controllers.UpdateSandboxSecrets)
+		sandboxController.GET("/:sandboxId/logs", controllers.GetSandboxLogs)
+		sandboxController.GET("/:sandboxId/hooks/logs", controllers.GetLifecycleHookLogs)
+		sandboxController.GET("/:sandboxId/processes", controllers.ListSandboxProcesses)
+		sandboxController.POST("/:sandboxId/processes/:pid/signal", controllers.SignalProcess)
+		sandboxController.GET("/:sandboxId/ports", controllers.ListExposedPorts)
+		sandboxController.POST("/:sandboxId/ports", controllers.ExposePort)
+		sandboxController.DELETE("/:sandboxId/ports/:port", controllers.UnexposePort)
+		sandboxController.POST("/:sandboxId/files/upload", controllers.UploadFile)
+		sandboxController.GET("/:sandboxId/files/download", controllers.DownloadFile)
+		sandboxController.POST("/:sandboxId/proxy-token", controllers.RefreshProxyToken)
+		sandboxController.POST("/:sandboxId/ssh-access", controllers.CreateSshAccess)
+		sandboxController.DELETE("/:sandboxId/ssh-access/:accessId", controllers.RevokeSshAccess)
+	}
 
-		// Add proxy endpoint within the sandbox controller for toolbox
+	// The toolbox proxy and TCP tunnels also accept sandbox scoped proxy tokens so they are registered outside the protected group
+	toolboxController := a.router.Group("/sandboxes")
+	toolboxController.Use(middlewares.ProxyAuthMiddleware())
+	toolboxController.Use(middlewares.ProxyAccessMiddleware())
+	toolboxController.Use(middlewares.MigrationRedirectMiddleware())
+	toolboxController.Use(middlewares.DrainMiddleware())
+	toolboxController.Use(middlewares.ActivityMiddleware())
+	{
 		// Using Any() to handle all HTTP methods for the toolbox proxy
-		sandboxController.Any("/:sandboxId/toolbox/*path", controllers.ProxyRequest)
+		toolboxController.Any("/:sandboxId/toolbox/*path", controllers.ProxyRequest)
+		toolboxController.GET("/:sandboxId/tunnel/:port", controllers.TunnelTCP)
+		toolboxController.Any("/:sandboxId/ports/:port/*path", middlewares.ProxyCacheMiddleware(), controllers.ProxyPortRequest)
+	}
+
+	composeController := protected.Group("/compose")
+	{
+		composeController.POST("", controllers.CreateCompose)
+		composeController.GET("/:sandboxId", controllers.ComposeInfo)
+		composeController.POST("/:sandboxId/start", controllers.StartCompose)
+		composeController.POST("/:sandboxId/stop", controllers.StopCompose)
+		composeController.POST("/:sandboxId/destroy", controllers.DestroyCompose)
 	}
 
 	snapshotController := protected.Group("/snapshots")
 	{
 		snapshotController.POST("/pull", controllers.PullSnapshot)
 		snapshotController.POST("/build", controllers.BuildSnapshot)
+		snapshotController.GET("/build/status", controllers.GetSnapshotBuildStatus)
+		snapshotController.POST("/push", controllers.PushSnapshot)
 		snapshotController.GET("/exists", controllers.SnapshotExists)
+		snapshotController.GET("/info", controllers.SnapshotInfo)
+		snapshotController.POST("/scan", controllers.ScanSnapshot)
 		snapshotController.POST("/remove", controllers.RemoveSnapshot)
+		snapshotController.POST("/prune", controllers.PruneSnapshots)
+		snapshotController.POST("/prewarm", controllers.PrewarmSnapshots)
+		snapshotController.GET("/prewarm", controllers.GetPrewarmStatus)
+		snapshotController.POST("/unpin", controllers.UnpinSnapshot)
 		snapshotController.GET("/logs", controllers.GetBuildLogs)
+		snapshotController.GET("/export", controllers.ExportSnapshot)
+		snapshotController.POST("/export", controllers.ExportSnapshotToStorage)
+		snapshotController.POST("/import", controllers.ImportSnapshot)
+		snapshotController.POST("/import/remote", controllers.ImportRemoteSnapshot)
 	}
 
+	volumeController := protected.Group("/volumes")
+	{
+		volumeController.POST("", controllers.CreateVolume)
+		volumeController.GET("", controllers.ListVolumes)
+		volumeController.GET("/:volumeName", controllers.InspectVolume)
+		volumeController.DELETE("/:volumeName", controllers.RemoveVolume)
+		volumeController.POST("/:volumeName/sync", controllers.SyncVolume)
+		volumeController.GET("/:volumeName/sync", controllers.GetVolumeSyncStatus)
+	}
+
+	a.previewRouter = gin.New()
+	a.previewRouter.Use(gin.Recovery())
+	a.previewRouter.Use(middlewares.TracingMiddleware())
+	a.previewRouter.Use(middlewares.LoggingMiddleware())
+	a.previewRouter.Use(middlewares.ErrorMiddleware())
+	a.previewRouter.Use(middlewares.PreviewHostMiddleware())
+	a.previewRouter.Use(middlewares.DrainMiddleware())
+	a.previewRouter.Use(middlewares.ActivityMiddleware())
+	a.previewRouter.Use(middlewares.PreviewAuthMiddleware())
+	a.previewRouter.Use(middlewares.ProxyAccessMiddleware())
+	a.previewRouter.Use(middlewares.ProxyCacheMiddleware())
+	a.previewRouter.Any("/*path", controllers.ProxyPortRequest)
+
 	a.httpServer = &http.Server{
-		Addr:    fmt.Sprintf(":%d", a.apiPort),
-		Handler: a.router,
+		Handler:           otelhttp.NewHandler(a.getHandler(), "runner-api"),
+		ReadHeaderTimeout: a.headerTimeout,
+		IdleTimeout:       a.idleTimeout,
 	}
 
-	listener, err := net.Listen("tcp", a.httpServer.Addr)
-	if err != nil {
-		return err
+	if a.enableTLS {
+		tlsConfig, err := a.getTLSConfig()
+		if err != nil {
+			return err
+		}
+		a.httpServer.TLSConfig = tlsConfig
+	}
+
+	listeners := make([]net.Listener, 0, len(a.listenAddresses))
+	for _, address := range a.listenAddresses {
+		listener, err := a.listen(address)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+		listeners = append(listeners, listener)
+	}
+
+	var previewListener net.Listener
+	if a.previewAddress != "" {
+		var err error
+		previewListener, err = a.listen(a.previewAddress)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+
+		a.previewServer = &http.Server{
+			Handler:           otelhttp.NewHandler(a.getPreviewHandler(), "runner-preview"),
+			ReadHeaderTimeout: a.headerTimeout,
+			IdleTimeout:       a.idleTimeout,
+		}
+		if a.previewCerts != nil {
+			a.previewServer.TLSConfig = a.previewCerts.TLSConfig()
+		}
 	}
 
-	errChan := make(chan error)
-	go func() {
-		if a.enableTLS {
-			// Start HTTPS server
-			errChan <- a.httpServer.ServeTLS(listener, a.tlsCertFile, a.tlsKeyFile)
-		} else {
-			// Start HTTP server
-			errChan <- a.httpServer.Serve(listener)
+	var debugListener net.Listener
+	if a.debugAddress != "" {
+		var err error
+		debugListener, err = a.listen(a.debugAddress)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			if previewListener != nil {
+				previewListener.Close()
+			}
+			return err
 		}
-	}()
+
+		// No write timeout, CPU profiles and traces stream for as long as requested
+		a.debugServer = &http.Server{
+			Handler:           getDebugHandler(),
+			ReadHeaderTimeout: a.headerTimeout,
+		}
+
+		log.Infof("Serving pprof and expvar on %s", a.debugAddress)
+	}
+
+	errChan := make(chan error, len(listeners)+2)
+	for _, listener := range listeners {
+		go func() {
+			// Unix sockets are only reachable locally and are protected by file permissions instead of TLS
+			if a.enableTLS && listener.Addr().Network() == "tcp" {
+				// Start HTTPS server
+				errChan <- a.httpServer.ServeTLS(listener, a.tlsCertFile, a.tlsKeyFile)
+			} else {
+				// Start HTTP server
+				errChan <- a.httpServer.Serve(listener)
+			}
+		}()
+	}
+
+	if previewListener != nil {
+		go func() {
+			// The certificate comes from the TLS config, a preview listener without one sits behind a TLS terminating load balancer
+			if a.previewServer.TLSConfig != nil {
+				errChan <- a.previewServer.ServeTLS(previewListener, "", "")
+			} else {
+				errChan <- a.previewServer.Serve(previewListener)
+			}
+		}()
+	}
+
+	if debugListener != nil {
+		go func() {
+			errChan <- a.debugServer.Serve(debugListener)
+		}()
+	}
 
 	return <-errChan
 }
 
+func (a *ApiServer) listen(address string) (net.Listener, error) {
+	socketPath, ok := strings.CutPrefix(address, "unix://")
+	if !ok {
+		_, err := net.Dial("tcp", address)
+		if err == nil {
+			return nil, fmt.Errorf("cannot start API server, address %s is already in use", address)
+		}
+
+		listenConfig := net.ListenConfig{KeepAlive: a.tcpKeepAlive}
+		return listenConfig.Listen(context.Background(), "tcp", address)
+	}
+
+	// Remove the socket left behind by a previous run, but never a regular file
+	info, err := os.Lstat(socketPath)
+	if err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("cannot start API server, %s exists and is not a socket", socketPath)
+		}
+		err = os.Remove(socketPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(socketPath), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Chmod(socketPath, 0660)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// getTLSConfig requires and verifies client certificates against the configured CA when one is set
+func (a *ApiServer) getTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if a.tlsClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	caCert, err := os.ReadFile(a.tlsClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS client CA file: %w", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse TLS client CA file %s", a.tlsClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return tlsConfig, nil
+}
+
 func (a *ApiServer) Stop() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := a.httpServer.Shutdown(ctx); err != nil {
 		log.Error(err)
 	}
+	if a.previewServer != nil {
+		if err := a.previewServer.Shutdown(ctx); err != nil {
+			log.Error(err)
+		}
+	}
+	if a.debugServer != nil {
+		if err := a.debugServer.Shutdown(ctx); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// getHandler sends requests for preview hostnames to the preview router, everything else to the API router
+func (a *ApiServer) getHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, ok := services.ParsePreviewHost(r.Host, config.GetPreviewDomain())
+		if ok {
+			a.previewRouter.ServeHTTP(w, r)
+			return
+		}
+
+		a.router.ServeHTTP(w, r)
+	})
+}
+
+// getPreviewHandler only serves preview hostnames. Over TLS the hostname has to match the SNI the certificate
+// was selected for, so a connection for one sandbox port can't be reused for requests to another.
+func (a *ApiServer) getPreviewHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			host := r.Host
+			if hostname, _, err := net.SplitHostPort(host); err == nil {
+				host = hostname
+			}
+			if !strings.EqualFold(host, r.TLS.ServerName) {
+				http.Error(w, "host does not match the TLS server name", http.StatusMisdirectedRequest)
+				return
+			}
+		}
+
+		a.previewRouter.ServeHTTP(w, r)
+	})
 }
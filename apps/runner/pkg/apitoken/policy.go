@@ -0,0 +1,95 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apitoken
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Routes keyed with this method require the scope for every method
+const anyMethod = "ANY"
+
+// defaultRoutePolicy holds the routes that don't follow the read for GET, write otherwise rule
+var defaultRoutePolicy = map[string]string{
+	"ANY /sandboxes/:sandboxId/toolbox/*path": ScopeToolbox,
+	"GET /sandboxes/:sandboxId/tunnel/:port":  ScopeToolbox,
+	// Downloads expose sandbox and snapshot content, not just state
+	"GET /sandboxes/:sandboxId/files/download": ScopeWrite,
+	"GET /snapshots/export":                    ScopeWrite,
+	"POST /drain":                              ScopeAdmin,
+	"POST /maintenance":                        ScopeAdmin,
+	"DELETE /maintenance":                      ScopeAdmin,
+	"POST /cleanup":                            ScopeAdmin,
+	"ANY /cache":                               ScopeAdmin,
+	"ANY /cache/:sandboxId":                    ScopeAdmin,
+	"ANY /security-profiles":                   ScopeAdmin,
+	"ANY /security-profiles/:name":             ScopeAdmin,
+	"POST /tokens":                             ScopeAdmin,
+	"GET /debug/goroutines":                    ScopeAdmin,
+	"ANY /debug/log-level":                     ScopeAdmin,
+}
+
+// Policy maps every route to the scope a token needs to call it
+type Policy struct {
+	routes map[string]string
+}
+
+// NewPolicy returns the default policy with the overrides applied on top of it
+func NewPolicy(overrides map[string]string) *Policy {
+	routes := make(map[string]string, len(defaultRoutePolicy)+len(overrides))
+	for route, scope := range defaultRoutePolicy {
+		routes[route] = scope
+	}
+	for route, scope := range overrides {
+		routes[route] = scope
+	}
+
+	return &Policy{
+		routes: routes,
+	}
+}
+
+// RequiredScope returns the scope required to call the route, routes without a policy
+// entry require the read scope for GET and HEAD requests and the write scope otherwise
+func (p *Policy) RequiredScope(method string, route string) string {
+	scope, ok := p.routes[method+" "+route]
+	if ok {
+		return scope
+	}
+
+	scope, ok = p.routes[anyMethod+" "+route]
+	if ok {
+		return scope
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		return ScopeRead
+	}
+
+	return ScopeWrite
+}
+
+// ParsePolicy parses entries in the "METHOD /route=scope" format, e.g. "GET /snapshots/logs=admin"
+func ParsePolicy(entries []string) (map[string]string, error) {
+	routes := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		route, scope, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route scope %q", entry)
+		}
+
+		scope = strings.TrimSpace(scope)
+		if !slices.Contains(AllScopes, scope) {
+			return nil, fmt.Errorf("unknown scope in route scope %q", entry)
+		}
+
+		routes[strings.Join(strings.Fields(route), " ")] = scope
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apitoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ScopeRead allows reading sandbox, snapshot and volume state, logs and events
+	ScopeRead = "read"
+	// ScopeWrite allows creating, changing and removing resources
+	ScopeWrite = "write"
+	// ScopeToolbox allows reaching sandboxes through the toolbox proxy and tunnels
+	ScopeToolbox = "toolbox"
+	// ScopeAdmin allows managing the runner itself, e.g. draining it or issuing scoped tokens
+	ScopeAdmin = "admin"
+)
+
+var AllScopes = []string{ScopeRead, ScopeWrite, ScopeToolbox, ScopeAdmin}
+
+// Scoped tokens are prefixed so they are never mistaken for the runner API token
+const scopedTokenPrefix = "scoped."
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+type Claims struct {
+	// Subject names the holder of the token, e.g. "log-viewer"
+	Subject   string   `json:"sub,omitempty"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+}
+
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// Authenticator accepts the runner API tokens, which hold every scope, and scoped tokens signed with them.
+// Scoped tokens have the "scoped.<base64url(claims)>.<base64url(HMAC-SHA256(base64url(claims)))>" format
+// and an API token as the HMAC key, so the control plane can issue them without calling the runner.
+type Authenticator struct {
+	tokensFile string
+
+	mutex    sync.RWMutex
+	apiToken string
+	// tokens holds the tokens of the tokens file followed by the API token, the first one signs issued tokens
+	tokens      []string
+	fileModTime time.Time
+}
+
+// NewAuthenticator accepts the API token and the tokens listed in the tokens file, either can be empty
+func NewAuthenticator(apiToken string, tokensFile string) (*Authenticator, error) {
+	a := &Authenticator{
+		apiToken:   apiToken,
+		tokensFile: tokensFile,
+	}
+
+	err := a.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *Authenticator) Authenticate(token string) (*Claims, error) {
+	a.mutex.RLock()
+	tokens := a.tokens
+	a.mutex.RUnlock()
+
+	for _, apiToken := range tokens {
+		if hmac.Equal([]byte(token), []byte(apiToken)) {
+			return &Claims{Scopes: AllScopes}, nil
+		}
+	}
+
+	scopedToken, found := strings.CutPrefix(token, scopedTokenPrefix)
+	if !found {
+		return nil, ErrInvalidToken
+	}
+
+	encodedPayload, signature, found := strings.Cut(scopedToken, ".")
+	if !found {
+		return nil, ErrInvalidToken
+	}
+
+	validSignature := false
+	for _, apiToken := range tokens {
+		if hmac.Equal([]byte(signature), []byte(sign(apiToken, encodedPayload))) {
+			validSignature = true
+			break
+		}
+	}
+	if !validSignature {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var c Claims
+	err = json.Unmarshal(payload, &c)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if c.ExpiresAt != 0 && time.Now().Unix() >= c.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &c, nil
+}
+
+// Issue signs a scoped token with the claims, tokens without an expiry stay valid until the signing API token is removed
+func (a *Authenticator) Issue(c Claims) (string, error) {
+	for _, scope := range c.Scopes {
+		if !slices.Contains(AllScopes, scope) {
+			return "", fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	a.mutex.RLock()
+	signingToken := a.tokens[0]
+	a.mutex.RUnlock()
+
+	return scopedTokenPrefix + encodedPayload + "." + sign(signingToken, encodedPayload), nil
+}
+
+func sign(apiToken string, encodedPayload string) string {
+	mac := hmac.New(sha256.New, []byte(apiToken))
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
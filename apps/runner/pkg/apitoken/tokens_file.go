@@ -0,0 +1,157 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apitoken
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Secret mounts are updated by swapping symlinks, polling the file is the only reliable way to notice it
+const tokensFilePollInterval = 10 * time.Second
+
+// Reload re-reads the tokens file. The current tokens are kept if the file can't be read or lists no tokens,
+// so a broken rotation never locks out the control plane.
+func (a *Authenticator) Reload() error {
+	tokens := make([]string, 0)
+	modTime := time.Time{}
+
+	if a.tokensFile != "" {
+		info, err := os.Stat(a.tokensFile)
+		if err != nil {
+			return fmt.Errorf("failed to read API tokens file: %w", err)
+		}
+		modTime = info.ModTime()
+
+		tokens, err = readTokensFile(a.tokensFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.mutex.RLock()
+	apiToken := a.apiToken
+	a.mutex.RUnlock()
+
+	if apiToken != "" {
+		tokens = append(tokens, apiToken)
+	}
+
+	if len(tokens) == 0 {
+		return errors.New("no API tokens configured")
+	}
+
+	a.mutex.Lock()
+	a.tokens = tokens
+	a.fileModTime = modTime
+	a.mutex.Unlock()
+
+	return nil
+}
+
+// SetApiToken replaces the API token accepted next to the tokens of the tokens file
+func (a *Authenticator) SetApiToken(apiToken string) error {
+	a.mutex.Lock()
+	previous := a.apiToken
+	a.apiToken = apiToken
+	a.mutex.Unlock()
+
+	err := a.Reload()
+	if err != nil {
+		a.mutex.Lock()
+		a.apiToken = previous
+		a.mutex.Unlock()
+	}
+
+	return err
+}
+
+// StartWatching reloads the tokens file when it changes or the runner receives SIGHUP
+func (a *Authenticator) StartWatching(ctx context.Context) {
+	if a.tokensFile == "" {
+		return
+	}
+
+	go func() {
+		hangupChannel := make(chan os.Signal, 1)
+		signal.Notify(hangupChannel, syscall.SIGHUP)
+		defer signal.Stop(hangupChannel)
+
+		ticker := time.NewTicker(tokensFilePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-hangupChannel:
+				a.reloadAndLog()
+			case <-ticker.C:
+				if a.tokensFileChanged() {
+					a.reloadAndLog()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (a *Authenticator) reloadAndLog() {
+	err := a.Reload()
+	if err != nil {
+		log.Errorf("Failed to reload API tokens, keeping the current tokens: %v", err)
+		return
+	}
+
+	a.mutex.RLock()
+	count := len(a.tokens)
+	a.mutex.RUnlock()
+
+	log.Infof("Reloaded API tokens, %d tokens are valid", count)
+}
+
+func (a *Authenticator) tokensFileChanged() bool {
+	info, err := os.Stat(a.tokensFile)
+	if err != nil {
+		log.Warnf("Failed to stat API tokens file: %v", err)
+		return false
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return !info.ModTime().Equal(a.fileModTime)
+}
+
+// readTokensFile reads one token per line, empty lines and lines starting with # are ignored
+func readTokensFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API tokens file: %w", err)
+	}
+	defer file.Close()
+
+	tokens := make([]string, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		token := strings.TrimSpace(scanner.Text())
+		if token == "" || strings.HasPrefix(token, "#") {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read API tokens file: %w", err)
+	}
+
+	return tokens, nil
+}
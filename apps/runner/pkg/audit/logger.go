@@ -0,0 +1,202 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+type Entry struct {
+	Time           time.Time         `json:"time"`
+	OrganizationId string            `json:"organizationId,omitempty"`
+	TokenSubject   string            `json:"tokenSubject,omitempty"`
+	ClientIp       string            `json:"clientIp"`
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	Path           string            `json:"path"`
+	SandboxId      string            `json:"sandboxId,omitempty"`
+	Snapshot       string            `json:"snapshot,omitempty"`
+	Params         map[string]string `json:"params,omitempty"`
+	BodyFields     []string          `json:"bodyFields,omitempty"` // Top level fields of the JSON body, values are never logged
+	Status         int               `json:"status"`
+	Error          string            `json:"error,omitempty"`
+	DurationMs     int64             `json:"durationMs"`
+}
+
+type LoggerConfig struct {
+	FilePath string
+	// ShipInterval is how often the log is rotated and uploaded to object storage, 0 keeps it local
+	ShipInterval time.Duration
+	ObjectPrefix string
+}
+
+// Logger appends audit entries to a JSONL file. The file is only ever appended to,
+// shipping rotates it first so uploaded logs are never modified afterwards.
+type Logger struct {
+	filePath     string
+	shipInterval time.Duration
+	objectPrefix string
+
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func NewLogger(config LoggerConfig) (*Logger, error) {
+	err := os.MkdirAll(filepath.Dir(config.FilePath), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := openLogFile(config.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Logger{
+		filePath:     config.FilePath,
+		shipInterval: config.ShipInterval,
+		objectPrefix: config.ObjectPrefix,
+		file:         file,
+	}, nil
+}
+
+func (l *Logger) Log(entry Entry) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		log.Errorf("Failed to serialize audit entry: %v", err)
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err = l.file.Write(append(raw, '\n'))
+	if err != nil {
+		log.Errorf("Failed to write audit entry: %v", err)
+	}
+}
+
+// StartShipping uploads the audit log to object storage on every ship interval
+func (l *Logger) StartShipping(ctx context.Context) {
+	if l.shipInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(l.shipInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := l.ship(ctx)
+				if err != nil {
+					log.Errorf("Failed to ship audit log: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// ship rotates the log and uploads every rotated log, logs that failed to upload are retried on the next run
+func (l *Logger) ship(ctx context.Context) error {
+	err := l.rotate()
+	if err != nil {
+		return err
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	rotated, err := filepath.Glob(l.filePath + ".*")
+	if err != nil {
+		return err
+	}
+
+	for _, rotatedPath := range rotated {
+		err = uploadLog(ctx, storageClient, rotatedPath, path.Join(l.objectPrefix, hostname, filepath.Base(rotatedPath)))
+		if err != nil {
+			return err
+		}
+
+		err = os.Remove(rotatedPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Logger) rotate() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	info, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		return nil
+	}
+
+	err = l.file.Close()
+	if err != nil {
+		return err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", l.filePath, time.Now().UTC().Format("20060102T150405Z"))
+	renameErr := os.Rename(l.filePath, rotatedPath)
+
+	// Keep logging even if the rename failed
+	l.file, err = openLogFile(l.filePath)
+	if err != nil {
+		return err
+	}
+
+	return renameErr
+}
+
+func uploadLog(ctx context.Context, storageClient storage.ObjectStorageClient, filePath string, objectPath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return storageClient.PutObjectStream(ctx, objectPath, file, info.Size())
+}
+
+func openLogFile(filePath string) (*os.File, error) {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return file, nil
+}
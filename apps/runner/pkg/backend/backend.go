@@ -0,0 +1,87 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// BACKEND_DOCKER is the only backend the runner implements, containerd is not supported
+const BACKEND_DOCKER = "docker"
+
+// SandboxBackend runs sandboxes, sandbox IDs are the container names
+type SandboxBackend interface {
+	Create(ctx context.Context, sandboxDto dto.CreateSandboxDTO) (string, error)
+	Start(ctx context.Context, sandboxId string) error
+	Stop(ctx context.Context, sandboxId string, stopDto dto.StopSandboxDTO) (*dto.StopSandboxResponseDTO, error)
+	Destroy(ctx context.Context, sandboxId string) error
+	Pause(ctx context.Context, sandboxId string) error
+	Resume(ctx context.Context, sandboxId string) error
+	Resize(ctx context.Context, sandboxId string, sandboxDto dto.ResizeSandboxDTO) error
+	DeduceSandboxState(ctx context.Context, sandboxId string) (enums.SandboxState, error)
+	GetSandboxRuntime(ctx context.Context, sandboxId string) (string, error)
+}
+
+// SnapshotBackend stores the snapshots sandboxes are created from
+type SnapshotBackend interface {
+	PullImage(ctx context.Context, imageName string, reg *dto.RegistryDTO, platform string) error
+	BuildImage(ctx context.Context, buildImageDto dto.BuildSnapshotRequestDTO) error
+	PushImage(ctx context.Context, imageName string, reg *dto.RegistryDTO) error
+	TagImage(ctx context.Context, sourceImage string, targetImage string) error
+	RemoveImage(ctx context.Context, imageName string, force bool) error
+	ImageExists(ctx context.Context, imageName string, includeLatest bool) (bool, error)
+	GetImageInfo(ctx context.Context, imageName string) (*dto.SnapshotInfoResponse, error)
+	GetBuildStatus(snapshotRef string) (dto.SnapshotBuildStatusDTO, bool)
+	WaitForBuild(ctx context.Context, snapshotRef string) (dto.SnapshotBuildStatusDTO, error)
+	PushSnapshot(ctx context.Context, snapshot string, targetImage string, reg *dto.RegistryDTO) error
+	CreateSnapshotFromSandbox(ctx context.Context, sandboxId string, snapshot string, squash bool) (string, error)
+}
+
+// ComposeBackend runs sandboxes made of several services
+type ComposeBackend interface {
+	CreateCompose(ctx context.Context, composeDto dto.CreateComposeSandboxDTO) error
+	GetComposeInfo(ctx context.Context, sandboxId string) (*dto.ComposeSandboxInfoResponse, error)
+	StartCompose(ctx context.Context, sandboxId string) error
+	StopCompose(ctx context.Context, sandboxId string) error
+	DestroyCompose(ctx context.Context, sandboxId string) error
+}
+
+// StateBackend copies the state of sandboxes, to checkpoints, backups, clones and other runners
+type StateBackend interface {
+	Checkpoint(ctx context.Context, sandboxId string, checkpointDto dto.CheckpointSandboxDTO) error
+	RestoreCheckpoint(ctx context.Context, sandboxId string, restoreDto dto.RestoreCheckpointDTO) error
+	StartBackupCreate(ctx context.Context, sandboxId string, backupDto dto.CreateBackupDTO) error
+	StartBackupRestore(ctx context.Context, sandboxId string, restoreDto dto.RestoreBackupDTO) error
+	CloneSandbox(ctx context.Context, sourceId string, cloneDto dto.CloneSandboxDTO) (string, error)
+	ExportSandbox(ctx context.Context, sandboxId string, objectPath string, live bool, ports []dto.ExposePortDTO) (*docker.SandboxMigration, error)
+	ResumeExportedSandbox(ctx context.Context, sandboxId string, migration *docker.SandboxMigration)
+	ReadSandboxMigration(ctx context.Context, objectPath string) (*docker.SandboxMigration, error)
+	ImportSandbox(ctx context.Context, objectPath string, migration *docker.SandboxMigration) error
+}
+
+// Backend is the container engine the sandbox, snapshot, compose and sandbox state APIs run on. Docker is the
+// only implementation, the runner refuses to start with another backend configured.
+type Backend interface {
+	SandboxBackend
+	SnapshotBackend
+	ComposeBackend
+	StateBackend
+}
+
+var _ Backend = (*docker.DockerClient)(nil)
+
+// New returns the backend selected in the configuration
+func New(name string, dockerClient *docker.DockerClient) (Backend, error) {
+	switch name {
+	case "", BACKEND_DOCKER:
+		return dockerClient, nil
+	default:
+		return nil, fmt.Errorf("unknown container backend %s", name)
+	}
+}
@@ -4,10 +4,13 @@
 package cache
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/daytonaio/runner/pkg/common"
 	"github.com/daytonaio/runner/pkg/models"
 	"github.com/daytonaio/runner/pkg/models/enums"
 )
@@ -15,41 +18,52 @@ import (
 type IRunnerCache interface {
 	SetSandboxState(ctx context.Context, sandboxId string, state enums.SandboxState)
 	SetBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error)
+	SetSandboxResources(ctx context.Context, sandboxId string, resources models.SandboxResources)
+	SetPullQueuePosition(ctx context.Context, sandboxId string, position int)
+	SetBackupProgress(ctx context.Context, sandboxId string, progress *models.BackupProgress)
+	SetExpiration(ctx context.Context, sandboxId string, expiration *models.SandboxExpiration)
+	SetDaemonHealth(ctx context.Context, sandboxId string, health enums.DaemonHealth)
+	SetRepositoryStatus(ctx context.Context, sandboxId string, status *models.RepositoryStatus)
 	SetSystemMetrics(ctx context.Context, metrics models.SystemMetrics)
 	GetSystemMetrics(ctx context.Context) *models.SystemMetrics
 
 	Set(ctx context.Context, sandboxId string, data models.CacheData)
 	Get(ctx context.Context, sandboxId string) *models.CacheData
 	Remove(ctx context.Context, sandboxId string)
+	// Delete drops the entry, unlike Remove which keeps the destroyed state until the retention ends
+	Delete(ctx context.Context, sandboxId string)
 	List(ctx context.Context) []string
 	Cleanup(ctx context.Context)
 }
 
+// Store system metrics under a special key
+const systemMetricsKey = "__system_metrics__"
+
 type InMemoryRunnerCacheConfig struct {
-	Cache         map[string]*models.CacheData
-	RetentionDays int
+	Cache    map[string]*models.CacheData
+	Eviction EvictionPolicy
 }
 
 type InMemoryRunnerCache struct {
-	mutex         sync.RWMutex
-	cache         map[string]*models.CacheData
-	retentionDays int
+	mutex  sync.RWMutex
+	cache  map[string]*models.CacheData
+	policy EvictionPolicy
+	// activity of the entries the memory bound evicts by, it is not persisted
+	activity map[string]entryActivity
+	// onChange is called with the write lock held for every entry that is changed or dropped
+	onChange func(sandboxId string)
 }
 
 func NewInMemoryRunnerCache(config InMemoryRunnerCacheConfig) IRunnerCache {
-	retentionDays := config.RetentionDays
-	if retentionDays <= 0 {
-		retentionDays = 7
-	}
-
 	cache := config.Cache
 	if cache == nil {
 		cache = make(map[string]*models.CacheData)
 	}
 
 	return &InMemoryRunnerCache{
-		cache:         cache,
-		retentionDays: config.RetentionDays,
+		cache:    cache,
+		policy:   config.Eviction.withDefaults(),
+		activity: make(map[string]entryActivity),
 	}
 }
 
@@ -65,11 +79,11 @@ func (c *InMemoryRunnerCache) SetSandboxState(ctx context.Context, sandboxId str
 			DestructionTime: nil,
 			SystemMetrics:   nil,
 		}
-	} else {
-		data.SandboxState = state
 	}
+	c.setState(data, state)
 
 	c.cache[sandboxId] = data
+	c.touch(sandboxId)
 }
 
 func (c *InMemoryRunnerCache) SetBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error) {
@@ -94,14 +108,145 @@ func (c *InMemoryRunnerCache) SetBackupState(ctx context.Context, sandboxId stri
 	}
 
 	c.cache[sandboxId] = data
+	c.touch(sandboxId)
 }
 
-func (c *InMemoryRunnerCache) SetSystemMetrics(ctx context.Context, metrics models.SystemMetrics) {
+func (c *InMemoryRunnerCache) SetSandboxResources(ctx context.Context, sandboxId string, resources models.SandboxResources) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStateUnknown,
+			BackupState:     enums.BackupStateNone,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+			Resources:       &resources,
+		}
+	} else {
+		data.Resources = &resources
+	}
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+// SetPullQueuePosition records the 1-based position of the sandbox in the pull queue, 0 clears it
+func (c *InMemoryRunnerCache) SetPullQueuePosition(ctx context.Context, sandboxId string, position int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var pullQueuePosition *int
+	if position > 0 {
+		pullQueuePosition = &position
+	}
+
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		if pullQueuePosition == nil {
+			return
+		}
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStatePullingSnapshot,
+			BackupState:     enums.BackupStateNone,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+		}
+	}
+	data.PullQueuePosition = pullQueuePosition
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+// SetBackupProgress records the progress of a running backup or restore, nil clears it
+func (c *InMemoryRunnerCache) SetBackupProgress(ctx context.Context, sandboxId string, progress *models.BackupProgress) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// Store system metrics under a special key
-	const systemMetricsKey = "__system_metrics__"
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		if progress == nil {
+			return
+		}
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStateUnknown,
+			BackupState:     enums.BackupStateInProgress,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+		}
+	}
+	data.BackupProgress = progress
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+// SetExpiration schedules the stop or destruction of the sandbox, nil clears it
+func (c *InMemoryRunnerCache) SetExpiration(ctx context.Context, sandboxId string, expiration *models.SandboxExpiration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		if expiration == nil {
+			return
+		}
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStateUnknown,
+			BackupState:     enums.BackupStateNone,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+		}
+	}
+	data.Expiration = expiration
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+// SetDaemonHealth records the health of the daemon, sandboxes only known to the cache are left alone
+func (c *InMemoryRunnerCache) SetDaemonHealth(ctx context.Context, sandboxId string, health enums.DaemonHealth) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		return
+	}
+	data.DaemonHealth = health
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+// SetRepositoryStatus records the progress or the outcome of the repository clone, nil clears it
+func (c *InMemoryRunnerCache) SetRepositoryStatus(ctx context.Context, sandboxId string, status *models.RepositoryStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, ok := c.cache[sandboxId]
+	if !ok {
+		if status == nil {
+			return
+		}
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStateCreating,
+			BackupState:     enums.BackupStateNone,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+		}
+	}
+	data.Repository = status
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+func (c *InMemoryRunnerCache) SetSystemMetrics(ctx context.Context, metrics models.SystemMetrics) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
 	data, ok := c.cache[systemMetricsKey]
 	if !ok {
@@ -122,8 +267,6 @@ func (c *InMemoryRunnerCache) GetSystemMetrics(ctx context.Context) *models.Syst
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	const systemMetricsKey = "__system_metrics__"
-
 	data, ok := c.cache[systemMetricsKey]
 	if !ok || data.SystemMetrics == nil {
 		return nil
@@ -137,11 +280,18 @@ func (c *InMemoryRunnerCache) Set(ctx context.Context, sandboxId string, data mo
 	defer c.mutex.Unlock()
 
 	c.cache[sandboxId] = &models.CacheData{
-		SandboxState:    data.SandboxState,
-		BackupState:     data.BackupState,
-		DestructionTime: data.DestructionTime,
-		SystemMetrics:   data.SystemMetrics,
+		SandboxState:      data.SandboxState,
+		BackupState:       data.BackupState,
+		DestructionTime:   data.DestructionTime,
+		SystemMetrics:     data.SystemMetrics,
+		Resources:         data.Resources,
+		Expiration:        data.Expiration,
+		PullQueuePosition: data.PullQueuePosition,
+		BackupProgress:    data.BackupProgress,
+		DaemonHealth:      data.DaemonHealth,
+		Repository:        data.Repository,
 	}
+	c.touch(sandboxId)
 }
 
 func (c *InMemoryRunnerCache) Get(ctx context.Context, sandboxId string) *models.CacheData {
@@ -165,21 +315,37 @@ func (c *InMemoryRunnerCache) Remove(ctx context.Context, sandboxId string) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	destructionTime := time.Now().Add(time.Duration(c.retentionDays) * 24 * time.Hour)
-	c.cache[sandboxId] = &models.CacheData{
+	data := &models.CacheData{
 		SandboxState:    enums.SandboxStateDestroyed,
 		BackupState:     enums.BackupStateNone,
-		DestructionTime: &destructionTime,
+		DestructionTime: nil,
 		SystemMetrics:   nil,
 	}
+	c.startRetention(data, time.Now())
+
+	c.cache[sandboxId] = data
+	c.touch(sandboxId)
+}
+
+func (c *InMemoryRunnerCache) Delete(ctx context.Context, sandboxId string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.cache, sandboxId)
+	delete(c.activity, sandboxId)
+	c.changed(sandboxId)
 }
 
+// List returns the IDs of all cached sandboxes
 func (c *InMemoryRunnerCache) List(ctx context.Context) []string {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
 	keys := make([]string, 0, len(c.cache))
 	for k := range c.cache {
+		if k == systemMetricsKey {
+			continue
+		}
 		keys = append(keys, k)
 	}
 
@@ -188,8 +354,7 @@ func (c *InMemoryRunnerCache) List(ctx context.Context) []string {
 
 func (c *InMemoryRunnerCache) Cleanup(ctx context.Context) {
 	go func() {
-		// Run cleanup every hour
-		ticker := time.NewTicker(1 * time.Hour)
+		ticker := time.NewTicker(c.policy.CleanupInterval)
 		defer ticker.Stop()
 
 		for {
@@ -203,14 +368,141 @@ func (c *InMemoryRunnerCache) Cleanup(ctx context.Context) {
 	}()
 }
 
+// cleanupExpiredEntries drops the entries past their destruction time and the least active ones over the bound
 func (c *InMemoryRunnerCache) cleanupExpiredEntries() {
+	start := time.Now()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	now := time.Now()
+	expired := 0
 	for id, data := range c.cache {
-		if data.DestructionTime != nil && (now.After(*data.DestructionTime) || now.Equal(*data.DestructionTime)) {
+		if id == systemMetricsKey {
+			continue
+		}
+
+		// Entries created in a state with retention or loaded from before it was set start their retention now
+		if data.DestructionTime == nil {
+			c.startRetention(data, now)
+			if data.DestructionTime != nil {
+				c.changed(id)
+			}
+		}
+
+		if data.DestructionTime != nil && !now.Before(*data.DestructionTime) {
 			delete(c.cache, id)
+			delete(c.activity, id)
+			c.changed(id)
+			expired++
+		}
+	}
+	common.CacheEvictionCount.WithLabelValues("retention").Add(float64(expired))
+
+	c.evictLeastActive(now)
+
+	entriesByState := make(map[enums.SandboxState]int)
+	for id, data := range c.cache {
+		if id != systemMetricsKey {
+			entriesByState[data.SandboxState]++
 		}
 	}
+	common.CacheEntryCount.Reset()
+	for state, count := range entriesByState {
+		common.CacheEntryCount.WithLabelValues(state.String()).Set(float64(count))
+	}
+
+	common.CacheCleanupDuration.Observe(time.Since(start).Seconds())
+}
+
+// setState changes the state of the entry, the retention of the new state starts when the state changes
+func (c *InMemoryRunnerCache) setState(data *models.CacheData, state enums.SandboxState) {
+	if data.SandboxState != state {
+		data.DestructionTime = nil
+	}
+	data.SandboxState = state
+
+	c.startRetention(data, time.Now())
+}
+
+// startRetention schedules the destruction of an entry in a state with retention, an entry that is scheduled
+// already keeps its destruction time
+func (c *InMemoryRunnerCache) startRetention(data *models.CacheData, now time.Time) {
+	if data.DestructionTime != nil {
+		return
+	}
+
+	retention, ok := c.policy.Retention[data.SandboxState]
+	if !ok {
+		return
+	}
+
+	destructionTime := now.Add(retention)
+	data.DestructionTime = &destructionTime
+}
+
+// touch records a write to the entry and enforces the bound once a new entry outgrows it, the caller holds the
+// write lock
+func (c *InMemoryRunnerCache) touch(sandboxId string) {
+	now := time.Now()
+	c.changed(sandboxId)
+
+	activity := c.activity[sandboxId]
+	c.activity[sandboxId] = entryActivity{
+		score: activity.decayed(now, c.policy.ActivityHalfLife) + 1,
+		at:    now,
+	}
+
+	if activity.at.IsZero() {
+		c.evictLeastActive(now)
+	}
+}
+
+// evictLeastActive drops the least active entries scheduled for destruction until the cache is a tenth below
+// the bound, so the entries aren't sorted again on every new one. Entries of live sandboxes are never evicted,
+// they carry state the container doesn't, e.g. expirations, and their number is bounded by the host anyway.
+func (c *InMemoryRunnerCache) evictLeastActive(now time.Time) {
+	if c.policy.MaxEntries <= 0 || len(c.cache) <= c.policy.MaxEntries {
+		return
+	}
+
+	type candidate struct {
+		id    string
+		score float64
+	}
+
+	candidates := make([]candidate, 0)
+	for id, data := range c.cache {
+		if id == systemMetricsKey || data.DestructionTime == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			id:    id,
+			score: c.activity[id].decayed(now, c.policy.ActivityHalfLife),
+		})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.score, b.score)
+	})
+
+	target := c.policy.MaxEntries - c.policy.MaxEntries/10
+	evicted := 0
+	for _, candidate := range candidates {
+		if len(c.cache) <= target {
+			break
+		}
+		delete(c.cache, candidate.id)
+		delete(c.activity, candidate.id)
+		c.changed(candidate.id)
+		evicted++
+	}
+
+	common.CacheEvictionCount.WithLabelValues("memory_bound").Add(float64(evicted))
+}
+
+func (c *InMemoryRunnerCache) changed(sandboxId string) {
+	if c.onChange != nil {
+		c.onChange(sandboxId)
+	}
 }
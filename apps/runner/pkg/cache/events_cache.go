@@ -0,0 +1,87 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cache
+
+import (
+	"context"
+
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// EventRunnerCache wraps a cache and publishes sandbox and backup state transitions
+type EventRunnerCache struct {
+	IRunnerCache
+	broker *events.Broker
+}
+
+func NewEventRunnerCache(cache IRunnerCache, broker *events.Broker) IRunnerCache {
+	return &EventRunnerCache{
+		IRunnerCache: cache,
+		broker:       broker,
+	}
+}
+
+func (c *EventRunnerCache) SetSandboxState(ctx context.Context, sandboxId string, state enums.SandboxState) {
+	// Copy the previous state before the cached entry is updated in place
+	previousState := enums.SandboxStateUnknown
+	if data := c.Get(ctx, sandboxId); data != nil {
+		previousState = data.SandboxState
+	}
+
+	c.IRunnerCache.SetSandboxState(ctx, sandboxId, state)
+
+	if previousState == state {
+		return
+	}
+
+	c.broker.Publish(events.Event{
+		Type:      enums.EventTypeSandboxStateChanged,
+		SandboxId: sandboxId,
+		State:     state.String(),
+	})
+}
+
+func (c *EventRunnerCache) SetBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error) {
+	previousState := enums.BackupStateNone
+	if data := c.Get(ctx, sandboxId); data != nil {
+		previousState = data.BackupState
+	}
+
+	c.IRunnerCache.SetBackupState(ctx, sandboxId, state, err)
+
+	if previousState == state && err == nil {
+		return
+	}
+
+	event := events.Event{
+		Type:      enums.EventTypeBackupStateChanged,
+		SandboxId: sandboxId,
+		State:     state.String(),
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+
+	c.broker.Publish(event)
+}
+
+func (c *EventRunnerCache) SetDaemonHealth(ctx context.Context, sandboxId string, health enums.DaemonHealth) {
+	previousHealth := enums.DaemonHealthUnknown
+	if data := c.Get(ctx, sandboxId); data != nil && data.DaemonHealth != "" {
+		previousHealth = data.DaemonHealth
+	}
+
+	c.IRunnerCache.SetDaemonHealth(ctx, sandboxId, health)
+
+	if previousHealth == health {
+		return
+	}
+
+	c.broker.Publish(events.Event{
+		Type:      enums.EventTypeDaemonHealthChanged,
+		SandboxId: sandboxId,
+		State:     health.String(),
+	})
+}
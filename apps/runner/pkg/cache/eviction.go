@@ -0,0 +1,105 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cache
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// defaultRetention keeps the entries of destroyed sandboxes for a week, entries in other states are kept
+var defaultRetention = map[enums.SandboxState]time.Duration{
+	enums.SandboxStateDestroyed: 7 * 24 * time.Hour,
+}
+
+var retainableStates = []enums.SandboxState{
+	enums.SandboxStateCreating,
+	enums.SandboxStateRestoring,
+	enums.SandboxStateDestroyed,
+	enums.SandboxStateDestroying,
+	enums.SandboxStateStarted,
+	enums.SandboxStateStopped,
+	enums.SandboxStateStarting,
+	enums.SandboxStateStopping,
+	enums.SandboxStateResizing,
+	enums.SandboxStatePausing,
+	enums.SandboxStatePaused,
+	enums.SandboxStateResuming,
+	enums.SandboxStateError,
+	enums.SandboxStateUnknown,
+	enums.SandboxStatePullingSnapshot,
+}
+
+// EvictionPolicy decides how long cache entries are kept and which are dropped first once the cache is full
+type EvictionPolicy struct {
+	// Retention is how long entries are kept after the sandbox entered the state, entries in states without
+	// retention are kept until the state changes
+	Retention map[enums.SandboxState]time.Duration
+	// MaxEntries bounds the number of entries, 0 leaves the cache unbounded
+	MaxEntries int
+	// ActivityHalfLife is the time after which the writes to an entry count half when picking entries to evict
+	ActivityHalfLife time.Duration
+	// CleanupInterval between removals of expired entries
+	CleanupInterval time.Duration
+}
+
+func (p EvictionPolicy) withDefaults() EvictionPolicy {
+	if p.Retention == nil {
+		p.Retention = maps.Clone(defaultRetention)
+	}
+	if p.ActivityHalfLife <= 0 {
+		p.ActivityHalfLife = time.Hour
+	}
+	if p.CleanupInterval <= 0 {
+		p.CleanupInterval = time.Hour
+	}
+
+	return p
+}
+
+// ParseRetention parses entries in the "state=duration" format, e.g. "error=72h", on top of the default retention
+func ParseRetention(entries []string) (map[enums.SandboxState]time.Duration, error) {
+	retention := maps.Clone(defaultRetention)
+
+	for _, entry := range entries {
+		state, rawDuration, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid cache retention %q", entry)
+		}
+
+		sandboxState := enums.SandboxState(strings.TrimSpace(state))
+		if !slices.Contains(retainableStates, sandboxState) {
+			return nil, fmt.Errorf("unknown sandbox state in cache retention %q", entry)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(rawDuration))
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("invalid duration in cache retention %q", entry)
+		}
+
+		retention[sandboxState] = duration
+	}
+
+	return retention, nil
+}
+
+// entryActivity counts the writes to an entry, halved every half-life so recent writes outweigh old ones
+type entryActivity struct {
+	score float64
+	at    time.Time
+}
+
+func (a entryActivity) decayed(now time.Time, halfLife time.Duration) float64 {
+	if a.at.IsZero() {
+		return 0
+	}
+
+	return a.score * math.Exp2(-float64(now.Sub(a.at))/float64(halfLife))
+}
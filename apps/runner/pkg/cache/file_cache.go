@@ -0,0 +1,339 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+)
+
+var (
+	cacheBucket = []byte("sandboxes")
+	metaBucket  = []byte("meta")
+	versionKey  = []byte("version")
+)
+
+// cacheSchemaVersion is the version of the layout of the cache entries, bump it when models.CacheData changes in a
+// way older entries can't be read as
+const cacheSchemaVersion = 1
+
+type FileRunnerCacheConfig struct {
+	FilePath string
+	// FlushInterval batches the writes, changes are written every interval and on close instead of as they
+	// happen. Changes since the last flush are lost on a crash, the reconciliation on startup corrects them.
+	FlushInterval time.Duration
+	Eviction      EvictionPolicy
+}
+
+// FileRunnerCache keeps the cache in memory and persists the changed entries to a BoltDB file so sandbox and
+// backup states survive runner restarts. Every entry is its own key, a change only rewrites the entries it touched.
+type FileRunnerCache struct {
+	*InMemoryRunnerCache
+	db            *bolt.DB
+	flushInterval time.Duration
+	// dirty holds the IDs of the entries changed since the last flush, it is guarded by the mutex of the cache
+	dirty      map[string]struct{}
+	flushMutex sync.Mutex
+}
+
+func NewFileRunnerCache(config FileRunnerCacheConfig) (*FileRunnerCache, error) {
+	if config.FilePath == "" {
+		return nil, errors.New("cache file path is required")
+	}
+
+	err := os.MkdirAll(filepath.Dir(config.FilePath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	db, err := openCacheFile(config.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureCacheSchema(db, config.FilePath)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	data, err := readCacheEntries(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	inMemoryCache := NewInMemoryRunnerCache(InMemoryRunnerCacheConfig{
+		Cache:    data,
+		Eviction: config.Eviction,
+	}).(*InMemoryRunnerCache)
+
+	log.Infof("Loaded %d cache entries from %s", len(data), config.FilePath)
+
+	cache := &FileRunnerCache{
+		InMemoryRunnerCache: inMemoryCache,
+		db:                  db,
+		flushInterval:       config.FlushInterval,
+		dirty:               make(map[string]struct{}),
+	}
+	inMemoryCache.onChange = cache.markDirty
+
+	return cache, nil
+}
+
+func (c *FileRunnerCache) SetSandboxState(ctx context.Context, sandboxId string, state enums.SandboxState) {
+	c.InMemoryRunnerCache.SetSandboxState(ctx, sandboxId, state)
+	c.persist()
+}
+
+func (c *FileRunnerCache) SetBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error) {
+	c.InMemoryRunnerCache.SetBackupState(ctx, sandboxId, state, err)
+	c.persist()
+}
+
+func (c *FileRunnerCache) SetSandboxResources(ctx context.Context, sandboxId string, resources models.SandboxResources) {
+	c.InMemoryRunnerCache.SetSandboxResources(ctx, sandboxId, resources)
+	c.persist()
+}
+
+func (c *FileRunnerCache) SetExpiration(ctx context.Context, sandboxId string, expiration *models.SandboxExpiration) {
+	c.InMemoryRunnerCache.SetExpiration(ctx, sandboxId, expiration)
+	c.persist()
+}
+
+func (c *FileRunnerCache) Set(ctx context.Context, sandboxId string, data models.CacheData) {
+	c.InMemoryRunnerCache.Set(ctx, sandboxId, data)
+	c.persist()
+}
+
+func (c *FileRunnerCache) Remove(ctx context.Context, sandboxId string) {
+	c.InMemoryRunnerCache.Remove(ctx, sandboxId)
+	c.persist()
+}
+
+func (c *FileRunnerCache) Delete(ctx context.Context, sandboxId string) {
+	c.InMemoryRunnerCache.Delete(ctx, sandboxId)
+	c.persist()
+}
+
+func (c *FileRunnerCache) Cleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.policy.CleanupInterval)
+		defer ticker.Stop()
+
+		// Stays nil and never fires when changes are written as they happen
+		var flushChan <-chan time.Time
+		if c.flushInterval > 0 {
+			flushTicker := time.NewTicker(c.flushInterval)
+			defer flushTicker.Stop()
+			flushChan = flushTicker.C
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				c.cleanupExpiredEntries()
+				c.persist()
+			case <-flushChan:
+				c.flush()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close writes the pending changes and closes the cache file
+func (c *FileRunnerCache) Close() error {
+	c.flush()
+	return c.db.Close()
+}
+
+// persist writes the changes right away unless they are flushed on an interval
+func (c *FileRunnerCache) persist() {
+	if c.flushInterval <= 0 {
+		c.flush()
+	}
+}
+
+// markDirty is called with the cache locked for writing
+func (c *FileRunnerCache) markDirty(sandboxId string) {
+	c.dirty[sandboxId] = struct{}{}
+}
+
+// flush writes the entries changed since the last flush in one transaction and drops the removed ones
+func (c *FileRunnerCache) flush() {
+	// Held until the transaction commits so an older version of an entry never overwrites a newer one
+	c.flushMutex.Lock()
+	defer c.flushMutex.Unlock()
+
+	c.mutex.Lock()
+	if len(c.dirty) == 0 {
+		c.mutex.Unlock()
+		return
+	}
+
+	entries := make(map[string][]byte, len(c.dirty))
+	var errs []error
+	for id := range c.dirty {
+		data, ok := c.cache[id]
+		if !ok {
+			entries[id] = nil
+			continue
+		}
+
+		raw, err := json.Marshal(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to serialize cache entry %s: %w", id, err))
+			continue
+		}
+		entries[id] = raw
+	}
+	dirty := c.dirty
+	c.dirty = make(map[string]struct{})
+	c.mutex.Unlock()
+
+	if len(errs) > 0 {
+		log.Error(errors.Join(errs...))
+	}
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(cacheBucket)
+		if err != nil {
+			return err
+		}
+
+		for id, raw := range entries {
+			if raw == nil {
+				err = bucket.Delete([]byte(id))
+			} else {
+				err = bucket.Put([]byte(id), raw)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Failed to write cache file: %v", err)
+
+		// The entries are written again with the next flush
+		c.mutex.Lock()
+		for id := range dirty {
+			c.dirty[id] = struct{}{}
+		}
+		c.mutex.Unlock()
+	}
+}
+
+// openCacheFile opens the cache file. The runner starts with an empty cache rather than not at all, a damaged
+// file is moved aside for inspection.
+func openCacheFile(filePath string) (*bolt.DB, error) {
+	// The timeout fails the startup instead of blocking when another runner holds the file
+	options := &bolt.Options{Timeout: 5 * time.Second}
+
+	db, err := bolt.Open(filePath, 0600, options)
+	if err == nil {
+		return db, nil
+	}
+
+	if !errors.Is(err, bolterrors.ErrInvalid) && !errors.Is(err, bolterrors.ErrChecksum) && !errors.Is(err, bolterrors.ErrVersionMismatch) {
+		return nil, fmt.Errorf("failed to open cache file %s: %w", filePath, err)
+	}
+
+	corruptFilePath := fmt.Sprintf("%s.corrupt-%d", filePath, time.Now().Unix())
+	renameErr := os.Rename(filePath, corruptFilePath)
+	if renameErr != nil {
+		return nil, fmt.Errorf("failed to move damaged cache file %s aside: %w", filePath, renameErr)
+	}
+	log.Warnf("Cache file %s is damaged, moved to %s and starting with an empty cache: %v", filePath, corruptFilePath, err)
+
+	db, err = bolt.Open(filePath, 0600, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache file %s: %w", filePath, err)
+	}
+
+	return db, nil
+}
+
+// ensureCacheSchema writes the schema version into a new cache file. The entries of a file written with another
+// version, or before versions were written, are dropped and left to the reconciliation on startup.
+func ensureCacheSchema(db *bolt.DB, filePath string) error {
+	err := db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+
+		version := meta.Get(versionKey)
+		if string(version) == strconv.Itoa(cacheSchemaVersion) {
+			return nil
+		}
+
+		if tx.Bucket(cacheBucket) != nil {
+			if version == nil {
+				log.Warnf("Cache file %s has no schema version, this runner reads version %d, dropping its entries", filePath, cacheSchemaVersion)
+			} else {
+				log.Warnf("Cache file %s has schema version %s, this runner reads version %d, dropping its entries", filePath, version, cacheSchemaVersion)
+			}
+
+			err = tx.DeleteBucket(cacheBucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		return meta.Put(versionKey, []byte(strconv.Itoa(cacheSchemaVersion)))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check the schema version of cache file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// readCacheEntries loads the cached entries, entries that can't be parsed are skipped and left to the
+// reconciliation on startup
+func readCacheEntries(db *bolt.DB) (map[string]*models.CacheData, error) {
+	data := make(map[string]*models.CacheData)
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(cacheBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, value []byte) error {
+			var entry models.CacheData
+			err := json.Unmarshal(value, &entry)
+			if err != nil {
+				log.Warnf("Skipping damaged cache entry %s: %v", key, err)
+				return nil
+			}
+
+			data[string(key)] = &entry
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	return data, nil
+}
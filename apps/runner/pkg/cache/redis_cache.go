@@ -0,0 +1,389 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const (
+	// Updates that keep losing the race against other runners are given up after this many attempts
+	redisMaxUpdateAttempts = 10
+	// System metrics are recollected every few seconds, stale metrics of a stopped runner expire
+	redisSystemMetricsTTL = 10 * time.Minute
+)
+
+type RedisRunnerCacheConfig struct {
+	Url string
+	// KeyPrefix separates the entries of runners sharing a Redis, runners sharing state use the same prefix
+	KeyPrefix string
+	// Retention by sandbox state, entries expire through key TTLs while Redis bounds its memory itself
+	Retention map[enums.SandboxState]time.Duration
+}
+
+// RedisRunnerCache keeps the cache in Redis so several runners, e.g. replicas on the same host or a hot
+// standby, share it. Entries are updated with WATCH and MULTI so concurrent updates of an entry are retried
+// instead of lost, entries in states with retention expire through key TTLs instead of the cleanup. Redis errors are logged,
+// reads fail like a missing entry.
+type RedisRunnerCache struct {
+	client    *redisClient
+	keyPrefix string
+	retention map[enums.SandboxState]time.Duration
+}
+
+// redisEntry also holds the fields of the cache data that are not persisted by the file cache, runners sharing
+// the cache need them as well
+type redisEntry struct {
+	models.CacheData
+	PullQueuePosition *int                     `json:"pullQueuePosition,omitempty"`
+	BackupProgress    *models.BackupProgress   `json:"backupProgress,omitempty"`
+	DaemonHealth      enums.DaemonHealth       `json:"daemonHealth,omitempty"`
+	Repository        *models.RepositoryStatus `json:"repository,omitempty"`
+}
+
+func NewRedisRunnerCache(ctx context.Context, config RedisRunnerCacheConfig) (IRunnerCache, error) {
+	if config.Url == "" {
+		return nil, errors.New("cache Redis URL is required")
+	}
+
+	client, err := newRedisClient(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.do(ctx, "PING")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisRunnerCache{
+		client:    client,
+		keyPrefix: config.KeyPrefix,
+		retention: EvictionPolicy{Retention: config.Retention}.withDefaults().Retention,
+	}, nil
+}
+
+func (c *RedisRunnerCache) SetSandboxState(ctx context.Context, sandboxId string, state enums.SandboxState) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetSandboxState(ctx, sandboxId, state)
+	})
+}
+
+func (c *RedisRunnerCache) SetBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetBackupState(ctx, sandboxId, state, err)
+	})
+}
+
+func (c *RedisRunnerCache) SetSandboxResources(ctx context.Context, sandboxId string, resources models.SandboxResources) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetSandboxResources(ctx, sandboxId, resources)
+	})
+}
+
+func (c *RedisRunnerCache) SetPullQueuePosition(ctx context.Context, sandboxId string, position int) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetPullQueuePosition(ctx, sandboxId, position)
+	})
+}
+
+func (c *RedisRunnerCache) SetBackupProgress(ctx context.Context, sandboxId string, progress *models.BackupProgress) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetBackupProgress(ctx, sandboxId, progress)
+	})
+}
+
+func (c *RedisRunnerCache) SetExpiration(ctx context.Context, sandboxId string, expiration *models.SandboxExpiration) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetExpiration(ctx, sandboxId, expiration)
+	})
+}
+
+func (c *RedisRunnerCache) SetDaemonHealth(ctx context.Context, sandboxId string, health enums.DaemonHealth) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetDaemonHealth(ctx, sandboxId, health)
+	})
+}
+
+func (c *RedisRunnerCache) SetRepositoryStatus(ctx context.Context, sandboxId string, status *models.RepositoryStatus) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.SetRepositoryStatus(ctx, sandboxId, status)
+	})
+}
+
+func (c *RedisRunnerCache) SetSystemMetrics(ctx context.Context, metrics models.SystemMetrics) {
+	raw, err := json.Marshal(metrics)
+	if err != nil {
+		log.Errorf("Failed to serialize system metrics: %v", err)
+		return
+	}
+
+	_, err = c.client.do(ctx, "SET", c.keyPrefix+systemMetricsKey, string(raw), "PX", strconv.FormatInt(redisSystemMetricsTTL.Milliseconds(), 10))
+	if err != nil {
+		log.Errorf("Failed to store system metrics in Redis: %v", err)
+	}
+}
+
+func (c *RedisRunnerCache) GetSystemMetrics(ctx context.Context) *models.SystemMetrics {
+	reply, err := c.client.do(ctx, "GET", c.keyPrefix+systemMetricsKey)
+	if err != nil {
+		if !errors.Is(err, errRedisNil) {
+			log.Errorf("Failed to read system metrics from Redis: %v", err)
+		}
+		return nil
+	}
+
+	var metrics models.SystemMetrics
+	err = json.Unmarshal([]byte(reply.(string)), &metrics)
+	if err != nil {
+		log.Errorf("Failed to parse system metrics from Redis: %v", err)
+		return nil
+	}
+
+	return &metrics
+}
+
+func (c *RedisRunnerCache) Set(ctx context.Context, sandboxId string, data models.CacheData) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.Set(ctx, sandboxId, data)
+	})
+}
+
+func (c *RedisRunnerCache) Get(ctx context.Context, sandboxId string) *models.CacheData {
+	data, err := c.read(ctx, c.getSandboxKey(sandboxId))
+	if err != nil && !errors.Is(err, errRedisNil) {
+		log.Errorf("Failed to read cache entry of sandbox %s from Redis: %v", sandboxId, err)
+	}
+
+	if data == nil {
+		data = &models.CacheData{
+			SandboxState:    enums.SandboxStateUnknown,
+			BackupState:     enums.BackupStateNone,
+			DestructionTime: nil,
+			SystemMetrics:   nil,
+		}
+	}
+
+	return data
+}
+
+func (c *RedisRunnerCache) Remove(ctx context.Context, sandboxId string) {
+	c.update(ctx, sandboxId, func(cache IRunnerCache) {
+		cache.Remove(ctx, sandboxId)
+	})
+}
+
+func (c *RedisRunnerCache) Delete(ctx context.Context, sandboxId string) {
+	_, err := c.client.do(ctx, "DEL", c.getSandboxKey(sandboxId))
+	if err != nil {
+		log.Errorf("Failed to delete cache entry of sandbox %s from Redis: %v", sandboxId, err)
+	}
+}
+
+// List returns the IDs of all cached sandboxes
+func (c *RedisRunnerCache) List(ctx context.Context) []string {
+	prefix := c.getSandboxKey("")
+
+	ids := []string{}
+	cursor := "0"
+	for {
+		reply, err := c.client.do(ctx, "SCAN", cursor, "MATCH", escapeRedisPattern(prefix)+"*", "COUNT", "500")
+		if err != nil {
+			log.Errorf("Failed to list cache entries in Redis: %v", err)
+			return ids
+		}
+
+		values, ok := reply.([]any)
+		if !ok || len(values) != 2 {
+			log.Errorf("Unexpected SCAN reply from Redis: %v", reply)
+			return ids
+		}
+
+		cursor, _ = values[0].(string)
+		keys, _ := values[1].([]any)
+		for _, key := range keys {
+			if key, ok := key.(string); ok {
+				ids = append(ids, strings.TrimPrefix(key, prefix))
+			}
+		}
+
+		if cursor == "0" || cursor == "" {
+			return ids
+		}
+	}
+}
+
+// Cleanup has nothing to do, entries expire in Redis
+func (c *RedisRunnerCache) Cleanup(ctx context.Context) {
+}
+
+// update applies the change to the entry the way the in-memory cache does, with the stored entry as the only
+// entry of a scratch cache. The entry is watched while the change is applied and written in a transaction that
+// Redis aborts if another runner changed it in the meantime, the change is applied again then.
+func (c *RedisRunnerCache) update(ctx context.Context, sandboxId string, apply func(cache IRunnerCache)) {
+	key := c.getSandboxKey(sandboxId)
+
+	for attempt := 0; attempt < redisMaxUpdateAttempts; attempt++ {
+		done, err := c.tryUpdate(ctx, key, sandboxId, apply)
+		if err != nil {
+			log.Errorf("Failed to update cache entry of sandbox %s in Redis: %v", sandboxId, err)
+			return
+		}
+		if done {
+			return
+		}
+	}
+
+	log.Errorf("Gave up updating cache entry of sandbox %s in Redis after %d conflicting updates", sandboxId, redisMaxUpdateAttempts)
+}
+
+// tryUpdate returns false if the transaction was aborted by a concurrent update
+func (c *RedisRunnerCache) tryUpdate(ctx context.Context, key string, sandboxId string, apply func(cache IRunnerCache)) (bool, error) {
+	conn, err := c.client.get(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var connErr error
+	defer func() {
+		c.client.put(conn, connErr)
+	}()
+
+	_, connErr = conn.do(ctx, "WATCH", key)
+	if connErr != nil {
+		return false, connErr
+	}
+
+	var raw string
+	reply, connErr := conn.do(ctx, "GET", key)
+	if connErr != nil && !errors.Is(connErr, errRedisNil) {
+		return false, connErr
+	}
+	if connErr == nil {
+		raw = reply.(string)
+	}
+	connErr = nil
+
+	scratch := NewInMemoryRunnerCache(InMemoryRunnerCacheConfig{
+		Eviction: EvictionPolicy{Retention: c.retention},
+	}).(*InMemoryRunnerCache)
+	if raw != "" {
+		data, err := decodeRedisEntry(raw)
+		if err != nil {
+			// A damaged entry is replaced by the update
+			log.Warnf("Replacing unreadable cache entry of sandbox %s in Redis: %v", sandboxId, err)
+		} else {
+			scratch.cache[sandboxId] = data
+		}
+	}
+
+	apply(scratch)
+
+	data, ok := scratch.cache[sandboxId]
+	if !ok {
+		_, connErr = conn.do(ctx, "UNWATCH")
+		return true, connErr
+	}
+	// Entries created in a state with retention expire as well
+	scratch.startRetention(data, time.Now())
+
+	updated, err := encodeRedisEntry(data)
+	if err != nil {
+		_, connErr = conn.do(ctx, "UNWATCH")
+		return false, err
+	}
+	if raw != "" && bytes.Equal(updated, []byte(raw)) {
+		_, connErr = conn.do(ctx, "UNWATCH")
+		return true, connErr
+	}
+
+	setArgs := []string{"SET", key, string(updated)}
+	if data.DestructionTime != nil {
+		// Expiring right away would delete the entry before anyone sees it is destroyed
+		ttl := max(time.Until(*data.DestructionTime), time.Minute)
+		setArgs = append(setArgs, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+
+	_, connErr = conn.do(ctx, "MULTI")
+	if connErr != nil {
+		return false, connErr
+	}
+	_, connErr = conn.do(ctx, setArgs...)
+	if connErr != nil {
+		conn.do(ctx, "DISCARD")
+		return false, connErr
+	}
+
+	_, connErr = conn.do(ctx, "EXEC")
+	if errors.Is(connErr, errRedisNil) {
+		connErr = nil
+		return false, nil
+	}
+	if connErr != nil {
+		return false, connErr
+	}
+
+	return true, nil
+}
+
+func (c *RedisRunnerCache) read(ctx context.Context, key string) (*models.CacheData, error) {
+	reply, err := c.client.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRedisEntry(reply.(string))
+}
+
+func (c *RedisRunnerCache) getSandboxKey(sandboxId string) string {
+	return fmt.Sprintf("%ssandbox:%s", c.keyPrefix, sandboxId)
+}
+
+func encodeRedisEntry(data *models.CacheData) ([]byte, error) {
+	return json.Marshal(redisEntry{
+		CacheData:         *data,
+		PullQueuePosition: data.PullQueuePosition,
+		BackupProgress:    data.BackupProgress,
+		DaemonHealth:      data.DaemonHealth,
+		Repository:        data.Repository,
+	})
+}
+
+func decodeRedisEntry(raw string) (*models.CacheData, error) {
+	var entry redisEntry
+	err := json.Unmarshal([]byte(raw), &entry)
+	if err != nil {
+		return nil, err
+	}
+
+	data := entry.CacheData
+	data.PullQueuePosition = entry.PullQueuePosition
+	data.BackupProgress = entry.BackupProgress
+	data.DaemonHealth = entry.DaemonHealth
+	data.Repository = entry.Repository
+
+	return &data, nil
+}
+
+// escapeRedisPattern escapes the glob characters of SCAN patterns
+func escapeRedisPattern(value string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`*?[]\`, r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redisTimeout      = 5 * time.Second
+	redisIdleConns    = 8
+	redisMaxReplySize = 64 * 1024 * 1024
+)
+
+// errRedisNil is the reply to a missing key or an aborted transaction
+var errRedisNil = errors.New("redis: nil")
+
+// redisError is an error reply of the server
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisClient speaks the subset of RESP2 the cache needs. A transaction holds on to its connection, which
+// WATCH requires, so the client hands out connections from a small pool instead of multiplexing them.
+type redisClient struct {
+	address   string
+	username  string
+	password  string
+	db        int
+	tlsConfig *tls.Config
+	idle      chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// newRedisClient accepts redis://[user:password@]host:port[/db] URLs, rediss:// connects with TLS
+func newRedisClient(rawUrl string) (*redisClient, error) {
+	redisUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := &redisClient{
+		address: redisUrl.Host,
+		idle:    make(chan *redisConn, redisIdleConns),
+	}
+
+	switch redisUrl.Scheme {
+	case "redis":
+	case "rediss":
+		client.tlsConfig = &tls.Config{ServerName: redisUrl.Hostname()}
+	default:
+		return nil, fmt.Errorf("invalid Redis URL scheme %s, use redis or rediss", redisUrl.Scheme)
+	}
+
+	if redisUrl.Port() == "" {
+		client.address = net.JoinHostPort(redisUrl.Hostname(), "6379")
+	}
+
+	if redisUrl.User != nil {
+		client.username = redisUrl.User.Username()
+		client.password, _ = redisUrl.User.Password()
+	}
+
+	db := strings.Trim(redisUrl.Path, "/")
+	if db != "" {
+		client.db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis database %s", db)
+		}
+	}
+
+	return client, nil
+}
+
+// do runs a single command on a pooled connection
+func (c *redisClient) do(ctx context.Context, args ...string) (any, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, args...)
+	c.put(conn, err)
+
+	return reply, err
+}
+
+// get returns an idle connection or dials a new one, the caller returns it with put
+func (c *redisClient) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: redisTimeout}
+
+	var conn net.Conn
+	var err error
+	if c.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: c.tlsConfig}).DialContext(ctx, "tcp", c.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", c.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	redisConn := &redisConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+
+	if c.password != "" {
+		args := []string{"AUTH", c.password}
+		if c.username != "" {
+			args = []string{"AUTH", c.username, c.password}
+		}
+		_, err = redisConn.do(ctx, args...)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate to Redis: %w", err)
+		}
+	}
+
+	if c.db != 0 {
+		_, err = redisConn.do(ctx, "SELECT", strconv.Itoa(c.db))
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select Redis database %d: %w", c.db, err)
+		}
+	}
+
+	return redisConn, nil
+}
+
+// put keeps the connection for reuse unless it failed with anything but an error reply, the stream may be
+// out of sync then
+func (c *redisClient) put(conn *redisConn, err error) {
+	var replyErr redisError
+	if err != nil && !errors.Is(err, errRedisNil) && !errors.As(err, &replyErr) {
+		conn.conn.Close()
+		return
+	}
+
+	select {
+	case c.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	err := c.conn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	_, err = io.WriteString(c.conn, command.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.readReply()
+}
+
+// readReply returns a string, an int64, a []any or nil for a nil reply along with errRedisNil
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errRedisNil
+		}
+		if size > redisMaxReplySize {
+			return nil, fmt.Errorf("redis: reply of %d bytes is too large", size)
+		}
+
+		value := make([]byte, size+2)
+		_, err = io.ReadFull(c.reader, value)
+		if err != nil {
+			return nil, err
+		}
+		return string(value[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, errRedisNil
+		}
+
+		values := make([]any, count)
+		for i := range values {
+			values[i], err = c.readReply()
+			if err != nil && !errors.Is(err, errRedisNil) {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
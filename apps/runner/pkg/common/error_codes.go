@@ -0,0 +1,118 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/docker/docker/errdefs"
+)
+
+// Error codes of the API error responses, clients match on them instead of the messages
+const (
+	ErrorCodeBadRequest            = "BAD_REQUEST"
+	ErrorCodeNotFound              = "NOT_FOUND"
+	ErrorCodeConflict              = "CONFLICT"
+	ErrorCodeInternal              = "INTERNAL_SERVER_ERROR"
+	ErrorCodeSandboxNotFound       = "SANDBOX_NOT_FOUND"
+	ErrorCodeImageNotFound         = "IMAGE_NOT_FOUND"
+	ErrorCodeRegistryAuthFailed    = "REGISTRY_AUTH_FAILED"
+	ErrorCodeDiskFull              = "DISK_FULL"
+	ErrorCodeContainerNameConflict = "CONTAINER_NAME_CONFLICT"
+	ErrorCodeDaemonInjectionFailed = "DAEMON_INJECTION_FAILED"
+	ErrorCodeResourceExhausted     = "RESOURCE_EXHAUSTED"
+	ErrorCodeConstraintViolated    = "CONSTRAINT_NOT_SATISFIED"
+	ErrorCodeMaintenance           = "MAINTENANCE_IN_PROGRESS"
+	ErrorCodePrivilegeNotAllowed   = "PRIVILEGE_NOT_ALLOWED"
+)
+
+// MapDockerError maps errors of the Docker engine and registries to a CustomError with the error code of the
+// failure, the original error stays reachable through errors.Is and errdefs. Other errors are returned as is.
+func MapDockerError(err error) error {
+	if err == nil || isRunnerError(err) {
+		return err
+	}
+
+	message := err.Error()
+	lowerMessage := strings.ToLower(message)
+
+	switch {
+	case strings.Contains(lowerMessage, "no space left on device"):
+		return newMappedError(http.StatusInsufficientStorage, fmt.Sprintf("disk full: %s", message), ErrorCodeDiskFull, err)
+	case errdefs.IsUnauthorized(err) || strings.Contains(lowerMessage, "unauthorized") || strings.Contains(lowerMessage, "authentication required"):
+		return newMappedError(http.StatusUnauthorized, fmt.Sprintf("unauthorized: %s", message), ErrorCodeRegistryAuthFailed, err)
+	case errdefs.IsNotFound(err):
+		code := ErrorCodeNotFound
+		if strings.Contains(lowerMessage, "no such container") {
+			code = ErrorCodeSandboxNotFound
+		} else if strings.Contains(lowerMessage, "no such image") || strings.Contains(lowerMessage, "manifest unknown") || strings.Contains(lowerMessage, "pull access denied") {
+			code = ErrorCodeImageNotFound
+		}
+		return newMappedError(http.StatusNotFound, fmt.Sprintf("resource not found: %s", message), code, err)
+	case errdefs.IsConflict(err):
+		code := ErrorCodeConflict
+		if strings.Contains(lowerMessage, "is already in use") {
+			code = ErrorCodeContainerNameConflict
+		}
+		return newMappedError(http.StatusConflict, fmt.Sprintf("conflict: %s", message), code, err)
+	case errdefs.IsInvalidParameter(err):
+		return newMappedError(http.StatusBadRequest, fmt.Sprintf("bad request: %s", message), ErrorCodeBadRequest, err)
+	case errdefs.IsSystem(err) && strings.Contains(message, "unable to find user"):
+		return newMappedError(http.StatusBadRequest, util.ExtractErrorPart(message), ErrorCodeBadRequest, err)
+	}
+
+	return err
+}
+
+// GetErrorCode returns the error code the API responds with for the error
+func GetErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var customErr *CustomError
+	if errors.As(MapDockerError(err), &customErr) {
+		return customErr.Code
+	}
+
+	var notFoundErr *NotFoundError
+	var conflictErr *ConflictError
+	var badRequestErr *BadRequestError
+	var resourceExhaustedErr *ResourceExhaustedError
+	switch {
+	case errors.As(err, &notFoundErr):
+		return ErrorCodeNotFound
+	case errors.As(err, &conflictErr):
+		return ErrorCodeConflict
+	case errors.As(err, &badRequestErr):
+		return ErrorCodeBadRequest
+	case errors.As(err, &resourceExhaustedErr):
+		return ErrorCodeResourceExhausted
+	}
+
+	return ErrorCodeInternal
+}
+
+func newMappedError(statusCode int, message, code string, err error) error {
+	return &CustomError{
+		StatusCode: statusCode,
+		Message:    message,
+		Code:       code,
+		err:        err,
+	}
+}
+
+// isRunnerError reports errors the runner already classified, wrapped ones are still matched by their message
+func isRunnerError(err error) bool {
+	switch err.(type) {
+	case *CustomError, *NotFoundError, *UnauthorizedError, *InvalidBodyRequestError, *ConflictError, *BadRequestError, *ResourceExhaustedError:
+		return true
+	}
+
+	return false
+}
@@ -4,7 +4,9 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -20,18 +22,27 @@ type ErrorResponse struct {
 	Timestamp  time.Time `json:"timestamp" example:"2023-01-01T12:00:00Z" binding:"required"`
 	Path       string    `json:"path" example:"/api/resource" binding:"required"`
 	Method     string    `json:"method" example:"GET" binding:"required"`
+	// Details of the failure, e.g. the image it concerns
+	Details map[string]string `json:"details,omitempty"`
 } //	@name	ErrorResponse
 
 type CustomError struct {
 	StatusCode int
 	Message    string
 	Code       string
+	Details    map[string]string
+	// err is the mapped error
+	err error
 }
 
 func (e *CustomError) Error() string {
 	return e.Message
 }
 
+func (e *CustomError) Unwrap() error {
+	return e.err
+}
+
 func NewCustomError(statusCode int, message, code string) error {
 	return &CustomError{
 		StatusCode: statusCode,
@@ -40,6 +51,53 @@ func NewCustomError(statusCode int, message, code string) error {
 	}
 }
 
+// NewConstraintNotSatisfiedError is returned for sandboxes requiring a runner label this runner doesn't have, the
+// control plane should place the sandbox on another runner
+func NewConstraintNotSatisfiedError(label, required, actual string) error {
+	message := fmt.Sprintf("constraint not satisfied: the sandbox requires runner label %s=%s", label, required)
+	if actual != "" {
+		message += fmt.Sprintf(", the runner has %s=%s", label, actual)
+	}
+
+	return &CustomError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    message,
+		Code:       ErrorCodeConstraintViolated,
+		Details: map[string]string{
+			"label":    label,
+			"required": required,
+			"actual":   actual,
+		},
+	}
+}
+
+// NewMaintenanceInProgressError is returned for new work refused during a maintenance window of the runner
+func NewMaintenanceInProgressError(endsAt time.Time, reason string) error {
+	message := fmt.Sprintf("runner is in maintenance until %s", endsAt.UTC().Format(time.RFC3339))
+	if reason != "" {
+		message += ": " + reason
+	}
+
+	return &CustomError{
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    message,
+		Code:       ErrorCodeMaintenance,
+		Details: map[string]string{
+			"endsAt": endsAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// NewPrivilegeNotAllowedError is returned for sandboxes asking for privileges the privilege policy of the runner
+// doesn't allow
+func NewPrivilegeNotAllowedError(err error) error {
+	return &CustomError{
+		StatusCode: http.StatusForbidden,
+		Message:    err.Error(),
+		Code:       ErrorCodePrivilegeNotAllowed,
+	}
+}
+
 type NotFoundError struct {
 	Message string
 }
@@ -129,3 +187,26 @@ func NewBadRequestError(err error) error {
 func IsBadRequestError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "bad request")
 }
+
+// ResourceExhaustedError is returned when the runner is at its limit for an operation, the caller should retry
+// after RetryAfter
+type ResourceExhaustedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *ResourceExhaustedError) Error() string {
+	return e.Message
+}
+
+func NewResourceExhaustedError(err error, retryAfter time.Duration) error {
+	return &ResourceExhaustedError{
+		Message:    fmt.Sprintf("resource exhausted: %s", err.Error()),
+		RetryAfter: retryAfter,
+	}
+}
+
+func IsResourceExhaustedError(err error) bool {
+	var resourceExhaustedErr *ResourceExhaustedError
+	return errors.As(err, &resourceExhaustedErr)
+}
@@ -36,4 +36,184 @@ var (
 		},
 		[]string{"operation", "status"},
 	)
+
+	// Counter to track retries of registry pulls and pushes
+	RegistryOperationRetryCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "registry_operation_retries_total",
+			Help: "Total number of retried registry operations, status retry for every retry and failure when retries were exhausted",
+		},
+		[]string{"operation", "status"},
+	)
+
+	// Counter to track disk space freed by snapshot garbage collection
+	SnapshotGCReclaimedBytes = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "snapshot_gc_reclaimed_bytes_total",
+			Help: "Total bytes reclaimed by removing unused snapshots",
+		},
+	)
+
+	// Counter to track requests refused by the rate limiter
+	RateLimitedRequestCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_requests_total",
+			Help: "Total number of requests refused because the caller exceeded its rate limit",
+		},
+		[]string{"route"},
+	)
+
+	// Counter to track resources removed by the cleanup policies
+	CleanupRemovedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cleanup_removed_total",
+			Help: "Total number of leftover containers, volumes and networks removed by cleanup",
+		},
+		[]string{"resource"},
+	)
+
+	// Histogram to track latency of requests proxied to sandboxes, port is "toolbox" for the toolbox proxy
+	ProxyRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "proxy_request_duration_seconds",
+			Help:    "Time taken to proxy requests to sandboxes in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"sandbox_id", "port", "method", "code"},
+	)
+
+	// Histogram to track size of responses proxied from sandboxes
+	ProxyResponseSize = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "proxy_response_size_bytes",
+			Help:    "Size of responses proxied from sandboxes in bytes",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 9),
+		},
+		[]string{"sandbox_id", "port"},
+	)
+
+	// Counter to track lookups of proxied requests in the proxy cache
+	ProxyCacheRequestCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_cache_requests_total",
+			Help: "Total number of cacheable proxied requests by whether they were served from the cache",
+		},
+		[]string{"result"},
+	)
+
+	// Counter to track snapshots removed by garbage collection
+	SnapshotGCRemovedCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "snapshot_gc_removed_total",
+			Help: "Total number of unused snapshots removed",
+		},
+	)
+
+	// Gauge to track the sandboxes the warm pool has ready per template
+	WarmPoolReadyCount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "warm_pool_ready_sandboxes",
+			Help: "Number of pool sandboxes ready to be claimed",
+		},
+		[]string{"template"},
+	)
+
+	// Counter to track creates matching a warm pool template by whether a pool sandbox was claimed
+	WarmPoolClaimCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "warm_pool_claims_total",
+			Help: "Total number of creates matching a warm pool template by whether they were served from the pool",
+		},
+		[]string{"template", "result"},
+	)
+
+	// Gauge to track the cache entries per sandbox state, updated on every cache cleanup
+	CacheEntryCount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cache_entries",
+			Help: "Number of runner cache entries by sandbox state",
+		},
+		[]string{"state"},
+	)
+
+	// Counter to track cache entries dropped because their retention ended or the cache outgrew its bound
+	CacheEvictionCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_evictions_total",
+			Help: "Total number of evicted runner cache entries, reason retention or memory_bound",
+		},
+		[]string{"reason"},
+	)
+
+	// Histogram to track duration of cache cleanups
+	CacheCleanupDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "cache_cleanup_duration_seconds",
+			Help:    "Time taken to remove expired runner cache entries in seconds",
+			Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+		},
+	)
+
+	// Counter to track requests answered with the stored response of their idempotency key
+	IdempotentReplayCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "idempotent_replays_total",
+			Help: "Total number of requests answered with the stored response of an earlier request with the same idempotency key",
+		},
+		[]string{"route"},
+	)
+
+	// Gauge to track the operations running under a concurrency limit
+	ConcurrencyLimitInFlight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limit_in_flight",
+			Help: "Number of operations currently holding a slot of the concurrency limit of their type",
+		},
+		[]string{"operation"},
+	)
+
+	// Gauge to track the operations waiting for a slot of a concurrency limit
+	ConcurrencyLimitQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limit_queue_depth",
+			Help: "Number of operations waiting for a slot of the concurrency limit of their type",
+		},
+		[]string{"operation"},
+	)
+
+	// Counter to track operations refused because their concurrency limit was saturated
+	ConcurrencyLimitRejectedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "concurrency_limit_rejected_total",
+			Help: "Total number of operations refused because the concurrency limit of their type was saturated",
+		},
+		[]string{"operation"},
+	)
+
+	// Counter to track log records delivered to the log shipping sink
+	LogShipRecordCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "log_ship_records_total",
+			Help: "Total number of log records delivered to the log shipping sink",
+		},
+		[]string{"sink"},
+	)
+
+	// Counter to track log records dropped because the log shipping buffer was full or the sink failed on shutdown
+	LogShipDroppedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "log_ship_dropped_records_total",
+			Help: "Total number of log records dropped instead of being shipped",
+		},
+		[]string{"sink"},
+	)
+
+	// Counter to track registrations and heartbeats sent to the control plane
+	ControlPlaneRequestCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "control_plane_requests_total",
+			Help: "Total number of registrations and heartbeats sent to the control plane",
+		},
+		[]string{"request", "status"},
+	)
 )
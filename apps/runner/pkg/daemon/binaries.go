@@ -0,0 +1,40 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Architectures the daemon is built for, the runner embeds a binary per architecture
+var Architectures = []string{"amd64", "arm64"}
+
+// WriteDaemonBinaries writes the embedded daemon binary of every architecture and returns their paths by
+// architecture. Architectures the runner was built without are left out.
+func WriteDaemonBinaries() (map[string]string, error) {
+	paths := make(map[string]string)
+
+	for _, arch := range Architectures {
+		path, err := WriteStaticBinary(getBinaryName(arch))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		paths[arch] = path
+	}
+
+	if len(paths) == 0 {
+		return nil, errors.New("the runner was built without a daemon binary")
+	}
+
+	return paths, nil
+}
+
+func getBinaryName(arch string) string {
+	return fmt.Sprintf("daemon-%s", arch)
+}
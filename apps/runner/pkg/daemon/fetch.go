@@ -0,0 +1,146 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package daemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+type FetchConfig struct {
+	Store  storage.ObjectStore
+	Bucket string
+	// Version is the pinned daemon version, binaries are stored under daemon/<version>/daemon-<arch>
+	Version string
+	// Checksums holds the SHA-256 of the binary by architecture, only the architectures listed are fetched
+	Checksums map[string]string
+	// CacheDir keeps the verified binaries so they are only downloaded once per version
+	CacheDir string
+}
+
+// FetchDaemonBinaries downloads the pinned daemon version from object storage and returns the paths of the
+// binaries by architecture. A binary that doesn't match its checksum is never used.
+func FetchDaemonBinaries(ctx context.Context, config FetchConfig) (map[string]string, error) {
+	if !versionPattern.MatchString(config.Version) {
+		return nil, fmt.Errorf("invalid daemon version %q", config.Version)
+	}
+
+	if len(config.Checksums) == 0 {
+		return nil, errors.New("no daemon checksums are pinned")
+	}
+
+	dir := filepath.Join(config.CacheDir, config.Version)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string)
+	for arch, checksum := range config.Checksums {
+		path := filepath.Join(dir, getBinaryName(arch))
+
+		err := fetchBinary(ctx, config, arch, path, strings.ToLower(checksum))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the %s daemon %s: %w", arch, config.Version, err)
+		}
+		paths[arch] = path
+	}
+
+	return paths, nil
+}
+
+// ParseChecksums parses arch=sha256 entries, e.g. amd64=9f86d0...
+func ParseChecksums(entries []string) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	for _, entry := range entries {
+		arch, checksum, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || !slices.Contains(Architectures, arch) {
+			return nil, fmt.Errorf("invalid daemon checksum %q, expected <%s>=<sha256>", entry, strings.Join(Architectures, "|"))
+		}
+
+		raw, err := hex.DecodeString(checksum)
+		if err != nil || len(raw) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 checksum of the %s daemon", arch)
+		}
+		checksums[arch] = strings.ToLower(checksum)
+	}
+
+	return checksums, nil
+}
+
+func fetchBinary(ctx context.Context, config FetchConfig, arch string, path string, checksum string) error {
+	cached, err := getFileChecksum(path)
+	if err == nil && cached == checksum {
+		return nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	key := fmt.Sprintf("daemon/%s/%s", config.Version, getBinaryName(arch))
+	log.Infof("Fetching daemon binary %s", key)
+
+	reader, err := config.Store.GetObject(ctx, config.Bucket, key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), ".daemon-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hash), reader)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if actual != checksum {
+		return fmt.Errorf("checksum mismatch, expected %s, got %s", checksum, actual)
+	}
+
+	err = os.Chmod(tmpFile.Name(), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func getFileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -44,5 +44,12 @@ func WriteStaticBinary(name string) (string, error) {
 		return "", err
 	}
 
+	// The binary is mounted into the sandboxes, root of a remapped user namespace can only run it if it is world
+	// executable regardless of the umask of the runner
+	err = os.Chmod(daemonPath, 0755)
+	if err != nil {
+		return "", err
+	}
+
 	return daemonPath, nil
 }
@@ -9,10 +9,9 @@ import (
 
 	"github.com/daytonaio/runner/pkg/api/dto"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
 
 	cmap "github.com/orcaman/concurrent-map/v2"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type backupContext struct {
@@ -23,55 +22,29 @@ type backupContext struct {
 var backup_context_map = cmap.New[backupContext]()
 
 func (d *DockerClient) StartBackupCreate(ctx context.Context, containerId string, backupDto dto.CreateBackupDTO) error {
-	// Cancel a backup if it's already in progress
-	backup_context, ok := backup_context_map.Get(containerId)
-	if ok {
-		backup_context.cancel()
+	if backupDto.ObjectPath != "" {
+		log.Infof("Creating backup for container %s in %s...", containerId, backupDto.ObjectPath)
+
+		return d.startBackupOperation(ctx, containerId, func(ctx context.Context) error {
+			return d.createStorageBackup(ctx, containerId, backupDto)
+		})
 	}
 
 	log.Infof("Creating backup for container %s...", containerId)
 
-	d.cache.SetBackupState(ctx, containerId, enums.BackupStateInProgress, nil)
-
-	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-
-		defer func() {
-			backupContext, ok := backup_context_map.Get(containerId)
-			if ok {
-				backupContext.cancel()
-			}
-			backup_context_map.Remove(containerId)
-		}()
-
-		backup_context_map.Set(containerId, backupContext{ctx, cancel})
-
+	return d.startBackupOperation(ctx, containerId, func(ctx context.Context) error {
 		err := d.commitContainer(ctx, containerId, backupDto.Snapshot)
 		if err != nil {
-			if errors.Is(err, context.Canceled) {
-				d.cache.SetBackupState(ctx, containerId, enums.BackupStateNone, nil)
-				log.Infof("Backup for container %s canceled", containerId)
-				return
-			}
 			log.Errorf("Error committing container %s: %v", containerId, err)
-			d.cache.SetBackupState(ctx, containerId, enums.BackupStateFailed, err)
-			return
+			return err
 		}
 
-		err = d.PushImage(ctx, backupDto.Snapshot, &backupDto.Registry)
+		err = d.PushImage(ctx, backupDto.Snapshot, backupDto.Registry)
 		if err != nil {
-			if errors.Is(err, context.Canceled) {
-				d.cache.SetBackupState(ctx, containerId, enums.BackupStateNone, nil)
-				log.Infof("Backup for container %s canceled", containerId)
-				return
-			}
 			log.Errorf("Error pushing image %s: %v", backupDto.Snapshot, err)
-			d.cache.SetBackupState(ctx, containerId, enums.BackupStateFailed, err)
-			return
+			return err
 		}
 
-		d.cache.SetBackupState(ctx, containerId, enums.BackupStateCompleted, nil)
-
 		log.Infof("Backup (%s) for container %s created successfully", backupDto.Snapshot, containerId)
 
 		err = d.RemoveImage(ctx, backupDto.Snapshot, true)
@@ -79,7 +52,90 @@ func (d *DockerClient) StartBackupCreate(ctx context.Context, containerId string
 			log.Errorf("Error removing image %s: %v", backupDto.Snapshot, err)
 			// Don't set backup to failed because the image is already pushed
 		}
+
+		return nil
+	})
+}
+
+// StartBackupRestore loads a backup exported to object storage so a sandbox can be created from it on this runner
+func (d *DockerClient) StartBackupRestore(ctx context.Context, sandboxId string, restoreDto dto.RestoreBackupDTO) error {
+	log.Infof("Restoring backup of sandbox %s from %s...", sandboxId, restoreDto.ObjectPath)
+
+	return d.startBackupOperation(ctx, sandboxId, func(ctx context.Context) error {
+		usernsMode := container.UsernsMode("")
+		if restoreDto.HostUserNamespace {
+			usernsMode = usernsModeHost
+		}
+
+		return d.restoreStorageBackup(ctx, sandboxId, restoreDto.ObjectPath, usernsMode)
+	})
+}
+
+// startBackupOperation runs a backup or restore in the background, canceling the one already in progress for the sandbox
+func (d *DockerClient) startBackupOperation(ctx context.Context, sandboxId string, operation func(ctx context.Context) error) error {
+	// A backup replacing a running one of the same sandbox needs a slot too, the running one frees its slot once
+	// it noticed the cancellation
+	release, err := d.backupLimiter.tryAcquire()
+	if err != nil {
+		return err
+	}
+
+	backup_context, ok := backup_context_map.Get(sandboxId)
+	if ok {
+		backup_context.cancel()
+	}
+
+	d.setBackupState(ctx, sandboxId, enums.BackupStateInProgress, nil)
+
+	operationCtx, cancel := context.WithCancel(context.Background())
+	backup_context_map.Set(sandboxId, backupContext{operationCtx, cancel})
+
+	go func() {
+		defer release()
+
+		err := operation(operationCtx)
+
+		// A canceled operation may already have been replaced by a new one which now owns the backup state
+		current := backup_context_map.RemoveCb(sandboxId, func(key string, v backupContext, exists bool) bool {
+			return exists && v.ctx == operationCtx
+		})
+		cancel()
+		if !current {
+			return
+		}
+
+		// The operation context is canceled by now, the final state has to be written regardless
+		ctx := context.WithoutCancel(operationCtx)
+
+		d.cache.SetBackupProgress(ctx, sandboxId, nil)
+
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				d.setBackupState(ctx, sandboxId, enums.BackupStateNone, nil)
+				log.Infof("Backup for container %s canceled", sandboxId)
+				return
+			}
+			d.setBackupState(ctx, sandboxId, enums.BackupStateFailed, err)
+			return
+		}
+
+		d.setBackupState(ctx, sandboxId, enums.BackupStateCompleted, nil)
 	}()
 
 	return nil
 }
+
+// setBackupState moves the backup of the sandbox to the given state if the backup state machine allows it
+func (d *DockerClient) setBackupState(ctx context.Context, sandboxId string, state enums.BackupState, err error) {
+	current := d.cache.Get(ctx, sandboxId).BackupState
+	if current == "" {
+		current = enums.BackupStateNone
+	}
+
+	if !current.CanTransitionTo(state) {
+		log.Warnf("Ignoring backup state change of sandbox %s from %s to %s", sandboxId, current, state)
+		return
+	}
+
+	d.cache.SetBackupState(ctx, sandboxId, state, err)
+}
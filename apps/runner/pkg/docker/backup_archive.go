@@ -0,0 +1,196 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+// Chains longer than this are most likely cyclic
+const maxBackupChainLength = 100
+
+// Entries of the docker save tarball whose content is not addressed by their name and has to be uploaded on every backup
+var mutableArchiveEntries = []string{"manifest.json", "index.json", "repositories", "oci-layout"}
+
+// backupArchiveEntry is an entry of the snapshot tarball saved by docker. Regular files without an object
+// were unchanged since the parent backup and are resolved by walking the backup chain.
+type backupArchiveEntry struct {
+	Name     string `json:"name"`
+	Typeflag byte   `json:"typeflag"`
+	Linkname string `json:"linkname,omitempty"`
+	Mode     int64  `json:"mode"`
+	Size     int64  `json:"size"`
+	Object   string `json:"object,omitempty"`
+}
+
+type backupChainLink struct {
+	objectPath string
+	manifest   *backupManifest
+}
+
+// exportSnapshotArchive uploads the entries of the snapshot tarball one by one, skipping the layers that
+// are already stored with the same size by a backup in the parent chain
+func (d *DockerClient) exportSnapshotArchive(ctx context.Context, storageClient storage.ObjectStorageClient, snapshot string, objectPath string, parentChain []backupChainLink, progress *backupProgressTracker) ([]backupArchiveEntry, error) {
+	stored := make(map[string]int64)
+	for _, link := range parentChain {
+		for _, entry := range link.manifest.Archive {
+			if entry.Object != "" {
+				stored[entry.Name] = entry.Size
+			}
+		}
+	}
+
+	reader, err := d.apiClient.ImageSave(ctx, []string{snapshot})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	entries := make([]backupArchiveEntry, 0)
+	skippedBytes := int64(0)
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+
+		entry := backupArchiveEntry{
+			Name:     header.Name,
+			Typeflag: header.Typeflag,
+			Linkname: header.Linkname,
+			Mode:     header.Mode,
+			Size:     header.Size,
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			size, ok := stored[header.Name]
+			if ok && size == header.Size && !slices.Contains(mutableArchiveEntries, header.Name) {
+				skippedBytes += header.Size
+			} else {
+				entry.Object = path.Join("snapshot", header.Name)
+				err = storageClient.PutObjectStream(ctx, path.Join(objectPath, entry.Object), progress.reader(ctx, tarReader), header.Size)
+				if err != nil {
+					return nil, fmt.Errorf("failed to upload %s: %w", header.Name, err)
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(parentChain) > 0 {
+		log.Infof("Skipped %d bytes of snapshot %s already stored in the backup chain", skippedBytes, snapshot)
+	}
+
+	return entries, nil
+}
+
+// importSnapshotArchive rebuilds the snapshot tarball of the last backup in the chain and loads it
+func (d *DockerClient) importSnapshotArchive(ctx context.Context, storageClient storage.ObjectStorageClient, chain []backupChainLink, progress *backupProgressTracker) error {
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		pipeWriter.CloseWithError(writeSnapshotArchive(ctx, storageClient, chain, pipeWriter))
+	}()
+
+	_, err := d.ImportImage(ctx, progress.reader(ctx, pipeReader))
+	pipeReader.CloseWithError(err)
+
+	return err
+}
+
+func writeSnapshotArchive(ctx context.Context, storageClient storage.ObjectStorageClient, chain []backupChainLink, writer io.Writer) error {
+	tarWriter := tar.NewWriter(writer)
+
+	for _, entry := range chain[0].manifest.Archive {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     entry.Name,
+			Typeflag: entry.Typeflag,
+			Linkname: entry.Linkname,
+			Mode:     entry.Mode,
+			Size:     entry.Size,
+		})
+		if err != nil {
+			return err
+		}
+
+		if entry.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		objectPath, err := resolveArchiveEntry(chain, entry)
+		if err != nil {
+			return err
+		}
+
+		err = copyObject(ctx, storageClient, objectPath, tarWriter)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name, err)
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+// resolveArchiveEntry returns the object path of the entry in the closest backup of the chain that uploaded it
+func resolveArchiveEntry(chain []backupChainLink, entry backupArchiveEntry) (string, error) {
+	for _, link := range chain {
+		for _, stored := range link.manifest.Archive {
+			if stored.Name == entry.Name && stored.Size == entry.Size && stored.Object != "" {
+				return path.Join(link.objectPath, stored.Object), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%s is not stored in any backup of the chain", entry.Name)
+}
+
+// readBackupChain returns the manifest of the backup followed by the manifests of its parents
+func readBackupChain(ctx context.Context, storageClient storage.ObjectStorageClient, objectPath string) ([]backupChainLink, error) {
+	chain := make([]backupChainLink, 0)
+
+	for objectPath != "" {
+		if len(chain) == maxBackupChainLength {
+			return nil, fmt.Errorf("backup chain is longer than %d backups", maxBackupChainLength)
+		}
+
+		manifest, err := readBackupManifest(ctx, storageClient, objectPath)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, backupChainLink{
+			objectPath: objectPath,
+			manifest:   manifest,
+		})
+
+		objectPath = manifest.Parent
+	}
+
+	return chain, nil
+}
+
+func copyObject(ctx context.Context, storageClient storage.ObjectStorageClient, objectPath string, writer io.Writer) error {
+	reader, err := storageClient.GetObjectStream(ctx, objectPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(writer, reader)
+	return err
+}
@@ -0,0 +1,376 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/storage"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+const backupManifestFileName = "manifest.json"
+
+// backupManifest describes the objects of a backup exported to object storage, object paths are relative to the backup prefix.
+// Incremental backups only store the snapshot layers their parent chain is missing.
+type backupManifest struct {
+	SandboxId string `json:"sandboxId"`
+	Snapshot  string `json:"snapshot"`
+	// SnapshotObject is only set by backups that exported the snapshot as a single tarball
+	SnapshotObject string                 `json:"snapshotObject,omitempty"`
+	Archive        []backupArchiveEntry   `json:"archive,omitempty"`
+	Parent         string                 `json:"parent,omitempty"`
+	Volumes        []backupManifestVolume `json:"volumes"`
+	CreatedAt      time.Time              `json:"createdAt"`
+}
+
+type backupManifestVolume struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination"`
+	Object      string `json:"object"`
+}
+
+// createStorageBackup commits the sandbox and exports the snapshot and the contents of its docker volumes to object storage.
+// Bind mounted sandbox volumes are not exported since they are already backed by object storage.
+func (d *DockerClient) createStorageBackup(ctx context.Context, containerId string, backupDto dto.CreateBackupDTO) error {
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	parentChain := make([]backupChainLink, 0)
+	if backupDto.BaseObjectPath != "" {
+		parentChain, err = readBackupChain(ctx, storageClient, backupDto.BaseObjectPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	ct, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	progress := newBackupProgressTracker(d.cache, containerId)
+
+	progress.setPhase(ctx, enums.BackupPhaseCommitting)
+	err = d.commitContainer(ctx, containerId, backupDto.Snapshot)
+	if err != nil {
+		log.Errorf("Error committing container %s: %v", containerId, err)
+		return err
+	}
+
+	defer func() {
+		err := d.RemoveImage(context.Background(), backupDto.Snapshot, true)
+		if err != nil {
+			log.Errorf("Error removing image %s: %v", backupDto.Snapshot, err)
+		}
+	}()
+
+	manifest := backupManifest{
+		SandboxId: containerId,
+		Snapshot:  backupDto.Snapshot,
+		Parent:    backupDto.BaseObjectPath,
+		Volumes:   make([]backupManifestVolume, 0),
+		CreatedAt: time.Now(),
+	}
+
+	progress.setPhase(ctx, enums.BackupPhaseExportingSnapshot)
+	manifest.Archive, err = d.exportSnapshotArchive(ctx, storageClient, backupDto.Snapshot, backupDto.ObjectPath, parentChain, progress)
+	if err != nil {
+		return fmt.Errorf("failed to export snapshot: %w", err)
+	}
+
+	progress.setPhase(ctx, enums.BackupPhaseExportingVolumes)
+	for _, mountPoint := range ct.Mounts {
+		if mountPoint.Type != mount.TypeVolume {
+			continue
+		}
+
+		volumeBackup := backupManifestVolume{
+			Name:        mountPoint.Name,
+			Destination: mountPoint.Destination,
+			Object:      path.Join("volumes", fmt.Sprintf("%d.tar", len(manifest.Volumes))),
+		}
+
+		err = d.exportVolume(ctx, storageClient, containerId, path.Join(backupDto.ObjectPath, volumeBackup.Object), mountPoint.Destination, progress)
+		if err != nil {
+			return fmt.Errorf("failed to export volume %s: %w", mountPoint.Name, err)
+		}
+
+		manifest.Volumes = append(manifest.Volumes, volumeBackup)
+	}
+
+	// The manifest is written last so an interrupted backup is never picked up for a restore
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	err = storageClient.PutObjectStream(ctx, path.Join(backupDto.ObjectPath, backupManifestFileName), bytes.NewReader(rawManifest), int64(len(rawManifest)))
+	if err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+
+	log.Infof("Backup (%s) for container %s exported to %s", backupDto.Snapshot, containerId, backupDto.ObjectPath)
+
+	return nil
+}
+
+func (d *DockerClient) exportVolume(ctx context.Context, storageClient storage.ObjectStorageClient, containerId string, objectPath string, destination string, progress *backupProgressTracker) error {
+	reader, _, err := d.apiClient.CopyFromContainer(ctx, containerId, destination)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return storageClient.PutObjectStream(ctx, objectPath, progress.reader(ctx, reader), -1)
+}
+
+// restoreStorageBackup loads the snapshot of a backup and recreates its volumes, they are mounted
+// at their original destinations once a sandbox with the same ID is created from the snapshot. The volumes
+// are filled in the user namespace the sandbox will run in so the files keep their owners.
+func (d *DockerClient) restoreStorageBackup(ctx context.Context, sandboxId string, objectPath string, usernsMode container.UsernsMode) error {
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	chain, err := readBackupChain(ctx, storageClient, objectPath)
+	if err != nil {
+		return err
+	}
+	manifest := chain[0].manifest
+
+	progress := newBackupProgressTracker(d.cache, sandboxId)
+
+	progress.setPhase(ctx, enums.BackupPhaseRestoringSnapshot)
+	if manifest.SnapshotObject != "" {
+		err = d.importSnapshotObject(ctx, storageClient, path.Join(objectPath, manifest.SnapshotObject), progress)
+	} else {
+		err = d.importSnapshotArchive(ctx, storageClient, chain, progress)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(manifest.Volumes) > 0 {
+		progress.setPhase(ctx, enums.BackupPhaseRestoringVolumes)
+		err = d.restoreVolumes(ctx, storageClient, sandboxId, objectPath, manifest, usernsMode, progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Backup of sandbox %s restored from %s as %s", sandboxId, objectPath, manifest.Snapshot)
+
+	return nil
+}
+
+func (d *DockerClient) importSnapshotObject(ctx context.Context, storageClient storage.ObjectStorageClient, objectPath string, progress *backupProgressTracker) error {
+	reader, err := storageClient.GetObjectStream(ctx, objectPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup snapshot: %w", err)
+	}
+	defer reader.Close()
+
+	_, err = d.ImportImage(ctx, progress.reader(ctx, reader))
+	return err
+}
+
+// restoreVolumes fills the volumes through a container that is created from the snapshot but never started
+func (d *DockerClient) restoreVolumes(ctx context.Context, storageClient storage.ObjectStorageClient, sandboxId string, objectPath string, manifest *backupManifest, usernsMode container.UsernsMode, progress *backupProgressTracker) error {
+	binds := make([]string, 0, len(manifest.Volumes))
+	for i, volumeBackup := range manifest.Volumes {
+		volumeName := getRestoredVolumeName(sandboxId, i)
+
+		// Drop the volume of an earlier restore so no stale files are left behind
+		err := d.apiClient.VolumeRemove(ctx, volumeName, false)
+		if err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+
+		_, err = d.apiClient.VolumeCreate(ctx, volume.CreateOptions{
+			Name: volumeName,
+			Labels: map[string]string{
+				constants.RESTORED_VOLUME_SANDBOX_LABEL:     sandboxId,
+				constants.RESTORED_VOLUME_DESTINATION_LABEL: volumeBackup.Destination,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create volume %s: %w", volumeName, err)
+		}
+
+		binds = append(binds, fmt.Sprintf("%s:%s", volumeName, volumeBackup.Destination))
+	}
+
+	restoreContainer, err := d.apiClient.ContainerCreate(ctx, &container.Config{
+		Image: manifest.Snapshot,
+	}, &container.HostConfig{
+		Binds:      binds,
+		UsernsMode: usernsMode,
+	}, nil, nil, fmt.Sprintf("%s-restore", sandboxId))
+	if err != nil {
+		return fmt.Errorf("failed to create restore container: %w", err)
+	}
+
+	defer func() {
+		err := d.apiClient.ContainerRemove(context.Background(), restoreContainer.ID, container.RemoveOptions{Force: true})
+		if err != nil {
+			log.Errorf("Failed to remove restore container of sandbox %s: %v", sandboxId, err)
+		}
+	}()
+
+	for _, volumeBackup := range manifest.Volumes {
+		err = d.importVolume(ctx, storageClient, restoreContainer.ID, path.Join(objectPath, volumeBackup.Object), volumeBackup.Destination, progress)
+		if err != nil {
+			return fmt.Errorf("failed to restore volume %s: %w", volumeBackup.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) importVolume(ctx context.Context, storageClient storage.ObjectStorageClient, containerId string, objectPath string, destination string, progress *backupProgressTracker) error {
+	reader, err := storageClient.GetObjectStream(ctx, objectPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// The archive root is the base name of the destination so it is extracted into the parent directory, the
+	// owners in the archive are IDs of the user namespace of the sandbox and mapped the same way again
+	return d.apiClient.CopyToContainer(ctx, containerId, path.Dir(destination), progress.reader(ctx, reader), container.CopyToContainerOptions{
+		CopyUIDGID: true,
+	})
+}
+
+// getRestoredVolumeBinds returns the binds of the volumes restored from a backup of the sandbox
+func (d *DockerClient) getRestoredVolumeBinds(ctx context.Context, sandboxId string) ([]string, error) {
+	volumes, err := d.listRestoredVolumes(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	binds := make([]string, 0, len(volumes))
+	for _, vol := range volumes {
+		binds = append(binds, fmt.Sprintf("%s:%s", vol.Name, vol.Labels[constants.RESTORED_VOLUME_DESTINATION_LABEL]))
+	}
+
+	return binds, nil
+}
+
+// removeRestoredVolumes only logs errors since the sandbox is already removed
+func (d *DockerClient) removeRestoredVolumes(ctx context.Context, sandboxId string) {
+	volumes, err := d.listRestoredVolumes(ctx, sandboxId)
+	if err != nil {
+		log.Errorf("Failed to list restored volumes of sandbox %s: %v", sandboxId, err)
+		return
+	}
+
+	for _, vol := range volumes {
+		err = d.apiClient.VolumeRemove(ctx, vol.Name, false)
+		if err != nil && !errdefs.IsNotFound(err) {
+			log.Errorf("Failed to remove restored volume %s: %v", vol.Name, err)
+		}
+	}
+}
+
+func (d *DockerClient) listRestoredVolumes(ctx context.Context, sandboxId string) ([]*volume.Volume, error) {
+	list, err := d.apiClient.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.RESTORED_VOLUME_SANDBOX_LABEL, sandboxId))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Volumes, nil
+}
+
+func getRestoredVolumeName(sandboxId string, index int) string {
+	return fmt.Sprintf("%s-restored-%d", sandboxId, index)
+}
+
+func readBackupManifest(ctx context.Context, storageClient storage.ObjectStorageClient, objectPath string) (*backupManifest, error) {
+	reader, err := storageClient.GetObjectStream(ctx, path.Join(objectPath, backupManifestFileName))
+	if err != nil {
+		return nil, common.NewNotFoundError(fmt.Errorf("backup manifest not found in %s: %w", objectPath, err))
+	}
+	defer reader.Close()
+
+	var manifest backupManifest
+	err = json.NewDecoder(reader).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// backupProgressTracker reports the phase of a backup or restore and the total bytes transferred across all phases
+type backupProgressTracker struct {
+	cache            cache.IRunnerCache
+	sandboxId        string
+	phase            enums.BackupPhase
+	bytesTransferred int64
+}
+
+func newBackupProgressTracker(runnerCache cache.IRunnerCache, sandboxId string) *backupProgressTracker {
+	return &backupProgressTracker{
+		cache:     runnerCache,
+		sandboxId: sandboxId,
+	}
+}
+
+func (t *backupProgressTracker) setPhase(ctx context.Context, phase enums.BackupPhase) {
+	t.phase = phase
+	t.update(ctx)
+}
+
+func (t *backupProgressTracker) update(ctx context.Context) {
+	t.cache.SetBackupProgress(ctx, t.sandboxId, &models.BackupProgress{
+		Phase:            t.phase,
+		BytesTransferred: t.bytesTransferred,
+	})
+}
+
+func (t *backupProgressTracker) reader(ctx context.Context, reader io.Reader) io.Reader {
+	return &backupProgressReader{
+		Reader:  reader,
+		ctx:     ctx,
+		tracker: t,
+	}
+}
+
+type backupProgressReader struct {
+	io.Reader
+	ctx     context.Context
+	tracker *backupProgressTracker
+}
+
+func (r *backupProgressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.tracker.bytesTransferred += int64(n)
+		r.tracker.update(r.ctx)
+	}
+
+	return n, err
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const (
+	BUILD_OPERATION_BUILD = "build"
+	BUILD_OPERATION_PULL  = "pull"
+)
+
+// buildStatusRetention is how long finished builds and pulls are remembered
+const buildStatusRetention = 24 * time.Hour
+
+// buildStatusRegistry tracks the builds and pulls since the runner started so log streams and the API
+// can tell a running build from a failed one
+type buildStatusRegistry struct {
+	mutex    sync.Mutex
+	statuses map[string]*dto.SnapshotBuildStatusDTO
+	finished map[string]time.Time
+	// changed is closed and replaced on every change
+	changed chan struct{}
+}
+
+func newBuildStatusRegistry() *buildStatusRegistry {
+	return &buildStatusRegistry{
+		statuses: make(map[string]*dto.SnapshotBuildStatusDTO),
+		finished: make(map[string]time.Time),
+		changed:  make(chan struct{}),
+	}
+}
+
+// start records a running build or pull of the snapshot and returns the function that finishes it
+func (r *buildStatusRegistry) start(snapshot string, operation string) func(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for expired, finishedAt := range r.finished {
+		if time.Since(finishedAt) > buildStatusRetention {
+			delete(r.statuses, expired)
+			delete(r.finished, expired)
+		}
+	}
+
+	status := &dto.SnapshotBuildStatusDTO{
+		Snapshot:  snapshot,
+		Operation: operation,
+		Status:    enums.BuildStatusBuilding.String(),
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	r.statuses[snapshot] = status
+	delete(r.finished, snapshot)
+	r.notify()
+
+	return func(err error) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		// A newer build of the snapshot owns the entry
+		if r.statuses[snapshot] != status {
+			return
+		}
+
+		now := time.Now()
+		status.FinishedAt = now.UTC().Format(time.RFC3339)
+		status.Status = enums.BuildStatusSucceeded.String()
+		if err != nil {
+			status.Status = enums.BuildStatusFailed.String()
+			status.Error = err.Error()
+			status.ErrorCode = common.GetErrorCode(err)
+		}
+		r.finished[snapshot] = now
+		r.notify()
+	}
+}
+
+// get returns the status of the snapshot. A snapshot ref without a tag matches the most recent build
+// writing the same build log.
+func (r *buildStatusRegistry) get(snapshotRef string) (dto.SnapshotBuildStatusDTO, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if status, ok := r.statuses[snapshotRef]; ok {
+		return *status, true
+	}
+
+	var latest *dto.SnapshotBuildStatusDTO
+	for snapshot, status := range r.statuses {
+		if status.Operation != BUILD_OPERATION_BUILD || config.GetBuildLogId(snapshot) != config.GetBuildLogId(snapshotRef) {
+			continue
+		}
+		if latest == nil || status.StartedAt > latest.StartedAt {
+			latest = status
+		}
+	}
+
+	if latest == nil {
+		return dto.SnapshotBuildStatusDTO{}, false
+	}
+
+	return *latest, true
+}
+
+// wait blocks until the snapshot has a finished status or the context is done
+func (r *buildStatusRegistry) wait(ctx context.Context, snapshotRef string) (dto.SnapshotBuildStatusDTO, error) {
+	for {
+		r.mutex.Lock()
+		changed := r.changed
+		r.mutex.Unlock()
+
+		status, ok := r.get(snapshotRef)
+		if ok && status.Status != enums.BuildStatusBuilding.String() {
+			return status, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return dto.SnapshotBuildStatusDTO{}, ctx.Err()
+		}
+	}
+}
+
+// notify wakes the waiters, the caller must hold the mutex
+func (r *buildStatusRegistry) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// GetBuildStatus returns the status of the latest build or pull of the snapshot since the runner started
+func (d *DockerClient) GetBuildStatus(snapshotRef string) (dto.SnapshotBuildStatusDTO, bool) {
+	return d.buildStatus.get(snapshotRef)
+}
+
+// WaitForBuild waits until the latest build or pull of the snapshot finished, including builds that
+// have not started yet
+func (d *DockerClient) WaitForBuild(ctx context.Context, snapshotRef string) (dto.SnapshotBuildStatusDTO, error) {
+	return d.buildStatus.wait(ctx, snapshotRef)
+}
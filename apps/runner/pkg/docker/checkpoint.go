@@ -0,0 +1,187 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daytonaio/common-go/pkg/timer"
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/storage"
+
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// Checkpoint dumps the sandbox process state with CRIU and uploads it to object storage.
+// Requires the Docker daemon to run with experimental features enabled.
+func (d *DockerClient) Checkpoint(ctx context.Context, containerId string, checkpointDto dto.CheckpointSandboxDTO) error {
+	defer timer.Timer()()
+
+	err := validateCheckpointId(checkpointDto.CheckpointId)
+	if err != nil {
+		return err
+	}
+
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	if !c.State.Running {
+		return common.NewConflictError(fmt.Errorf("sandbox %s must be running to be checkpointed", containerId))
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return fmt.Errorf("failed to initialize object storage client: %w", err)
+	}
+
+	checkpointDir := getCheckpointDir(containerId)
+	err = os.MkdirAll(checkpointDir, 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	defer os.RemoveAll(filepath.Join(checkpointDir, checkpointDto.CheckpointId))
+
+	log.Infof("Checkpointing sandbox %s as %s...", containerId, checkpointDto.CheckpointId)
+
+	// The exit is not a crash the sandbox is restarted after
+	if checkpointDto.Exit {
+		d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStopping)
+	}
+
+	err = d.apiClient.CheckpointCreate(ctx, containerId, checkpoint.CreateOptions{
+		CheckpointID:  checkpointDto.CheckpointId,
+		CheckpointDir: checkpointDir,
+		Exit:          checkpointDto.Exit,
+	})
+	if err != nil {
+		if checkpointDto.Exit {
+			d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStarted)
+		}
+		return fmt.Errorf("failed to checkpoint sandbox: %w", err)
+	}
+
+	if checkpointDto.Exit {
+		d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStopped)
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(util.TarDirectory(filepath.Join(checkpointDir, checkpointDto.CheckpointId), writer))
+	}()
+
+	err = storageClient.PutObjectStream(ctx, getCheckpointObjectPath(containerId, checkpointDto.CheckpointId), reader, -1)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("failed to upload checkpoint: %w", err)
+	}
+
+	log.Infof("Checkpoint %s for sandbox %s uploaded successfully", checkpointDto.CheckpointId, containerId)
+
+	return nil
+}
+
+// RestoreCheckpoint downloads a checkpoint from object storage and starts the stopped sandbox from it.
+// The sandbox container must already exist on this runner, created from the same snapshot.
+func (d *DockerClient) RestoreCheckpoint(ctx context.Context, containerId string, restoreDto dto.RestoreCheckpointDTO) error {
+	defer timer.Timer()()
+
+	err := validateCheckpointId(restoreDto.CheckpointId)
+	if err != nil {
+		return err
+	}
+
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	if c.State.Running {
+		return common.NewConflictError(fmt.Errorf("sandbox %s must be stopped to be restored from a checkpoint", containerId))
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return fmt.Errorf("failed to initialize object storage client: %w", err)
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateRestoring)
+
+	checkpointDir := getCheckpointDir(containerId)
+	targetDir := filepath.Join(checkpointDir, restoreDto.CheckpointId)
+	err = os.MkdirAll(targetDir, 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	defer os.RemoveAll(targetDir)
+
+	object, err := storageClient.GetObjectStream(ctx, getCheckpointObjectPath(containerId, restoreDto.CheckpointId))
+	if err != nil {
+		return common.NewNotFoundError(fmt.Errorf("checkpoint %s: %w", restoreDto.CheckpointId, err))
+	}
+	defer object.Close()
+
+	err = util.UntarToDirectory(object, targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to extract checkpoint: %w", err)
+	}
+
+	log.Infof("Restoring sandbox %s from checkpoint %s...", containerId, restoreDto.CheckpointId)
+
+	err = d.apiClient.ContainerStart(ctx, containerId, container.StartOptions{
+		CheckpointID:  restoreDto.CheckpointId,
+		CheckpointDir: checkpointDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore sandbox from checkpoint: %w", err)
+	}
+
+	err = d.waitForContainerRunning(ctx, containerId, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	c, err = d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	// The daemon process is part of the restored process tree so it doesn't need to be started again
+	err = d.setupStartedContainer(ctx, containerId, &c, false)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStarted)
+
+	log.Infof("Sandbox %s restored from checkpoint %s", containerId, restoreDto.CheckpointId)
+
+	return nil
+}
+
+func validateCheckpointId(checkpointId string) error {
+	if checkpointId == "." || checkpointId == ".." || filepath.Base(checkpointId) != checkpointId {
+		return common.NewBadRequestError(fmt.Errorf("invalid checkpoint ID: %s", checkpointId))
+	}
+
+	return nil
+}
+
+func getCheckpointDir(containerId string) string {
+	return filepath.Join(os.TempDir(), "daytona-checkpoints", containerId)
+}
+
+func getCheckpointObjectPath(containerId, checkpointId string) string {
+	return fmt.Sprintf("checkpoints/%s/%s.tar", containerId, checkpointId)
+}
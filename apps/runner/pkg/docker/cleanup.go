@@ -0,0 +1,218 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+type CleanupOptions struct {
+	// ExitedContainerMaxAge removes sandbox containers that exited longer ago than this, 0 keeps them
+	ExitedContainerMaxAge time.Duration
+	// DanglingVolumes removes volumes the runner created for a sandbox that no container uses and that have no cache entry
+	DanglingVolumes bool
+	// SandboxNetworks removes per-sandbox networks whose sandbox has no container
+	SandboxNetworks bool
+	DryRun          bool
+}
+
+// Cleanup removes the resources long-lived runners accumulate. Multi-container sandboxes are left to
+// their own destroy endpoint since their service containers can't be removed one by one.
+func (d *DockerClient) Cleanup(ctx context.Context, options CleanupOptions) (*dto.CleanupResponseDTO, error) {
+	result := &dto.CleanupResponseDTO{
+		Containers: []string{},
+		Volumes:    []string{},
+		Networks:   []string{},
+		DryRun:     options.DryRun,
+	}
+
+	if options.ExitedContainerMaxAge > 0 {
+		removed, err := d.cleanupExitedContainers(ctx, options.ExitedContainerMaxAge, options.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Containers = removed
+	}
+
+	if options.DanglingVolumes {
+		removed, err := d.cleanupDanglingVolumes(ctx, options.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Volumes = removed
+	}
+
+	// Networks last so the networks of the containers removed above go in the same run
+	if options.SandboxNetworks {
+		removed, err := d.cleanupSandboxNetworks(ctx, options.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Networks = removed
+	}
+
+	return result, nil
+}
+
+func (d *DockerClient) cleanupExitedContainers(ctx context.Context, maxAge time.Duration, dryRun bool) ([]string, error) {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", constants.ORGANIZATION_ID_LABEL),
+			filters.Arg("status", "exited"),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, ct := range containers {
+		// Stopped pool sandboxes are kept on purpose, the warm pool replaces them when its templates change
+		if len(ct.Names) == 0 || ct.Labels[constants.SIDECAR_OF_LABEL] != "" || ct.Labels[constants.COMPOSE_SANDBOX_LABEL] != "" || IsPoolSandbox(ct.Names[0]) {
+			continue
+		}
+
+		sandboxId := strings.TrimPrefix(ct.Names[0], "/")
+
+		inspect, err := d.ContainerInspect(ctx, ct.ID)
+		if err != nil {
+			log.Debugf("Failed to inspect sandbox %s: %v", sandboxId, err)
+			continue
+		}
+
+		finishedAt, err := time.Parse(time.RFC3339Nano, inspect.State.FinishedAt)
+		if err != nil || time.Since(finishedAt) < maxAge {
+			continue
+		}
+
+		if !dryRun {
+			err = d.Destroy(ctx, sandboxId)
+			if err != nil {
+				log.Warnf("Failed to remove exited sandbox %s: %v", sandboxId, err)
+				continue
+			}
+
+			common.CleanupRemovedCount.WithLabelValues("container").Inc()
+			log.Infof("Removed sandbox %s, exited at %s", sandboxId, finishedAt.UTC().Format(time.RFC3339))
+		}
+
+		removed = append(removed, sandboxId)
+	}
+
+	return removed, nil
+}
+
+func (d *DockerClient) cleanupDanglingVolumes(ctx context.Context, dryRun bool) ([]string, error) {
+	list, err := d.apiClient.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", constants.RESTORED_VOLUME_SANDBOX_LABEL),
+			filters.Arg("dangling", "true"),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cached := make(map[string]bool)
+	for _, sandboxId := range d.cache.List(ctx) {
+		cached[sandboxId] = true
+	}
+
+	removed := []string{}
+	for _, vol := range list.Volumes {
+		if cached[vol.Labels[constants.RESTORED_VOLUME_SANDBOX_LABEL]] {
+			continue
+		}
+
+		if !dryRun {
+			// Not forced so a volume a sandbox started using meanwhile is kept
+			err = d.apiClient.VolumeRemove(ctx, vol.Name, false)
+			if err != nil {
+				if !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+					log.Warnf("Failed to remove dangling volume %s: %v", vol.Name, err)
+				}
+				continue
+			}
+
+			common.CleanupRemovedCount.WithLabelValues("volume").Inc()
+			log.Infof("Removed dangling volume %s", vol.Name)
+		}
+
+		removed = append(removed, vol.Name)
+	}
+
+	return removed, nil
+}
+
+// cleanupSandboxNetworks removes the per-sandbox networks whose sandbox has no container left. Stopped
+// containers aren't attached to their network, so dangling networks alone aren't safe to remove.
+func (d *DockerClient) cleanupSandboxNetworks(ctx context.Context, dryRun bool) ([]string, error) {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", constants.ORGANIZATION_ID_LABEL)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxes := make(map[string]bool, len(containers))
+	for _, ct := range containers {
+		if len(ct.Names) > 0 {
+			sandboxes[strings.TrimPrefix(ct.Names[0], "/")] = true
+		}
+		if composeSandboxId := ct.Labels[constants.COMPOSE_SANDBOX_LABEL]; composeSandboxId != "" {
+			sandboxes[composeSandboxId] = true
+		}
+	}
+
+	removed := []string{}
+
+	for _, label := range []string{sandboxnet.SANDBOX_NETWORK_LABEL, constants.COMPOSE_SANDBOX_LABEL} {
+		networks, err := d.apiClient.NetworkList(ctx, network.ListOptions{
+			Filters: filters.NewArgs(
+				filters.Arg("label", label),
+				filters.Arg("dangling", "true"),
+			),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, nw := range networks {
+			if sandboxes[nw.Labels[label]] {
+				continue
+			}
+
+			if !dryRun {
+				err = d.apiClient.NetworkRemove(ctx, nw.ID)
+				if err != nil {
+					if !errdefs.IsNotFound(err) && !errdefs.IsForbidden(err) {
+						log.Warnf("Failed to remove sandbox network %s: %v", nw.Name, err)
+					}
+					continue
+				}
+
+				common.CleanupRemovedCount.WithLabelValues("network").Inc()
+				log.Infof("Removed sandbox network %s", nw.Name)
+			}
+
+			removed = append(removed, nw.Name)
+		}
+	}
+
+	return removed, nil
+}
@@ -5,10 +5,20 @@ package docker
 
 import (
 	"io"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/lifecycle"
+	"github.com/daytonaio/runner/pkg/logship"
 	"github.com/daytonaio/runner/pkg/netrules"
+	"github.com/daytonaio/runner/pkg/sandboxenv"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+	"github.com/daytonaio/runner/pkg/securityprofile"
+	"github.com/daytonaio/runner/pkg/volumesync"
 	"github.com/docker/docker/client"
 )
 
@@ -20,12 +30,85 @@ type DockerClientConfig struct {
 	AWSEndpointUrl        string
 	AWSAccessKeyId        string
 	AWSSecretAccessKey    string
-	DaemonPath            string
+	DaemonPaths           map[string]string
 	ComputerUsePluginPath string
 	NetRulesManager       *netrules.NetRulesManager
+	SandboxNetwork        *sandboxnet.Manager
+	RegistryMirrors       []string
+	PullThroughCacheUrl   string
+	VolumeSyncer          *volumesync.Syncer
+	Events                *events.Broker
+	TrivyPath             string
+	SignatureVerification SignatureVerificationConfig
+	// MaxConcurrentPulls bounds the number of image pulls running at once, 0 means unlimited
+	MaxConcurrentPulls int
+	// MaxQueuedPulls bounds the number of pulls waiting for a slot, further pulls are refused, 0 means unlimited
+	MaxQueuedPulls int
+	// MaxConcurrentCreates bounds the number of sandbox creates running at once, further creates are refused,
+	// 0 means unlimited
+	MaxConcurrentCreates int
+	// MaxConcurrentBackups bounds the number of backups running at once, further backups are refused, 0 means
+	// unlimited
+	MaxConcurrentBackups int
+	// MaxConcurrentExecs bounds the number of execs the runner itself runs in sandboxes at once, e.g. lifecycle
+	// hooks, further execs wait for a slot. The execs users run through the toolbox and the SSH gateway are
+	// bounded by the same number apart from those of the runner and are refused instead. 0 means unlimited.
+	MaxConcurrentExecs int
+	RegistryRetry      RegistryRetryPolicy
+	// ParallelLayerPulls is the number of layers downloaded at once by the runner instead of the daemon, 0 leaves pulls to the daemon
+	ParallelLayerPulls int
+	// Rootless is set when the engine is rootless Docker or Podman, see applyRootlessConfig
+	Rootless bool
+	// SandboxEnv stores the variables and secrets set on sandboxes outside of the container config
+	SandboxEnv *sandboxenv.Store
+	// LifecycleHooks stores the hooks passed on create and their output
+	LifecycleHooks *lifecycle.Store
+	// PrivilegePolicy restricts the privileges sandboxes may run with
+	PrivilegePolicy PrivilegePolicy
+	// SecurityProfiles stores the seccomp and AppArmor profiles sandboxes reference on create
+	SecurityProfiles *securityprofile.Store
+	// StopTimeout is the grace period between SIGTERM and SIGKILL when a sandbox stops, defaults to 10 seconds
+	StopTimeout time.Duration
+	// LogShipper receives the output of lifecycle hooks, nothing is shipped if it is nil
+	LogShipper *logship.Shipper
 }
 
 func NewDockerClient(config DockerClientConfig) *DockerClient {
+	trivyPath := config.TrivyPath
+	if trivyPath == "" {
+		trivyPath = "trivy"
+	}
+
+	signatureVerification := config.SignatureVerification
+	if signatureVerification.CosignPath == "" {
+		signatureVerification.CosignPath = "cosign"
+	}
+
+	registryRetry := config.RegistryRetry
+	if registryRetry.MaxAttempts <= 0 {
+		registryRetry.MaxAttempts = 1
+	}
+	if registryRetry.InitialBackoff <= 0 {
+		registryRetry.InitialBackoff = 1 * time.Second
+	}
+	if registryRetry.MaxBackoff < registryRetry.InitialBackoff {
+		registryRetry.MaxBackoff = registryRetry.InitialBackoff
+	}
+
+	stopTimeout := config.StopTimeout
+	if stopTimeout <= 0 {
+		stopTimeout = 10 * time.Second
+	}
+
+	privilegePolicy := config.PrivilegePolicy
+	if privilegePolicy.Mode == "" {
+		privilegePolicy.Mode = PRIVILEGE_POLICY_DEFAULT
+	}
+	privilegePolicy.AllowedCapabilities = slices.Clone(privilegePolicy.AllowedCapabilities)
+	for i, capability := range privilegePolicy.AllowedCapabilities {
+		privilegePolicy.AllowedCapabilities[i] = strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+	}
+
 	return &DockerClient{
 		apiClient:             config.ApiClient,
 		cache:                 config.Cache,
@@ -35,9 +118,30 @@ func NewDockerClient(config DockerClientConfig) *DockerClient {
 		awsAccessKeyId:        config.AWSAccessKeyId,
 		awsSecretAccessKey:    config.AWSSecretAccessKey,
 		volumeMutexes:         make(map[string]*sync.Mutex),
-		daemonPath:            config.DaemonPath,
+		daemonPaths:           config.DaemonPaths,
 		computerUsePluginPath: config.ComputerUsePluginPath,
 		netRulesManager:       config.NetRulesManager,
+		sandboxNetwork:        config.SandboxNetwork,
+		registryMirrors:       getRegistryMirrors(config.RegistryMirrors, config.PullThroughCacheUrl),
+		volumeSyncer:          config.VolumeSyncer,
+		events:                config.Events,
+		trivyPath:             trivyPath,
+		signatureVerification: signatureVerification,
+		pullLimiter:           newPullLimiter(config.MaxConcurrentPulls, config.MaxQueuedPulls),
+		createLimiter:         newOperationLimiter(LIMITED_OPERATION_CREATE, config.MaxConcurrentCreates),
+		backupLimiter:         newOperationLimiter(LIMITED_OPERATION_BACKUP, config.MaxConcurrentBackups),
+		execLimiter:           newOperationLimiter(LIMITED_OPERATION_EXEC, config.MaxConcurrentExecs),
+		sandboxExecLimiter:    newOperationLimiter(LIMITED_OPERATION_SANDBOX_EXEC, config.MaxConcurrentExecs),
+		registryRetry:         registryRetry,
+		rootless:              config.Rootless,
+		parallelLayerPulls:    config.ParallelLayerPulls,
+		buildStatus:           newBuildStatusRegistry(),
+		sandboxEnv:            config.SandboxEnv,
+		lifecycleHooks:        config.LifecycleHooks,
+		securityProfiles:      config.SecurityProfiles,
+		privilegePolicy:       privilegePolicy,
+		stopTimeout:           stopTimeout,
+		logShipper:            config.LogShipper,
 	}
 }
 
@@ -55,7 +159,29 @@ type DockerClient struct {
 	awsSecretAccessKey    string
 	volumeMutexes         map[string]*sync.Mutex
 	volumeMutexesMutex    sync.Mutex
-	daemonPath            string
+	daemonPaths           map[string]string
 	computerUsePluginPath string
 	netRulesManager       *netrules.NetRulesManager
+	sandboxNetwork        *sandboxnet.Manager
+	registryMirrors       []string
+	registryMirrorsMutex  sync.RWMutex
+	volumeSyncer          *volumesync.Syncer
+	events                *events.Broker
+	trivyPath             string
+	signatureVerification SignatureVerificationConfig
+	pullLimiter           *pullLimiter
+	createLimiter         *operationLimiter
+	backupLimiter         *operationLimiter
+	execLimiter           *operationLimiter
+	sandboxExecLimiter    *operationLimiter
+	registryRetry         RegistryRetryPolicy
+	rootless              bool
+	parallelLayerPulls    int
+	buildStatus           *buildStatusRegistry
+	sandboxEnv            *sandboxenv.Store
+	lifecycleHooks        *lifecycle.Store
+	securityProfiles      *securityprofile.Store
+	privilegePolicy       PrivilegePolicy
+	stopTimeout           time.Duration
+	logShipper            *logship.Shipper
 }
@@ -0,0 +1,288 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+const sandboxEnvPrefix = "DAYTONA_SANDBOX_"
+
+// CloneSandbox commits the source sandbox and creates the clone from the result. The Docker volumes of the
+// source are copied into volumes of the clone while S3 volumes are mounted into the clone as well, they
+// are shared and not copied. The resources of the source are kept.
+func (d *DockerClient) CloneSandbox(ctx context.Context, sourceId string, cloneDto dto.CloneSandboxDTO) (string, error) {
+	ct, err := d.ContainerInspect(ctx, sourceId)
+	if err != nil {
+		return "", err
+	}
+
+	if ct.Config.Labels[constants.COMPOSE_SANDBOX_LABEL] != "" || ct.Config.Labels[constants.SIDECAR_OF_LABEL] != "" {
+		return "", common.NewBadRequestError(errors.New("only single container sandboxes can be cloned"))
+	}
+
+	_, err = d.apiClient.ContainerInspect(ctx, cloneDto.Id)
+	if err == nil {
+		return "", common.NewConflictError(fmt.Errorf("sandbox %s already exists", cloneDto.Id))
+	}
+	if !errdefs.IsNotFound(err) {
+		return "", err
+	}
+
+	// The latest tag would make the create pull the snapshot from a registry
+	snapshot := cloneDto.Snapshot
+	if snapshot == "" {
+		snapshot = fmt.Sprintf("daytona-clone:%s", cloneDto.Id)
+	}
+
+	imageId, err := d.CreateSnapshotFromSandbox(ctx, sourceId, snapshot, false)
+	if err != nil {
+		return "", err
+	}
+
+	createDto, err := d.getCloneCreateDto(ctx, &ct, snapshot, cloneDto)
+	if err != nil {
+		return "", err
+	}
+
+	err = d.copySandboxVolumes(ctx, &ct, cloneDto.Id, imageId)
+	if err != nil {
+		d.removeRestoredVolumes(context.WithoutCancel(ctx), cloneDto.Id)
+		return "", fmt.Errorf("failed to copy the volumes of sandbox %s: %w", sourceId, err)
+	}
+
+	log.Infof("Creating sandbox %s as a clone of sandbox %s", cloneDto.Id, sourceId)
+
+	return d.Create(ctx, createDto)
+}
+
+// getCloneCreateDto derives the create request of the clone from the config the source was created with
+func (d *DockerClient) getCloneCreateDto(ctx context.Context, ct *types.ContainerJSON, snapshot string, cloneDto dto.CloneSandboxDTO) (dto.CreateSandboxDTO, error) {
+	createDto := dto.CreateSandboxDTO{
+		Id:          cloneDto.Id,
+		UserId:      ct.Config.Labels[constants.ORGANIZATION_ID_LABEL],
+		Snapshot:    snapshot,
+		CpuQuota:    ct.HostConfig.CPUQuota / 100000,
+		MemoryQuota: ct.HostConfig.Memory / (1024 * 1024 * 1024),
+		Volumes:     getSandboxS3Volumes(ct, d.getRunnerVolumeMountPath(""), d.getRunnerVolumeSyncPath("")),
+		Env:         make(map[string]string),
+		Security:    getSandboxSecurity(ct),
+	}
+
+	storageQuota, err := strconv.ParseInt(ct.Config.Labels[constants.STORAGE_QUOTA_LABEL], 10, 64)
+	if err == nil {
+		createDto.StorageQuota = storageQuota
+	}
+
+	for _, request := range ct.HostConfig.DeviceRequests {
+		if request.Driver != "nvidia" {
+			continue
+		}
+		if len(request.DeviceIDs) > 0 {
+			createDto.GpuDeviceIds = request.DeviceIDs
+		} else {
+			createDto.GpuQuota = int64(request.Count)
+		}
+	}
+
+	// Runtimes picked per sandbox are kept, the others follow the runner setting
+	if isSandboxedRuntime(ct.HostConfig.Runtime) {
+		for runtime, names := range runtimeNames {
+			if slices.Contains(names, ct.HostConfig.Runtime) {
+				createDto.Runtime = runtime
+			}
+		}
+	}
+
+	if cloneDto.CopyLabels {
+		createDto.IdleTimeoutMinutes, _ = strconv.Atoi(ct.Config.Labels[constants.IDLE_TIMEOUT_LABEL])
+		createDto.IngressBandwidthMbps, _ = strconv.ParseInt(ct.Config.Labels[constants.INGRESS_BANDWIDTH_LABEL], 10, 64)
+		createDto.EgressBandwidthMbps, _ = strconv.ParseInt(ct.Config.Labels[constants.EGRESS_BANDWIDTH_LABEL], 10, 64)
+		createDto.RestartPolicy = GetRestartPolicy(ct.Config.Labels)
+	}
+
+	sourceImage, _, err := d.apiClient.ImageInspectWithRaw(ctx, ct.Image)
+	if err != nil {
+		return dto.CreateSandboxDTO{}, fmt.Errorf("failed to inspect the snapshot of sandbox %s: %w", ct.ID, err)
+	}
+
+	imageEnv := make(map[string]string)
+	if sourceImage.Config != nil {
+		for _, entry := range sourceImage.Config.Env {
+			key, value, _ := strings.Cut(entry, "=")
+			imageEnv[key] = value
+		}
+	}
+
+	for _, entry := range ct.Config.Env {
+		key, value, _ := strings.Cut(entry, "=")
+		if key == sandboxEnvPrefix+"USER" {
+			createDto.OsUser = value
+		}
+
+		// The create sets the sandbox variables of the clone
+		if strings.HasPrefix(key, sandboxEnvPrefix) || cloneDto.CopyEnv {
+			continue
+		}
+
+		// The commit keeps the sandbox environment in the snapshot, it can only be reset to the snapshot
+		// value or cleared
+		if imageValue, ok := imageEnv[key]; !ok || imageValue != value {
+			createDto.Env[key] = imageValue
+		}
+	}
+
+	// Variables set after the source was created become part of the clone config
+	if cloneDto.CopyEnv {
+		sourceEnv, err := d.sandboxEnv.Get(strings.TrimPrefix(ct.Name, "/"))
+		if err != nil {
+			return dto.CreateSandboxDTO{}, err
+		}
+
+		for key, value := range sourceEnv.Env {
+			createDto.Env[key] = value
+		}
+		createDto.Secrets = sourceEnv.Secrets
+	}
+
+	for key, value := range cloneDto.Env {
+		createDto.Env[key] = value
+	}
+
+	return createDto, nil
+}
+
+// getSandboxS3Volumes returns the S3 volumes mounted into the sandbox from its binds
+func getSandboxS3Volumes(ct *types.ContainerJSON, mountRoot string, syncRoot string) []dto.VolumeDTO {
+	var volumes []dto.VolumeDTO
+
+	for _, bind := range ct.HostConfig.Binds {
+		parts := strings.SplitN(bind, ":", 3)
+		if len(parts) < 2 {
+			continue
+		}
+
+		hostPath := filepath.Clean(parts[0])
+		volumeId, ok := strings.CutPrefix(filepath.Base(hostPath), "daytona-volume-")
+		if !ok {
+			continue
+		}
+
+		root := filepath.Dir(hostPath)
+		if root != mountRoot && root != syncRoot {
+			continue
+		}
+
+		volumes = append(volumes, dto.VolumeDTO{
+			VolumeId:  volumeId,
+			MountPath: path.Clean(parts[1]),
+			Sync:      root == syncRoot,
+		})
+	}
+
+	return volumes
+}
+
+// copySandboxVolumes copies the Docker volumes of the source into volumes of the clone, they are mounted
+// by the create like volumes restored from a backup. The copy goes through a container that is created
+// from the snapshot but never started, the source is paused while its volumes are read.
+func (d *DockerClient) copySandboxVolumes(ctx context.Context, ct *types.ContainerJSON, cloneId string, imageId string) error {
+	var mountPoints []types.MountPoint
+	for _, mountPoint := range ct.Mounts {
+		if mountPoint.Type == mount.TypeVolume {
+			mountPoints = append(mountPoints, mountPoint)
+		}
+	}
+
+	if len(mountPoints) == 0 {
+		return nil
+	}
+
+	binds := make([]string, 0, len(mountPoints))
+	for i, mountPoint := range mountPoints {
+		volumeName := getRestoredVolumeName(cloneId, i)
+
+		_, err := d.apiClient.VolumeCreate(ctx, volume.CreateOptions{
+			Name: volumeName,
+			Labels: map[string]string{
+				constants.RESTORED_VOLUME_SANDBOX_LABEL:     cloneId,
+				constants.RESTORED_VOLUME_DESTINATION_LABEL: mountPoint.Destination,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create volume %s: %w", volumeName, err)
+		}
+
+		binds = append(binds, fmt.Sprintf("%s:%s", volumeName, mountPoint.Destination))
+	}
+
+	copyContainer, err := d.apiClient.ContainerCreate(ctx, &container.Config{
+		Image: imageId,
+	}, &container.HostConfig{
+		Binds: binds,
+		// The files keep their owners only if they are copied in the user namespace of the source
+		UsernsMode: ct.HostConfig.UsernsMode,
+	}, nil, nil, fmt.Sprintf("%s-clone", cloneId))
+	if err != nil {
+		return fmt.Errorf("failed to create clone container: %w", err)
+	}
+
+	defer func() {
+		err := d.apiClient.ContainerRemove(context.WithoutCancel(ctx), copyContainer.ID, container.RemoveOptions{Force: true})
+		if err != nil {
+			log.Errorf("Failed to remove clone container of sandbox %s: %v", cloneId, err)
+		}
+	}()
+
+	if ct.State.Running && !ct.State.Paused {
+		err = d.apiClient.ContainerPause(ctx, ct.ID)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := d.apiClient.ContainerUnpause(context.WithoutCancel(ctx), ct.ID)
+			if err != nil {
+				log.Errorf("Failed to unpause sandbox %s after cloning it: %v", ct.ID, err)
+			}
+		}()
+	}
+
+	for _, mountPoint := range mountPoints {
+		err = d.copyVolume(ctx, ct.ID, copyContainer.ID, mountPoint.Destination)
+		if err != nil {
+			return fmt.Errorf("failed to copy volume %s: %w", mountPoint.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) copyVolume(ctx context.Context, sourceId string, targetId string, destination string) error {
+	reader, _, err := d.apiClient.CopyFromContainer(ctx, sourceId, destination)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// The archive root is the base name of the destination so it is extracted into the parent directory
+	return d.apiClient.CopyToContainer(ctx, targetId, path.Dir(destination), reader, container.CopyToContainerOptions{
+		CopyUIDGID: true,
+	})
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+)
+
+func getComposeNetworkName(sandboxId string) string {
+	return fmt.Sprintf("daytona-compose-%s", sandboxId)
+}
+
+func getComposeContainerName(sandboxId string, service string) string {
+	return fmt.Sprintf("%s-%s", sandboxId, service)
+}
+
+// CreateCompose creates a multi-container sandbox from a compose spec. All services join a dedicated network
+// where they are reachable by service name and are tracked as one sandbox in the cache.
+func (d *DockerClient) CreateCompose(ctx context.Context, composeDto dto.CreateComposeSandboxDTO) error {
+	ctx, span := telemetry.StartSpan(ctx, "docker.CreateCompose", composeDto.Id)
+	defer span.End()
+
+	spec, err := parseComposeSpec(composeDto.Compose)
+	if err != nil {
+		return err
+	}
+
+	order, err := spec.startOrder()
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, composeDto.Id, enums.SandboxStateCreating)
+
+	networkName, err := d.ensureComposeNetwork(ctx, composeDto.Id)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, composeDto.Id, enums.SandboxStatePullingSnapshot)
+
+	for _, name := range order {
+		err = d.PullImage(ctx, spec.Services[name].Image, composeDto.Registry, "")
+		if err != nil {
+			return fmt.Errorf("failed to pull image for service %s: %w", name, err)
+		}
+	}
+
+	d.cache.SetSandboxState(ctx, composeDto.Id, enums.SandboxStateCreating)
+
+	for index, name := range order {
+		err = d.createComposeService(ctx, composeDto, name, spec.Services[name], networkName, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = d.StartCompose(ctx, composeDto.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		d.applyCreatePolicies(ctx, getComposeServiceSandboxDTO(composeDto, name, spec.Services[name]))
+	}
+
+	return nil
+}
+
+func (d *DockerClient) ensureComposeNetwork(ctx context.Context, sandboxId string) (string, error) {
+	networkName := getComposeNetworkName(sandboxId)
+
+	_, err := d.apiClient.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if err == nil {
+		return networkName, nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return "", err
+	}
+
+	_, err = d.apiClient.NetworkCreate(ctx, networkName, network.CreateOptions{
+		Driver: "bridge",
+		Labels: map[string]string{
+			constants.COMPOSE_SANDBOX_LABEL: sandboxId,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create sandbox network: %w", err)
+	}
+
+	return networkName, nil
+}
+
+func (d *DockerClient) createComposeService(ctx context.Context, composeDto dto.CreateComposeSandboxDTO, name string, service composeService, networkName string, startIndex int) error {
+	containerName := getComposeContainerName(composeDto.Id, name)
+
+	_, err := d.ContainerInspect(ctx, containerName)
+	if err == nil {
+		// Already created by an earlier attempt
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	env := append([]string{
+		"DAYTONA_SANDBOX_ID=" + composeDto.Id,
+		"DAYTONA_SANDBOX_SERVICE=" + name,
+	}, service.Environment...)
+	for key, value := range composeDto.Env {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	labels := map[string]string{
+		constants.ORGANIZATION_ID_LABEL:     composeDto.UserId,
+		constants.COMPOSE_SANDBOX_LABEL:     composeDto.Id,
+		constants.COMPOSE_SERVICE_LABEL:     name,
+		constants.COMPOSE_START_INDEX_LABEL: strconv.Itoa(startIndex),
+		constants.STORAGE_QUOTA_LABEL:       strconv.FormatInt(composeDto.StorageQuota, 10),
+	}
+	if composeDto.Security != nil && composeDto.Security.Profile != "" {
+		labels[constants.SECURITY_PROFILE_LABEL] = composeDto.Security.Profile
+	}
+
+	containerConfig := &container.Config{
+		Hostname:   name,
+		Image:      service.Image,
+		Env:        env,
+		Cmd:        []string(service.Command),
+		Entrypoint: []string(service.Entrypoint),
+		WorkingDir: service.WorkingDir,
+		User:       service.User,
+		Labels:     labels,
+	}
+
+	// Services get the resources, the privileges and the security options a single-container sandbox would get
+	hostConfig, err := d.getContainerHostConfig(ctx, getComposeServiceSandboxDTO(composeDto, name, service), nil)
+	if err != nil {
+		return err
+	}
+
+	if d.rootless {
+		info, err := d.apiClient.Info(ctx)
+		if err != nil {
+			return err
+		}
+		applyRootlessConfig(info, containerName, containerConfig, hostConfig)
+	}
+
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			networkName: {
+				Aliases: []string{name},
+			},
+		},
+	}
+
+	_, err = d.apiClient.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to create container for service %s: %w", name, err)
+	}
+
+	log.Infof("Created service %s of sandbox %s", name, composeDto.Id)
+
+	return nil
+}
+
+// getComposeServiceSandboxDTO describes a service as the sandbox its container would be on its own, the host config
+// and the egress policy of the service are derived from it
+func getComposeServiceSandboxDTO(composeDto dto.CreateComposeSandboxDTO, name string, service composeService) dto.CreateSandboxDTO {
+	return dto.CreateSandboxDTO{
+		Id:               getComposeContainerName(composeDto.Id, name),
+		UserId:           composeDto.UserId,
+		Snapshot:         service.Image,
+		CpuQuota:         composeDto.CpuQuota,
+		MemoryQuota:      composeDto.MemoryQuota,
+		StorageQuota:     composeDto.StorageQuota,
+		NetworkBlockAll:  composeDto.NetworkBlockAll,
+		NetworkAllowList: composeDto.NetworkAllowList,
+		EgressPolicy:     composeDto.EgressPolicy,
+		Security:         composeDto.Security,
+	}
+}
+
+// listComposeContainers returns the containers of a multi-container sandbox
+func (d *DockerClient) listComposeContainers(ctx context.Context, sandboxId string) ([]types.Container, error) {
+	return d.apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.COMPOSE_SANDBOX_LABEL, sandboxId))),
+	})
+}
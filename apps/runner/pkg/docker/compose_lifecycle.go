@@ -0,0 +1,174 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+// getOrderedComposeContainers returns the sandbox containers sorted in dependency (start) order
+func (d *DockerClient) getOrderedComposeContainers(ctx context.Context, sandboxId string) ([]types.Container, error) {
+	containers, err := d.listComposeContainers(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containers) == 0 {
+		return nil, common.NewNotFoundError(fmt.Errorf("compose sandbox %s not found", sandboxId))
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		a, _ := strconv.Atoi(containers[i].Labels[constants.COMPOSE_START_INDEX_LABEL])
+		b, _ := strconv.Atoi(containers[j].Labels[constants.COMPOSE_START_INDEX_LABEL])
+		return a < b
+	})
+
+	return containers, nil
+}
+
+func (d *DockerClient) StartCompose(ctx context.Context, sandboxId string) error {
+	containers, err := d.getOrderedComposeContainers(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateStarting)
+
+	for _, ct := range containers {
+		if ct.State == "running" {
+			continue
+		}
+
+		err = d.apiClient.ContainerStart(ctx, ct.ID, container.StartOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to start service %s: %w", ct.Labels[constants.COMPOSE_SERVICE_LABEL], err)
+		}
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateStarted)
+
+	return nil
+}
+
+// StopCompose stops the services in reverse dependency order
+func (d *DockerClient) StopCompose(ctx context.Context, sandboxId string) error {
+	containers, err := d.getOrderedComposeContainers(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateStopping)
+
+	for i := len(containers) - 1; i >= 0; i-- {
+		ct := containers[i]
+		if ct.State != "running" {
+			continue
+		}
+
+		err = d.apiClient.ContainerStop(ctx, ct.ID, container.StopOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to stop service %s: %w", ct.Labels[constants.COMPOSE_SERVICE_LABEL], err)
+		}
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateStopped)
+
+	return nil
+}
+
+// DestroyCompose removes all service containers and the sandbox network
+func (d *DockerClient) DestroyCompose(ctx context.Context, sandboxId string) error {
+	containers, err := d.listComposeContainers(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateDestroying)
+
+	for _, ct := range containers {
+		err = d.apiClient.ContainerRemove(ctx, ct.ID, container.RemoveOptions{Force: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to remove service %s: %w", ct.Labels[constants.COMPOSE_SERVICE_LABEL], err)
+		}
+	}
+
+	err = d.apiClient.NetworkRemove(ctx, getComposeNetworkName(sandboxId))
+	if err != nil && !errdefs.IsNotFound(err) {
+		log.Errorf("Failed to remove network of sandbox %s: %v", sandboxId, err)
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateDestroyed)
+
+	return nil
+}
+
+// GetComposeInfo returns the state of every service and the aggregated sandbox state
+func (d *DockerClient) GetComposeInfo(ctx context.Context, sandboxId string) (*dto.ComposeSandboxInfoResponse, error) {
+	containers, err := d.getOrderedComposeContainers(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]dto.ComposeServiceDTO, 0, len(containers))
+	for _, ct := range containers {
+		services = append(services, dto.ComposeServiceDTO{
+			Name:        ct.Labels[constants.COMPOSE_SERVICE_LABEL],
+			ContainerId: ct.ID,
+			Image:       ct.Image,
+			State:       ct.State,
+		})
+	}
+
+	state := aggregateComposeState(containers)
+
+	// Transitional states are only known to the cache
+	cached := d.cache.Get(ctx, sandboxId)
+	if cached != nil {
+		switch cached.SandboxState {
+		case enums.SandboxStateCreating, enums.SandboxStatePullingSnapshot, enums.SandboxStateStarting, enums.SandboxStateStopping, enums.SandboxStateDestroying:
+			state = cached.SandboxState
+		}
+	}
+
+	return &dto.ComposeSandboxInfoResponse{
+		State:    state.String(),
+		Services: services,
+	}, nil
+}
+
+// aggregateComposeState is started only when every service runs, stopped when none does
+// and error if a service died or only part of the sandbox is running
+func aggregateComposeState(containers []types.Container) enums.SandboxState {
+	running := 0
+	for _, ct := range containers {
+		switch ct.State {
+		case "running":
+			running++
+		case "dead":
+			return enums.SandboxStateError
+		case "restarting", "created":
+			return enums.SandboxStateStarting
+		}
+	}
+
+	switch running {
+	case len(containers):
+		return enums.SandboxStateStarted
+	case 0:
+		return enums.SandboxStateStopped
+	default:
+		return enums.SandboxStateError
+	}
+}
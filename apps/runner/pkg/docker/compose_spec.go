@@ -0,0 +1,220 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"gopkg.in/yaml.v3"
+)
+
+// composeSpec is the subset of the compose file format supported for multi-container sandboxes
+type composeSpec struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string         `yaml:"image"`
+	Command     composeCommand `yaml:"command"`
+	Entrypoint  composeCommand `yaml:"entrypoint"`
+	Environment composeEnv     `yaml:"environment"`
+	DependsOn   composeDeps    `yaml:"depends_on"`
+	WorkingDir  string         `yaml:"working_dir"`
+	User        string         `yaml:"user"`
+}
+
+// composeCommand accepts both the string and the list form
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		args, err := splitCommand(node.Value)
+		if err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	}
+
+	var args []string
+	err := node.Decode(&args)
+	if err != nil {
+		return err
+	}
+	*c = args
+	return nil
+}
+
+// composeEnv accepts both the mapping and the KEY=VALUE list form
+type composeEnv []string
+
+func (e *composeEnv) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var values map[string]string
+		err := node.Decode(&values)
+		if err != nil {
+			return err
+		}
+
+		env := make([]string, 0, len(values))
+		for key, value := range values {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(env)
+		*e = env
+		return nil
+	}
+
+	var env []string
+	err := node.Decode(&env)
+	if err != nil {
+		return err
+	}
+	*e = env
+	return nil
+}
+
+// composeDeps accepts both the list and the mapping (service: {condition: ...}) form. Conditions are ignored,
+// dependencies are only used to order container start.
+type composeDeps []string
+
+func (d *composeDeps) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var values map[string]any
+		err := node.Decode(&values)
+		if err != nil {
+			return err
+		}
+
+		deps := make([]string, 0, len(values))
+		for name := range values {
+			deps = append(deps, name)
+		}
+		sort.Strings(deps)
+		*d = deps
+		return nil
+	}
+
+	var deps []string
+	err := node.Decode(&deps)
+	if err != nil {
+		return err
+	}
+	*d = deps
+	return nil
+}
+
+func parseComposeSpec(raw string) (*composeSpec, error) {
+	var spec composeSpec
+	err := yaml.Unmarshal([]byte(raw), &spec)
+	if err != nil {
+		return nil, common.NewBadRequestError(fmt.Errorf("invalid compose spec: %w", err))
+	}
+
+	if len(spec.Services) == 0 {
+		return nil, common.NewBadRequestError(errors.New("compose spec has no services"))
+	}
+
+	for name, service := range spec.Services {
+		if service.Image == "" {
+			return nil, common.NewBadRequestError(fmt.Errorf("service %s has no image, building services is not supported", name))
+		}
+	}
+
+	return &spec, nil
+}
+
+// startOrder sorts services so every service comes after its dependencies
+func (s *composeSpec) startOrder() ([]string, error) {
+	names := make([]string, 0, len(s.Services))
+	for name := range s.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int, len(names))
+	order := make([]string, 0, len(names))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visiting:
+			return common.NewBadRequestError(fmt.Errorf("dependency cycle detected at service %s", name))
+		case visited:
+			return nil
+		}
+
+		marks[name] = visiting
+		for _, dep := range s.Services[name].DependsOn {
+			if _, ok := s.Services[dep]; !ok {
+				return common.NewBadRequestError(fmt.Errorf("service %s depends on unknown service %s", name, dep))
+			}
+			err := visit(dep)
+			if err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range names {
+		err := visit(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// splitCommand splits a command string into arguments honoring single and double quotes
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
@@ -11,8 +11,6 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) commitContainer(ctx context.Context, containerId, imageName string) error {
@@ -75,67 +73,73 @@ func (d *DockerClient) exportImportContainer(ctx context.Context, containerId, i
 		Message: fmt.Sprintf("Imported from container %s", containerId),
 	}
 
-	// Build the configuration changes to preserve CMD, ENTRYPOINT, ENV, etc.
+	// Apply the changes
+	changes := getImportChanges(containerInfo.Config)
+	importOptions.Changes = changes
+
+	log.Infof("Applying configuration changes: %v", changes)
+
+	importResponse, err := d.apiClient.ImageImport(ctx, image.ImportSource{
+		Source:     exportReader,
+		SourceName: "-",
+	}, imageName, importOptions)
+	if err != nil {
+		return fmt.Errorf("failed to import container %s as image %s: %w", containerId, imageName, err)
+	}
+	defer importResponse.Close()
+
+	// Read the import response to completion
+	_, err = io.ReadAll(importResponse)
+	if err != nil {
+		return fmt.Errorf("failed to read import response for container %s: %w", containerId, err)
+	}
+
+	log.Infof("Container %s successfully exported and imported as image %s with preserved configuration", containerId, imageName)
+	return nil
+}
+
+// getImportChanges returns the Dockerfile instructions that preserve CMD, ENTRYPOINT, ENV, etc. of the
+// container config on an imported filesystem
+func getImportChanges(config *container.Config) []string {
 	var changes []string
 
 	// Preserve CMD if it exists
-	if len(containerInfo.Config.Cmd) > 0 {
-		cmdStr := buildDockerfileCmd(containerInfo.Config.Cmd)
+	if len(config.Cmd) > 0 {
+		cmdStr := buildDockerfileCmd(config.Cmd)
 		changes = append(changes, fmt.Sprintf("CMD %s", cmdStr))
 	}
 
 	// Preserve ENTRYPOINT if it exists
-	if len(containerInfo.Config.Entrypoint) > 0 {
-		entrypointStr := buildDockerfileCmd(containerInfo.Config.Entrypoint)
+	if len(config.Entrypoint) > 0 {
+		entrypointStr := buildDockerfileCmd(config.Entrypoint)
 		changes = append(changes, fmt.Sprintf("ENTRYPOINT %s", entrypointStr))
 	}
 
 	// Preserve environment variables
-	if len(containerInfo.Config.Env) > 0 {
-		for _, env := range containerInfo.Config.Env {
+	if len(config.Env) > 0 {
+		for _, env := range config.Env {
 			changes = append(changes, fmt.Sprintf("ENV %s", env))
 		}
 	}
 
 	// Preserve working directory
-	if containerInfo.Config.WorkingDir != "" {
-		changes = append(changes, fmt.Sprintf("WORKDIR %s", containerInfo.Config.WorkingDir))
+	if config.WorkingDir != "" {
+		changes = append(changes, fmt.Sprintf("WORKDIR %s", config.WorkingDir))
 	}
 
 	// Preserve exposed ports
-	if len(containerInfo.Config.ExposedPorts) > 0 {
-		for port := range containerInfo.Config.ExposedPorts {
+	if len(config.ExposedPorts) > 0 {
+		for port := range config.ExposedPorts {
 			changes = append(changes, fmt.Sprintf("EXPOSE %s", string(port)))
 		}
 	}
 
 	// Preserve user
-	if containerInfo.Config.User != "" {
-		changes = append(changes, fmt.Sprintf("USER %s", containerInfo.Config.User))
+	if config.User != "" {
+		changes = append(changes, fmt.Sprintf("USER %s", config.User))
 	}
 
-	// Apply the changes
-	importOptions.Changes = changes
-
-	log.Infof("Applying configuration changes: %v", changes)
-
-	importResponse, err := d.apiClient.ImageImport(ctx, image.ImportSource{
-		Source:     exportReader,
-		SourceName: "-",
-	}, imageName, importOptions)
-	if err != nil {
-		return fmt.Errorf("failed to import container %s as image %s: %w", containerId, imageName, err)
-	}
-	defer importResponse.Close()
-
-	// Read the import response to completion
-	_, err = io.ReadAll(importResponse)
-	if err != nil {
-		return fmt.Errorf("failed to read import response for container %s: %w", containerId, err)
-	}
-
-	log.Infof("Container %s successfully exported and imported as image %s with preserved configuration", containerId, imageName)
-	return nil
+	return changes
 }
 
 // buildDockerfileCmd converts a slice of command arguments to a properly formatted Dockerfile CMD/ENTRYPOINT string
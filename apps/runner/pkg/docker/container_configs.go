@@ -7,15 +7,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/internal/constants"
 	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 
 	"github.com/docker/docker/api/types/container"
 )
 
-func (d *DockerClient) getContainerConfigs(ctx context.Context, sandboxDto dto.CreateSandboxDTO, volumeMountPathBinds []string) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+const NVIDIA_RUNTIME = "nvidia"
+
+func (d *DockerClient) getContainerConfigs(ctx context.Context, sandboxDto dto.CreateSandboxDTO, volumeMountPathBinds []string, networkName string) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
 	containerConfig := d.getContainerCreateConfig(sandboxDto)
 
 	hostConfig, err := d.getContainerHostConfig(ctx, sandboxDto, volumeMountPathBinds)
@@ -23,7 +29,15 @@ func (d *DockerClient) getContainerConfigs(ctx context.Context, sandboxDto dto.C
 		return nil, nil, nil, err
 	}
 
-	networkingConfig := d.getContainerNetworkingConfig(ctx)
+	if d.rootless {
+		info, err := d.apiClient.Info(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		applyRootlessConfig(info, sandboxDto.Id, containerConfig, hostConfig)
+	}
+
+	networkingConfig := d.getContainerNetworkingConfig(ctx, networkName)
 	return containerConfig, hostConfig, networkingConfig, nil
 }
 
@@ -38,6 +52,35 @@ func (d *DockerClient) getContainerCreateConfig(sandboxDto dto.CreateSandboxDTO)
 		envVars = append(envVars, fmt.Sprintf("%s=%s", key, value))
 	}
 
+	labels := map[string]string{
+		constants.ORGANIZATION_ID_LABEL: sandboxDto.UserId,
+	}
+
+	if sandboxDto.IdleTimeoutMinutes > 0 {
+		labels[constants.IDLE_TIMEOUT_LABEL] = strconv.Itoa(sandboxDto.IdleTimeoutMinutes)
+	}
+
+	restartPolicy, ok := getRestartPolicyLabel(sandboxDto.RestartPolicy)
+	if ok {
+		labels[constants.RESTART_POLICY_LABEL] = restartPolicy
+	}
+
+	if sandboxDto.StorageQuota > 0 {
+		labels[constants.STORAGE_QUOTA_LABEL] = strconv.FormatInt(sandboxDto.StorageQuota, 10)
+	}
+
+	if sandboxDto.IngressBandwidthMbps > 0 {
+		labels[constants.INGRESS_BANDWIDTH_LABEL] = strconv.FormatInt(sandboxDto.IngressBandwidthMbps, 10)
+	}
+
+	if sandboxDto.EgressBandwidthMbps > 0 {
+		labels[constants.EGRESS_BANDWIDTH_LABEL] = strconv.FormatInt(sandboxDto.EgressBandwidthMbps, 10)
+	}
+
+	if sandboxDto.Security != nil && sandboxDto.Security.Profile != "" {
+		labels[constants.SECURITY_PROFILE_LABEL] = sandboxDto.Security.Profile
+	}
+
 	return &container.Config{
 		Hostname: sandboxDto.Id,
 		Image:    sandboxDto.Snapshot,
@@ -46,13 +89,18 @@ func (d *DockerClient) getContainerCreateConfig(sandboxDto dto.CreateSandboxDTO)
 		Entrypoint:   sandboxDto.Entrypoint,
 		AttachStdout: true,
 		AttachStderr: true,
+		Labels:       labels,
 	}
 }
 
 func (d *DockerClient) getContainerHostConfig(ctx context.Context, sandboxDto dto.CreateSandboxDTO, volumeMountPathBinds []string) (*container.HostConfig, error) {
 	var binds []string
 
-	binds = append(binds, fmt.Sprintf("%s:/usr/local/bin/daytona:ro", d.daemonPath))
+	daemonPath, err := d.getDaemonPath(ctx, sandboxDto.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	binds = append(binds, fmt.Sprintf("%s:/usr/local/bin/daytona:ro", daemonPath))
 
 	// Mount the plugin if available
 	if d.computerUsePluginPath != "" {
@@ -63,6 +111,12 @@ func (d *DockerClient) getContainerHostConfig(ctx context.Context, sandboxDto dt
 		binds = append(binds, volumeMountPathBinds...)
 	}
 
+	restoredVolumeBinds, err := d.getRestoredVolumeBinds(ctx, sandboxDto.Id)
+	if err != nil {
+		return nil, err
+	}
+	binds = append(binds, restoredVolumeBinds...)
+
 	hostConfig := &container.HostConfig{
 		Privileged: true,
 		ExtraHosts: []string{"host.docker.internal:host-gateway"},
@@ -73,29 +127,87 @@ func (d *DockerClient) getContainerHostConfig(ctx context.Context, sandboxDto dt
 			MemorySwap: sandboxDto.MemoryQuota * 1024 * 1024 * 1024,
 		},
 		Binds: binds,
+		Tmpfs: map[string]string{
+			SANDBOX_SECRETS_PATH: "rw,noexec,nosuid,size=16m,mode=0755",
+		},
+	}
+
+	info, err := d.apiClient.Info(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	containerRuntime := config.GetContainerRuntime()
+	if sandboxDto.Runtime != "" {
+		containerRuntime, err = resolveRuntime(info, sandboxDto.Runtime)
+		if err != nil {
+			return nil, err
+		}
+
+		// A privileged container under gVisor or Kata would give up the isolation the runtime was chosen for
+		if isSandboxedRuntime(containerRuntime) {
+			hostConfig.Privileged = false
+		}
+	}
 	if containerRuntime != "" {
 		hostConfig.Runtime = containerRuntime
 	}
 
-	filesystem, err := d.getFilesystem(ctx)
+	err = applySecurityOptions(sandboxDto, hostConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if sandboxDto.Security != nil && sandboxDto.Security.Profile != "" {
+		err = d.applySecurityProfile(sandboxDto.Security.Profile, hostConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = d.applyPrivilegePolicy(sandboxDto, hostConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.applyUserNamespace(info, sandboxDto, hostConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	if filesystem == "xfs" {
+	deviceRequest := getGpuDeviceRequest(sandboxDto)
+	if deviceRequest != nil {
+		if sandboxDto.Runtime != "" && isSandboxedRuntime(containerRuntime) {
+			return nil, common.NewBadRequestError(fmt.Errorf("GPUs are not supported with the %s runtime", sandboxDto.Runtime))
+		}
+
+		if _, ok := info.Runtimes[NVIDIA_RUNTIME]; !ok {
+			return nil, common.NewBadRequestError(errors.New("GPUs were requested but the nvidia runtime is not available on this runner"))
+		}
+
+		hostConfig.DeviceRequests = []container.DeviceRequest{*deviceRequest}
+		if containerRuntime == "" || sandboxDto.Runtime == RUNTIME_RUNC {
+			hostConfig.Runtime = NVIDIA_RUNTIME
+		}
+	}
+
+	if supportsStorageQuota(info) {
 		hostConfig.StorageOpt = map[string]string{
 			"size": fmt.Sprintf("%dG", sandboxDto.StorageQuota),
 		}
+	} else {
+		log.Warnf("Storage driver %s does not support size limits, the storage quota of sandbox %s is not enforced", info.Driver, sandboxDto.Id)
 	}
 
 	return hostConfig, nil
 }
 
-func (d *DockerClient) getContainerNetworkingConfig(_ context.Context) *network.NetworkingConfig {
+// getContainerNetworkingConfig attaches the container to its dedicated network if it has one, otherwise to the shared network
+func (d *DockerClient) getContainerNetworkingConfig(_ context.Context, networkName string) *network.NetworkingConfig {
 	containerNetwork := config.GetContainerNetwork()
+	if networkName != "" {
+		containerNetwork = networkName
+	}
 	if containerNetwork != "" {
 		return &network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -106,12 +218,40 @@ func (d *DockerClient) getContainerNetworkingConfig(_ context.Context) *network.
 	return nil
 }
 
-func (d *DockerClient) getFilesystem(ctx context.Context) (string, error) {
-	info, err := d.apiClient.Info(ctx)
-	if err != nil {
-		return "", err
+func getGpuDeviceRequest(sandboxDto dto.CreateSandboxDTO) *container.DeviceRequest {
+	if len(sandboxDto.GpuDeviceIds) == 0 && sandboxDto.GpuQuota <= 0 {
+		return nil
+	}
+
+	deviceRequest := &container.DeviceRequest{
+		Driver:       "nvidia",
+		Capabilities: [][]string{{"gpu"}},
+	}
+
+	if len(sandboxDto.GpuDeviceIds) > 0 {
+		deviceRequest.DeviceIDs = sandboxDto.GpuDeviceIds
+	} else {
+		deviceRequest.Count = int(sandboxDto.GpuQuota)
+	}
+
+	return deviceRequest
+}
+
+// supportsStorageQuota reports whether the writable layer size can be limited. overlay2 needs an XFS
+// backing filesystem mounted with project quotas (pquota), btrfs and zfs support the size option natively.
+func supportsStorageQuota(info system.Info) bool {
+	switch info.Driver {
+	case "btrfs", "zfs":
+		return true
+	case "overlay2":
+		filesystem, err := getFilesystem(info)
+		return err == nil && filesystem == "xfs"
+	default:
+		return false
 	}
+}
 
+func getFilesystem(info system.Info) (string, error) {
 	for _, driver := range info.DriverStatus {
 		if driver[0] == "Backing Filesystem" {
 			return driver[1], nil
@@ -0,0 +1,232 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+const defaultTmpfsSizeMiB = 64
+
+const noNewPrivilegesOption = "no-new-privileges"
+
+var capabilityRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
+
+// Docker only applies its default masked and read-only paths if none are set, they are kept when the sandbox adds its own
+var (
+	defaultMaskedPaths = []string{
+		"/proc/asound",
+		"/proc/acpi",
+		"/proc/interrupts",
+		"/proc/kcore",
+		"/proc/keys",
+		"/proc/latency_stats",
+		"/proc/timer_list",
+		"/proc/timer_stats",
+		"/proc/sched_debug",
+		"/proc/scsi",
+		"/sys/firmware",
+		"/sys/devices/virtual/powercap",
+	}
+	defaultReadonlyPaths = []string{
+		"/proc/bus",
+		"/proc/fs",
+		"/proc/irq",
+		"/proc/sys",
+		"/proc/sysrq-trigger",
+	}
+)
+
+// Paths the runner mounts into every sandbox, the sandbox can't mount over them
+var reservedSandboxPaths = []string{
+	"/usr/local/bin/daytona",
+	"/usr/local/lib/daytona-computer-use",
+	SANDBOX_SECRETS_PATH,
+}
+
+// applySecurityOptions applies the hardening the sandbox was created with to its host config
+func applySecurityOptions(sandboxDto dto.CreateSandboxDTO, hostConfig *container.HostConfig) error {
+	security := sandboxDto.Security
+	if security == nil {
+		return nil
+	}
+
+	// The repository is copied into the root filesystem
+	if security.ReadOnlyRootfs && sandboxDto.Repository != nil {
+		return common.NewBadRequestError(fmt.Errorf("a repository can't be cloned into sandbox %s with a read-only root filesystem", sandboxDto.Id))
+	}
+	hostConfig.ReadonlyRootfs = security.ReadOnlyRootfs
+
+	for _, tmpfs := range security.Tmpfs {
+		mountPath := path.Clean(tmpfs.Path)
+		if slices.Contains(reservedSandboxPaths, mountPath) {
+			return common.NewBadRequestError(fmt.Errorf("tmpfs can't be mounted at %s, the path is reserved by the runner", mountPath))
+		}
+
+		if _, ok := hostConfig.Tmpfs[mountPath]; ok {
+			return common.NewBadRequestError(fmt.Errorf("tmpfs is mounted at %s more than once", mountPath))
+		}
+
+		options, err := getTmpfsOptions(tmpfs)
+		if err != nil {
+			return err
+		}
+		hostConfig.Tmpfs[mountPath] = options
+	}
+
+	if len(security.MaskedPaths) > 0 || len(security.ReadonlyPaths) > 0 {
+		hostConfig.MaskedPaths = appendUnique(slices.Clone(defaultMaskedPaths), security.MaskedPaths)
+		hostConfig.ReadonlyPaths = appendUnique(slices.Clone(defaultReadonlyPaths), security.ReadonlyPaths)
+		// Docker ignores masked and read-only paths of privileged containers
+		hostConfig.Privileged = false
+	}
+
+	if len(security.CapAdd) > 0 || len(security.CapDrop) > 0 || security.NoNewPrivileges {
+		var err error
+		hostConfig.CapAdd, err = normalizeCapabilities(security.CapAdd)
+		if err != nil {
+			return err
+		}
+
+		hostConfig.CapDrop, err = normalizeCapabilities(security.CapDrop)
+		if err != nil {
+			return err
+		}
+
+		if security.NoNewPrivileges {
+			hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, noNewPrivilegesOption)
+		}
+
+		// Privileged containers get every capability and may gain privileges regardless
+		hostConfig.Privileged = false
+	}
+
+	return nil
+}
+
+// normalizeCapabilities returns the capabilities without the CAP_ prefix, Docker accepts either form
+func normalizeCapabilities(capabilities []string) ([]string, error) {
+	var normalized []string
+	for _, capability := range capabilities {
+		capability = strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+		if !capabilityRegex.MatchString(capability) {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid capability %q", capability))
+		}
+
+		if !slices.Contains(normalized, capability) {
+			normalized = append(normalized, capability)
+		}
+	}
+
+	return normalized, nil
+}
+
+func getTmpfsOptions(tmpfs dto.TmpfsMountDTO) (string, error) {
+	options := []string{"rw", "nosuid", "nodev"}
+	if !tmpfs.Exec {
+		options = append(options, "noexec")
+	}
+
+	sizeMiB := tmpfs.SizeMiB
+	if sizeMiB == 0 {
+		sizeMiB = defaultTmpfsSizeMiB
+	}
+	options = append(options, fmt.Sprintf("size=%dm", sizeMiB))
+
+	mode := tmpfs.Mode
+	if mode == "" {
+		mode = "1777"
+	}
+	parsed, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || parsed > 07777 {
+		return "", common.NewBadRequestError(fmt.Errorf("invalid mode %s of the tmpfs at %s", tmpfs.Mode, tmpfs.Path))
+	}
+	options = append(options, "mode="+mode)
+
+	return strings.Join(options, ","), nil
+}
+
+// getSandboxSecurity derives the hardening options of a sandbox from its container, e.g. to clone it
+func getSandboxSecurity(ct *types.ContainerJSON) *dto.SandboxSecurityDTO {
+	security := &dto.SandboxSecurityDTO{
+		ReadOnlyRootfs: ct.HostConfig.ReadonlyRootfs,
+		Profile:        ct.Config.Labels[constants.SECURITY_PROFILE_LABEL],
+	}
+
+	for mountPath, options := range ct.HostConfig.Tmpfs {
+		if mountPath == SANDBOX_SECRETS_PATH {
+			continue
+		}
+
+		tmpfs := dto.TmpfsMountDTO{Path: mountPath}
+		for _, option := range strings.Split(options, ",") {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "exec":
+				tmpfs.Exec = true
+			case "mode":
+				tmpfs.Mode = value
+			case "size":
+				tmpfs.SizeMiB, _ = strconv.ParseInt(strings.TrimSuffix(value, "m"), 10, 64)
+			}
+		}
+		security.Tmpfs = append(security.Tmpfs, tmpfs)
+	}
+	slices.SortFunc(security.Tmpfs, func(a, b dto.TmpfsMountDTO) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	for _, maskedPath := range ct.HostConfig.MaskedPaths {
+		if !slices.Contains(defaultMaskedPaths, maskedPath) {
+			security.MaskedPaths = append(security.MaskedPaths, maskedPath)
+		}
+	}
+
+	for _, readonlyPath := range ct.HostConfig.ReadonlyPaths {
+		if !slices.Contains(defaultReadonlyPaths, readonlyPath) {
+			security.ReadonlyPaths = append(security.ReadonlyPaths, readonlyPath)
+		}
+	}
+
+	security.CapAdd = ct.HostConfig.CapAdd
+	security.CapDrop = ct.HostConfig.CapDrop
+	security.NoNewPrivileges = slices.Contains(ct.HostConfig.SecurityOpt, noNewPrivilegesOption)
+	security.HostUserNamespace = ct.HostConfig.UsernsMode.IsHost()
+
+	// The policy of the runner may make clones of sandboxes that opted out privileged otherwise
+	if !ct.HostConfig.Privileged {
+		privileged := false
+		security.Privileged = &privileged
+	}
+
+	if !security.ReadOnlyRootfs && len(security.Tmpfs) == 0 && len(security.MaskedPaths) == 0 && len(security.ReadonlyPaths) == 0 &&
+		security.Profile == "" && len(security.CapAdd) == 0 && len(security.CapDrop) == 0 && !security.NoNewPrivileges && security.Privileged == nil &&
+		!security.HostUserNamespace {
+		return nil
+	}
+
+	return security
+}
+
+func appendUnique(paths []string, additional []string) []string {
+	for _, p := range additional {
+		p = path.Clean(p)
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+
+	return paths
+}
@@ -6,20 +6,25 @@ package docker
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/daytonaio/common-go/pkg/timer"
 	"github.com/daytonaio/runner/internal/constants"
 	"github.com/daytonaio/runner/pkg/api/dto"
 	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/errdefs"
-
-	log "github.com/sirupsen/logrus"
 )
 
-func (d *DockerClient) Create(ctx context.Context, sandboxDto dto.CreateSandboxDTO) (string, error) {
+// Create creates and starts the sandbox. A create cancelled by the caller or refused because the runner is at its
+// limits is rolled back so the retry starts over instead of finding a half created sandbox.
+func (d *DockerClient) Create(ctx context.Context, sandboxDto dto.CreateSandboxDTO) (_ string, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "docker.Create", sandboxDto.Id)
+	defer span.End()
+
 	defer timer.Timer()()
 
 	startTime := time.Now()
@@ -39,7 +44,8 @@ func (d *DockerClient) Create(ctx context.Context, sandboxDto dto.CreateSandboxD
 		return sandboxDto.Id, nil
 	}
 
-	if state == enums.SandboxStateStopped || state == enums.SandboxStateCreating {
+	// Start also resumes paused sandboxes
+	if state == enums.SandboxStateStopped || state == enums.SandboxStateCreating || state == enums.SandboxStatePaused {
 		err = d.Start(ctx, sandboxDto.Id)
 		if err != nil {
 			return "", err
@@ -48,22 +54,72 @@ func (d *DockerClient) Create(ctx context.Context, sandboxDto dto.CreateSandboxD
 		return sandboxDto.Id, nil
 	}
 
+	release, err := d.createLimiter.tryAcquire()
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	// Sandboxes in the error or unknown state are created again, the rollback must leave their container alone
+	_, inspectErr := d.ContainerInspect(ctx, sandboxDto.Id)
+	existed := !errdefs.IsNotFound(inspectErr)
+
 	d.cache.SetSandboxState(ctx, sandboxDto.Id, enums.SandboxStateCreating)
 
+	var containerId string
+	defer func() {
+		if err != nil && (ctx.Err() != nil || common.IsResourceExhaustedError(err)) {
+			d.rollbackCreate(context.WithoutCancel(ctx), sandboxDto, containerId, existed)
+		}
+	}()
+
 	ctx = context.WithValue(ctx, constants.ID_KEY, sandboxDto.Id)
-	err = d.PullImage(ctx, sandboxDto.Snapshot, sandboxDto.Registry)
+
+	// The clone runs while the image is pulled and the container is created
+	var repository *repositoryClone
+	if sandboxDto.Repository != nil {
+		repository, err = d.startRepositoryClone(ctx, sandboxDto.Id, *sandboxDto.Repository)
+		if err != nil {
+			return "", err
+		}
+		defer d.closeRepositoryClone(ctx, repository)
+	}
+
+	var devcontainer *devcontainerPlan
+	if sandboxDto.Devcontainer != nil {
+		devcontainer, err = d.resolveDevcontainer(ctx, &sandboxDto)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = d.PullImage(ctx, sandboxDto.Snapshot, sandboxDto.Registry, "")
 	if err != nil {
 		return "", err
 	}
 
 	d.cache.SetSandboxState(ctx, sandboxDto.Id, enums.SandboxStateCreating)
 
+	if devcontainer == nil || !devcontainer.built {
+		err = d.ensureImageVerified(ctx, sandboxDto.Snapshot, sandboxDto.Registry)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	err = d.validateImageArchitecture(ctx, sandboxDto.Snapshot)
 	if err != nil {
 		log.Errorf("ERROR: %s.\n", err.Error())
 		return "", err
 	}
 
+	if sandboxDto.ScanSeverityThreshold != "" {
+		err = d.enforceScanThreshold(ctx, sandboxDto.Snapshot, enums.VulnerabilitySeverity(sandboxDto.ScanSeverityThreshold))
+		if err != nil {
+			return "", err
+		}
+	}
+
 	volumeMountPathBinds := make([]string, 0)
 	if sandboxDto.Volumes != nil {
 		volumeMountPathBinds, err = d.getVolumesMountPathBinds(ctx, sandboxDto.Volumes)
@@ -72,45 +128,163 @@ func (d *DockerClient) Create(ctx context.Context, sandboxDto dto.CreateSandboxD
 		}
 	}
 
-	containerConfig, hostConfig, networkingConfig, err := d.getContainerConfigs(ctx, sandboxDto, volumeMountPathBinds)
+	networkName, err := d.sandboxNetwork.EnsureNetwork(ctx, sandboxDto.Id)
 	if err != nil {
 		return "", err
 	}
 
+	containerConfig, hostConfig, networkingConfig, err := d.getContainerConfigs(ctx, sandboxDto, volumeMountPathBinds, networkName)
+	if err != nil {
+		return "", err
+	}
+
+	if devcontainer != nil {
+		applyDevcontainerPlan(devcontainer, containerConfig, hostConfig)
+	}
+
+	err = d.storeCreateSecrets(sandboxDto)
+	if err != nil {
+		return "", err
+	}
+
+	err = d.storeLifecycleHooks(sandboxDto)
+	if err != nil {
+		return "", fmt.Errorf("failed to store lifecycle hooks of sandbox %s: %w", sandboxDto.Id, err)
+	}
+
 	c, err := d.apiClient.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, sandboxDto.Id)
 	if err != nil {
 		return "", err
 	}
+	containerId = c.ID
+
+	err = d.createSidecars(ctx, sandboxDto)
+	if err != nil {
+		return "", err
+	}
+
+	if repository != nil {
+		err = d.copyRepositoryToSandbox(ctx, repository)
+		if err != nil {
+			return "", err
+		}
+	}
 
 	err = d.Start(ctx, sandboxDto.Id)
 	if err != nil {
 		return "", err
 	}
 
-	containerShortId := c.ID[:12]
-	info, err := d.apiClient.ContainerInspect(context.Background(), sandboxDto.Id)
+	d.applyCreatePolicies(ctx, sandboxDto)
+
+	return c.ID, nil
+}
+
+// rollbackCreate removes what a cancelled or refused create left behind. Only the container created by the create is
+// removed, containerId is empty if ContainerCreate didn't return, in which case the container is looked up by name
+// since the daemon may have created it after the cancellation reached the runner. Nothing is removed if the
+// container existed before the create.
+func (d *DockerClient) rollbackCreate(ctx context.Context, sandboxDto dto.CreateSandboxDTO, containerId string, existed bool) {
+	if existed {
+		log.Infof("Create of sandbox %s was cancelled or refused, keeping its existing container", sandboxDto.Id)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	log.Infof("Create of sandbox %s was cancelled or refused, rolling it back", sandboxDto.Id)
+
+	if containerId == "" {
+		ct, err := d.ContainerInspect(ctx, sandboxDto.Id)
+		if err == nil {
+			containerId = ct.ID
+		}
+	}
+
+	failed := false
+
+	if containerId != "" {
+		d.removeSidecars(ctx, sandboxDto.Id)
+
+		// Network rules are added once the sandbox starts
+		err := d.netRulesManager.DeleteNetworkRules(containerId[:12])
+		if err != nil {
+			log.Errorf("Failed to delete network rules of cancelled sandbox %s: %v", sandboxDto.Id, err)
+			failed = true
+		}
+
+		err = d.apiClient.ContainerRemove(ctx, containerId, container.RemoveOptions{
+			Force: true,
+		})
+		if err != nil && !errdefs.IsNotFound(err) {
+			log.Errorf("Failed to remove container of cancelled sandbox %s: %v", sandboxDto.Id, err)
+			failed = true
+		}
+
+		err = d.sandboxNetwork.RemoveNetwork(ctx, sandboxDto.Id)
+		if err != nil {
+			log.Errorf("Failed to remove network of cancelled sandbox %s: %v", sandboxDto.Id, err)
+			failed = true
+		}
+
+		if sandboxDto.Devcontainer != nil {
+			d.removeDevcontainerVolumes(ctx, sandboxDto.Id)
+		}
+	}
+
+	err := d.sandboxEnv.Remove(sandboxDto.Id)
 	if err != nil {
-		log.Errorf("Failed to inspect container: %v", err)
+		log.Errorf("Failed to remove env of cancelled sandbox %s: %v", sandboxDto.Id, err)
+		failed = true
 	}
-	ip := info.NetworkSettings.IPAddress
 
-	if sandboxDto.NetworkBlockAll != nil && *sandboxDto.NetworkBlockAll {
-		go func() {
-			err = d.netRulesManager.SetNetWorkRules(containerShortId, ip, "")
-			if err != nil {
-				log.Errorf("Failed to update sandbox network settings: %v", err)
-			}
-		}()
-	} else if sandboxDto.NetworkAllowList != nil && *sandboxDto.NetworkAllowList != "" {
+	err = d.lifecycleHooks.Remove(sandboxDto.Id)
+	if err != nil {
+		log.Errorf("Failed to remove lifecycle hooks of cancelled sandbox %s: %v", sandboxDto.Id, err)
+		failed = true
+	}
+
+	// The sandbox never existed as far as the runner is concerned
+	d.cache.Delete(ctx, sandboxDto.Id)
+
+	if failed {
+		common.ContainerOperationCount.WithLabelValues("create_rollback", string(common.PrometheusOperationStatusFailure)).Inc()
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("create_rollback", string(common.PrometheusOperationStatusSuccess)).Inc()
+}
+
+// applyCreatePolicies sets the TTL and the egress policy requested for the sandbox once it is running
+func (d *DockerClient) applyCreatePolicies(ctx context.Context, sandboxDto dto.CreateSandboxDTO) {
+	// An empty allowlist on create has always meant no restrictions
+	networkAllowList := sandboxDto.NetworkAllowList
+	if networkAllowList != nil && *networkAllowList == "" {
+		networkAllowList = nil
+	}
+
+	if sandboxDto.TtlMinutes > 0 {
+		action := enums.ExpiryActionDestroy
+		if sandboxDto.TtlAction != "" {
+			action = enums.ExpiryAction(sandboxDto.TtlAction)
+		}
+
+		d.cache.SetExpiration(ctx, sandboxDto.Id, &models.SandboxExpiration{
+			ExpiresAt: time.Now().Add(time.Duration(sandboxDto.TtlMinutes) * time.Minute),
+			Action:    action,
+		})
+	}
+
+	egressPolicy := GetEgressPolicy(sandboxDto.NetworkBlockAll, networkAllowList, sandboxDto.EgressPolicy)
+	if egressPolicy != nil && egressPolicy.Mode != enums.EgressPolicyModeAllowAll {
 		go func() {
-			err = d.netRulesManager.SetNetWorkRules(containerShortId, ip, *sandboxDto.NetworkAllowList)
+			err := d.sandboxNetwork.ApplyEgressPolicy(context.Background(), sandboxDto.Id, *egressPolicy)
 			if err != nil {
 				log.Errorf("Failed to update sandbox network settings: %v", err)
 			}
 		}()
 	}
-
-	return c.ID, nil
 }
 
 func (p *DockerClient) validateImageArchitecture(ctx context.Context, image string) error {
@@ -124,14 +298,10 @@ func (p *DockerClient) validateImageArchitecture(ctx context.Context, image stri
 		return fmt.Errorf("failed to inspect image: %w", err)
 	}
 
-	arch := strings.ToLower(inspect.Architecture)
-	validArchs := []string{"amd64", "x86_64"}
-
-	for _, validArch := range validArchs {
-		if arch == validArch {
-			return nil
-		}
+	hostPlatform := HostPlatform()
+	if normalizeArchitecture(inspect.Architecture) == hostPlatform.Architecture {
+		return nil
 	}
 
-	return common.NewConflictError(fmt.Errorf("image %s architecture (%s) is not x64 compatible", image, inspect.Architecture))
+	return common.NewConflictError(fmt.Errorf("image %s architecture (%s) is not compatible with the runner architecture (%s)", image, inspect.Architecture, hostPlatform.Architecture))
 }
@@ -0,0 +1,38 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// getDaemonPath returns the daemon binary built for the architecture of the image, a runner executing images
+// of several architectures (e.g. through binfmt emulation) would otherwise inject a daemon that can't run
+func (d *DockerClient) getDaemonPath(ctx context.Context, imageName string) (string, error) {
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return "", common.MapDockerError(err)
+	}
+
+	arch := HostPlatform().Architecture
+	if inspect.Architecture != "" {
+		arch = normalizeArchitecture(inspect.Architecture)
+	}
+
+	daemonPath, ok := d.daemonPaths[arch]
+	if !ok {
+		return "", &common.CustomError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("bad request: no daemon binary for the %s architecture of image %s", arch, imageName),
+			Code:       common.ErrorCodeDaemonInjectionFailed,
+			Details:    map[string]string{"image": imageName, "architecture": arch},
+		}
+	}
+
+	return daemonPath, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Kills what is left of a hung daemon so the restarted one can bind its port, images don't ship a common pkill
+const killDaemonScript = `for p in /proc/[0-9]*; do
+	[ "$(readlink "$p/exe" 2>/dev/null)" = /usr/local/bin/daytona ] && kill -9 "${p#/proc/}"
+done
+exit 0`
+
+// ProbeDaemon checks that the daemon of the running sandbox answers on the address the toolbox proxy forwards to
+func (d *DockerClient) ProbeDaemon(ctx context.Context, containerId string) error {
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := d.GetDaemonAddress(&c)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/version", daemonAddress), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RestartDaemon kills the daemon of the running sandbox if it is still around and starts it again
+func (d *DockerClient) RestartDaemon(ctx context.Context, containerId string) error {
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := d.GetDaemonAddress(&c)
+	if err != nil {
+		return err
+	}
+
+	release, err := d.execLimiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	result, err := d.execSync(ctx, containerId, container.ExecOptions{
+		Cmd:          []string{"sh", "-c", killDaemonScript},
+		AttachStdout: true,
+		AttachStderr: true,
+	}, container.ExecStartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to stop the daemon: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to stop the daemon: %s", result.StdErr)
+	}
+
+	go func() {
+		if err := d.startDaytonaDaemon(context.Background(), containerId, d.getDaemonEnv(containerId)); err != nil {
+			log.Errorf("Failed to start Daytona daemon: %s\n", err.Error())
+		}
+	}()
+
+	return d.waitForDaemonRunning(ctx, daemonAddress, 10*time.Second)
+}
@@ -10,13 +10,15 @@ import (
 
 	"github.com/daytonaio/runner/pkg/common"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/errdefs"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) Destroy(ctx context.Context, containerId string) error {
+	ctx, span := telemetry.StartSpan(ctx, "docker.Destroy", containerId)
+	defer span.End()
+
 	startTime := time.Now()
 	defer func() {
 		obs, err := common.ContainerOperationDuration.GetMetricWithLabelValues("destroy")
@@ -47,6 +49,8 @@ func (d *DockerClient) Destroy(ctx context.Context, containerId string) error {
 		return err
 	}
 
+	d.removeSidecars(ctx, containerId)
+
 	err = d.apiClient.ContainerRemove(ctx, containerId, container.RemoveOptions{
 		Force: true,
 	})
@@ -59,10 +63,28 @@ func (d *DockerClient) Destroy(ctx context.Context, containerId string) error {
 
 	go func() {
 		containerShortId := ct.ID[:12]
-		err = d.netRulesManager.DeleteNetworkRules(containerShortId)
+		err := d.netRulesManager.DeleteNetworkRules(containerShortId)
 		if err != nil {
 			log.Errorf("Failed to delete sandbox network settings: %v", err)
 		}
+
+		err = d.sandboxNetwork.RemoveNetwork(context.Background(), containerId)
+		if err != nil {
+			log.Errorf("Failed to remove sandbox network: %v", err)
+		}
+
+		d.removeRestoredVolumes(context.Background(), containerId)
+		d.removeDevcontainerVolumes(context.Background(), containerId)
+
+		err = d.sandboxEnv.Remove(containerId)
+		if err != nil {
+			log.Errorf("Failed to remove env of sandbox %s: %v", containerId, err)
+		}
+
+		err = d.lifecycleHooks.Remove(containerId)
+		if err != nil {
+			log.Errorf("Failed to remove lifecycle hooks of sandbox %s: %v", containerId, err)
+		}
 	}()
 
 	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateDestroyed)
@@ -0,0 +1,415 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// Images built for devcontainers are tagged with a hash of their inputs so unchanged devcontainers are built once
+const devcontainerImageRepository = "daytona-devcontainer"
+
+var devcontainerConfigPaths = []string{".devcontainer/devcontainer.json", ".devcontainer.json"}
+
+var localEnvVariable = regexp.MustCompile(`\$\{localEnv:[^}:]*(?::([^}]*))?\}`)
+
+// devcontainerSource is the checked out repository of a devcontainer, empty for an inline devcontainer.json
+type devcontainerSource struct {
+	repositoryDir string
+	configDir     string
+	commit        string
+}
+
+// devcontainerPlan is what the sandbox container needs from the devcontainer on top of the create request
+type devcontainerPlan struct {
+	mounts []mount.Mount
+	ports  []int
+	// built is set if the image was built on the runner, built images have no signature to verify
+	built bool
+}
+
+// resolveDevcontainer builds or pulls the image of the devcontainer and applies it to the request: the image
+// becomes the snapshot, the container and remote env are added to the env and the lifecycle commands to the
+// hooks. Values in the request take precedence over the devcontainer.
+func (d *DockerClient) resolveDevcontainer(ctx context.Context, sandboxDto *dto.CreateSandboxDTO) (*devcontainerPlan, error) {
+	workDir, err := os.MkdirTemp("", "daytona-devcontainer-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	source, rawConfig, err := d.getDevcontainerConfig(ctx, sandboxDto.Devcontainer, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := parseDevcontainerSpec(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	hash.Write(rawConfig)
+	fmt.Fprintf(hash, "\n%s\n%s\n%s", sandboxDto.Devcontainer.RepositoryUrl, source.commit, sandboxDto.Devcontainer.ConfigPath)
+	imageName := fmt.Sprintf("%s:%s", devcontainerImageRepository, hex.EncodeToString(hash.Sum(nil))[:16])
+
+	plan := &devcontainerPlan{}
+
+	image := spec.Image
+	if spec.Build != nil {
+		image = imageName
+		if len(spec.Features) > 0 {
+			image = imageName + "-base"
+		}
+
+		err = d.buildDevcontainerImage(ctx, source, spec.Build, image)
+		if err != nil {
+			return nil, err
+		}
+		plan.built = true
+	} else {
+		err = d.PullImage(ctx, image, sandboxDto.Registry, "")
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.ensureImageVerified(ctx, image, sandboxDto.Registry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(spec.Features) > 0 {
+		err = d.buildDevcontainerFeatures(ctx, source, spec, image, imageName, filepath.Join(workDir, "features"))
+		if err != nil {
+			return nil, err
+		}
+		image = imageName
+		plan.built = true
+	}
+
+	sandboxDto.Snapshot = image
+	applyDevcontainerEnv(sandboxDto, spec)
+	applyDevcontainerHooks(sandboxDto, spec)
+
+	for _, specMount := range spec.Mounts {
+		switch specMount.Type {
+		case "volume":
+			// Volume names are scoped to the sandbox so sandboxes never share a volume by accident
+			plan.mounts = append(plan.mounts, mount.Mount{
+				Type:   mount.TypeVolume,
+				Source: fmt.Sprintf("%s-%s", sandboxDto.Id, specMount.Source),
+				Target: specMount.Target,
+				VolumeOptions: &mount.VolumeOptions{
+					Labels: map[string]string{constants.DEVCONTAINER_VOLUME_SANDBOX_LABEL: sandboxDto.Id},
+				},
+			})
+		case "tmpfs":
+			plan.mounts = append(plan.mounts, mount.Mount{
+				Type:   mount.TypeTmpfs,
+				Target: specMount.Target,
+			})
+		}
+	}
+
+	for _, port := range spec.ForwardPorts {
+		plan.ports = append(plan.ports, int(port))
+	}
+
+	return plan, nil
+}
+
+// getDevcontainerConfig clones the repository if there is one and returns the devcontainer.json to use
+func (d *DockerClient) getDevcontainerConfig(ctx context.Context, devcontainer *dto.DevcontainerDTO, workDir string) (devcontainerSource, []byte, error) {
+	if devcontainer.RepositoryUrl == "" {
+		return devcontainerSource{}, []byte(devcontainer.Config), nil
+	}
+
+	repositoryDir := filepath.Join(workDir, "repository")
+	commit, err := cloneRepository(ctx, repositoryOptions{
+		url:   devcontainer.RepositoryUrl,
+		ref:   devcontainer.Ref,
+		token: devcontainer.AuthToken,
+		depth: 1,
+	}, repositoryDir, nil)
+	if err != nil {
+		return devcontainerSource{}, nil, err
+	}
+
+	configPaths := devcontainerConfigPaths
+	if devcontainer.ConfigPath != "" {
+		configPaths = []string{devcontainer.ConfigPath}
+	}
+
+	for _, configPath := range configPaths {
+		path, err := getRepositoryPath(repositoryDir, configPath)
+		if err != nil {
+			return devcontainerSource{}, nil, err
+		}
+
+		source := devcontainerSource{
+			repositoryDir: repositoryDir,
+			configDir:     filepath.Dir(path),
+			commit:        commit,
+		}
+
+		if devcontainer.Config != "" {
+			return source, []byte(devcontainer.Config), nil
+		}
+
+		raw, err := os.ReadFile(path)
+		if err == nil {
+			return source, raw, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return devcontainerSource{}, nil, err
+		}
+	}
+
+	return devcontainerSource{}, nil, common.NewBadRequestError(fmt.Errorf("no devcontainer.json found in %s", strings.Join(configPaths, " or ")))
+}
+
+// getRepositoryPath resolves a path of the devcontainer that must stay inside of the repository
+func getRepositoryPath(repositoryDir string, path string) (string, error) {
+	resolved := filepath.Join(repositoryDir, path)
+	if resolved != repositoryDir && !strings.HasPrefix(resolved, repositoryDir+string(os.PathSeparator)) {
+		return "", common.NewBadRequestError(fmt.Errorf("path %s is outside of the repository", path))
+	}
+
+	return resolved, nil
+}
+
+// buildDevcontainerImage builds the Dockerfile of the devcontainer, the context is relative to the devcontainer.json
+func (d *DockerClient) buildDevcontainerImage(ctx context.Context, source devcontainerSource, build *devcontainerBuild, imageName string) error {
+	if source.repositoryDir == "" {
+		return common.NewBadRequestError(errors.New("building a devcontainer needs a repository with the build context"))
+	}
+
+	exists, err := d.ImageExists(ctx, imageName, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	contextPath, err := filepath.Rel(source.repositoryDir, filepath.Join(source.configDir, build.Context))
+	if err != nil {
+		return err
+	}
+	contextDir, err := getRepositoryPath(source.repositoryDir, contextPath)
+	if err != nil {
+		return err
+	}
+
+	dockerfile, err := filepath.Rel(contextDir, filepath.Join(source.configDir, build.Dockerfile))
+	if err != nil || strings.HasPrefix(dockerfile, "..") {
+		return common.NewBadRequestError(fmt.Errorf("the Dockerfile %s must be inside of the build context", build.Dockerfile))
+	}
+
+	buildArgs := make(map[string]*string, len(build.Args))
+	for key, value := range build.Args {
+		buildArgs[key] = &value
+	}
+
+	return d.buildContextDir(ctx, contextDir, types.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: filepath.ToSlash(dockerfile),
+		BuildArgs:  buildArgs,
+		Target:     build.Target,
+		PullParent: true,
+	})
+}
+
+// buildDevcontainerFeatures installs the features of the devcontainer on top of the base image
+func (d *DockerClient) buildDevcontainerFeatures(ctx context.Context, source devcontainerSource, spec *devcontainerSpec, baseImage string, imageName string, contextDir string) error {
+	exists, err := d.ImageExists(ctx, imageName, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	features, err := d.resolveDevcontainerFeatures(ctx, spec.Features, source, contextDir)
+	if err != nil {
+		return err
+	}
+
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, baseImage)
+	if err != nil {
+		return err
+	}
+	baseUser := ""
+	if inspect.Config != nil {
+		baseUser = inspect.Config.User
+	}
+
+	dockerfile := getFeaturesDockerfile(baseImage, baseUser, spec.user(), features)
+	err = os.WriteFile(filepath.Join(contextDir, "Dockerfile"), []byte(dockerfile), 0644)
+	if err != nil {
+		return err
+	}
+
+	return d.buildContextDir(ctx, contextDir, types.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: "Dockerfile",
+	})
+}
+
+// buildContextDir builds the image for the host platform with the directory as build context
+func (d *DockerClient) buildContextDir(ctx context.Context, contextDir string, options types.ImageBuildOptions) error {
+	buildContext := tarDir(contextDir, "")
+	defer buildContext.Close()
+
+	options.Remove = true
+	options.ForceRemove = true
+	options.Platform = HostPlatform().String()
+
+	log.Infof("Building devcontainer image %s...", options.Tags[0])
+
+	resp, err := d.apiClient.ImageBuild(ctx, buildContext, options)
+	if err != nil {
+		return fmt.Errorf("failed to build devcontainer image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	logWriter := d.logWriter
+	if logWriter == nil {
+		logWriter = io.Discard
+	}
+
+	err = jsonmessage.DisplayJSONMessagesStream(resp.Body, logWriter, 0, false, nil)
+	if err != nil {
+		var jsonError *jsonmessage.JSONError
+		if errors.As(err, &jsonError) {
+			return common.NewBadRequestError(fmt.Errorf("failed to build devcontainer image: %w", err))
+		}
+		return err
+	}
+
+	log.Infof("Built devcontainer image %s", options.Tags[0])
+
+	return nil
+}
+
+// applyDevcontainerEnv adds the container and the remote env, there is no separate remote process so both go
+// to the container. Local env variables resolve to their default since the runner env is not the user's.
+func applyDevcontainerEnv(sandboxDto *dto.CreateSandboxDTO, spec *devcontainerSpec) {
+	env := make(map[string]string)
+	for _, values := range []map[string]string{spec.ContainerEnv, spec.RemoteEnv} {
+		for key, value := range values {
+			value = localEnvVariable.ReplaceAllString(value, "$1")
+			env[key] = strings.ReplaceAll(value, "${devcontainerId}", sandboxDto.Id)
+		}
+	}
+
+	for key, value := range sandboxDto.Env {
+		env[key] = value
+	}
+
+	if len(env) > 0 {
+		sandboxDto.Env = env
+	}
+}
+
+// applyDevcontainerHooks runs the create commands once after the sandbox is created and the start command on
+// every start, they run before the hooks of the request
+func applyDevcontainerHooks(sandboxDto *dto.CreateSandboxDTO, spec *devcontainerSpec) {
+	var postCreate, postStart []dto.HookDTO
+	for _, commands := range []devcontainerCommand{spec.OnCreateCommand, spec.UpdateContentCommand, spec.PostCreateCommand} {
+		for _, command := range commands {
+			postCreate = append(postCreate, dto.HookDTO{Command: command, User: spec.user()})
+		}
+	}
+	for _, command := range spec.PostStartCommand {
+		postStart = append(postStart, dto.HookDTO{Command: command, User: spec.user()})
+	}
+
+	if len(postCreate) == 0 && len(postStart) == 0 {
+		return
+	}
+
+	hooks := dto.SandboxHooksDTO{}
+	if sandboxDto.Hooks != nil {
+		hooks = *sandboxDto.Hooks
+	}
+	hooks.PostCreate = append(postCreate, hooks.PostCreate...)
+	hooks.PostStart = append(postStart, hooks.PostStart...)
+	sandboxDto.Hooks = &hooks
+}
+
+func applyDevcontainerPlan(plan *devcontainerPlan, containerConfig *container.Config, hostConfig *container.HostConfig) {
+	hostConfig.Mounts = append(hostConfig.Mounts, plan.mounts...)
+
+	if len(plan.ports) > 0 {
+		ports := make([]string, len(plan.ports))
+		for i, port := range plan.ports {
+			ports[i] = strconv.Itoa(port)
+		}
+		containerConfig.Labels[constants.DEVCONTAINER_PORTS_LABEL] = strings.Join(ports, ",")
+	}
+}
+
+// GetDevcontainerPorts returns the ports the devcontainer of the sandbox forwards
+func (d *DockerClient) GetDevcontainerPorts(ctx context.Context, sandboxId string) ([]int, error) {
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	value := ct.Config.Labels[constants.DEVCONTAINER_PORTS_LABEL]
+	if value == "" {
+		return nil, nil
+	}
+
+	var ports []int
+	for _, field := range strings.Split(value, ",") {
+		port, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid devcontainer port %s of sandbox %s", field, sandboxId)
+		}
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+func (d *DockerClient) removeDevcontainerVolumes(ctx context.Context, sandboxId string) {
+	list, err := d.apiClient.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.DEVCONTAINER_VOLUME_SANDBOX_LABEL, sandboxId))),
+	})
+	if err != nil {
+		log.Errorf("Failed to list devcontainer volumes of sandbox %s: %v", sandboxId, err)
+		return
+	}
+
+	for _, vol := range list.Volumes {
+		err = d.apiClient.VolumeRemove(ctx, vol.Name, false)
+		if err != nil && !errdefs.IsNotFound(err) {
+			log.Errorf("Failed to remove devcontainer volume %s: %v", vol.Name, err)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/distribution/reference"
+)
+
+// Features are copied here in the image while they are installed
+const devcontainerFeaturesPath = "/tmp/daytona-features"
+
+type devcontainerFeature struct {
+	// id is the feature reference as written in devcontainer.json without the version
+	id       string
+	dir      string
+	options  map[string]string
+	metadata devcontainerFeatureMetadata
+}
+
+// devcontainerFeatureMetadata is the subset of devcontainer-feature.json used to install a feature
+type devcontainerFeatureMetadata struct {
+	Id      string `json:"id"`
+	Options map[string]struct {
+		Default any `json:"default"`
+	} `json:"options"`
+	ContainerEnv  map[string]string `json:"containerEnv"`
+	InstallsAfter []string          `json:"installsAfter"`
+}
+
+// resolveDevcontainerFeatures fetches the features into the build context directory, OCI features from their
+// registry and local features from the directory of the devcontainer.json. Features are returned in install order.
+func (d *DockerClient) resolveDevcontainerFeatures(ctx context.Context, features map[string]json.RawMessage, source devcontainerSource, contextDir string) ([]devcontainerFeature, error) {
+	keys := make([]string, 0, len(features))
+	for key := range features {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resolved := make([]devcontainerFeature, 0, len(keys))
+	for index, key := range keys {
+		feature := devcontainerFeature{
+			id:  key,
+			dir: fmt.Sprintf("feature-%d", index),
+		}
+
+		featureDir := filepath.Join(contextDir, feature.dir)
+		var err error
+		switch {
+		case strings.HasPrefix(key, "./") || strings.HasPrefix(key, "../"):
+			err = copyLocalFeature(source, key, featureDir)
+		case strings.Contains(key, "://"):
+			err = common.NewBadRequestError(fmt.Errorf("feature %s: only OCI and local features are supported", key))
+		default:
+			feature.id, err = d.fetchOciFeature(ctx, key, featureDir)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := os.ReadFile(filepath.Join(featureDir, "devcontainer-feature.json"))
+		if err != nil {
+			return nil, fmt.Errorf("feature %s has no devcontainer-feature.json: %w", key, err)
+		}
+		err = json.Unmarshal(stripJsonComments(raw), &feature.metadata)
+		if err != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid devcontainer-feature.json of feature %s: %w", key, err))
+		}
+
+		_, err = os.Stat(filepath.Join(featureDir, "install.sh"))
+		if err != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("feature %s has no install.sh", key))
+		}
+
+		feature.options, err = getFeatureOptions(feature.metadata, features[key])
+		if err != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid options of feature %s: %w", key, err))
+		}
+
+		resolved = append(resolved, feature)
+	}
+
+	return sortFeatures(resolved), nil
+}
+
+// fetchOciFeature downloads the feature artifact, a single tar layer, and returns the feature ID without the version
+func (d *DockerClient) fetchOciFeature(ctx context.Context, key string, dir string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(key)
+	if err != nil {
+		return "", common.NewBadRequestError(fmt.Errorf("invalid feature reference %s: %w", key, err))
+	}
+
+	manifestRef := "latest"
+	if digested, ok := named.(reference.Digested); ok {
+		manifestRef = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		manifestRef = tagged.Tag()
+	}
+
+	client := newRegistryClient(named, nil)
+
+	manifest, err := client.getImageManifest(ctx, manifestRef, HostPlatform())
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch feature %s: %w", key, err)
+	}
+	if len(manifest.Layers) != 1 {
+		return "", fmt.Errorf("feature %s has %d layers, expected one", key, len(manifest.Layers))
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	layerPath := dir + ".tar"
+	err = client.downloadBlob(ctx, manifest.Layers[0], layerPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to download feature %s: %w", key, err)
+	}
+	defer os.Remove(layerPath)
+
+	layer, err := os.Open(layerPath)
+	if err != nil {
+		return "", err
+	}
+	defer layer.Close()
+
+	err = untar(layer, dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract feature %s: %w", key, err)
+	}
+
+	return reference.TrimNamed(named).String(), nil
+}
+
+// copyLocalFeature copies a feature shipped next to the devcontainer.json, it must not point outside of the repository
+func copyLocalFeature(source devcontainerSource, key string, dir string) error {
+	if source.repositoryDir == "" {
+		return common.NewBadRequestError(fmt.Errorf("local feature %s needs a repository", key))
+	}
+
+	featurePath, err := filepath.Rel(source.repositoryDir, filepath.Join(source.configDir, key))
+	if err != nil {
+		return err
+	}
+	featureDir, err := getRepositoryPath(source.repositoryDir, featurePath)
+	if err != nil {
+		return err
+	}
+
+	tarStream := tarDir(featureDir, "")
+	defer tarStream.Close()
+
+	return untar(tarStream, dir)
+}
+
+// getFeatureOptions merges the options set in devcontainer.json, a string sets the version, with the option defaults
+func getFeatureOptions(metadata devcontainerFeatureMetadata, raw json.RawMessage) (map[string]string, error) {
+	values := make(map[string]any)
+
+	var version string
+	var enabled bool
+	switch {
+	case len(raw) == 0 || json.Unmarshal(raw, &enabled) == nil:
+	case json.Unmarshal(raw, &version) == nil:
+		values["version"] = version
+	default:
+		err := json.Unmarshal(raw, &values)
+		if err != nil {
+			return nil, errors.New("options must be an object or a version string")
+		}
+	}
+
+	options := make(map[string]string)
+	for name, option := range metadata.Options {
+		if option.Default != nil {
+			options[name] = fmt.Sprint(option.Default)
+		}
+	}
+	for name, value := range values {
+		options[name] = fmt.Sprint(value)
+	}
+
+	return options, nil
+}
+
+// sortFeatures moves features after the features they install after, the order is kept otherwise
+func sortFeatures(features []devcontainerFeature) []devcontainerFeature {
+	sorted := make([]devcontainerFeature, 0, len(features))
+	added := make(map[string]bool)
+
+	isPending := func(id string) bool {
+		for _, feature := range features {
+			if !added[feature.id] && (feature.id == id || feature.metadata.Id == id) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for len(sorted) < len(features) {
+		progress := false
+		for _, feature := range features {
+			if added[feature.id] {
+				continue
+			}
+
+			ready := true
+			for _, after := range feature.metadata.InstallsAfter {
+				if after != feature.id && isPending(after) {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				sorted = append(sorted, feature)
+				added[feature.id] = true
+				progress = true
+			}
+		}
+
+		// installsAfter is a soft dependency, a cycle falls back to the order of the keys
+		if !progress {
+			for _, feature := range features {
+				if !added[feature.id] {
+					sorted = append(sorted, feature)
+					added[feature.id] = true
+				}
+			}
+		}
+	}
+
+	return sorted
+}
+
+// getFeaturesDockerfile installs the features on top of the base image as root and restores the image user after
+func getFeaturesDockerfile(baseImage string, baseUser string, remoteUser string, features []devcontainerFeature) string {
+	if remoteUser == "" {
+		remoteUser = baseUser
+	}
+	if remoteUser == "" {
+		remoteUser = "root"
+	}
+	remoteUserHome := "/home/" + remoteUser
+	if remoteUser == "root" {
+		remoteUserHome = "/root"
+	}
+
+	var dockerfile strings.Builder
+	fmt.Fprintf(&dockerfile, "FROM %s\nUSER root\n", baseImage)
+
+	for _, feature := range features {
+		path := fmt.Sprintf("%s/%s", devcontainerFeaturesPath, feature.dir)
+
+		env := []string{
+			"_REMOTE_USER=" + shellQuote(remoteUser),
+			"_REMOTE_USER_HOME=" + shellQuote(remoteUserHome),
+			"_CONTAINER_USER=" + shellQuote(remoteUser),
+		}
+		names := make([]string, 0, len(feature.options))
+		for name := range feature.options {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			env = append(env, getFeatureOptionEnvName(name)+"="+shellQuote(feature.options[name]))
+		}
+
+		fmt.Fprintf(&dockerfile, "COPY %s %s\n", feature.dir, path)
+		fmt.Fprintf(&dockerfile, "RUN cd %s && chmod +x install.sh && env %s ./install.sh\n", path, strings.Join(env, " "))
+
+		envNames := make([]string, 0, len(feature.metadata.ContainerEnv))
+		for name := range feature.metadata.ContainerEnv {
+			envNames = append(envNames, name)
+		}
+		sort.Strings(envNames)
+		for _, name := range envNames {
+			fmt.Fprintf(&dockerfile, "ENV %s=%s\n", name, strconv.Quote(feature.metadata.ContainerEnv[name]))
+		}
+	}
+
+	fmt.Fprintf(&dockerfile, "RUN rm -rf %s\n", devcontainerFeaturesPath)
+	if baseUser != "" {
+		fmt.Fprintf(&dockerfile, "USER %s\n", baseUser)
+	}
+
+	return dockerfile.String()
+}
+
+// getFeatureOptionEnvName follows the spec, options are passed to install.sh as upper case variables
+func getFeatureOptionEnvName(name string) string {
+	envName := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(name))
+
+	if envName == "" || (envName[0] >= '0' && envName[0] <= '9') {
+		envName = "_" + envName
+	}
+
+	return envName
+}
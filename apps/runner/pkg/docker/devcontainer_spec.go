@@ -0,0 +1,291 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// devcontainerSpec is the subset of the devcontainer.json format supported for sandboxes
+type devcontainerSpec struct {
+	Image             string                     `json:"image"`
+	Build             *devcontainerBuild         `json:"build"`
+	DockerFile        string                     `json:"dockerFile"`
+	Context           string                     `json:"context"`
+	DockerComposeFile json.RawMessage            `json:"dockerComposeFile"`
+	Features          map[string]json.RawMessage `json:"features"`
+	ContainerEnv      map[string]string          `json:"containerEnv"`
+	RemoteEnv         map[string]string          `json:"remoteEnv"`
+	ContainerUser     string                     `json:"containerUser"`
+	RemoteUser        string                     `json:"remoteUser"`
+	Mounts            []devcontainerMount        `json:"mounts"`
+	ForwardPorts      []devcontainerPort         `json:"forwardPorts"`
+
+	OnCreateCommand      devcontainerCommand `json:"onCreateCommand"`
+	UpdateContentCommand devcontainerCommand `json:"updateContentCommand"`
+	PostCreateCommand    devcontainerCommand `json:"postCreateCommand"`
+	PostStartCommand     devcontainerCommand `json:"postStartCommand"`
+}
+
+type devcontainerBuild struct {
+	Dockerfile string            `json:"dockerfile"`
+	Context    string            `json:"context"`
+	Args       map[string]string `json:"args"`
+	Target     string            `json:"target"`
+}
+
+// devcontainerCommand accepts the string, the array and the object (named commands) form. Every command is
+// turned into a shell command, named commands run one after the other in name order.
+type devcontainerCommand []string
+
+func (c *devcontainerCommand) UnmarshalJSON(raw []byte) error {
+	command, err := parseDevcontainerCommand(raw)
+	if err == nil {
+		*c = command
+		return nil
+	}
+
+	var commands map[string]json.RawMessage
+	if json.Unmarshal(raw, &commands) != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	*c = nil
+	for _, name := range names {
+		command, err := parseDevcontainerCommand(commands[name])
+		if err != nil {
+			return fmt.Errorf("invalid command %s: %w", name, err)
+		}
+		*c = append(*c, command...)
+	}
+
+	return nil
+}
+
+func parseDevcontainerCommand(raw []byte) (devcontainerCommand, error) {
+	var command string
+	if json.Unmarshal(raw, &command) == nil {
+		if command == "" {
+			return nil, nil
+		}
+		return devcontainerCommand{command}, nil
+	}
+
+	var args []string
+	err := json.Unmarshal(raw, &args)
+	if err != nil {
+		return nil, errors.New("a command must be a string, an array of strings or an object of commands")
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return devcontainerCommand{strings.Join(quoted, " ")}, nil
+}
+
+// devcontainerMount accepts the object and the docker --mount string form
+type devcontainerMount struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func (m *devcontainerMount) UnmarshalJSON(raw []byte) error {
+	var mount string
+	if json.Unmarshal(raw, &mount) != nil {
+		type plain devcontainerMount
+		return json.Unmarshal(raw, (*plain)(m))
+	}
+
+	for _, option := range strings.Split(mount, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		}
+	}
+
+	return nil
+}
+
+// devcontainerPort accepts a port number and the "host:port" string form, only the port is used
+type devcontainerPort int
+
+func (p *devcontainerPort) UnmarshalJSON(raw []byte) error {
+	var port int
+	if json.Unmarshal(raw, &port) == nil {
+		*p = devcontainerPort(port)
+		return nil
+	}
+
+	var value string
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return errors.New("a forwarded port must be a number or a host:port string")
+	}
+
+	host, portValue, ok := strings.Cut(value, ":")
+	if !ok {
+		portValue = host
+	} else if host != "localhost" && host != "127.0.0.1" {
+		return fmt.Errorf("forwarded port %s is not a port of the sandbox", value)
+	}
+
+	port, err = strconv.Atoi(portValue)
+	if err != nil {
+		return fmt.Errorf("invalid forwarded port %s", value)
+	}
+	*p = devcontainerPort(port)
+	return nil
+}
+
+func parseDevcontainerSpec(raw []byte) (*devcontainerSpec, error) {
+	var spec devcontainerSpec
+	err := json.Unmarshal(stripJsonComments(raw), &spec)
+	if err != nil {
+		return nil, common.NewBadRequestError(fmt.Errorf("invalid devcontainer.json: %w", err))
+	}
+
+	if len(spec.DockerComposeFile) > 0 {
+		return nil, common.NewBadRequestError(errors.New("devcontainers based on compose files are not supported, create a compose sandbox instead"))
+	}
+
+	// The top level dockerFile and context predate the build property
+	if spec.Build == nil && spec.DockerFile != "" {
+		spec.Build = &devcontainerBuild{Dockerfile: spec.DockerFile, Context: spec.Context}
+	}
+
+	if spec.Image == "" && (spec.Build == nil || spec.Build.Dockerfile == "") {
+		return nil, common.NewBadRequestError(errors.New("devcontainer.json has neither an image nor a Dockerfile"))
+	}
+
+	for _, mount := range spec.Mounts {
+		if mount.Target == "" {
+			return nil, common.NewBadRequestError(errors.New("devcontainer mount has no target"))
+		}
+
+		switch mount.Type {
+		case "volume":
+			if mount.Source == "" {
+				return nil, common.NewBadRequestError(fmt.Errorf("devcontainer volume mount %s has no source", mount.Target))
+			}
+		case "tmpfs":
+		default:
+			// Bind mounts would expose paths of the runner host
+			return nil, common.NewBadRequestError(fmt.Errorf("devcontainer mount %s has type %q, only volume and tmpfs mounts are supported", mount.Target, mount.Type))
+		}
+	}
+
+	for _, port := range spec.ForwardPorts {
+		if port < 1 || port > 65535 {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid forwarded port %d", port))
+		}
+	}
+
+	return &spec, nil
+}
+
+// user is the user lifecycle commands and features are set up for
+func (s *devcontainerSpec) user() string {
+	if s.RemoteUser != "" {
+		return s.RemoteUser
+	}
+	return s.ContainerUser
+}
+
+// stripJsonComments turns the JSON with comments devcontainer.json is written in into plain JSON by dropping
+// comments and then trailing commas
+func stripJsonComments(raw []byte) []byte {
+	withoutComments := scanJson(raw, func(raw []byte, i int) (int, bool) {
+		switch {
+		case raw[i] == '/' && i+1 < len(raw) && raw[i+1] == '/':
+			end := i
+			for end < len(raw) && raw[end] != '\n' {
+				end++
+			}
+			return end, true
+		case raw[i] == '/' && i+1 < len(raw) && raw[i+1] == '*':
+			end := i + 2
+			for end+1 < len(raw) && (raw[end] != '*' || raw[end+1] != '/') {
+				end++
+			}
+			return min(end+2, len(raw)), true
+		}
+		return i, false
+	})
+
+	return scanJson(withoutComments, func(raw []byte, i int) (int, bool) {
+		if raw[i] != ',' {
+			return i, false
+		}
+
+		next := i + 1
+		for next < len(raw) && strings.ContainsRune(" \t\r\n", rune(raw[next])) {
+			next++
+		}
+		if next < len(raw) && (raw[next] == '}' || raw[next] == ']') {
+			return i + 1, true
+		}
+		return i, false
+	})
+}
+
+// scanJson copies raw except for the ranges skip drops, skip is only called outside of strings and returns
+// where copying continues
+func scanJson(raw []byte, skip func(raw []byte, i int) (int, bool)) []byte {
+	result := make([]byte, 0, len(raw))
+	inString := false
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		if inString {
+			result = append(result, c)
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				result = append(result, raw[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		if next, ok := skip(raw, i); ok {
+			i = next - 1
+			continue
+		}
+
+		if c == '"' {
+			inString = true
+		}
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// tarDir streams the directory as a tar archive with the entries below prefix, symlinks are kept as links. The
+// directories of the prefix are part of the archive so it can be extracted at the root.
+func tarDir(dir string, prefix string) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		tarWriter := tar.NewWriter(writer)
+
+		err := writeTarDir(tarWriter, dir, strings.Trim(prefix, "/"))
+		if err == nil {
+			err = tarWriter.Close()
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader
+}
+
+func writeTarDir(tarWriter *tar.Writer, dir string, prefix string) error {
+	parent := ""
+	for _, name := range strings.Split(prefix, "/") {
+		if name == "" {
+			continue
+		}
+		parent = path.Join(parent, name)
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     parent + "/",
+			Mode:     0755,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, filePath)
+		if err != nil || name == "." {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err = os.Readlink(filePath)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(parent, filepath.ToSlash(name))
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+}
+
+// untar extracts the regular files and directories of the archive into dir, entries that would end up outside
+// of dir and links are refused
+func untar(reader io.Reader, dir string) error {
+	tarReader := tar.NewReader(reader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, header.Name)
+		if path != dir && !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %s is outside of the target directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+			if err != nil {
+				return err
+			}
+
+			err = writeArchiveFile(path, tarReader, fs.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("archive entry %s has an unsupported type", header.Name)
+		}
+	}
+}
+
+func writeArchiveFile(path string, reader io.Reader, mode fs.FileMode) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+)
+
+// GetEgressPolicy converts the requested network settings into an egress policy.
+// The legacy block-all and allowlist flags are mapped onto policy modes, nil means the settings are left unchanged.
+func GetEgressPolicy(networkBlockAll *bool, networkAllowList *string, egressPolicy *dto.EgressPolicyDTO) *sandboxnet.EgressPolicy {
+	if egressPolicy != nil {
+		return &sandboxnet.EgressPolicy{
+			Mode:    enums.EgressPolicyMode(egressPolicy.Mode),
+			Cidrs:   egressPolicy.Cidrs,
+			Domains: egressPolicy.Domains,
+		}
+	}
+
+	if networkBlockAll != nil && *networkBlockAll {
+		return &sandboxnet.EgressPolicy{Mode: enums.EgressPolicyModeDenyAll}
+	}
+
+	if networkAllowList != nil {
+		return &sandboxnet.EgressPolicy{
+			Mode:  enums.EgressPolicyModeAllowList,
+			Cidrs: splitAllowList(*networkAllowList),
+		}
+	}
+
+	return nil
+}
+
+func splitAllowList(allowList string) []string {
+	var cidrs []string
+	for _, cidr := range strings.Split(allowList, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	return cidrs
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+func (d *DockerClient) publishSnapshotEvent(eventType enums.EventType, snapshot string, state enums.SnapshotOperationState, message string) {
+	if d.events == nil {
+		return
+	}
+
+	d.events.Publish(events.Event{
+		Type:     eventType,
+		Snapshot: snapshot,
+		State:    state.String(),
+		Message:  message,
+	})
+}
+
+// publishProgress tees a Docker JSON message stream and publishes status changes as snapshot events.
+// Byte level progress updates are skipped so subscribers only see layer and build step transitions.
+// The returned reader must be consumed in place of the stream and the returned function called once it is.
+func (d *DockerClient) publishProgress(stream io.Reader, eventType enums.EventType, snapshot string) (io.Reader, func()) {
+	if d.events == nil {
+		return stream, func() {}
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() {
+			// Keep draining so the tee never blocks if the stream can't be decoded
+			_, _ = io.Copy(io.Discard, pipeReader)
+		}()
+
+		lastStatus := make(map[string]string)
+		decoder := json.NewDecoder(pipeReader)
+		for {
+			var message jsonmessage.JSONMessage
+			if err := decoder.Decode(&message); err != nil {
+				return
+			}
+
+			text := strings.TrimSpace(message.Stream)
+			if text == "" {
+				if message.Status == "" || lastStatus[message.ID] == message.Status {
+					continue
+				}
+				lastStatus[message.ID] = message.Status
+				text = strings.TrimSpace(message.ID + " " + message.Status)
+			}
+
+			d.publishSnapshotEvent(eventType, snapshot, enums.SnapshotOperationStateInProgress, text)
+		}
+	}()
+
+	return io.TeeReader(stream, pipeWriter), func() {
+		pipeWriter.Close()
+		<-done
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/daytonaio/common-go/pkg/timer"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types/container"
+)
+
+// UploadFile writes the content of reader to destPath inside the sandbox.
+// If size is unknown (-1) the content is buffered to a temporary file first since tar headers need the size upfront.
+func (d *DockerClient) UploadFile(ctx context.Context, containerId string, destPath string, reader io.Reader, size int64, mode int64) error {
+	defer timer.Timer()()
+
+	if !path.IsAbs(destPath) || path.Base(destPath) == "/" {
+		return common.NewBadRequestError(fmt.Errorf("destination path must be an absolute file path: %s", destPath))
+	}
+
+	if size < 0 {
+		tmpFile, err := os.CreateTemp("", "daytona-upload-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary upload file: %w", err)
+		}
+		defer os.Remove(tmpFile.Name())
+		defer tmpFile.Close()
+
+		size, err = io.Copy(tmpFile, reader)
+		if err != nil {
+			return fmt.Errorf("failed to buffer upload: %w", err)
+		}
+
+		_, err = tmpFile.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		reader = tmpFile
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		tarWriter := tar.NewWriter(pipeWriter)
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:    path.Base(destPath),
+			Mode:    mode,
+			Size:    size,
+			ModTime: time.Now(),
+		})
+		if err == nil {
+			_, err = io.CopyN(tarWriter, reader, size)
+		}
+		if err == nil {
+			err = tarWriter.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+	defer pipeReader.Close()
+
+	err := d.apiClient.CopyToContainer(ctx, containerId, path.Dir(destPath), pipeReader, container.CopyToContainerOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Uploaded %d bytes to %s in sandbox %s", size, destPath, containerId)
+
+	return nil
+}
+
+// DownloadFile returns the content of a file in the sandbox and its stat with the size of the content.
+// Directories are returned as a tar archive, which is reported by the returned stat, symlinks are followed.
+func (d *DockerClient) DownloadFile(ctx context.Context, containerId string, srcPath string) (io.ReadCloser, *container.PathStat, error) {
+	defer timer.Timer()()
+
+	content, stat, err := d.apiClient.CopyFromContainer(ctx, containerId, srcPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The archive of a symlink only holds the link, the file it points to is downloaded under the name of the link
+	if stat.Mode&os.ModeSymlink != 0 && stat.LinkTarget != "" {
+		content.Close()
+
+		name := stat.Name
+		content, stat, err = d.apiClient.CopyFromContainer(ctx, containerId, stat.LinkTarget)
+		if err != nil {
+			return nil, nil, err
+		}
+		stat.Name = name
+	}
+
+	if stat.Mode.IsDir() {
+		return content, &stat, nil
+	}
+
+	tarReader := tar.NewReader(content)
+	header, err := tarReader.Next()
+	if err != nil {
+		content.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, common.NewNotFoundError(fmt.Errorf("file %s is empty or missing", srcPath))
+		}
+		return nil, nil, fmt.Errorf("failed to read file archive: %w", err)
+	}
+
+	if header.Typeflag != tar.TypeReg {
+		content.Close()
+		return nil, nil, common.NewBadRequestError(fmt.Errorf("%s is not a regular file", srcPath))
+	}
+
+	// The size of the stat is the one of the path itself, the tar entry holds the size of the content that is streamed
+	stat.Size = header.Size
+
+	return &tarEntryReadCloser{Reader: tarReader, closer: content}, &stat, nil
+}
+
+type tarEntryReadCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (t *tarEntryReadCloser) Close() error {
+	return t.closer.Close()
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/daytonaio/runner/cmd/runner/config"
 	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models/enums"
 	"github.com/daytonaio/runner/pkg/storage"
 
 	"github.com/docker/docker/api/types"
@@ -21,6 +22,20 @@ import (
 )
 
 func (d *DockerClient) BuildImage(ctx context.Context, buildImageDto dto.BuildSnapshotRequestDTO) error {
+	finish := d.buildStatus.start(buildImageDto.Snapshot, BUILD_OPERATION_BUILD)
+
+	err := d.buildImage(ctx, buildImageDto)
+	finish(err)
+	if err != nil {
+		d.publishSnapshotEvent(enums.EventTypeSnapshotBuild, buildImageDto.Snapshot, enums.SnapshotOperationStateFailed, err.Error())
+		return err
+	}
+
+	d.publishSnapshotEvent(enums.EventTypeSnapshotBuild, buildImageDto.Snapshot, enums.SnapshotOperationStateCompleted, "")
+	return nil
+}
+
+func (d *DockerClient) buildImage(ctx context.Context, buildImageDto dto.BuildSnapshotRequestDTO) error {
 	if !strings.Contains(buildImageDto.Snapshot, ":") || strings.HasSuffix(buildImageDto.Snapshot, ":") {
 		return fmt.Errorf("invalid image format: must contain exactly one colon (e.g., 'myimage:1.0')")
 	}
@@ -41,6 +56,8 @@ func (d *DockerClient) BuildImage(ctx context.Context, buildImageDto dto.BuildSn
 		return nil
 	}
 
+	d.publishSnapshotEvent(enums.EventTypeSnapshotBuild, buildImageDto.Snapshot, enums.SnapshotOperationStateStarted, "")
+
 	// Create a build context from the provided hashes
 	buildContextTar := new(bytes.Buffer)
 	tarWriter := tar.NewWriter(buildContextTar)
@@ -139,20 +156,11 @@ func (d *DockerClient) BuildImage(ctx context.Context, buildImageDto dto.BuildSn
 		}
 	}
 
-	buildContext := io.NopCloser(buildContextTar)
-
-	resp, err := d.apiClient.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
-		Tags:        []string{buildImageDto.Snapshot},
-		Dockerfile:  "Dockerfile",
-		Remove:      true,
-		ForceRemove: true,
-		PullParent:  true,
-		Platform:    "linux/amd64", // Force AMD64 architecture
-	})
+	// Flush the end of archive marker before handing the context to the builder
+	err = tarWriter.Close()
 	if err != nil {
-		return fmt.Errorf("failed to build image: %w", err)
+		return fmt.Errorf("failed to finalize build context: %w", err)
 	}
-	defer resp.Body.Close()
 
 	// Extract image name without tag
 	filePath := buildImageDto.Snapshot[:strings.LastIndex(buildImageDto.Snapshot, ":")]
@@ -170,7 +178,39 @@ func (d *DockerClient) BuildImage(ctx context.Context, buildImageDto dto.BuildSn
 
 	multiWriter := io.MultiWriter(d.logWriter, logFile)
 
-	err = jsonmessage.DisplayJSONMessagesStream(resp.Body, multiWriter, 0, true, nil)
+	// The classic builder can't import or export registry caches, so cached builds go through BuildKit
+	if buildImageDto.Cache != nil {
+		err = d.buildImageWithBuildKit(ctx, buildImageDto, buildContextTar, multiWriter)
+		if err != nil {
+			return err
+		}
+
+		if d.logWriter != nil {
+			d.logWriter.Write([]byte("Image built successfully\n"))
+		}
+
+		return nil
+	}
+
+	buildContext := io.NopCloser(buildContextTar)
+
+	resp, err := d.apiClient.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        []string{buildImageDto.Snapshot},
+		Dockerfile:  "Dockerfile",
+		Remove:      true,
+		ForceRemove: true,
+		PullParent:  true,
+		Platform:    "linux/amd64", // Force AMD64 architecture
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	stream, waitForProgress := d.publishProgress(resp.Body, enums.EventTypeSnapshotBuild, buildImageDto.Snapshot)
+	defer waitForProgress()
+
+	err = jsonmessage.DisplayJSONMessagesStream(stream, multiWriter, 0, true, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,160 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/telemetry"
+)
+
+// Registry cache export is not supported by the default docker buildx driver so builds run on a
+// dedicated docker-container builder that is created on first use
+const buildKitBuilderName = "daytona-builder"
+
+var buildKitBuilderMutex sync.Mutex
+
+// buildImageWithBuildKit builds the tar build context with docker buildx, importing and optionally
+// exporting the layer cache from the registry reference in the request
+func (d *DockerClient) buildImageWithBuildKit(ctx context.Context, buildImageDto dto.BuildSnapshotRequestDTO, buildContext io.Reader, output io.Writer) error {
+	ctx, span := telemetry.StartSpan(ctx, "docker.BuildImageWithBuildKit", "")
+	defer span.End()
+
+	dockerPath, err := exec.LookPath("docker")
+	if err != nil {
+		return fmt.Errorf("docker CLI is required for BuildKit builds: %w", err)
+	}
+
+	// Credentials are only written to a per build config directory that is removed afterwards
+	dockerConfigDir, err := os.MkdirTemp("", "daytona-buildx-")
+	if err != nil {
+		return fmt.Errorf("failed to create docker config directory: %w", err)
+	}
+	defer os.RemoveAll(dockerConfigDir)
+
+	err = writeDockerConfig(dockerConfigDir, buildImageDto.Registry, buildImageDto.Cache.Registry)
+	if err != nil {
+		return err
+	}
+
+	buildxConfigDir, err := getBuildxConfigDir()
+	if err != nil {
+		return err
+	}
+
+	env := append(os.Environ(), "DOCKER_CONFIG="+dockerConfigDir, "BUILDX_CONFIG="+buildxConfigDir)
+
+	err = ensureBuildKitBuilder(ctx, dockerPath, env)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"buildx", "build",
+		"--builder", buildKitBuilderName,
+		"--progress", "plain",
+		"--platform", "linux/amd64",
+		"--load",
+		"--pull",
+		"--tag", buildImageDto.Snapshot,
+		"--file", "Dockerfile",
+		"--cache-from", "type=registry,ref=" + buildImageDto.Cache.Ref,
+	}
+	if buildImageDto.Cache.Export {
+		args = append(args, "--cache-to", "type=registry,mode=max,ref="+buildImageDto.Cache.Ref)
+	}
+	// Read the tar build context from stdin
+	args = append(args, "-")
+
+	log.Infof("Building image %s with BuildKit using cache %s", buildImageDto.Snapshot, buildImageDto.Cache.Ref)
+
+	cmd := exec.CommandContext(ctx, dockerPath, args...)
+	cmd.Env = env
+	cmd.Stdin = buildContext
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("failed to build image with BuildKit: %w", err)
+	}
+
+	return nil
+}
+
+func ensureBuildKitBuilder(ctx context.Context, dockerPath string, env []string) error {
+	buildKitBuilderMutex.Lock()
+	defer buildKitBuilderMutex.Unlock()
+
+	inspect := exec.CommandContext(ctx, dockerPath, "buildx", "inspect", buildKitBuilderName)
+	inspect.Env = env
+	if inspect.Run() == nil {
+		return nil
+	}
+
+	var stderr bytes.Buffer
+	create := exec.CommandContext(ctx, dockerPath, "buildx", "create", "--name", buildKitBuilderName, "--driver", "docker-container")
+	create.Env = env
+	create.Stderr = &stderr
+
+	err := create.Run()
+	if err != nil {
+		return fmt.Errorf("failed to create BuildKit builder: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func writeDockerConfig(dir string, registries ...*dto.RegistryDTO) error {
+	type authEntry struct {
+		Auth string `json:"auth"`
+	}
+
+	auths := make(map[string]authEntry)
+	for _, registry := range registries {
+		if registry == nil || registry.Username == "" {
+			continue
+		}
+
+		host := normalizeMirrorUrl(registry.Url)
+		auths[host] = authEntry{
+			Auth: base64.StdEncoding.EncodeToString([]byte(registry.Username + ":" + registry.Password)),
+		}
+	}
+
+	raw, err := json.Marshal(map[string]any{"auths": auths})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "config.json"), raw, 0600)
+}
+
+// getBuildxConfigDir keeps buildx builder state next to the runner logs so the builder is reused across builds
+func getBuildxConfigDir() (string, error) {
+	c, err := config.GetConfig()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(filepath.Dir(c.LogFilePath), "buildx")
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create buildx config directory: %w", err)
+	}
+
+	return dir, nil
+}
@@ -8,8 +8,6 @@ import (
 	"strings"
 
 	"github.com/docker/docker/api/types/image"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) ImageExists(ctx context.Context, imageName string, includeLatest bool) (bool, error) {
@@ -0,0 +1,134 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/daytonaio/common-go/pkg/timer"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/storage"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// ExportImage returns the image as a tarball that can be loaded on another runner
+func (d *DockerClient) ExportImage(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	defer timer.Timer()()
+
+	exists, err := d.ImageExists(ctx, imageName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, common.NewNotFoundError(fmt.Errorf("snapshot %s not found locally", imageName))
+	}
+
+	return d.apiClient.ImageSave(ctx, []string{imageName})
+}
+
+// ExportImageToStorage streams the image tarball to the given path in object storage
+func (d *DockerClient) ExportImageToStorage(ctx context.Context, imageName string, objectPath string) error {
+	defer timer.Timer()()
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	reader, err := d.ExportImage(ctx, imageName)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	log.Infof("Exporting snapshot %s to %s", imageName, objectPath)
+
+	err = storageClient.PutObjectStream(ctx, objectPath, reader, -1)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Snapshot %s exported to %s", imageName, objectPath)
+
+	return nil
+}
+
+// ImportImage loads an image tarball and returns the names of the loaded images
+func (d *DockerClient) ImportImage(ctx context.Context, reader io.Reader) ([]string, error) {
+	defer timer.Timer()()
+
+	response, err := d.apiClient.ImageLoad(ctx, reader, true)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	images := make([]string, 0)
+
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var message jsonmessage.JSONMessage
+		err := decoder.Decode(&message)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image load response: %w", err)
+		}
+
+		if message.Error != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("failed to load snapshot: %s", message.Error.Message))
+		}
+
+		if loaded, ok := strings.CutPrefix(strings.TrimSpace(message.Stream), "Loaded image: "); ok {
+			images = append(images, loaded)
+		}
+	}
+
+	log.Infof("Imported snapshots: %s", strings.Join(images, ", "))
+
+	return images, nil
+}
+
+func (d *DockerClient) ImportImageFromStorage(ctx context.Context, objectPath string) ([]string, error) {
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := storageClient.GetObjectStream(ctx, objectPath)
+	if err != nil {
+		return nil, common.NewNotFoundError(err)
+	}
+	defer reader.Close()
+
+	return d.ImportImage(ctx, reader)
+}
+
+// ImportImageFromUrl downloads and loads an image tarball, e.g. from a presigned S3 URL
+func (d *DockerClient) ImportImageFromUrl(ctx context.Context, url string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, common.NewBadRequestError(fmt.Errorf("failed to download snapshot: %s", resp.Status))
+	}
+
+	return d.ImportImage(ctx, resp.Body)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/errdefs"
+)
+
+func (d *DockerClient) GetImageInfo(ctx context.Context, imageName string) (*dto.SnapshotInfoResponse, error) {
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, common.NewNotFoundError(fmt.Errorf("snapshot %s not found", imageName))
+		}
+		return nil, err
+	}
+
+	platform := Platform{
+		OS:           inspect.Os,
+		Architecture: normalizeArchitecture(inspect.Architecture),
+		Variant:      inspect.Variant,
+	}
+
+	return &dto.SnapshotInfoResponse{
+		Name:         imageName,
+		Id:           inspect.ID,
+		SizeGB:       float64(inspect.Size) / (1024 * 1024 * 1024),
+		Os:           platform.OS,
+		Architecture: platform.Architecture,
+		Variant:      platform.Variant,
+		Platform:     platform.String(),
+		RepoDigests:  inspect.RepoDigests,
+		Created:      inspect.Created,
+	}, nil
+}
+
+// imageMatchesPlatform reports whether the local image exists and was built for the platform
+func (d *DockerClient) imageMatchesPlatform(ctx context.Context, imageName string, platform Platform) (bool, error) {
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return platform.Matches(Platform{
+		OS:           inspect.Os,
+		Architecture: normalizeArchitecture(inspect.Architecture),
+		Variant:      inspect.Variant,
+	}), nil
+}
+
+// ImageExistsForPlatform is ImageExists restricted to images built for the given platform
+func (d *DockerClient) ImageExistsForPlatform(ctx context.Context, imageName string, includeLatest bool, platform Platform) (bool, error) {
+	exists, err := d.ImageExists(ctx, imageName, includeLatest)
+	if err != nil || !exists {
+		return exists, err
+	}
+
+	return d.imageMatchesPlatform(ctx, imageName, platform)
+}
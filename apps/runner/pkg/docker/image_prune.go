@@ -0,0 +1,140 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+)
+
+type PruneSnapshotsOptions struct {
+	// MinAge protects snapshots that were pulled or tagged recently, e.g. for a sandbox that is about to be created
+	MinAge time.Duration
+	// KeepList holds snapshot names or glob patterns (e.g. daytonaio/sandbox:*) that are never removed
+	KeepList []string
+	// Pinned holds exact snapshot references, by tag or by digest, that are never removed
+	Pinned []string
+	DryRun bool
+}
+
+// PruneSnapshots removes snapshots that aren't used by any sandbox container, including stopped ones.
+// Reclaimed bytes only count layers that aren't shared with other images, so they are a lower bound.
+func (d *DockerClient) PruneSnapshots(ctx context.Context, options PruneSnapshotsOptions) (*dto.PruneSnapshotsResponseDTO, error) {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	usedImages := make(map[string]bool, len(containers))
+	for _, ct := range containers {
+		usedImages[ct.ImageID] = true
+	}
+
+	images, err := d.apiClient.ImageList(ctx, image.ListOptions{SharedSize: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.PruneSnapshotsResponseDTO{
+		Removed: []string{},
+	}
+
+	for _, summary := range images {
+		if usedImages[summary.ID] || matchesKeepList(summary.RepoTags, options.KeepList) || isPinned(summary, options.Pinned) {
+			continue
+		}
+
+		inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, summary.ID)
+		if err != nil {
+			log.Debugf("Failed to inspect snapshot %s: %v", summary.ID, err)
+			continue
+		}
+
+		lastUsed := inspect.Metadata.LastTagTime
+		if lastUsed.IsZero() {
+			lastUsed = time.Unix(summary.Created, 0)
+		}
+		if time.Since(lastUsed) < options.MinAge {
+			continue
+		}
+
+		name := summary.ID
+		if len(summary.RepoTags) > 0 {
+			name = strings.Join(summary.RepoTags, ",")
+		}
+
+		reclaimed := summary.Size
+		if summary.SharedSize > 0 {
+			reclaimed -= summary.SharedSize
+		}
+
+		if !options.DryRun {
+			err = d.removeUnusedImage(ctx, summary)
+			if err != nil {
+				if !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+					log.Warnf("Failed to remove snapshot %s: %v", name, err)
+				}
+				continue
+			}
+
+			common.SnapshotGCRemovedCount.Inc()
+			common.SnapshotGCReclaimedBytes.Add(float64(reclaimed))
+			log.Infof("Removed unused snapshot %s (%d bytes)", name, reclaimed)
+		}
+
+		result.Removed = append(result.Removed, name)
+		result.ReclaimedBytes += reclaimed
+	}
+
+	return result, nil
+}
+
+// removeUnusedImage removes the image without forcing so it is kept if a sandbox started using it meanwhile.
+// An image with several tags can only be removed without force tag by tag.
+func (d *DockerClient) removeUnusedImage(ctx context.Context, summary image.Summary) error {
+	references := summary.RepoTags
+	if len(references) == 0 {
+		references = []string{summary.ID}
+	}
+
+	for _, reference := range references {
+		_, err := d.apiClient.ImageRemove(ctx, reference, image.RemoveOptions{PruneChildren: true})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func matchesKeepList(repoTags []string, keepList []string) bool {
+	for _, tag := range repoTags {
+		for _, pattern := range keepList {
+			if matched, err := path.Match(pattern, tag); err == nil && matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isPinned(summary image.Summary, pinned []string) bool {
+	for _, reference := range pinned {
+		if slices.Contains(summary.RepoTags, reference) || slices.Contains(summary.RepoDigests, reference) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -15,17 +15,25 @@ import (
 	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/api/dto"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
 
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/pkg/jsonmessage"
-
-	log "github.com/sirupsen/logrus"
 )
 
-func (d *DockerClient) PullImage(ctx context.Context, imageName string, reg *dto.RegistryDTO) error {
+// PullImage pulls the image for the given platform (os/arch[/variant]) or the runner platform if empty
+func (d *DockerClient) PullImage(ctx context.Context, imageName string, reg *dto.RegistryDTO, platform string) error {
+	ctx, span := telemetry.StartSpan(ctx, "docker.PullImage", "")
+	defer span.End()
+
 	defer timer.Timer()()
 
+	targetPlatform, err := ParsePlatform(platform)
+	if err != nil {
+		return err
+	}
+
 	tag := "latest"
 	lastColonIndex := strings.LastIndex(imageName, ":")
 	if lastColonIndex != -1 {
@@ -33,7 +41,8 @@ func (d *DockerClient) PullImage(ctx context.Context, imageName string, reg *dto
 	}
 
 	if tag != "latest" {
-		exists, err := d.ImageExists(ctx, imageName, true)
+		// A tag stored for another architecture has to be replaced by the requested platform
+		exists, err := d.ImageExistsForPlatform(ctx, imageName, true, targetPlatform)
 		if err != nil {
 			return err
 		}
@@ -52,24 +61,82 @@ func (d *DockerClient) PullImage(ctx context.Context, imageName string, reg *dto
 		d.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStatePullingSnapshot)
 	}
 
-	responseBody, err := d.apiClient.ImagePull(ctx, imageName, image.PullOptions{
-		RegistryAuth: getRegistryAuth(reg),
+	release, err := d.pullLimiter.acquire(ctx, func(position int) {
+		if sandboxIdValue != nil {
+			d.cache.SetPullQueuePosition(ctx, sandboxIdValue.(string), position)
+		}
+		if position > 0 {
+			log.Infof("Pull of image %s queued at position %d", imageName, position)
+		}
 	})
 	if err != nil {
 		return err
 	}
-	defer responseBody.Close()
+	defer release()
 
-	err = jsonmessage.DisplayJSONMessagesStream(responseBody, io.Writer(&util.DebugLogWriter{}), 0, true, nil)
+	d.publishSnapshotEvent(enums.EventTypeSnapshotPull, imageName, enums.SnapshotOperationStateStarted, "")
+	finish := d.buildStatus.start(imageName, BUILD_OPERATION_PULL)
+
+	err = d.verifyAndPullImage(ctx, imageName, reg, targetPlatform)
+	finish(err)
 	if err != nil {
+		d.publishSnapshotEvent(enums.EventTypeSnapshotPull, imageName, enums.SnapshotOperationStateFailed, err.Error())
 		return err
 	}
 
-	log.Infof("Image %s pulled successfully", imageName)
+	d.publishSnapshotEvent(enums.EventTypeSnapshotPull, imageName, enums.SnapshotOperationStateCompleted, "")
 
 	return nil
 }
 
+func (d *DockerClient) verifyAndPullImage(ctx context.Context, imageName string, reg *dto.RegistryDTO, platform Platform) error {
+	var signedDigest string
+	if d.signatureVerification.enabled() {
+		digest, err := d.verifyImageSignature(ctx, imageName, reg)
+		if err != nil {
+			return err
+		}
+		signedDigest = digest
+	}
+
+	if d.pullFromMirrors(ctx, imageName, platform) {
+		log.Infof("Image %s pulled successfully from mirror", imageName)
+	} else if d.pullInParallel(ctx, imageName, reg, platform, signedDigest) {
+		log.Infof("Image %s pulled successfully with parallel layer downloads", imageName)
+	} else {
+		err := d.withRegistryRetry(ctx, "pull", imageName, func() error {
+			return d.pullImage(ctx, imageName, getRegistryAuth(reg), platform)
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Image %s pulled successfully", imageName)
+	}
+
+	if signedDigest != "" {
+		return d.ensureSignedDigest(ctx, imageName, signedDigest)
+	}
+
+	return nil
+}
+
+func (d *DockerClient) pullImage(ctx context.Context, imageName string, registryAuth string, platform Platform) error {
+	responseBody, err := d.apiClient.ImagePull(ctx, imageName, image.PullOptions{
+		RegistryAuth: registryAuth,
+		Platform:     platform.String(),
+	})
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	stream, waitForProgress := d.publishProgress(responseBody, enums.EventTypeSnapshotPull, imageName)
+	defer waitForProgress()
+
+	return jsonmessage.DisplayJSONMessagesStream(stream, io.Writer(&util.DebugLogWriter{}), 0, true, nil)
+}
+
 func getRegistryAuth(reg *dto.RegistryDTO) string {
 	if reg == nil {
 		// Sometimes registry auth fails if "" is sent, so sending "empty" instead
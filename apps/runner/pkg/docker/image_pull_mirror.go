@@ -0,0 +1,95 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+)
+
+const dockerHubDomain = "docker.io"
+
+// pullFromMirrors tries the pull-through cache and the configured mirrors in order for Docker Hub images.
+// On success the image is tagged with its original name so callers can keep referencing it unchanged.
+// Mirrors are always queried anonymously, upstream credentials are never forwarded to them.
+func (d *DockerClient) pullFromMirrors(ctx context.Context, imageName string, platform Platform) bool {
+	d.registryMirrorsMutex.RLock()
+	registryMirrors := d.registryMirrors
+	d.registryMirrorsMutex.RUnlock()
+
+	if len(registryMirrors) == 0 {
+		return false
+	}
+
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil || reference.Domain(named) != dockerHubDomain {
+		return false
+	}
+
+	named = reference.TagNameOnly(named)
+	suffix := strings.TrimPrefix(named.String(), dockerHubDomain+"/")
+
+	for _, mirror := range registryMirrors {
+		mirrorImage := fmt.Sprintf("%s/%s", mirror, suffix)
+
+		log.Infof("Pulling image %s from mirror %s...", imageName, mirror)
+
+		err := d.pullImage(ctx, mirrorImage, "empty", platform)
+		if err != nil {
+			log.Warnf("Failed to pull image %s from mirror %s: %v", imageName, mirror, err)
+			continue
+		}
+
+		err = d.apiClient.ImageTag(ctx, mirrorImage, imageName)
+		if err != nil {
+			log.Warnf("Failed to tag mirrored image %s as %s: %v", mirrorImage, imageName, err)
+			continue
+		}
+
+		// Only untag the mirror reference, the image layers stay under the original name
+		_, err = d.apiClient.ImageRemove(ctx, mirrorImage, image.RemoveOptions{})
+		if err != nil {
+			log.Warnf("Failed to remove mirror tag %s: %v", mirrorImage, err)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// SetRegistryMirrors replaces the mirrors used by pulls started from now on
+func (d *DockerClient) SetRegistryMirrors(mirrors []string, pullThroughCacheUrl string) {
+	registryMirrors := getRegistryMirrors(mirrors, pullThroughCacheUrl)
+
+	d.registryMirrorsMutex.Lock()
+	d.registryMirrors = registryMirrors
+	d.registryMirrorsMutex.Unlock()
+}
+
+func getRegistryMirrors(mirrors []string, pullThroughCacheUrl string) []string {
+	// The pull-through cache is local to the runner so it is tried before any remote mirror
+	registryMirrors := make([]string, 0, len(mirrors)+1)
+	if pullThroughCacheUrl != "" {
+		registryMirrors = append(registryMirrors, normalizeMirrorUrl(pullThroughCacheUrl))
+	}
+	for _, mirror := range mirrors {
+		if mirror != "" {
+			registryMirrors = append(registryMirrors, normalizeMirrorUrl(mirror))
+		}
+	}
+
+	return registryMirrors
+}
+
+// normalizeMirrorUrl strips the scheme and trailing slash since image references only carry the registry host
+func normalizeMirrorUrl(mirror string) string {
+	mirror = strings.TrimPrefix(mirror, "https://")
+	mirror = strings.TrimPrefix(mirror, "http://")
+	return strings.TrimSuffix(mirror, "/")
+}
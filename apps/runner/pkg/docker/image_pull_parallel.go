@@ -0,0 +1,292 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// loadManifest is the manifest.json of a docker save archive
+type loadManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// pullInParallel tries the parallel layer download if it is enabled and reports whether it pulled the image.
+// Signed images are left to the daemon so it records the repo digest the signature is checked against.
+func (d *DockerClient) pullInParallel(ctx context.Context, imageName string, reg *dto.RegistryDTO, platform Platform, signedDigest string) bool {
+	if d.parallelLayerPulls <= 0 || signedDigest != "" {
+		return false
+	}
+
+	// The load archive can only name the image by tag
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return false
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return false
+	}
+
+	err = d.pullImageParallel(ctx, imageName, reg, platform)
+	if err != nil {
+		log.Warnf("Parallel layer download of image %s failed, pulling it through the daemon: %v", imageName, err)
+		return false
+	}
+
+	return true
+}
+
+// pullImageParallel downloads the layers of the image straight from the registry with up to
+// parallelLayerPulls downloads at once and loads them into the daemon. Compressed layers are loaded as
+// they are, the daemon detects gzip and zstd compression. Layers already stored by the daemon are
+// downloaded again since the daemon doesn't expose which compressed layers it has.
+func (d *DockerClient) pullImageParallel(ctx context.Context, imageName string, reg *dto.RegistryDTO, platform Platform) error {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return err
+	}
+
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !ok {
+		return fmt.Errorf("image %s is not referenced by tag", imageName)
+	}
+
+	registry := newRegistryClient(tagged, reg)
+
+	manifest, err := registry.getImageManifest(ctx, tagged.Tag(), platform)
+	if err != nil {
+		return err
+	}
+
+	downloadDir, err := os.MkdirTemp("", "daytona-pull-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloadDir)
+
+	err = registry.downloadBlobs(ctx, getManifestBlobs(manifest), downloadDir, d.parallelLayerPulls)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeLoadArchive(writer, downloadDir, reference.FamiliarString(tagged), manifest))
+	}()
+	defer reader.Close()
+
+	_, err = d.ImportImage(ctx, reader)
+	return err
+}
+
+// getImageManifest fetches the image manifest, resolving an index to the manifest of the platform
+func (c *registryClient) getImageManifest(ctx context.Context, manifestRef string, platform Platform) (*ocispec.Manifest, error) {
+	response, err := c.get(ctx, "manifests/"+manifestRef, ocispec.MediaTypeImageIndex, dockerManifestListMediaType, ocispec.MediaTypeImageManifest, dockerManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		ocispec.Manifest
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	err = json.Unmarshal(raw, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	if manifest.Manifests == nil {
+		return &manifest.Manifest, nil
+	}
+
+	for _, descriptor := range manifest.Manifests {
+		if descriptor.Platform == nil {
+			continue
+		}
+
+		candidate := Platform{OS: descriptor.Platform.OS, Architecture: descriptor.Platform.Architecture, Variant: descriptor.Platform.Variant}
+		if candidate.Matches(platform) {
+			return c.getImageManifest(ctx, descriptor.Digest.String(), platform)
+		}
+	}
+
+	return nil, fmt.Errorf("no manifest for platform %s", platform.String())
+}
+
+// downloadBlobs downloads the blobs into dir, named by their digest, with up to parallelism downloads at once
+func (c *registryClient) downloadBlobs(ctx context.Context, blobs []ocispec.Descriptor, dir string, parallelism int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var firstErr error
+	var errMutex sync.Mutex
+
+	for _, blob := range blobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-slots }()
+
+			err := c.downloadBlob(ctx, blob, filepath.Join(dir, blob.Digest.Encoded()))
+			if err != nil {
+				errMutex.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				errMutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+func (c *registryClient) downloadBlob(ctx context.Context, blob ocispec.Descriptor, path string) error {
+	err := blob.Digest.Validate()
+	if err != nil {
+		return err
+	}
+
+	response, err := c.get(ctx, "blobs/"+blob.Digest.String())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	verifier := blob.Digest.Verifier()
+
+	written, err := io.Copy(io.MultiWriter(file, verifier), response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download blob %s: %w", blob.Digest, err)
+	}
+
+	if written != blob.Size || !verifier.Verified() {
+		return fmt.Errorf("blob %s does not match its digest", blob.Digest)
+	}
+
+	log.Debugf("Downloaded blob %s (%d bytes)", blob.Digest, written)
+
+	return nil
+}
+
+// getManifestBlobs returns the config and the layers of the manifest, a layer used twice is only returned once
+func getManifestBlobs(manifest *ocispec.Manifest) []ocispec.Descriptor {
+	seen := make(map[digest.Digest]bool, len(manifest.Layers)+1)
+	blobs := make([]ocispec.Descriptor, 0, len(manifest.Layers)+1)
+
+	for _, blob := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+		if seen[blob.Digest] {
+			continue
+		}
+		seen[blob.Digest] = true
+		blobs = append(blobs, blob)
+	}
+
+	return blobs
+}
+
+// writeLoadArchive writes the downloaded blobs as an archive in the docker save format
+func writeLoadArchive(writer io.Writer, dir string, imageName string, manifest *ocispec.Manifest) error {
+	archive := tar.NewWriter(writer)
+
+	for _, blob := range getManifestBlobs(manifest) {
+		err := addArchiveFile(archive, filepath.Join(dir, blob.Digest.Encoded()), blob.Digest.Encoded())
+		if err != nil {
+			return err
+		}
+	}
+
+	layers := make([]string, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layers = append(layers, layer.Digest.Encoded())
+	}
+
+	raw, err := json.Marshal([]loadManifest{{
+		Config:   manifest.Config.Digest.Encoded(),
+		RepoTags: []string{imageName},
+		Layers:   layers,
+	}})
+	if err != nil {
+		return err
+	}
+
+	err = archive.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0644, Size: int64(len(raw))})
+	if err != nil {
+		return err
+	}
+
+	_, err = archive.Write(raw)
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func addArchiveFile(archive *tar.Writer, path string, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: info.Size()})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(archive, file)
+	return err
+}
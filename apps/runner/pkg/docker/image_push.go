@@ -5,29 +5,69 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"os"
+	"strings"
 
+	"github.com/daytonaio/runner/cmd/runner/config"
 	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/api/dto"
 
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/pkg/jsonmessage"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) PushImage(ctx context.Context, imageName string, reg *dto.RegistryDTO) error {
-	log.Infof("Pushing image %s...", imageName)
+	return d.pushImage(ctx, imageName, reg, io.Writer(&util.DebugLogWriter{}))
+}
 
-	responseBody, err := d.apiClient.ImagePush(ctx, imageName, image.PushOptions{
-		RegistryAuth: getRegistryAuth(reg),
-	})
+// PushSnapshot pushes a local snapshot to the registry and reports the push progress into the snapshot's build log file
+func (d *DockerClient) PushSnapshot(ctx context.Context, snapshot string, targetImage string, reg *dto.RegistryDTO) error {
+	logFilePath, err := config.GetBuildLogFilePath(snapshot[:strings.LastIndex(snapshot, ":")])
+	if err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	writer := io.Writer(logFile)
+	if d.logWriter != nil {
+		writer = io.MultiWriter(d.logWriter, logFile)
+	}
+
+	writer.Write([]byte(fmt.Sprintf("Pushing snapshot %s...\n", targetImage)))
+
+	err = d.pushImage(ctx, targetImage, reg, writer)
 	if err != nil {
+		writer.Write([]byte(fmt.Sprintf("Failed to push snapshot %s: %s\n", targetImage, err.Error())))
 		return err
 	}
-	defer responseBody.Close()
 
-	err = jsonmessage.DisplayJSONMessagesStream(responseBody, io.Writer(&util.DebugLogWriter{}), 0, true, nil)
+	writer.Write([]byte("Snapshot pushed successfully\n"))
+
+	return nil
+}
+
+func (d *DockerClient) pushImage(ctx context.Context, imageName string, reg *dto.RegistryDTO, out io.Writer) error {
+	log.Infof("Pushing image %s...", imageName)
+
+	// Layers that were already uploaded are skipped by the registry, so a retried push resumes where it failed
+	err := d.withRegistryRetry(ctx, "push", imageName, func() error {
+		responseBody, err := d.apiClient.ImagePush(ctx, imageName, image.PushOptions{
+			RegistryAuth: getRegistryAuth(reg),
+		})
+		if err != nil {
+			return err
+		}
+		defer responseBody.Close()
+
+		return jsonmessage.DisplayJSONMessagesStream(responseBody, out, 0, true, nil)
+	})
 	if err != nil {
 		return err
 	}
@@ -8,8 +8,6 @@ import (
 
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/errdefs"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) RemoveImage(ctx context.Context, imageName string, force bool) error {
@@ -0,0 +1,116 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
+)
+
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// ScanImage runs Trivy against a local image and returns its vulnerability report
+func (d *DockerClient) ScanImage(ctx context.Context, imageName string) (*dto.ScanSnapshotResponseDTO, error) {
+	ctx, span := telemetry.StartSpan(ctx, "docker.ScanImage", "")
+	defer span.End()
+
+	exists, err := d.ImageExists(ctx, imageName, true)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, common.NewNotFoundError(fmt.Errorf("snapshot %s not found", imageName))
+	}
+
+	trivyPath, err := exec.LookPath(d.trivyPath)
+	if err != nil {
+		return nil, fmt.Errorf("trivy is not available on this runner: %w", err)
+	}
+
+	log.Infof("Scanning image %s for vulnerabilities", imageName)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, trivyPath, "image", "--quiet", "--format", "json", "--image-src", "docker", imageName)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("trivy scan failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var report trivyReport
+	err = json.Unmarshal(stdout.Bytes(), &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trivy report: %w", err)
+	}
+
+	response := &dto.ScanSnapshotResponseDTO{
+		Snapshot:        imageName,
+		Vulnerabilities: []dto.VulnerabilityDTO{},
+		Summary:         make(map[string]int),
+	}
+
+	for _, result := range report.Results {
+		for _, vulnerability := range result.Vulnerabilities {
+			response.Vulnerabilities = append(response.Vulnerabilities, dto.VulnerabilityDTO{
+				Id:               vulnerability.VulnerabilityID,
+				Target:           result.Target,
+				PkgName:          vulnerability.PkgName,
+				InstalledVersion: vulnerability.InstalledVersion,
+				FixedVersion:     vulnerability.FixedVersion,
+				Severity:         vulnerability.Severity,
+				Title:            vulnerability.Title,
+			})
+			response.Summary[vulnerability.Severity]++
+		}
+	}
+
+	return response, nil
+}
+
+// enforceScanThreshold fails if the image has vulnerabilities at or above the given severity
+func (d *DockerClient) enforceScanThreshold(ctx context.Context, imageName string, threshold enums.VulnerabilitySeverity) error {
+	report, err := d.ScanImage(ctx, imageName)
+	if err != nil {
+		return err
+	}
+
+	var blocking []string
+	for severity, count := range report.Summary {
+		if enums.VulnerabilitySeverity(severity).Rank() >= threshold.Rank() {
+			blocking = append(blocking, fmt.Sprintf("%d %s", count, severity))
+		}
+	}
+
+	if len(blocking) > 0 {
+		sort.Strings(blocking)
+		return common.NewCustomError(http.StatusUnprocessableEntity, fmt.Sprintf("snapshot %s has vulnerabilities at or above %s severity: %s", imageName, threshold, strings.Join(blocking, ", ")), "VULNERABILITY_THRESHOLD_EXCEEDED")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	cmap "github.com/orcaman/concurrent-map/v2"
+)
+
+type SignatureVerificationConfig struct {
+	CosignPath string
+	// PublicKeys are paths or KMS URIs of keys trusted to sign snapshots
+	PublicKeys []string
+	// KeylessIdentities are trusted Fulcio identities in the "<oidc issuer>|<identity regexp>" format
+	KeylessIdentities []string
+}
+
+func (c SignatureVerificationConfig) enabled() bool {
+	return len(c.PublicKeys) > 0 || len(c.KeylessIdentities) > 0
+}
+
+// Manifest digests whose signature was verified by this runner
+var verified_digests_map = cmap.New[bool]()
+
+type cosignVerification struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifyImageSignature checks the image in its registry against every trusted key and identity and succeeds
+// on the first match. It returns the signed manifest digest so the pulled image can be pinned to it.
+func (d *DockerClient) verifyImageSignature(ctx context.Context, imageName string, reg *dto.RegistryDTO) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "docker.VerifyImageSignature", "")
+	defer span.End()
+
+	cosignPath, err := exec.LookPath(d.signatureVerification.CosignPath)
+	if err != nil {
+		return "", fmt.Errorf("signature verification is enabled but cosign is not available: %w", err)
+	}
+
+	var attempts [][]string
+	for _, key := range d.signatureVerification.PublicKeys {
+		attempts = append(attempts, []string{"--key", key})
+	}
+	for _, identity := range d.signatureVerification.KeylessIdentities {
+		issuer, identityRegexp, ok := strings.Cut(identity, "|")
+		if !ok {
+			return "", fmt.Errorf("invalid keyless identity %q, expected <oidc issuer>|<identity regexp>", identity)
+		}
+		attempts = append(attempts, []string{"--certificate-oidc-issuer", issuer, "--certificate-identity-regexp", identityRegexp})
+	}
+
+	var failures []string
+	for _, attempt := range attempts {
+		args := append([]string{"verify", "--output", "json"}, attempt...)
+		if reg != nil && reg.Username != "" {
+			args = append(args, "--registry-username", reg.Username, "--registry-password", reg.Password)
+		}
+		args = append(args, imageName)
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, cosignPath, args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err = cmd.Run()
+		if err != nil {
+			failures = append(failures, strings.TrimSpace(stderr.String()))
+			continue
+		}
+
+		digest, err := parseSignedDigest(stdout.Bytes())
+		if err != nil {
+			return "", err
+		}
+
+		log.Infof("Verified signature of image %s (%s)", imageName, digest)
+		verified_digests_map.Set(digest, true)
+		return digest, nil
+	}
+
+	return "", newSignatureVerificationError(fmt.Errorf("no trusted signature found for %s: %s", imageName, strings.Join(failures, "; ")))
+}
+
+func parseSignedDigest(output []byte) (string, error) {
+	var verifications []cosignVerification
+	err := json.Unmarshal(output, &verifications)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse cosign output: %w", err)
+	}
+
+	for _, verification := range verifications {
+		if digest := verification.Critical.Image.DockerManifestDigest; digest != "" {
+			return digest, nil
+		}
+	}
+
+	return "", errors.New("cosign output does not contain the signed manifest digest")
+}
+
+// ensureSignedDigest removes the pulled image if it does not match the manifest digest that was verified,
+// e.g. because the tag moved between verification and pull
+func (d *DockerClient) ensureSignedDigest(ctx context.Context, imageName string, digest string) error {
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return err
+	}
+
+	for _, repoDigest := range inspect.RepoDigests {
+		if strings.HasSuffix(repoDigest, "@"+digest) {
+			return nil
+		}
+	}
+
+	err = d.RemoveImage(ctx, imageName, true)
+	if err != nil {
+		log.Errorf("Failed to remove unverified image %s: %v", imageName, err)
+	}
+
+	return newSignatureVerificationError(fmt.Errorf("pulled image %s does not match the signed digest %s", imageName, digest))
+}
+
+// ensureImageVerified makes sure a local image matches a digest verified by this runner before it is run.
+// Images that were pulled before the runner started (or built locally) are verified against their registry.
+func (d *DockerClient) ensureImageVerified(ctx context.Context, imageName string, reg *dto.RegistryDTO) error {
+	if !d.signatureVerification.enabled() {
+		return nil
+	}
+
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return err
+	}
+
+	for _, repoDigest := range inspect.RepoDigests {
+		_, digest, ok := strings.Cut(repoDigest, "@")
+		if ok && verified_digests_map.Has(digest) {
+			return nil
+		}
+	}
+
+	digest, err := d.verifyImageSignature(ctx, imageName, reg)
+	if err != nil {
+		return err
+	}
+
+	return d.ensureSignedDigest(ctx, imageName, digest)
+}
+
+func newSignatureVerificationError(err error) error {
+	return common.NewCustomError(http.StatusForbidden, err.Error(), "SIGNATURE_VERIFICATION_FAILED")
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/lifecycle"
+	"github.com/daytonaio/runner/pkg/logship"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const defaultHookTimeout = 10 * time.Minute
+
+// storeLifecycleHooks keeps the hooks passed on create, the start runs them
+func (d *DockerClient) storeLifecycleHooks(sandboxDto dto.CreateSandboxDTO) error {
+	if sandboxDto.Hooks == nil {
+		return nil
+	}
+
+	return d.lifecycleHooks.Update(sandboxDto.Id, func(record *lifecycle.Record) {
+		*record = lifecycle.Record{Hooks: *sandboxDto.Hooks}
+	})
+}
+
+// GetLifecycleHookLogs writes the output of the hooks the sandbox ran so far to w
+func (d *DockerClient) GetLifecycleHookLogs(sandboxId string, w io.Writer) error {
+	return d.lifecycleHooks.ReadLog(sandboxId, w)
+}
+
+// GetSandboxWarnings returns the latest hook failures of the sandbox that were only recorded as warnings
+func (d *DockerClient) GetSandboxWarnings(sandboxId string) ([]dto.SandboxWarningDTO, error) {
+	record, err := d.lifecycleHooks.Get(sandboxId)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	return record.Warnings, nil
+}
+
+// runLifecycleHooks runs the hooks of the stage in order and applies the failure policy of each. Post create
+// hooks only run until they succeeded once.
+func (d *DockerClient) runLifecycleHooks(ctx context.Context, sandboxId string, stage enums.LifecycleHookStage) error {
+	record, err := d.lifecycleHooks.Get(sandboxId)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return nil
+	}
+
+	var hooks []dto.HookDTO
+	switch stage {
+	case enums.LifecycleHookStagePostCreate:
+		if record.PostCreateDone {
+			return nil
+		}
+		hooks = record.Hooks.PostCreate
+	case enums.LifecycleHookStagePostStart:
+		hooks = record.Hooks.PostStart
+	case enums.LifecycleHookStagePreStop:
+		hooks = record.Hooks.PreStop
+	}
+
+	if len(hooks) > 0 {
+		logWriter, err := d.lifecycleHooks.OpenLogWriter(sandboxId)
+		if err != nil {
+			return fmt.Errorf("failed to open the hook log: %w", err)
+		}
+		defer logWriter.Close()
+
+		shipWriter := d.logShipper.Writer(logship.SOURCE_LIFECYCLE_HOOK, map[string]string{
+			"sandboxId": sandboxId,
+			"stage":     string(stage),
+		})
+		defer shipWriter.Close()
+
+		hookLogWriter := io.MultiWriter(logWriter, shipWriter)
+
+		for _, hook := range hooks {
+			err := d.runLifecycleHook(ctx, sandboxId, stage, hook, hookLogWriter)
+			if err == nil {
+				continue
+			}
+
+			switch enums.HookFailurePolicy(hook.FailurePolicy) {
+			case enums.HookFailurePolicyIgnore:
+				log.Debugf("Ignoring failed %s hook of sandbox %s: %v", stage, sandboxId, err)
+			case enums.HookFailurePolicyFail:
+				return fmt.Errorf("%s hook %q failed: %w", stage, hook.Command, err)
+			default:
+				d.addSandboxWarning(sandboxId, stage, hook, err)
+			}
+		}
+	}
+
+	if stage == enums.LifecycleHookStagePostCreate {
+		return d.lifecycleHooks.Update(sandboxId, func(record *lifecycle.Record) {
+			record.PostCreateDone = true
+		})
+	}
+
+	return nil
+}
+
+func (d *DockerClient) runLifecycleHook(ctx context.Context, sandboxId string, stage enums.LifecycleHookStage, hook dto.HookDTO, logWriter io.Writer) error {
+	timeout := defaultHookTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Fprintf(logWriter, "[%s] %s $ %s\n", time.Now().UTC().Format(time.RFC3339), stage, hook.Command)
+
+	startTime := time.Now()
+	exitCode, err := d.execHook(ctx, sandboxId, hook, logWriter)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		fmt.Fprintf(logWriter, "[%s] %s failed: %v\n", time.Now().UTC().Format(time.RFC3339), stage, err)
+		return err
+	}
+
+	fmt.Fprintf(logWriter, "[%s] %s exited with code %d after %s\n", time.Now().UTC().Format(time.RFC3339), stage, exitCode, time.Since(startTime).Round(time.Millisecond))
+
+	if exitCode != 0 {
+		return fmt.Errorf("exited with code %d", exitCode)
+	}
+
+	return nil
+}
+
+// execHook runs the command with its output going to the hook log. A hook that times out is left running in
+// the sandbox, exec processes can't be killed through the API.
+func (d *DockerClient) execHook(ctx context.Context, sandboxId string, hook dto.HookDTO, logWriter io.Writer) (int, error) {
+	release, err := d.execLimiter.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	response, err := d.apiClient.ContainerExecCreate(ctx, sandboxId, container.ExecOptions{
+		Cmd:          []string{"sh", "-c", hook.Command},
+		User:         hook.User,
+		Env:          d.getDaemonEnv(sandboxId),
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	attach, err := d.apiClient.ContainerExecAttach(ctx, response.ID, container.ExecStartOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer attach.Close()
+
+	copyDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(logWriter, logWriter, attach.Reader)
+		copyDone <- err
+	}()
+
+	select {
+	case err = <-copyDone:
+		if err != nil {
+			return 0, err
+		}
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	inspect, err := d.apiClient.ContainerExecInspect(ctx, response.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return inspect.ExitCode, nil
+}
+
+func (d *DockerClient) addSandboxWarning(sandboxId string, stage enums.LifecycleHookStage, hook dto.HookDTO, hookErr error) {
+	warning := dto.SandboxWarningDTO{
+		Stage:     stage.String(),
+		Command:   hook.Command,
+		Message:   hookErr.Error(),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := d.lifecycleHooks.AddWarning(sandboxId, warning)
+	if err != nil {
+		log.Errorf("Failed to record the warning of sandbox %s: %v", sandboxId, err)
+	}
+
+	if d.events != nil {
+		d.events.Publish(events.Event{
+			Type:      enums.EventTypeSandboxWarning,
+			SandboxId: sandboxId,
+			State:     stage.String(),
+			Message:   fmt.Sprintf("%s hook %q failed: %v", stage, hook.Command, hookErr),
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+type SandboxLogsOptions struct {
+	Follow bool
+	// Since and Until bound the logs by the time they were written, zero values leave them unbounded
+	Since time.Time
+	Until time.Time
+	// Tail is the number of lines from the end of the logs to return, negative values return all lines
+	Tail       int
+	Stdout     bool
+	Stderr     bool
+	Timestamps bool
+}
+
+// GetSandboxLogs writes the output of the container itself to stdout and stderr, unlike the daemon and hook logs.
+// The logs of containers without a TTY are multiplexed and are split into the two streams.
+func (d *DockerClient) GetSandboxLogs(ctx context.Context, containerId string, options SandboxLogsOptions, stdout, stderr io.Writer) error {
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	logsOptions := container.LogsOptions{
+		ShowStdout: options.Stdout,
+		ShowStderr: options.Stderr,
+		Follow:     options.Follow,
+		Timestamps: options.Timestamps,
+		Tail:       "all",
+	}
+	if options.Tail >= 0 {
+		logsOptions.Tail = strconv.Itoa(options.Tail)
+	}
+	if !options.Since.IsZero() {
+		logsOptions.Since = options.Since.Format(time.RFC3339Nano)
+	}
+	if !options.Until.IsZero() {
+		logsOptions.Until = options.Until.Format(time.RFC3339Nano)
+	}
+
+	logs, err := d.apiClient.ContainerLogs(ctx, containerId, logsOptions)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of sandbox %s: %w", containerId, err)
+	}
+	defer logs.Close()
+
+	// A TTY merges both streams, its logs are raw
+	if c.Config != nil && c.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs of sandbox %s: %w", containerId, err)
+	}
+
+	return nil
+}
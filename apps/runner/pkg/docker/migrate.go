@@ -0,0 +1,222 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/storage"
+	"github.com/docker/docker/errdefs"
+)
+
+const migrationManifestFileName = "migration.json"
+
+// SandboxMigration is written next to the backup of a migrated sandbox and holds what the destination runner needs
+// to create the sandbox again. Secrets are not written to object storage, the control plane sets them again once the
+// destination took the sandbox over.
+type SandboxMigration struct {
+	Sandbox dto.CreateSandboxDTO `json:"sandbox"`
+	// CheckpointId is only set for live migrations, the sandbox is restored from it instead of being started fresh
+	CheckpointId string              `json:"checkpointId,omitempty"`
+	Ports        []dto.ExposePortDTO `json:"ports,omitempty"`
+	CreatedAt    time.Time           `json:"createdAt"`
+}
+
+// ExportSandbox stops the sandbox, or checkpoints it for live migrations, and exports its filesystem and volumes to
+// object storage for another runner to import. The sandbox is left stopped on this runner, ResumeExportedSandbox
+// starts it again if the migration is aborted.
+func (d *DockerClient) ExportSandbox(ctx context.Context, sandboxId string, objectPath string, live bool, ports []dto.ExposePortDTO) (*SandboxMigration, error) {
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	if ct.Config.Labels[constants.COMPOSE_SANDBOX_LABEL] != "" || ct.Config.Labels[constants.SIDECAR_OF_LABEL] != "" {
+		return nil, common.NewBadRequestError(errors.New("only single container sandboxes can be migrated"))
+	}
+
+	if live && !ct.State.Running {
+		return nil, common.NewConflictError(fmt.Errorf("sandbox %s must be running to be migrated live", sandboxId))
+	}
+
+	// The latest tag would make the create on the destination pull the snapshot from a registry
+	snapshot := fmt.Sprintf("daytona-migration:%s", sandboxId)
+
+	createDto, err := d.getCloneCreateDto(ctx, &ct, snapshot, dto.CloneSandboxDTO{
+		Id:         sandboxId,
+		CopyEnv:    true,
+		CopyLabels: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	createDto.Secrets = nil
+
+	migration := &SandboxMigration{
+		Sandbox:   createDto,
+		Ports:     ports,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if live {
+		migration.CheckpointId = fmt.Sprintf("migration-%d", migration.CreatedAt.Unix())
+
+		err = d.Checkpoint(ctx, sandboxId, dto.CheckpointSandboxDTO{
+			CheckpointId: migration.CheckpointId,
+			Exit:         true,
+		})
+	} else if ct.State.Running {
+		_, err = d.Stop(ctx, sandboxId, dto.StopSandboxDTO{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Exporting sandbox %s to %s for migration...", sandboxId, objectPath)
+
+	err = d.exportMigration(ctx, sandboxId, objectPath, migration)
+	if err != nil {
+		d.ResumeExportedSandbox(context.WithoutCancel(ctx), sandboxId, migration)
+		return nil, err
+	}
+
+	log.Infof("Sandbox %s exported to %s for migration", sandboxId, objectPath)
+
+	return migration, nil
+}
+
+func (d *DockerClient) exportMigration(ctx context.Context, sandboxId string, objectPath string, migration *SandboxMigration) error {
+	defer d.cache.SetBackupProgress(context.WithoutCancel(ctx), sandboxId, nil)
+
+	err := d.createStorageBackup(ctx, sandboxId, dto.CreateBackupDTO{
+		Snapshot:   migration.Sandbox.Snapshot,
+		ObjectPath: objectPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	rawManifest, err := json.Marshal(migration)
+	if err != nil {
+		return err
+	}
+
+	err = storageClient.PutObjectStream(ctx, path.Join(objectPath, migrationManifestFileName), bytes.NewReader(rawManifest), int64(len(rawManifest)))
+	if err != nil {
+		return fmt.Errorf("failed to write migration manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeExportedSandbox brings a sandbox back up on this runner after its migration failed, live migrated sandboxes
+// are restored from their checkpoint so their processes keep running. Errors are only logged, the sandbox stays
+// stopped and can be started again.
+func (d *DockerClient) ResumeExportedSandbox(ctx context.Context, sandboxId string, migration *SandboxMigration) {
+	var err error
+	if migration.CheckpointId != "" {
+		err = d.RestoreCheckpoint(ctx, sandboxId, dto.RestoreCheckpointDTO{CheckpointId: migration.CheckpointId})
+	} else {
+		err = d.Start(ctx, sandboxId)
+	}
+	if err != nil {
+		log.Errorf("Failed to resume sandbox %s after its migration was aborted: %v", sandboxId, err)
+		return
+	}
+
+	log.Infof("Sandbox %s resumed after its migration was aborted", sandboxId)
+}
+
+// ReadSandboxMigration reads the manifest of a sandbox exported for migration
+func (d *DockerClient) ReadSandboxMigration(ctx context.Context, objectPath string) (*SandboxMigration, error) {
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := storageClient.GetObjectStream(ctx, path.Join(objectPath, migrationManifestFileName))
+	if err != nil {
+		return nil, common.NewNotFoundError(fmt.Errorf("migration manifest not found in %s: %w", objectPath, err))
+	}
+	defer reader.Close()
+
+	var migration SandboxMigration
+	err = json.NewDecoder(reader).Decode(&migration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse migration manifest: %w", err)
+	}
+
+	return &migration, nil
+}
+
+// ImportSandbox creates a sandbox exported by another runner on this runner. Live migrated sandboxes are restored
+// from their checkpoint, the others are started fresh. A failed import is rolled back so the migration can be
+// retried, here or on another runner.
+func (d *DockerClient) ImportSandbox(ctx context.Context, objectPath string, migration *SandboxMigration) (err error) {
+	sandboxId := migration.Sandbox.Id
+
+	_, err = d.apiClient.ContainerInspect(ctx, sandboxId)
+	if err == nil {
+		return common.NewConflictError(fmt.Errorf("sandbox %s already exists", sandboxId))
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		rollbackErr := d.Destroy(context.WithoutCancel(ctx), sandboxId)
+		if rollbackErr != nil && !errdefs.IsNotFound(rollbackErr) {
+			log.Errorf("Failed to roll back the import of sandbox %s: %v", sandboxId, rollbackErr)
+		}
+		d.removeRestoredVolumes(context.WithoutCancel(ctx), sandboxId)
+	}()
+
+	log.Infof("Importing sandbox %s from %s...", sandboxId, objectPath)
+
+	err = d.restoreStorageBackup(ctx, sandboxId, objectPath, getUsernsMode(migration.Sandbox.Security))
+	d.cache.SetBackupProgress(ctx, sandboxId, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.Create(ctx, migration.Sandbox)
+	if err != nil {
+		return err
+	}
+
+	if migration.CheckpointId != "" {
+		// The create started the sandbox fresh, CRIU restores the checkpointed processes into the stopped container
+		_, err = d.Stop(ctx, sandboxId, dto.StopSandboxDTO{Force: true})
+		if err != nil {
+			return err
+		}
+
+		err = d.RestoreCheckpoint(ctx, sandboxId, dto.RestoreCheckpointDTO{CheckpointId: migration.CheckpointId})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Sandbox %s imported from %s", sandboxId, objectPath)
+
+	return nil
+}
@@ -10,10 +10,10 @@ import (
 	"time"
 
 	"github.com/daytonaio/runner/pkg/netrules"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
-	log "github.com/sirupsen/logrus"
 )
 
 type DockerMonitor struct {
@@ -139,7 +139,7 @@ func (dm *DockerMonitor) handleContainerEvent(event events.Message) {
 			return
 		}
 		shortContainerID := containerID[:12]
-		err = dm.netRulesManager.AssignNetworkRules(shortContainerID, ct.NetworkSettings.IPAddress)
+		err = dm.netRulesManager.AssignNetworkRules(shortContainerID, sandboxnet.GetContainerIP(ct.NetworkSettings.Networks))
 		if err != nil {
 			log.Errorf("Error assigning network rules: %v", err)
 		}
@@ -219,9 +219,10 @@ func (dm *DockerMonitor) reconcileNetworkRules() {
 			ruleIP = strings.Split(sourceIP, "/")[0]
 		}
 
-		if container.NetworkSettings.IPAddress != ruleIP {
+		containerIP := sandboxnet.GetContainerIP(container.NetworkSettings.Networks)
+		if containerIP != ruleIP {
 			log.Warnf("IP mismatch for container %s: rule has %s, container has %s",
-				containerID, sourceIP, container.NetworkSettings.IPAddress)
+				containerID, sourceIP, containerIP)
 
 			// Delete only this specific mismatched rule
 			if err := dm.netRulesManager.DeleteDockerUserRule(rule); err != nil {
@@ -0,0 +1,154 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+const (
+	LIMITED_OPERATION_CREATE = "create"
+	LIMITED_OPERATION_PULL   = "pull"
+	LIMITED_OPERATION_BACKUP = "backup"
+	LIMITED_OPERATION_EXEC   = "exec"
+	// Execs started by users through the toolbox or the SSH gateway, they are limited apart from the execs of the
+	// runner so open shells can't hold up sandbox starts
+	LIMITED_OPERATION_SANDBOX_EXEC = "sandbox_exec"
+)
+
+// Retry-After bounds of refused operations, the default is used until an operation of the type finished
+const (
+	defaultRetryAfter = 5 * time.Second
+	minRetryAfter     = 1 * time.Second
+	maxRetryAfter     = 5 * time.Minute
+)
+
+// operationLimiter bounds the number of concurrent operations of a type. Waiting operations block until a slot is
+// free, others are refused right away with a ResourceExhaustedError telling when a slot is expected to be free.
+type operationLimiter struct {
+	operation string
+	slots     chan struct{}
+	durations durationEstimate
+
+	mutex  sync.Mutex
+	queued int
+}
+
+// newOperationLimiter returns nil, which never blocks, if the limit is not positive
+func newOperationLimiter(operation string, limit int) *operationLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &operationLimiter{
+		operation: operation,
+		slots:     make(chan struct{}, limit),
+	}
+}
+
+// AcquireExec takes a slot for an exec started by a user, e.g. a command run through the toolbox, and returns the
+// function releasing it. A ResourceExhaustedError is returned if all slots are taken.
+func (d *DockerClient) AcquireExec() (func(), error) {
+	return d.sandboxExecLimiter.tryAcquire()
+}
+
+// tryAcquire takes a slot without waiting and returns the function releasing it
+func (l *operationLimiter) tryAcquire() (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.acquired(), nil
+	default:
+	}
+
+	common.ConcurrencyLimitRejectedCount.WithLabelValues(l.operation).Inc()
+
+	err := fmt.Errorf("the runner is already running %d %s operations", cap(l.slots), l.operation)
+	return nil, common.NewResourceExhaustedError(err, l.durations.retryAfter(len(l.slots), cap(l.slots)))
+}
+
+// acquire waits for a free slot and returns the function releasing it
+func (l *operationLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.acquired(), nil
+	default:
+	}
+
+	l.setQueued(1)
+	defer l.setQueued(-1)
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.acquired(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *operationLimiter) setQueued(delta int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.queued += delta
+	common.ConcurrencyLimitQueueDepth.WithLabelValues(l.operation).Set(float64(l.queued))
+}
+
+func (l *operationLimiter) acquired() func() {
+	common.ConcurrencyLimitInFlight.WithLabelValues(l.operation).Inc()
+	startTime := time.Now()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.durations.observe(time.Since(startTime))
+			<-l.slots
+			common.ConcurrencyLimitInFlight.WithLabelValues(l.operation).Dec()
+		})
+	}
+}
+
+// durationEstimate keeps a moving average of how long the operations of a limiter hold their slot
+type durationEstimate struct {
+	mutex   sync.Mutex
+	average time.Duration
+}
+
+func (e *durationEstimate) observe(duration time.Duration) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.average == 0 {
+		e.average = duration
+		return
+	}
+
+	e.average = (e.average*4 + duration) / 5
+}
+
+// retryAfter estimates when a slot frees up given the operations ahead of the caller and the number of slots
+func (e *durationEstimate) retryAfter(ahead, slots int) time.Duration {
+	e.mutex.Lock()
+	average := e.average
+	e.mutex.Unlock()
+
+	if average == 0 || slots <= 0 {
+		return defaultRetryAfter
+	}
+
+	retryAfter := average * time.Duration(ahead) / time.Duration(slots)
+	return min(max(retryAfter, minRetryAfter), maxRetryAfter)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daytonaio/common-go/pkg/timer"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+func (d *DockerClient) Pause(ctx context.Context, containerId string) error {
+	defer timer.Timer()()
+
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	if c.State.Paused {
+		d.cache.SetSandboxState(ctx, containerId, enums.SandboxStatePaused)
+		return nil
+	}
+
+	if !c.State.Running {
+		return common.NewConflictError(fmt.Errorf("sandbox %s is not running", containerId))
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStatePausing)
+
+	// Cancel a backup if it's already in progress
+	backup_context, ok := backup_context_map.Get(containerId)
+	if ok {
+		backup_context.cancel()
+	}
+
+	err = d.apiClient.ContainerPause(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStatePaused)
+
+	log.Infof("Sandbox %s paused", containerId)
+
+	return nil
+}
+
+func (d *DockerClient) Resume(ctx context.Context, containerId string) error {
+	defer timer.Timer()()
+
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	if !c.State.Paused {
+		if c.State.Running {
+			d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStarted)
+			return nil
+		}
+		return common.NewConflictError(fmt.Errorf("sandbox %s is not paused", containerId))
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateResuming)
+
+	err = d.apiClient.ContainerUnpause(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := d.GetDaemonAddress(&c)
+	if err != nil {
+		return err
+	}
+
+	err = d.waitForDaemonRunning(ctx, daemonAddress, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStarted)
+
+	log.Infof("Sandbox %s resumed", containerId)
+
+	return nil
+}
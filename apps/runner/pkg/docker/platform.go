@@ -0,0 +1,69 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// Platform identifies the OS and CPU architecture an image was built for, e.g. linux/arm64/v8
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+func (p Platform) String() string {
+	if p.Variant != "" {
+		return fmt.Sprintf("%s/%s/%s", p.OS, p.Architecture, p.Variant)
+	}
+	return fmt.Sprintf("%s/%s", p.OS, p.Architecture)
+}
+
+// Matches compares OS and architecture, the variant is only compared when both sides specify it
+func (p Platform) Matches(other Platform) bool {
+	if p.OS != other.OS || p.Architecture != other.Architecture {
+		return false
+	}
+	return p.Variant == "" || other.Variant == "" || p.Variant == other.Variant
+}
+
+// HostPlatform is the platform sandboxes on this runner can execute
+func HostPlatform() Platform {
+	return Platform{OS: "linux", Architecture: normalizeArchitecture(runtime.GOARCH)}
+}
+
+// ParsePlatform parses "os/arch[/variant]", an empty string resolves to the host platform
+func ParsePlatform(platform string) (Platform, error) {
+	if platform == "" {
+		return HostPlatform(), nil
+	}
+
+	parts := strings.Split(strings.ToLower(platform), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Platform{}, common.NewBadRequestError(fmt.Errorf("invalid platform %q, expected os/arch[/variant]", platform))
+	}
+
+	p := Platform{OS: parts[0], Architecture: normalizeArchitecture(parts[1])}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+
+	return p, nil
+}
+
+func normalizeArchitecture(architecture string) string {
+	switch strings.ToLower(architecture) {
+	case "x86_64", "x86-64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return strings.ToLower(architecture)
+	}
+}
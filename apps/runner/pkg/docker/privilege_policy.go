@@ -0,0 +1,73 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	// PRIVILEGE_POLICY_DEFAULT runs sandboxes privileged unless they opt out or their hardening options rule it out
+	PRIVILEGE_POLICY_DEFAULT = "default"
+	// PRIVILEGE_POLICY_OPT_IN runs sandboxes unprivileged unless they ask for privileged mode
+	PRIVILEGE_POLICY_OPT_IN = "opt-in"
+	// PRIVILEGE_POLICY_FORBIDDEN refuses privileged mode and added capabilities and sets no new privileges on every sandbox
+	PRIVILEGE_POLICY_FORBIDDEN = "forbidden"
+)
+
+type PrivilegePolicy struct {
+	// Mode is one of the PRIVILEGE_POLICY_* modes, empty means PRIVILEGE_POLICY_DEFAULT
+	Mode string
+	// AllowedCapabilities are the capabilities sandboxes may add, any capability may be added if it is empty
+	AllowedCapabilities []string
+}
+
+// applyPrivilegePolicy settles whether the sandbox runs privileged and validates the privileges it asks for against the
+// policy of the runner, it expects the other security options to be applied to the host config already
+func (d *DockerClient) applyPrivilegePolicy(sandboxDto dto.CreateSandboxDTO, hostConfig *container.HostConfig) error {
+	security := sandboxDto.Security
+	mode := d.privilegePolicy.Mode
+
+	switch {
+	case security != nil && security.Privileged != nil && *security.Privileged:
+		if mode == PRIVILEGE_POLICY_FORBIDDEN {
+			return common.NewPrivilegeNotAllowedError(errors.New("privileged sandboxes are forbidden on this runner"))
+		}
+
+		// Cleared by the runtime or by hardening options Docker ignores for privileged containers
+		if !hostConfig.Privileged {
+			return common.NewBadRequestError(errors.New("privileged mode can't be combined with a sandboxed runtime, masked or read-only paths, a security profile, capabilities or no new privileges"))
+		}
+	case security != nil && security.Privileged != nil:
+		hostConfig.Privileged = false
+	case mode == PRIVILEGE_POLICY_OPT_IN || mode == PRIVILEGE_POLICY_FORBIDDEN:
+		hostConfig.Privileged = false
+	}
+
+	if mode == PRIVILEGE_POLICY_FORBIDDEN {
+		if len(hostConfig.CapAdd) > 0 {
+			return common.NewPrivilegeNotAllowedError(errors.New("adding capabilities is forbidden on this runner"))
+		}
+
+		if !slices.Contains(hostConfig.SecurityOpt, noNewPrivilegesOption) {
+			hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, noNewPrivilegesOption)
+		}
+	}
+
+	if len(d.privilegePolicy.AllowedCapabilities) > 0 {
+		for _, capability := range hostConfig.CapAdd {
+			if !slices.Contains(d.privilegePolicy.AllowedCapabilities, capability) {
+				return common.NewPrivilegeNotAllowedError(fmt.Errorf("capability %s is not allowed on this runner", capability))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// processListArgs are the ps arguments of the process list, the command comes last since it contains spaces
+var processListArgs = []string{"-eo", "pid,ppid,user,stat,etime,rss,args"}
+
+var processSignals = map[string]syscall.Signal{
+	"SIGTERM": syscall.SIGTERM,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGINT":  syscall.SIGINT,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGSTOP": syscall.SIGSTOP,
+	"SIGCONT": syscall.SIGCONT,
+}
+
+// ListProcesses lists the processes of the running sandbox with their host PIDs, the parent PIDs make up the tree
+func (d *DockerClient) ListProcesses(ctx context.Context, containerId string) (*dto.ListSandboxProcessesResponseDTO, error) {
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.State.Running {
+		return nil, common.NewConflictError(fmt.Errorf("sandbox %s is not running", containerId))
+	}
+
+	top, err := d.apiClient.ContainerTop(ctx, containerId, processListArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes of sandbox %s: %w", containerId, err)
+	}
+
+	columns := make(map[string]int, len(top.Titles))
+	for i, title := range top.Titles {
+		columns[title] = i
+	}
+
+	column := func(process []string, title string) string {
+		i, ok := columns[title]
+		if !ok || i >= len(process) {
+			return ""
+		}
+		return process[i]
+	}
+
+	processes := make([]dto.SandboxProcessDTO, 0, len(top.Processes))
+	for _, process := range top.Processes {
+		pid, err := strconv.Atoi(column(process, "PID"))
+		if err != nil {
+			continue
+		}
+		ppid, _ := strconv.Atoi(column(process, "PPID"))
+		rss, _ := strconv.ParseInt(column(process, "RSS"), 10, 64)
+
+		processes = append(processes, dto.SandboxProcessDTO{
+			Pid:     pid,
+			Ppid:    ppid,
+			User:    column(process, "USER"),
+			State:   column(process, "STAT"),
+			Elapsed: column(process, "ELAPSED"),
+			RssKiB:  rss,
+			Command: column(process, "COMMAND"),
+		})
+	}
+
+	return &dto.ListSandboxProcessesResponseDTO{
+		Processes: processes,
+	}, nil
+}
+
+// SignalProcess sends the signal to a process of the sandbox by its host PID. The PID is looked up among the
+// processes of the sandbox first so no other host process can be signalled. The init process of the sandbox is
+// refused, the sandbox is stopped through the API instead.
+func (d *DockerClient) SignalProcess(ctx context.Context, containerId string, pid int, signalName string) error {
+	signal, ok := processSignals[signalName]
+	if !ok {
+		return common.NewBadRequestError(fmt.Errorf("unsupported signal %s", signalName))
+	}
+
+	// The PIDs of a rootless engine are in the namespace of the engine, not of the runner
+	if d.rootless {
+		return common.NewBadRequestError(errors.New("signalling processes is not supported with a rootless container engine"))
+	}
+
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	if pid == c.State.Pid {
+		return common.NewConflictError(fmt.Errorf("process %d is the init process of sandbox %s, stop the sandbox instead", pid, containerId))
+	}
+
+	processes, err := d.ListProcesses(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, process := range processes.Processes {
+		if process.Pid == pid {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return common.NewNotFoundError(fmt.Errorf("process %d of sandbox %s not found", pid, containerId))
+	}
+
+	err = syscall.Kill(pid, signal)
+	if err != nil {
+		return fmt.Errorf("failed to send %s to process %d of sandbox %s: %w", signalName, pid, containerId, err)
+	}
+
+	log.Infof("Sent %s to process %d of sandbox %s", signalName, pid, containerId)
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+type pullWaiter struct {
+	ready      chan struct{}
+	onPosition func(position int)
+}
+
+// pullLimiter bounds the number of concurrent image pulls. Pulls over the limit wait in FIFO order
+// and are told their queue position whenever it changes, 0 meaning the pull is no longer queued.
+// Position callbacks run with the mutex held so updates are never applied out of order.
+// Once maxQueued pulls are waiting further pulls are refused with a ResourceExhaustedError.
+type pullLimiter struct {
+	mutex     sync.Mutex
+	limit     int
+	maxQueued int
+	running   int
+	queue     []*pullWaiter
+	durations durationEstimate
+}
+
+// newPullLimiter returns nil, which never blocks, if the limit is not positive. A maxQueued of 0 queues
+// every pull.
+func newPullLimiter(limit, maxQueued int) *pullLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &pullLimiter{limit: limit, maxQueued: maxQueued}
+}
+
+// acquire waits for a free pull slot and returns the function releasing it
+func (l *pullLimiter) acquire(ctx context.Context, onPosition func(position int)) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mutex.Lock()
+	if l.running < l.limit && len(l.queue) == 0 {
+		l.running++
+		l.updateMetrics()
+		l.mutex.Unlock()
+		return l.releaseOnce(), nil
+	}
+
+	if l.maxQueued > 0 && len(l.queue) >= l.maxQueued {
+		retryAfter := l.durations.retryAfter(l.running+len(l.queue), l.limit)
+		l.mutex.Unlock()
+		common.ConcurrencyLimitRejectedCount.WithLabelValues(LIMITED_OPERATION_PULL).Inc()
+		return nil, common.NewResourceExhaustedError(fmt.Errorf("%d image pulls are already queued", l.maxQueued), retryAfter)
+	}
+
+	waiter := &pullWaiter{
+		ready:      make(chan struct{}),
+		onPosition: onPosition,
+	}
+	l.queue = append(l.queue, waiter)
+	waiter.onPosition(len(l.queue))
+	l.updateMetrics()
+	l.mutex.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return l.releaseOnce(), nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		queued := l.removeWaiter(waiter)
+		l.mutex.Unlock()
+
+		if !queued {
+			// The slot was handed over right as the context was cancelled
+			l.release()
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+func (l *pullLimiter) releaseOnce() func() {
+	startTime := time.Now()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.durations.observe(time.Since(startTime))
+			l.release()
+		})
+	}
+}
+
+// release hands the slot over to the first queued pull or frees it if the queue is empty
+func (l *pullLimiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	defer l.updateMetrics()
+
+	if len(l.queue) == 0 {
+		l.running--
+		return
+	}
+
+	next := l.queue[0]
+	l.queue = l.queue[1:]
+	next.onPosition(0)
+	close(next.ready)
+
+	l.notifyPositions()
+}
+
+// removeWaiter must be called with the mutex held, it reports whether the waiter was still queued
+func (l *pullLimiter) removeWaiter(waiter *pullWaiter) bool {
+	for i, w := range l.queue {
+		if w == waiter {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			waiter.onPosition(0)
+			l.notifyPositions()
+			l.updateMetrics()
+			return true
+		}
+	}
+
+	return false
+}
+
+// notifyPositions must be called with the mutex held
+func (l *pullLimiter) notifyPositions() {
+	for i, w := range l.queue {
+		w.onPosition(i + 1)
+	}
+}
+
+// updateMetrics must be called with the mutex held
+func (l *pullLimiter) updateMetrics() {
+	common.ConcurrencyLimitInFlight.WithLabelValues(LIMITED_OPERATION_PULL).Set(float64(l.running))
+	common.ConcurrencyLimitQueueDepth.WithLabelValues(LIMITED_OPERATION_PULL).Set(float64(len(l.queue)))
+}
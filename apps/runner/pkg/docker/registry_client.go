@@ -0,0 +1,215 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/distribution/reference"
+)
+
+const dockerHubRegistryHost = "registry-1.docker.io"
+
+// registryClient is a minimal client of the registry HTTP API that supports anonymous access, basic
+// auth and bearer token auth
+type registryClient struct {
+	httpClient *http.Client
+	baseUrl    string
+	repository string
+	username   string
+	password   string
+
+	authorizationMutex sync.Mutex
+	authorization      string
+}
+
+func newRegistryClient(named reference.Named, reg *dto.RegistryDTO) *registryClient {
+	host := reference.Domain(named)
+	if host == dockerHubDomain {
+		host = dockerHubRegistryHost
+	}
+
+	client := &registryClient{
+		httpClient: &http.Client{},
+		baseUrl:    "https://" + host,
+		repository: reference.Path(named),
+	}
+
+	if reg != nil {
+		client.username = reg.Username
+		client.password = reg.Password
+	}
+
+	return client
+}
+
+// get requests a path below /v2/<repository>/ and authenticates once if the registry asks for it
+func (c *registryClient) get(ctx context.Context, path string, accept ...string) (*http.Response, error) {
+	requestUrl := fmt.Sprintf("%s/v2/%s/%s", c.baseUrl, c.repository, path)
+
+	response, err := c.send(ctx, requestUrl, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+
+		err = c.authenticate(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = c.send(ctx, requestUrl, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("registry returned %s for %s: %s", response.Status, requestUrl, strings.TrimSpace(string(body)))
+	}
+
+	return response, nil
+}
+
+func (c *registryClient) send(ctx context.Context, requestUrl string, accept []string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accept) > 0 {
+		request.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+
+	c.authorizationMutex.Lock()
+	authorization := c.authorization
+	c.authorizationMutex.Unlock()
+
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	return c.httpClient.Do(request)
+}
+
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return fmt.Errorf("registry %s requires credentials", c.baseUrl)
+		}
+
+		c.setAuthorization("Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)))
+		return nil
+	case "bearer":
+		token, err := c.fetchToken(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		c.setAuthorization("Bearer " + token)
+		return nil
+	default:
+		return fmt.Errorf("unsupported registry auth challenge: %s", challenge)
+	}
+}
+
+func (c *registryClient) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid registry auth realm: %s", params["realm"])
+	}
+
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if c.username != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token request returned %s", response.Status)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&tokenResponse)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("registry token response contains no token")
+}
+
+func (c *registryClient) setAuthorization(authorization string) {
+	c.authorizationMutex.Lock()
+	defer c.authorizationMutex.Unlock()
+
+	c.authorization = authorization
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header like Bearer realm="...",service="...",scope="..."
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+
+	return scheme, params
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/errdefs"
+)
+
+type RegistryRetryPolicy struct {
+	// MaxAttempts includes the first attempt, 1 disables retries
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Registry errors are mostly reported as text in the pull/push progress stream, so transient failures are matched by message
+var retryableRegistryErrors = []string{
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+	"toomanyrequests",
+	"429 too many requests",
+	"i/o timeout",
+	"tls handshake timeout",
+	"connection reset by peer",
+	"connection refused",
+	"unexpected eof",
+	"client.timeout exceeded",
+	"context deadline exceeded",
+}
+
+// withRegistryRetry runs a registry operation and retries transient failures with exponential backoff
+func (d *DockerClient) withRegistryRetry(ctx context.Context, operation string, imageName string, fn func() error) error {
+	backoff := d.registryRetry.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				log.Infof("%s of image %s succeeded after %d attempts", operation, imageName, attempt)
+			}
+			return nil
+		}
+
+		if attempt >= d.registryRetry.MaxAttempts || !isRetryableRegistryError(ctx, err) {
+			if attempt > 1 {
+				common.RegistryOperationRetryCount.WithLabelValues(operation, string(common.PrometheusOperationStatusFailure)).Inc()
+			}
+			return err
+		}
+
+		log.Warnf("%s of image %s failed (attempt %d/%d), retrying in %s: %v", operation, imageName, attempt, d.registryRetry.MaxAttempts, backoff, err)
+		common.RegistryOperationRetryCount.WithLabelValues(operation, "retry").Inc()
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+
+		backoff = min(backoff*2, d.registryRetry.MaxBackoff)
+	}
+}
+
+func isRetryableRegistryError(ctx context.Context, err error) bool {
+	// The caller gave up, a deadline of the operation itself is still worth retrying
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if errdefs.IsNotFound(err) || errdefs.IsUnauthorized(err) || errdefs.IsForbidden(err) || errdefs.IsInvalidParameter(err) {
+		return false
+	}
+
+	if errdefs.IsUnavailable(err) || errdefs.IsDeadline(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, retryable := range retryableRegistryErrors {
+		if strings.Contains(message, retryable) {
+			return true
+		}
+	}
+
+	return false
+}
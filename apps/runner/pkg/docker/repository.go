@@ -0,0 +1,170 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// Only the last lines of the git output end up in the error of a failed clone
+const maxGitErrorLines = 10
+
+var gitProgressLine = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+(\d+)%`)
+
+var commitRef = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+type repositoryOptions struct {
+	url   string
+	ref   string
+	token string
+	// depth limits the fetched history, 0 fetches all of it
+	depth       int
+	filter      string
+	sparsePaths []string
+}
+
+// cloneRepository clones the ref into dir and returns the cloned commit. Only HTTPS URLs are accepted so the
+// clone can't read paths of the runner host, the token is passed in the git config environment to keep it out
+// of the process list. onProgress, if set, receives the git progress.
+func cloneRepository(ctx context.Context, options repositoryOptions, dir string, onProgress func(step string, percent int)) (string, error) {
+	if !strings.HasPrefix(options.url, "https://") {
+		return "", common.NewBadRequestError(fmt.Errorf("repository URL %s is not an HTTPS URL", options.url))
+	}
+
+	var fetchArgs []string
+	if options.depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth", strconv.Itoa(options.depth))
+	}
+	if options.filter != "" {
+		fetchArgs = append(fetchArgs, "--filter", options.filter)
+	}
+
+	// A commit can't be cloned by name, it is fetched into an empty repository instead
+	checkoutRef := ""
+	var err error
+	if commitRef.MatchString(options.ref) {
+		err = runGit(ctx, options.token, nil, "init", "--quiet", dir)
+		if err == nil {
+			err = runGit(ctx, options.token, nil, "-C", dir, "remote", "add", "origin", options.url)
+		}
+		if err == nil {
+			args := append([]string{"-C", dir, "fetch", "--progress", "--no-tags"}, fetchArgs...)
+			err = runGit(ctx, options.token, onProgress, append(args, "origin", options.ref)...)
+		}
+		checkoutRef = "FETCH_HEAD"
+	} else {
+		args := append([]string{"clone", "--progress", "--no-checkout"}, fetchArgs...)
+		if options.ref != "" {
+			args = append(args, "--branch", options.ref)
+		}
+		err = runGit(ctx, options.token, onProgress, append(args, "--", options.url, dir)...)
+	}
+	if err != nil {
+		return "", common.NewBadRequestError(fmt.Errorf("failed to clone %s: %w", options.url, err))
+	}
+
+	if len(options.sparsePaths) > 0 {
+		err = runGit(ctx, options.token, nil, append([]string{"-C", dir, "sparse-checkout", "set", "--"}, options.sparsePaths...)...)
+		if err != nil {
+			return "", common.NewBadRequestError(fmt.Errorf("failed to set the sparse checkout of %s: %w", options.url, err))
+		}
+	}
+
+	checkoutArgs := []string{"-C", dir, "checkout", "--quiet"}
+	if checkoutRef != "" {
+		checkoutArgs = append(checkoutArgs, checkoutRef)
+	}
+	// Objects left out by a filter are fetched during the checkout
+	err = runGit(ctx, options.token, onProgress, checkoutArgs...)
+	if err != nil {
+		return "", fmt.Errorf("failed to check out %s: %w", options.url, err)
+	}
+
+	output, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the cloned commit of %s: %w", options.url, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// runGit runs git without prompts and reports the progress lines git writes to stderr
+func runGit(ctx context.Context, token string, onProgress func(step string, percent int), args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if token != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		cmd.Env = append(cmd.Env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanGitLines)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		match := gitProgressLine.FindStringSubmatch(line)
+		if match != nil {
+			if onProgress != nil {
+				percent, _ := strconv.Atoi(match[2])
+				onProgress(match[1], percent)
+			}
+			continue
+		}
+
+		lines = append(lines, line)
+		if len(lines) > maxGitErrorLines {
+			lines = lines[1:]
+		}
+	}
+
+	err = cmd.Wait()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.Join(lines, "\n"))
+	}
+
+	return nil
+}
+
+// scanGitLines splits on carriage returns too, git rewrites progress lines in place
+func scanGitLines(data []byte, atEOF bool) (int, []byte, error) {
+	index := bytes.IndexAny(data, "\r\n")
+	if index >= 0 {
+		return index + 1, data[:index], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
+)
+
+// repositoryClone is a clone running in the background while the sandbox container is prepared
+type repositoryClone struct {
+	sandboxId  string
+	targetPath string
+	workDir    string
+	cancel     context.CancelFunc
+	done       chan struct{}
+	commit     string
+	err        error
+	// reported is set once the outcome of the clone is in the cache
+	reported bool
+}
+
+// startRepositoryClone clones the repository of the sandbox into a temporary directory of the runner, the
+// progress is reported through the repository status in the cache
+func (d *DockerClient) startRepositoryClone(ctx context.Context, sandboxId string, repository dto.RepositoryDTO) (*repositoryClone, error) {
+	targetPath, err := getRepositoryTargetPath(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "daytona-repository-")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	clone := &repositoryClone{
+		sandboxId:  sandboxId,
+		targetPath: targetPath,
+		workDir:    workDir,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+
+	d.cache.SetRepositoryStatus(ctx, sandboxId, &models.RepositoryStatus{Phase: enums.RepositoryPhaseCloning})
+
+	go func() {
+		defer close(clone.done)
+
+		lastStep, lastPercent := "", -1
+		clone.commit, clone.err = cloneRepository(ctx, repositoryOptions{
+			url:         repository.Url,
+			ref:         repository.Ref,
+			token:       repository.AuthToken,
+			depth:       repository.Depth,
+			filter:      repository.Filter,
+			sparsePaths: repository.SparsePaths,
+		}, filepath.Join(workDir, "repository"), func(step string, percent int) {
+			if step == lastStep && percent == lastPercent {
+				return
+			}
+			lastStep, lastPercent = step, percent
+
+			d.cache.SetRepositoryStatus(ctx, sandboxId, &models.RepositoryStatus{
+				Phase:   enums.RepositoryPhaseCloning,
+				Step:    step,
+				Percent: percent,
+			})
+		})
+	}()
+
+	return clone, nil
+}
+
+// copyRepositoryToSandbox waits for the clone and copies it into the sandbox container, which must not be
+// started yet so nothing in the sandbox sees a partial checkout
+func (d *DockerClient) copyRepositoryToSandbox(ctx context.Context, clone *repositoryClone) error {
+	clone.reported = true
+
+	select {
+	case <-clone.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if clone.err != nil {
+		d.setRepositoryFailed(ctx, clone.sandboxId, clone.err)
+		return clone.err
+	}
+
+	d.cache.SetRepositoryStatus(ctx, clone.sandboxId, &models.RepositoryStatus{
+		Phase:  enums.RepositoryPhaseCopying,
+		Commit: clone.commit,
+	})
+
+	archive := tarDir(filepath.Join(clone.workDir, "repository"), clone.targetPath)
+	defer archive.Close()
+
+	// The files are owned by the user of the image like with docker cp -a
+	err := d.apiClient.CopyToContainer(ctx, clone.sandboxId, "/", archive, container.CopyToContainerOptions{
+		CopyUIDGID: true,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to copy the repository into the sandbox: %w", err)
+		d.setRepositoryFailed(ctx, clone.sandboxId, err)
+		return err
+	}
+
+	d.cache.SetRepositoryStatus(ctx, clone.sandboxId, &models.RepositoryStatus{
+		Phase:   enums.RepositoryPhaseReady,
+		Percent: 100,
+		Commit:  clone.commit,
+	})
+
+	log.Infof("Cloned commit %s into %s of sandbox %s", clone.commit, clone.targetPath, clone.sandboxId)
+
+	return nil
+}
+
+// closeRepositoryClone stops the clone if it is still running and removes it from the runner, a clone that was
+// never copied failed along with the creation of the sandbox
+func (d *DockerClient) closeRepositoryClone(ctx context.Context, clone *repositoryClone) {
+	clone.cancel()
+	<-clone.done
+
+	if !clone.reported {
+		d.setRepositoryFailed(ctx, clone.sandboxId, errors.New("the sandbox was not created"))
+	}
+
+	err := os.RemoveAll(clone.workDir)
+	if err != nil {
+		log.Warnf("Failed to remove the repository clone of sandbox %s: %v", clone.sandboxId, err)
+	}
+}
+
+func (d *DockerClient) setRepositoryFailed(ctx context.Context, sandboxId string, err error) {
+	d.cache.SetRepositoryStatus(ctx, sandboxId, &models.RepositoryStatus{
+		Phase: enums.RepositoryPhaseFailed,
+		Error: err.Error(),
+	})
+}
+
+// getRepositoryTargetPath defaults to /workspaces/<repository name> like devcontainers do
+func getRepositoryTargetPath(repository dto.RepositoryDTO) (string, error) {
+	if repository.TargetPath != "" {
+		targetPath := path.Clean(repository.TargetPath)
+		if !path.IsAbs(targetPath) || targetPath == "/" {
+			return "", common.NewBadRequestError(fmt.Errorf("invalid repository target path %s", repository.TargetPath))
+		}
+		return targetPath, nil
+	}
+
+	name := strings.TrimSuffix(path.Base(strings.TrimSuffix(repository.Url, "/")), ".git")
+	if name == "" || name == "." || name == "/" {
+		return "", common.NewBadRequestError(errors.New("the repository URL has no repository name, set a target path"))
+	}
+
+	return path.Join("/workspaces", name), nil
+}
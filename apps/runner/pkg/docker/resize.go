@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models"
 	"github.com/daytonaio/runner/pkg/models/enums"
 
 	"github.com/docker/docker/api/types/container"
@@ -19,10 +20,27 @@ func (d *DockerClient) Resize(ctx context.Context, sandboxId string, sandboxDto
 		Resources: container.Resources{
 			CPUQuota:   sandboxDto.Cpu * 100000, // Convert CPU cores to quota (1 core = 100000)
 			CPUPeriod:  100000,
-			Memory:     sandboxDto.Memory * 1024 * 1024 * 1024, // Convert GB to bytes
-			MemorySwap: sandboxDto.Memory * 1024 * 1024 * 1024, // Set swap equal to memory to disable swap
+			Memory:     sandboxDto.Memory * 1024 * 1024 * 1024,                     // Convert GB to bytes
+			MemorySwap: (sandboxDto.Memory + sandboxDto.Swap) * 1024 * 1024 * 1024, // Swap equal to memory disables swap
 		},
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	d.cache.SetSandboxResources(ctx, sandboxId, models.SandboxResources{
+		Cpu:    sandboxDto.Cpu,
+		Memory: sandboxDto.Memory,
+		Swap:   sandboxDto.Swap,
+	})
+
+	// The container keeps running while it is updated so restore its actual state
+	state, err := d.DeduceSandboxState(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxId, state)
+
+	return nil
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"encoding/json"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+)
+
+const (
+	RESTART_POLICY_NEVER      = "never"
+	RESTART_POLICY_ON_FAILURE = "on-failure"
+	RESTART_POLICY_ALWAYS     = "always"
+)
+
+// GetRestartPolicy returns the restart policy in the labels of the sandbox, nil if it is never restarted
+func GetRestartPolicy(labels map[string]string) *dto.RestartPolicyDTO {
+	raw, ok := labels[constants.RESTART_POLICY_LABEL]
+	if !ok {
+		return nil
+	}
+
+	var policy dto.RestartPolicyDTO
+	err := json.Unmarshal([]byte(raw), &policy)
+	if err != nil || policy.Mode == RESTART_POLICY_NEVER {
+		return nil
+	}
+
+	return &policy
+}
+
+// getRestartPolicyLabel returns the label value of the policy, sandboxes that are never restarted don't get one
+func getRestartPolicyLabel(policy *dto.RestartPolicyDTO) (string, bool) {
+	if policy == nil || policy.Mode == RESTART_POLICY_NEVER {
+		return "", false
+	}
+
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		return "", false
+	}
+
+	return string(raw), true
+}
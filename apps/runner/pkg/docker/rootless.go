@@ -0,0 +1,87 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/go-connections/nat"
+)
+
+// DAEMON_PORT is the port the sandbox daemon listens on inside the sandbox
+const DAEMON_PORT = 2280
+
+var daemonContainerPort = nat.Port(fmt.Sprintf("%d/tcp", DAEMON_PORT))
+
+// IsRootlessEngine reports whether the engine runs rootless. Rootless Docker and Podman report it in
+// the security options of docker info.
+func IsRootlessEngine(info system.Info) bool {
+	return slices.Contains(info.SecurityOptions, "name=rootless")
+}
+
+// Rootless reports whether the runner talks to a rootless Docker or Podman engine
+func (d *DockerClient) Rootless() bool {
+	return d.rootless
+}
+
+// applyRootlessConfig adapts the sandbox container to a rootless engine:
+//   - Container IPs live in the network namespace of the engine and aren't reachable from the runner, so
+//     the daemon port is published on the loopback interface instead
+//   - Resource limits need delegated cgroup v2 controllers, limits the engine can't enforce are dropped
+//     since the engine refuses to create the container otherwise
+func applyRootlessConfig(info system.Info, sandboxId string, containerConfig *container.Config, hostConfig *container.HostConfig) {
+	containerConfig.ExposedPorts = nat.PortSet{daemonContainerPort: struct{}{}}
+	hostConfig.PortBindings = nat.PortMap{
+		daemonContainerPort: {{HostIP: "127.0.0.1"}},
+	}
+
+	if info.CgroupVersion != "2" {
+		log.Warnf("Rootless engine uses cgroup v%s, the CPU and memory limits of sandbox %s are not enforced", info.CgroupVersion, sandboxId)
+		hostConfig.Resources.CPUPeriod = 0
+		hostConfig.Resources.CPUQuota = 0
+		hostConfig.Resources.Memory = 0
+		hostConfig.Resources.MemorySwap = 0
+		return
+	}
+
+	if !info.CPUCfsQuota {
+		log.Warnf("The cpu cgroup controller is not delegated to the rootless engine, the CPU limit of sandbox %s is not enforced", sandboxId)
+		hostConfig.Resources.CPUPeriod = 0
+		hostConfig.Resources.CPUQuota = 0
+	}
+
+	if !info.MemoryLimit {
+		log.Warnf("The memory cgroup controller is not delegated to the rootless engine, the memory limit of sandbox %s is not enforced", sandboxId)
+		hostConfig.Resources.Memory = 0
+		hostConfig.Resources.MemorySwap = 0
+	}
+}
+
+// GetDaemonAddress returns the host:port the runner reaches the sandbox daemon at
+func (d *DockerClient) GetDaemonAddress(ct *types.ContainerJSON) (string, error) {
+	if !d.rootless {
+		containerIP, err := getContainerIP(ct)
+		if err != nil {
+			return "", err
+		}
+
+		return net.JoinHostPort(containerIP, fmt.Sprint(DAEMON_PORT)), nil
+	}
+
+	if ct.NetworkSettings != nil {
+		for _, binding := range ct.NetworkSettings.Ports[daemonContainerPort] {
+			if binding.HostPort != "" {
+				return net.JoinHostPort("127.0.0.1", binding.HostPort), nil
+			}
+		}
+	}
+
+	return "", errors.New("the sandbox daemon port is not published. Is the Sandbox started?")
+}
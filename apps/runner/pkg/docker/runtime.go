@@ -0,0 +1,61 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types/system"
+)
+
+const (
+	RUNTIME_RUNC  = "runc"
+	RUNTIME_RUNSC = "runsc"
+	RUNTIME_KATA  = "kata"
+)
+
+// runtimeNames holds the names a runtime is commonly registered under in the daemon configuration,
+// in order of preference
+var runtimeNames = map[string][]string{
+	RUNTIME_RUNC:  {"runc"},
+	RUNTIME_RUNSC: {"runsc", "gvisor"},
+	RUNTIME_KATA:  {"kata", "kata-runtime", "io.containerd.kata.v2"},
+}
+
+// resolveRuntime returns the name the daemon knows the requested runtime under
+func resolveRuntime(info system.Info, runtime string) (string, error) {
+	for _, name := range runtimeNames[runtime] {
+		if _, ok := info.Runtimes[name]; ok {
+			return name, nil
+		}
+	}
+
+	available := make([]string, 0, len(info.Runtimes))
+	for name := range info.Runtimes {
+		available = append(available, name)
+	}
+	sort.Strings(available)
+
+	return "", common.NewBadRequestError(fmt.Errorf("runtime %s is not available on this runner, available runtimes: %s", runtime, strings.Join(available, ", ")))
+}
+
+// isSandboxedRuntime reports whether the daemon runtime isolates the sandbox from the host kernel
+func isSandboxedRuntime(name string) bool {
+	return slices.Contains(runtimeNames[RUNTIME_RUNSC], name) || slices.Contains(runtimeNames[RUNTIME_KATA], name)
+}
+
+// GetSandboxRuntime returns the runtime the sandbox container runs under
+func (d *DockerClient) GetSandboxRuntime(ctx context.Context, sandboxId string) (string, error) {
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return "", err
+	}
+
+	return ct.HostConfig.Runtime, nil
+}
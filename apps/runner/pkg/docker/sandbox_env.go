@@ -0,0 +1,259 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/sandboxenv"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// SANDBOX_SECRETS_PATH is the tmpfs secrets are written to as files, they never reach the container config
+const SANDBOX_SECRETS_PATH = "/run/daytona/secrets"
+
+// GetSandboxEnv returns the variables set since the sandbox was created and the names of its secrets
+func (d *DockerClient) GetSandboxEnv(ctx context.Context, sandboxId string) (*dto.SandboxEnvDTO, error) {
+	_, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := d.sandboxEnv.Get(sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSandboxEnvDto(env), nil
+}
+
+// UpdateSandboxEnv sets and removes variables of the sandbox. The container config can't change, the
+// variables are passed to the daemon instead so the daemon and the processes it starts get them from the
+// next start of the sandbox on.
+func (d *DockerClient) UpdateSandboxEnv(ctx context.Context, sandboxId string, updateDto dto.UpdateSandboxEnvDTO) (*dto.SandboxEnvDTO, error) {
+	for key := range updateDto.Env {
+		err := sandboxenv.ValidateEnvKey(key)
+		if err != nil {
+			return nil, common.NewBadRequestError(err)
+		}
+	}
+
+	_, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := d.sandboxEnv.Update(sandboxId, func(env *sandboxenv.SandboxEnv) {
+		for _, key := range updateDto.Remove {
+			delete(env.Env, key)
+		}
+		for key, value := range updateDto.Env {
+			env.Env[key] = value
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store env of sandbox %s: %w", sandboxId, err)
+	}
+
+	return getSandboxEnvDto(env), nil
+}
+
+// UpdateSandboxSecrets sets and removes secrets of the sandbox, a running sandbox gets them right away
+func (d *DockerClient) UpdateSandboxSecrets(ctx context.Context, sandboxId string, updateDto dto.UpdateSandboxSecretsDTO) (*dto.SandboxEnvDTO, error) {
+	for name := range updateDto.Secrets {
+		err := sandboxenv.ValidateSecretName(name)
+		if err != nil {
+			return nil, common.NewBadRequestError(err)
+		}
+	}
+
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(updateDto.Secrets) > 0 && !hasSecretsMount(&ct) {
+		return nil, common.NewBadRequestError(errors.New("the sandbox was created without a secrets mount, recreate it to use secrets"))
+	}
+
+	env, err := d.sandboxEnv.Update(sandboxId, func(env *sandboxenv.SandboxEnv) {
+		for _, name := range updateDto.Remove {
+			delete(env.Secrets, name)
+		}
+		for name, value := range updateDto.Secrets {
+			env.Secrets[name] = value
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store secrets of sandbox %s: %w", sandboxId, err)
+	}
+
+	if ct.State.Running && !ct.State.Paused {
+		err = d.injectSecrets(ctx, &ct, env.Secrets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return getSandboxEnvDto(env), nil
+}
+
+// storeCreateSecrets stores the secrets passed on create, the start writes them into the secrets mount
+func (d *DockerClient) storeCreateSecrets(sandboxDto dto.CreateSandboxDTO) error {
+	if len(sandboxDto.Secrets) == 0 {
+		return nil
+	}
+
+	for name := range sandboxDto.Secrets {
+		err := sandboxenv.ValidateSecretName(name)
+		if err != nil {
+			return common.NewBadRequestError(err)
+		}
+	}
+
+	_, err := d.sandboxEnv.Update(sandboxDto.Id, func(env *sandboxenv.SandboxEnv) {
+		env.Secrets = sandboxDto.Secrets
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store secrets of sandbox %s: %w", sandboxDto.Id, err)
+	}
+
+	return nil
+}
+
+// injectSandboxSecrets writes the stored secrets into the tmpfs of the running sandbox, the tmpfs is
+// emptied whenever the sandbox stops
+func (d *DockerClient) injectSandboxSecrets(ctx context.Context, ct *types.ContainerJSON) error {
+	env, err := d.sandboxEnv.Get(strings.TrimPrefix(ct.Name, "/"))
+	if err != nil {
+		return err
+	}
+
+	if len(env.Secrets) == 0 {
+		return nil
+	}
+
+	if !hasSecretsMount(ct) {
+		log.Warnf("Sandbox %s has secrets but no secrets mount, they are not injected", ct.Name)
+		return nil
+	}
+
+	return d.injectSecrets(ctx, ct, env.Secrets)
+}
+
+// injectSecrets replaces the files in the secrets mount. The values are streamed through the stdin of an
+// exec so they don't show up in the exec config either.
+func (d *DockerClient) injectSecrets(ctx context.Context, ct *types.ContainerJSON, secrets map[string]string) error {
+	err := d.execWithStdin(ctx, ct.ID, []string{"sh", "-c", `rm -f "$0"/*`, SANDBOX_SECRETS_PATH}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to clear secrets of sandbox %s: %w", ct.ID, err)
+	}
+
+	for name, value := range secrets {
+		script := `umask 077 && cat > "$0/$1" && { chown "$DAYTONA_SANDBOX_USER" "$0/$1" 2>/dev/null || true; }`
+		err = d.execWithStdin(ctx, ct.ID, []string{"sh", "-c", script, SANDBOX_SECRETS_PATH, name}, strings.NewReader(value))
+		if err != nil {
+			return fmt.Errorf("failed to write secret %s of sandbox %s: %w", name, ct.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// execWithStdin runs the command with the reader as its stdin and fails if it exits with an error
+func (d *DockerClient) execWithStdin(ctx context.Context, containerId string, cmd []string, stdin io.Reader) error {
+	release, err := d.execLimiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	response, err := d.apiClient.ContainerExecCreate(ctx, containerId, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdin:  stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	attach, err := d.apiClient.ContainerExecAttach(ctx, response.ID, container.ExecStartOptions{})
+	if err != nil {
+		return err
+	}
+	defer attach.Close()
+
+	if stdin != nil {
+		_, err = io.Copy(attach.Conn, stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = attach.CloseWrite()
+	if err != nil {
+		return err
+	}
+
+	var stderr strings.Builder
+	_, err = stdcopy.StdCopy(io.Discard, &stderr, attach.Reader)
+	if err != nil {
+		return err
+	}
+
+	inspect, err := d.apiClient.ContainerExecInspect(ctx, response.ID)
+	if err != nil {
+		return err
+	}
+
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("exit code %d: %s", inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// getDaemonEnv returns the variables set on the sandbox after it was created
+func (d *DockerClient) getDaemonEnv(sandboxId string) []string {
+	env, err := d.sandboxEnv.Get(sandboxId)
+	if err != nil {
+		log.Warnf("Failed to read env of sandbox %s: %v", sandboxId, err)
+		return nil
+	}
+
+	result := make([]string, 0, len(env.Env))
+	for key, value := range env.Env {
+		result = append(result, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return result
+}
+
+func hasSecretsMount(ct *types.ContainerJSON) bool {
+	_, ok := ct.HostConfig.Tmpfs[SANDBOX_SECRETS_PATH]
+	return ok
+}
+
+func getSandboxEnvDto(env *sandboxenv.SandboxEnv) *dto.SandboxEnvDTO {
+	secrets := make([]string, 0, len(env.Secrets))
+	for name := range env.Secrets {
+		secrets = append(secrets, name)
+	}
+	slices.Sort(secrets)
+
+	return &dto.SandboxEnvDTO{
+		Env:     env.Env,
+		Secrets: secrets,
+	}
+}
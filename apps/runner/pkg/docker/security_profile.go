@@ -0,0 +1,141 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/securityprofile"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// UploadSecurityProfile stores a security profile sandboxes can reference on create
+func (d *DockerClient) UploadSecurityProfile(ctx context.Context, uploadDto dto.UploadSecurityProfileDTO) (*dto.SecurityProfileDTO, error) {
+	profile := &securityprofile.Profile{
+		Name:     uploadDto.Name,
+		Seccomp:  uploadDto.Seccomp,
+		AppArmor: uploadDto.AppArmor,
+	}
+
+	err := securityprofile.Validate(profile)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	info, err := d.apiClient.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Seccomp != "" && !hasSecurityOption(info.SecurityOptions, "seccomp") {
+		return nil, common.NewBadRequestError(errors.New("the container engine of this runner doesn't support seccomp"))
+	}
+
+	if profile.AppArmor != "" && !hasSecurityOption(info.SecurityOptions, "apparmor") {
+		return nil, common.NewBadRequestError(errors.New("the container engine of this runner doesn't support AppArmor"))
+	}
+
+	err = d.securityProfiles.Add(ctx, profile)
+	if err != nil {
+		if errors.Is(err, securityprofile.ErrExists) {
+			return nil, common.NewConflictError(err)
+		}
+		return nil, err
+	}
+
+	log.Infof("Security profile %s uploaded", profile.Name)
+
+	return toSecurityProfileDTO(profile), nil
+}
+
+func (d *DockerClient) ListSecurityProfiles() ([]dto.SecurityProfileDTO, error) {
+	profiles, err := d.securityProfiles.List()
+	if err != nil {
+		return nil, err
+	}
+
+	profileDtos := make([]dto.SecurityProfileDTO, 0, len(profiles))
+	for _, profile := range profiles {
+		profileDtos = append(profileDtos, *toSecurityProfileDTO(&profile))
+	}
+
+	return profileDtos, nil
+}
+
+// DeleteSecurityProfile removes a security profile, profiles sandboxes were created with can't be deleted since
+// their AppArmor profile is still applied
+func (d *DockerClient) DeleteSecurityProfile(ctx context.Context, name string) error {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.SECURITY_PROFILE_LABEL, name))),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(containers) > 0 {
+		return common.NewConflictError(fmt.Errorf("security profile %s is used by %d sandboxes", name, len(containers)))
+	}
+
+	err = d.securityProfiles.Delete(ctx, name)
+	if err != nil {
+		if errors.Is(err, securityprofile.ErrNotFound) {
+			return common.NewNotFoundError(fmt.Errorf("security profile %s not found", name))
+		}
+		return err
+	}
+
+	log.Infof("Security profile %s deleted", name)
+
+	return nil
+}
+
+// applySecurityProfile applies the seccomp and AppArmor profiles of the security profile to the host config
+func (d *DockerClient) applySecurityProfile(name string, hostConfig *container.HostConfig) error {
+	profile, err := d.securityProfiles.Get(name)
+	if err != nil {
+		if errors.Is(err, securityprofile.ErrNotFound) {
+			return common.NewBadRequestError(fmt.Errorf("security profile %s not found", name))
+		}
+		return err
+	}
+
+	if profile.Seccomp != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+profile.Seccomp)
+	}
+
+	if profile.AppArmorName != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "apparmor="+profile.AppArmorName)
+	}
+
+	// Docker ignores seccomp and AppArmor profiles of privileged containers
+	hostConfig.Privileged = false
+
+	return nil
+}
+
+// hasSecurityOption reports whether the engine lists the security option, e.g. "name=seccomp,profile=builtin"
+func hasSecurityOption(securityOptions []string, name string) bool {
+	return slices.ContainsFunc(securityOptions, func(option string) bool {
+		return option == "name="+name || strings.HasPrefix(option, "name="+name+",")
+	})
+}
+
+func toSecurityProfileDTO(profile *securityprofile.Profile) *dto.SecurityProfileDTO {
+	return &dto.SecurityProfileDTO{
+		Name:            profile.Name,
+		Seccomp:         profile.Seccomp != "",
+		AppArmorProfile: profile.AppArmorName,
+		CreatedAt:       profile.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+)
+
+func getSidecarContainerName(sandboxId string, name string) string {
+	return fmt.Sprintf("%s-sidecar-%s", sandboxId, name)
+}
+
+// createSidecars creates the sidecar containers in the network namespace of the sandbox container.
+// They are started, stopped and destroyed together with the sandbox.
+func (d *DockerClient) createSidecars(ctx context.Context, sandboxDto dto.CreateSandboxDTO) error {
+	for _, sidecar := range sandboxDto.Sidecars {
+		err := d.PullImage(ctx, sidecar.Image, sandboxDto.Registry, "")
+		if err != nil {
+			return fmt.Errorf("failed to pull image for sidecar %s: %w", sidecar.Name, err)
+		}
+
+		env := []string{"DAYTONA_SANDBOX_ID=" + sandboxDto.Id}
+		for key, value := range sidecar.Env {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+
+		containerConfig := &container.Config{
+			Image:      sidecar.Image,
+			Env:        env,
+			Entrypoint: sidecar.Entrypoint,
+			Cmd:        sidecar.Cmd,
+			Labels: map[string]string{
+				constants.ORGANIZATION_ID_LABEL: sandboxDto.UserId,
+				constants.SIDECAR_OF_LABEL:      sandboxDto.Id,
+			},
+		}
+
+		hostConfig := &container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + sandboxDto.Id),
+		}
+
+		_, err = d.apiClient.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, getSidecarContainerName(sandboxDto.Id, sidecar.Name))
+		if err != nil && !errdefs.IsConflict(err) {
+			return fmt.Errorf("failed to create sidecar %s: %w", sidecar.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) listSidecars(ctx context.Context, sandboxId string) ([]types.Container, error) {
+	return d.apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.SIDECAR_OF_LABEL, sandboxId))),
+	})
+}
+
+// startSidecars must run after the sandbox container is running since sidecars join its network namespace
+func (d *DockerClient) startSidecars(ctx context.Context, sandboxId string) error {
+	sidecars, err := d.listSidecars(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	for _, sidecar := range sidecars {
+		if sidecar.State == "running" {
+			continue
+		}
+
+		err = d.apiClient.ContainerStart(ctx, sidecar.ID, container.StartOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to start sidecar %s: %w", sidecar.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) stopSidecars(ctx context.Context, sandboxId string) error {
+	sidecars, err := d.listSidecars(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	for _, sidecar := range sidecars {
+		if sidecar.State != "running" {
+			continue
+		}
+
+		err = d.apiClient.ContainerStop(ctx, sidecar.ID, container.StopOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to stop sidecar %s: %w", sidecar.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) removeSidecars(ctx context.Context, sandboxId string) {
+	sidecars, err := d.listSidecars(ctx, sandboxId)
+	if err != nil {
+		log.Errorf("Failed to list sidecars of sandbox %s: %v", sandboxId, err)
+		return
+	}
+
+	for _, sidecar := range sidecars {
+		err = d.apiClient.ContainerRemove(ctx, sidecar.ID, container.RemoveOptions{Force: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			log.Errorf("Failed to remove sidecar %s of sandbox %s: %v", sidecar.ID, sandboxId, err)
+		}
+	}
+}
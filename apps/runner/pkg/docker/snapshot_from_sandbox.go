@@ -0,0 +1,135 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+)
+
+// CreateSnapshotFromSandbox saves the filesystem and config of the sandbox as a snapshot and returns its
+// image ID. A squashed snapshot is a single layer holding the whole filesystem, including the layers of
+// the snapshot the sandbox was created from. Volumes are not part of the snapshot.
+func (d *DockerClient) CreateSnapshotFromSandbox(ctx context.Context, sandboxId string, snapshot string, squash bool) (string, error) {
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return "", err
+	}
+
+	if ct.Config.Labels[constants.COMPOSE_SANDBOX_LABEL] != "" || ct.Config.Labels[constants.SIDECAR_OF_LABEL] != "" {
+		return "", common.NewBadRequestError(errors.New("snapshots can only be created from single container sandboxes"))
+	}
+
+	labels := getSnapshotLabels(&ct, sandboxId)
+
+	var imageId string
+	if squash {
+		imageId, err = d.squashSandbox(ctx, &ct, snapshot, labels)
+	} else {
+		var response types.IDResponse
+		response, err = d.apiClient.ContainerCommit(ctx, sandboxId, container.CommitOptions{
+			Reference: snapshot,
+			Comment:   fmt.Sprintf("Snapshot of sandbox %s", sandboxId),
+			Config:    &container.Config{Labels: labels},
+			Pause:     true,
+		})
+		imageId = response.ID
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot from sandbox %s: %w", sandboxId, err)
+	}
+
+	log.Infof("Created snapshot %s (%s) from sandbox %s", snapshot, imageId, sandboxId)
+
+	return imageId, nil
+}
+
+// getSnapshotLabels returns the lineage labels of the snapshot. The labels the runner set on the sandbox
+// are blanked since a commit merges the container labels into the image and sandboxes created from the
+// snapshot would inherit them.
+func getSnapshotLabels(ct *types.ContainerJSON, sandboxId string) map[string]string {
+	labels := make(map[string]string, len(ct.Config.Labels)+3)
+	for label := range ct.Config.Labels {
+		if strings.HasPrefix(label, "daytona.") {
+			labels[label] = ""
+		}
+	}
+
+	labels[constants.SNAPSHOT_SOURCE_SANDBOX_LABEL] = sandboxId
+	labels[constants.SNAPSHOT_SOURCE_IMAGE_LABEL] = ct.Config.Image
+	labels[constants.SNAPSHOT_CREATED_AT_LABEL] = time.Now().UTC().Format(time.RFC3339)
+
+	return labels
+}
+
+// squashSandbox exports the sandbox filesystem and imports it as a single layer, the sandbox is paused
+// while it is exported so the filesystem is consistent
+func (d *DockerClient) squashSandbox(ctx context.Context, ct *types.ContainerJSON, snapshot string, labels map[string]string) (string, error) {
+	if ct.State.Running && !ct.State.Paused {
+		err := d.apiClient.ContainerPause(ctx, ct.ID)
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			err := d.apiClient.ContainerUnpause(context.WithoutCancel(ctx), ct.ID)
+			if err != nil {
+				log.Errorf("Failed to unpause sandbox %s after creating a snapshot: %v", ct.Name, err)
+			}
+		}()
+	}
+
+	exportReader, err := d.apiClient.ContainerExport(ctx, ct.ID)
+	if err != nil {
+		return "", err
+	}
+	defer exportReader.Close()
+
+	changes := getImportChanges(ct.Config)
+	for label, value := range labels {
+		// Imported images start without labels, the blanked runner labels can be left out
+		if value != "" {
+			changes = append(changes, fmt.Sprintf("LABEL %s=%s", strconv.Quote(label), strconv.Quote(value)))
+		}
+	}
+	for label, value := range ct.Config.Labels {
+		if _, ok := labels[label]; !ok {
+			changes = append(changes, fmt.Sprintf("LABEL %s=%s", strconv.Quote(label), strconv.Quote(value)))
+		}
+	}
+
+	importResponse, err := d.apiClient.ImageImport(ctx, image.ImportSource{
+		Source:     exportReader,
+		SourceName: "-",
+	}, snapshot, image.ImportOptions{
+		Message: fmt.Sprintf("Squashed snapshot of sandbox %s", strings.TrimPrefix(ct.Name, "/")),
+		Changes: changes,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer importResponse.Close()
+
+	_, err = io.Copy(io.Discard, importResponse)
+	if err != nil {
+		return "", err
+	}
+
+	inspect, _, err := d.apiClient.ImageInspectWithRaw(ctx, snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	return inspect.ID, nil
+}
@@ -13,13 +13,23 @@ import (
 	"github.com/daytonaio/common-go/pkg/timer"
 	"github.com/daytonaio/runner/pkg/common"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) Start(ctx context.Context, containerId string) error {
+	ctx, span := telemetry.StartSpan(ctx, "docker.Start", containerId)
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		obs, err := common.ContainerOperationDuration.GetMetricWithLabelValues("start")
+		if err == nil {
+			obs.Observe(time.Since(startTime).Seconds())
+		}
+	}()
+
 	defer timer.Timer()()
 	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStarting)
 
@@ -34,13 +44,22 @@ func (d *DockerClient) Start(ctx context.Context, containerId string) error {
 		return err
 	}
 
+	if c.State.Paused {
+		return d.Resume(ctx, containerId)
+	}
+
 	if c.State.Running {
-		containerIP, err := getContainerIP(&c)
+		daemonAddress, err := d.GetDaemonAddress(&c)
+		if err != nil {
+			return err
+		}
+
+		err = d.waitForDaemonRunning(ctx, daemonAddress, 10*time.Second)
 		if err != nil {
 			return err
 		}
 
-		err = d.waitForDaemonRunning(ctx, containerIP, 10*time.Second)
+		err = d.startSidecars(ctx, containerId)
 		if err != nil {
 			return err
 		}
@@ -49,6 +68,11 @@ func (d *DockerClient) Start(ctx context.Context, containerId string) error {
 		return nil
 	}
 
+	err = d.syncSandboxVolumes(ctx, &c, enums.VolumeSyncDirectionPull)
+	if err != nil {
+		return err
+	}
+
 	err = d.apiClient.ContainerStart(ctx, containerId, container.StartOptions{})
 	if err != nil {
 		return err
@@ -65,19 +89,18 @@ func (d *DockerClient) Start(ctx context.Context, containerId string) error {
 		return err
 	}
 
-	containerIP, err := getContainerIP(&c)
+	err = d.setupStartedContainer(ctx, containerId, &c, true)
 	if err != nil {
 		return err
 	}
 
-	processesCtx := context.Background()
-	go func() {
-		if err := d.startDaytonaDaemon(processesCtx, containerId); err != nil {
-			log.Errorf("Failed to start Daytona daemon: %s\n", err.Error())
-		}
-	}()
+	// Hooks may rely on the daemon and the sidecars being up
+	err = d.runLifecycleHooks(ctx, containerId, enums.LifecycleHookStagePostCreate)
+	if err != nil {
+		return err
+	}
 
-	err = d.waitForDaemonRunning(ctx, containerIP, 10*time.Second)
+	err = d.runLifecycleHooks(ctx, containerId, enums.LifecycleHookStagePostStart)
 	if err != nil {
 		return err
 	}
@@ -87,6 +110,44 @@ func (d *DockerClient) Start(ctx context.Context, containerId string) error {
 	return nil
 }
 
+// setupStartedContainer brings up what a sandbox needs after its container started, none of it survives a stop. The
+// daemon is only started when it isn't part of a restored process tree.
+func (d *DockerClient) setupStartedContainer(ctx context.Context, containerId string, c *types.ContainerJSON, startDaemon bool) error {
+	daemonAddress, err := d.GetDaemonAddress(c)
+	if err != nil {
+		return err
+	}
+
+	// The veth is recreated on every start so the limits have to be applied again
+	err = d.sandboxNetwork.ApplyBandwidthLimits(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	// The secrets tmpfs starts out empty
+	err = d.injectSandboxSecrets(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if startDaemon {
+		processesCtx := context.Background()
+		go func() {
+			if err := d.startDaytonaDaemon(processesCtx, containerId, d.getDaemonEnv(containerId)); err != nil {
+				log.Errorf("Failed to start Daytona daemon: %s\n", err.Error())
+			}
+		}()
+	}
+
+	err = d.waitForDaemonRunning(ctx, daemonAddress, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	// Sidecars join the network namespace of the sandbox so they can only start once it is running
+	return d.startSidecars(ctx, containerId)
+}
+
 func (d *DockerClient) waitForContainerRunning(ctx context.Context, containerId string, timeout time.Duration) error {
 	defer timer.Timer()()
 
@@ -113,11 +174,11 @@ func (d *DockerClient) waitForContainerRunning(ctx context.Context, containerId
 	}
 }
 
-func (d *DockerClient) waitForDaemonRunning(ctx context.Context, containerIP string, timeout time.Duration) error {
+func (d *DockerClient) waitForDaemonRunning(ctx context.Context, daemonAddress string, timeout time.Duration) error {
 	defer timer.Timer()()
 
 	// Build the target URL
-	targetURL := fmt.Sprintf("http://%s:2280/version", containerIP)
+	targetURL := fmt.Sprintf("http://%s/version", daemonAddress)
 	target, err := url.Parse(targetURL)
 	if err != nil {
 		return common.NewBadRequestError(fmt.Errorf("failed to parse target URL: %w", err))
@@ -8,15 +8,16 @@ import (
 
 	"github.com/daytonaio/common-go/pkg/timer"
 	"github.com/docker/docker/api/types/container"
-
-	log "github.com/sirupsen/logrus"
 )
 
-func (d *DockerClient) startDaytonaDaemon(ctx context.Context, containerId string) error {
+// startDaytonaDaemon runs the daemon with the variables set on the sandbox after it was created, processes
+// started by the daemon inherit them
+func (d *DockerClient) startDaytonaDaemon(ctx context.Context, containerId string, env []string) error {
 	defer timer.Timer()()
 
 	execOptions := container.ExecOptions{
 		Cmd:          []string{"sh", "-c", "/usr/local/bin/daytona"},
+		Env:          env,
 		AttachStdout: true,
 		AttachStderr: true,
 		Tty:          true,
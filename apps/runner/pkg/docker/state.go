@@ -6,9 +6,11 @@ package docker
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 )
@@ -37,7 +39,7 @@ func (d *DockerClient) DeduceSandboxState(ctx context.Context, sandboxId string)
 		return enums.SandboxStateStarted, nil
 
 	case "paused":
-		return enums.SandboxStateStopped, nil
+		return enums.SandboxStatePaused, nil
 
 	case "restarting":
 		return enums.SandboxStateStarting, nil
@@ -60,6 +62,36 @@ func (d *DockerClient) DeduceSandboxState(ctx context.Context, sandboxId string)
 	}
 }
 
+// DeduceSandboxStateFromSummary deduces the sandbox state from a container list entry without inspecting
+// the container, running containers still pulling their snapshot are reported as started
+func DeduceSandboxStateFromSummary(ct types.Container) enums.SandboxState {
+	switch ct.State {
+	case "created":
+		return enums.SandboxStateCreating
+	case "running":
+		return enums.SandboxStateStarted
+	case "paused":
+		return enums.SandboxStatePaused
+	case "restarting":
+		return enums.SandboxStateStarting
+	case "removing":
+		return enums.SandboxStateDestroying
+	case "exited":
+		// The status of exited containers reads "Exited (<code>) <duration> ago"
+		_, rest, _ := strings.Cut(ct.Status, "(")
+		rawExitCode, _, _ := strings.Cut(rest, ")")
+		exitCode, err := strconv.Atoi(rawExitCode)
+		if err == nil && exitCode != 0 && exitCode != 137 && exitCode != 143 {
+			return enums.SandboxStateError
+		}
+		return enums.SandboxStateStopped
+	case "dead":
+		return enums.SandboxStateDestroyed
+	default:
+		return enums.SandboxStateUnknown
+	}
+}
+
 // isContainerPullingImage checks if the container is still in image pulling phase
 func (d *DockerClient) isContainerPullingImage(containerId string) bool {
 	options := container.LogsOptions{
@@ -5,14 +5,33 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
 	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 )
 
-func (d *DockerClient) Stop(ctx context.Context, containerId string) error {
+// Stop runs the pre-stop hooks and sends SIGTERM, the sandbox is killed once it didn't exit within the grace
+// period. Force kills it right away without running the hooks, paused sandboxes can't handle the signal and are
+// killed right away as well.
+func (d *DockerClient) Stop(ctx context.Context, containerId string, stopDto dto.StopSandboxDTO) (*dto.StopSandboxResponseDTO, error) {
+	ctx, span := telemetry.StartSpan(ctx, "docker.Stop", containerId)
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		obs, err := common.ContainerOperationDuration.GetMetricWithLabelValues("stop")
+		if err == nil {
+			obs.Observe(time.Since(startTime).Seconds())
+		}
+	}()
+
 	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStopping)
 
 	// Cancel a backup if it's already in progress
@@ -21,21 +40,94 @@ func (d *DockerClient) Stop(ctx context.Context, containerId string) error {
 		backup_context.cancel()
 	}
 
+	c, err := d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.State.Running && !c.State.Paused && !stopDto.Force {
+		err = d.runLifecycleHooks(ctx, containerId, enums.LifecycleHookStagePreStop)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = d.stopSidecars(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	gracePeriod := d.stopTimeout
+	if stopDto.TimeoutSeconds > 0 {
+		gracePeriod = time.Duration(stopDto.TimeoutSeconds) * time.Second
+	}
+
+	stopStartTime := time.Now()
+	method, err := d.stopContainer(ctx, containerId, c.State, gracePeriod, stopDto.Force)
+	if err != nil {
+		return nil, err
+	}
+	stopDuration := time.Since(stopStartTime)
+
+	c, err = d.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync after the container is stopped so no writes are missed
+	err = d.syncSandboxVolumes(ctx, &c, enums.VolumeSyncDirectionPush)
+	if err != nil {
+		return nil, err
+	}
+
+	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStopped)
+
+	log.Infof("Sandbox %s stopped (%s) with exit code %d", containerId, method, c.State.ExitCode)
+
+	return &dto.StopSandboxResponseDTO{
+		Method:     method.String(),
+		ExitCode:   c.State.ExitCode,
+		DurationMs: stopDuration.Milliseconds(),
+	}, nil
+}
+
+// stopContainer sends SIGTERM and kills the container if it is still running after the grace period
+func (d *DockerClient) stopContainer(ctx context.Context, containerId string, state *types.ContainerState, gracePeriod time.Duration, force bool) (enums.StopMethod, error) {
+	if !state.Running {
+		return enums.StopMethodNotRunning, nil
+	}
+
+	method := enums.StopMethodForced
+	if !force && !state.Paused && gracePeriod > 0 {
+		err := d.apiClient.ContainerKill(ctx, containerId, "SIGTERM")
+		if err != nil {
+			return "", err
+		}
+
+		err = d.waitForContainerStopped(ctx, containerId, gracePeriod)
+		if err == nil {
+			return enums.StopMethodGraceful, nil
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return "", err
+		}
+
+		method = enums.StopMethodKilled
+	}
+
 	err := d.apiClient.ContainerStop(ctx, containerId, container.StopOptions{
 		Signal: "SIGKILL",
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	err = d.waitForContainerStopped(ctx, containerId, 10*time.Second)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	d.cache.SetSandboxState(ctx, containerId, enums.SandboxStateStopped)
-
-	return nil
+	return method, nil
 }
 
 func (d *DockerClient) waitForContainerStopped(ctx context.Context, containerId string, timeout time.Duration) error {
@@ -48,7 +140,7 @@ func (d *DockerClient) waitForContainerStopped(ctx context.Context, containerId
 	for {
 		select {
 		case <-timeoutCtx.Done():
-			return fmt.Errorf("timeout waiting for container %s to stop", containerId)
+			return fmt.Errorf("timeout waiting for container %s to stop: %w", containerId, context.DeadlineExceeded)
 		case <-ticker.C:
 			c, err := d.ContainerInspect(ctx, containerId)
 			if err != nil {
@@ -0,0 +1,63 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"errors"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+)
+
+const usernsModeHost = container.UsernsMode("host")
+
+// IsUsernsRemapEngine reports whether the engine runs containers in a remapped user namespace, set with the
+// userns-remap option of the daemon
+func IsUsernsRemapEngine(info system.Info) bool {
+	return hasSecurityOption(info.SecurityOptions, "userns")
+}
+
+// getUsernsMode returns the user namespace mode of the sandbox, empty leaves it to the engine
+func getUsernsMode(security *dto.SandboxSecurityDTO) container.UsernsMode {
+	if security != nil && security.HostUserNamespace {
+		return usernsModeHost
+	}
+
+	return ""
+}
+
+// applyUserNamespace runs the sandbox in the user namespace of the host if it opted out of the remapping of the
+// engine. Remapped sandboxes can't be privileged, Docker refuses privileged containers in a user namespace.
+func (d *DockerClient) applyUserNamespace(info system.Info, sandboxDto dto.CreateSandboxDTO, hostConfig *container.HostConfig) error {
+	security := sandboxDto.Security
+
+	if security != nil && security.HostUserNamespace {
+		if d.rootless {
+			return common.NewBadRequestError(errors.New("sandboxes can't use the host user namespace on a rootless engine"))
+		}
+
+		// Root in the sandbox is root on the host
+		if d.privilegePolicy.Mode == PRIVILEGE_POLICY_FORBIDDEN && IsUsernsRemapEngine(info) {
+			return common.NewPrivilegeNotAllowedError(errors.New("sandboxes can't opt out of the user namespace remapping on this runner"))
+		}
+
+		hostConfig.UsernsMode = usernsModeHost
+		return nil
+	}
+
+	if !IsUsernsRemapEngine(info) || !hostConfig.Privileged {
+		return nil
+	}
+
+	if security != nil && security.Privileged != nil && *security.Privileged {
+		return common.NewBadRequestError(errors.New("privileged sandboxes need the host user namespace on this runner, set hostUserNamespace"))
+	}
+
+	// The remapping isolates sandboxes that would run privileged by default
+	hostConfig.Privileged = false
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daytonaio/common-go/pkg/timer"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+func (d *DockerClient) CreateVolume(ctx context.Context, volumeDto dto.CreateVolumeDTO) (*dto.VolumeInfoResponse, error) {
+	defer timer.Timer()()
+
+	volumeMutex := d.getVolumeMutex(volumeDto.Name)
+	volumeMutex.Lock()
+	defer volumeMutex.Unlock()
+
+	_, err := d.apiClient.VolumeInspect(ctx, volumeDto.Name)
+	if err == nil {
+		return nil, common.NewConflictError(fmt.Errorf("volume %s already exists", volumeDto.Name))
+	}
+	if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+
+	vol, err := d.apiClient.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       volumeDto.Name,
+		Driver:     volumeDto.Driver,
+		DriverOpts: volumeDto.DriverOpts,
+		Labels:     volumeDto.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Volume %s created", vol.Name)
+
+	return toVolumeInfo(vol, -1, nil), nil
+}
+
+// ListVolumes lists volumes matching the docker volume filters (name, label, driver, dangling)
+func (d *DockerClient) ListVolumes(ctx context.Context, volumeFilters filters.Args) ([]dto.VolumeInfoResponse, error) {
+	defer timer.Timer()()
+
+	list, err := d.apiClient.VolumeList(ctx, volume.ListOptions{Filters: volumeFilters})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]dto.VolumeInfoResponse, 0, len(list.Volumes))
+	for _, vol := range list.Volumes {
+		volumes = append(volumes, *toVolumeInfo(*vol, -1, nil))
+	}
+
+	return volumes, nil
+}
+
+func (d *DockerClient) InspectVolume(ctx context.Context, volumeName string) (*dto.VolumeInfoResponse, error) {
+	defer timer.Timer()()
+
+	vol, err := d.apiClient.VolumeInspect(ctx, volumeName)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, common.NewNotFoundError(fmt.Errorf("volume %s not found", volumeName))
+		}
+		return nil, err
+	}
+
+	sandboxes, err := d.getVolumeSandboxes(ctx, volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Volume usage is only computed by the disk usage endpoint
+	size := int64(-1)
+	diskUsage, err := d.apiClient.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		log.Warnf("Failed to get disk usage for volume %s: %v", volumeName, err)
+	} else {
+		for _, v := range diskUsage.Volumes {
+			if v.Name == volumeName && v.UsageData != nil {
+				size = v.UsageData.Size
+				break
+			}
+		}
+	}
+
+	return toVolumeInfo(vol, size, sandboxes), nil
+}
+
+// RemoveVolume refuses to remove a volume while any sandbox, running or stopped, still references it
+func (d *DockerClient) RemoveVolume(ctx context.Context, volumeName string) error {
+	defer timer.Timer()()
+
+	volumeMutex := d.getVolumeMutex(volumeName)
+	volumeMutex.Lock()
+	defer volumeMutex.Unlock()
+
+	sandboxes, err := d.getVolumeSandboxes(ctx, volumeName)
+	if err != nil {
+		return err
+	}
+
+	if len(sandboxes) > 0 {
+		return common.NewConflictError(fmt.Errorf("volume %s is in use by sandboxes: %s", volumeName, strings.Join(sandboxes, ", ")))
+	}
+
+	err = d.apiClient.VolumeRemove(ctx, volumeName, false)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return common.NewNotFoundError(fmt.Errorf("volume %s not found", volumeName))
+		}
+		if errdefs.IsConflict(err) {
+			return common.NewConflictError(err)
+		}
+		return err
+	}
+
+	log.Infof("Volume %s removed", volumeName)
+
+	return nil
+}
+
+func (d *DockerClient) getVolumeSandboxes(ctx context.Context, volumeName string) ([]string, error) {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("volume", volumeName)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxes := make([]string, 0, len(containers))
+	for _, c := range containers {
+		if len(c.Names) > 0 {
+			sandboxes = append(sandboxes, strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+
+	return sandboxes, nil
+}
+
+func toVolumeInfo(vol volume.Volume, size int64, sandboxes []string) *dto.VolumeInfoResponse {
+	return &dto.VolumeInfoResponse{
+		Name:       vol.Name,
+		Driver:     vol.Driver,
+		Mountpoint: vol.Mountpoint,
+		CreatedAt:  vol.CreatedAt,
+		Labels:     vol.Labels,
+		Options:    vol.Options,
+		Size:       size,
+		Sandboxes:  sandboxes,
+	}
+}
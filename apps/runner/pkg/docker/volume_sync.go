@@ -0,0 +1,83 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/volumesync"
+	"github.com/docker/docker/api/types"
+)
+
+func (d *DockerClient) SyncVolume(ctx context.Context, volumeId string, direction enums.VolumeSyncDirection) error {
+	if d.volumeSyncer == nil {
+		return common.NewBadRequestError(errors.New("volume sync is not configured on this runner"))
+	}
+
+	volumeIdPrefixed := fmt.Sprintf("daytona-volume-%s", volumeId)
+
+	return d.volumeSyncer.Sync(ctx, volumeIdPrefixed, d.getRunnerVolumeSyncPath(volumeIdPrefixed), direction)
+}
+
+func (d *DockerClient) GetVolumeSyncStatus(volumeId string) (*volumesync.SyncStatus, error) {
+	if d.volumeSyncer == nil {
+		return nil, common.NewBadRequestError(errors.New("volume sync is not configured on this runner"))
+	}
+
+	return d.volumeSyncer.GetStatus(fmt.Sprintf("daytona-volume-%s", volumeId)), nil
+}
+
+func (d *DockerClient) pullSyncedVolume(ctx context.Context, volumeIdPrefixed string) (string, error) {
+	if d.volumeSyncer == nil {
+		return "", common.NewBadRequestError(errors.New("volume sync is not configured on this runner"))
+	}
+
+	syncPath := d.getRunnerVolumeSyncPath(volumeIdPrefixed)
+
+	err := d.volumeSyncer.Sync(ctx, volumeIdPrefixed, syncPath, enums.VolumeSyncDirectionPull)
+	if err != nil {
+		return "", err
+	}
+
+	return syncPath, nil
+}
+
+// syncSandboxVolumes syncs every materialized volume bound into the sandbox
+func (d *DockerClient) syncSandboxVolumes(ctx context.Context, c *types.ContainerJSON, direction enums.VolumeSyncDirection) error {
+	if d.volumeSyncer == nil || c.HostConfig == nil {
+		return nil
+	}
+
+	syncRoot := d.getRunnerVolumeSyncPath("")
+
+	for _, bind := range c.HostConfig.Binds {
+		hostPath := filepath.Clean(strings.SplitN(bind, ":", 2)[0])
+		if filepath.Dir(hostPath) != syncRoot {
+			continue
+		}
+
+		err := d.volumeSyncer.Sync(ctx, filepath.Base(hostPath), hostPath, direction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DockerClient) getRunnerVolumeSyncPath(volumeId string) string {
+	volumePath := filepath.Join("/mnt", "volume-sync", volumeId)
+	if config.GetEnvironment() == "development" {
+		volumePath = filepath.Join("/tmp", "volume-sync", volumeId)
+	}
+
+	return volumePath
+}
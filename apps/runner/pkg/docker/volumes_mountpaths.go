@@ -15,7 +15,6 @@ import (
 	"github.com/daytonaio/runner/cmd/runner/config"
 	"github.com/daytonaio/runner/internal/util"
 	"github.com/daytonaio/runner/pkg/api/dto"
-	log "github.com/sirupsen/logrus"
 )
 
 func (d *DockerClient) getVolumesMountPathBinds(ctx context.Context, volumes []dto.VolumeDTO) ([]string, error) {
@@ -25,19 +24,21 @@ func (d *DockerClient) getVolumesMountPathBinds(ctx context.Context, volumes []d
 		volumeIdPrefixed := fmt.Sprintf("daytona-volume-%s", vol.VolumeId)
 		runnerVolumeMountPath := d.getRunnerVolumeMountPath(volumeIdPrefixed)
 
-		// Get or create mutex for this volume
-		d.volumeMutexesMutex.Lock()
-		volumeMutex, exists := d.volumeMutexes[volumeIdPrefixed]
-		if !exists {
-			volumeMutex = &sync.Mutex{}
-			d.volumeMutexes[volumeIdPrefixed] = volumeMutex
-		}
-		d.volumeMutexesMutex.Unlock()
-
 		// Lock this specific volume's mutex
+		volumeMutex := d.getVolumeMutex(volumeIdPrefixed)
 		volumeMutex.Lock()
 		defer volumeMutex.Unlock()
 
+		if vol.Sync {
+			syncPath, err := d.pullSyncedVolume(ctx, volumeIdPrefixed)
+			if err != nil {
+				return nil, err
+			}
+
+			volumeMountPathBinds = append(volumeMountPathBinds, fmt.Sprintf("%s/:%s/", syncPath, vol.MountPath))
+			continue
+		}
+
 		if d.isDirectoryMounted(runnerVolumeMountPath) {
 			log.Infof("volume %s is already mounted to %s", volumeIdPrefixed, runnerVolumeMountPath)
 			volumeMountPathBinds = append(volumeMountPathBinds, fmt.Sprintf("%s/:%s/", runnerVolumeMountPath, vol.MountPath))
@@ -65,6 +66,20 @@ func (d *DockerClient) getVolumesMountPathBinds(ctx context.Context, volumes []d
 	return volumeMountPathBinds, nil
 }
 
+// getVolumeMutex returns the mutex serializing operations on the given volume, creating it on first use
+func (d *DockerClient) getVolumeMutex(volumeName string) *sync.Mutex {
+	d.volumeMutexesMutex.Lock()
+	defer d.volumeMutexesMutex.Unlock()
+
+	volumeMutex, exists := d.volumeMutexes[volumeName]
+	if !exists {
+		volumeMutex = &sync.Mutex{}
+		d.volumeMutexes[volumeName] = volumeMutex
+	}
+
+	return volumeMutex
+}
+
 func (d *DockerClient) getRunnerVolumeMountPath(volumeId string) string {
 	volumePath := filepath.Join("/mnt", volumeId)
 	if config.GetEnvironment() == "development" {
@@ -0,0 +1,200 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/sandboxenv"
+	"github.com/daytonaio/runner/pkg/telemetry"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// POOL_SANDBOX_PREFIX starts the names of the sandboxes the warm pool keeps ready, they are not sandboxes of
+// the API until they are claimed and renamed
+const POOL_SANDBOX_PREFIX = "daytona-pool-"
+
+// ErrPoolSandboxNotClaimable is returned when the sandbox can't be created from the pool sandbox, the pool
+// sandbox is left as it was
+var ErrPoolSandboxNotClaimable = errors.New("the sandbox can't be created from a pool sandbox")
+
+func IsPoolSandbox(name string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(name, "/"), POOL_SANDBOX_PREFIX)
+}
+
+// ListPoolSandboxes returns the states of the pool sandboxes by name
+func (d *DockerClient) ListPoolSandboxes(ctx context.Context) (map[string]enums.SandboxState, error) {
+	containers, err := d.apiClient.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", constants.ORGANIZATION_ID_LABEL),
+			filters.Arg("name", POOL_SANDBOX_PREFIX),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxes := make(map[string]enums.SandboxState, len(containers))
+	for _, ct := range containers {
+		if len(ct.Names) == 0 || !IsPoolSandbox(ct.Names[0]) {
+			continue
+		}
+
+		sandboxes[strings.TrimPrefix(ct.Names[0], "/")] = DeduceSandboxStateFromSummary(ct)
+	}
+
+	return sandboxes, nil
+}
+
+// CreatePoolSandbox creates the sandbox like any other and leaves it stopped or paused. The pool sandbox is
+// removed if it can't be prepared and is not kept in the cache either way.
+func (d *DockerClient) CreatePoolSandbox(ctx context.Context, sandboxDto dto.CreateSandboxDTO, state enums.SandboxState) error {
+	if !IsPoolSandbox(sandboxDto.Id) {
+		return fmt.Errorf("%s is not a pool sandbox name", sandboxDto.Id)
+	}
+
+	_, err := d.Create(ctx, sandboxDto)
+	if err == nil {
+		if state == enums.SandboxStatePaused {
+			err = d.Pause(ctx, sandboxDto.Id)
+		} else {
+			_, err = d.Stop(ctx, sandboxDto.Id, dto.StopSandboxDTO{})
+		}
+	}
+
+	if err != nil {
+		removeErr := d.RemovePoolSandbox(ctx, sandboxDto.Id)
+		if removeErr != nil {
+			log.Warnf("Failed to remove pool sandbox %s: %v", sandboxDto.Id, removeErr)
+		}
+		return err
+	}
+
+	d.cache.Remove(ctx, sandboxDto.Id)
+
+	return nil
+}
+
+// RemovePoolSandbox destroys the pool sandbox without leaving it in the cache
+func (d *DockerClient) RemovePoolSandbox(ctx context.Context, poolSandboxId string) error {
+	err := d.Destroy(ctx, poolSandboxId)
+	d.cache.Remove(ctx, poolSandboxId)
+
+	return err
+}
+
+// ClaimPoolSandbox turns the pool sandbox into the requested sandbox. The container is renamed, moved to the
+// network of the sandbox and started or resumed with the env and secrets of the request. The container env and
+// the hostname can't change, they keep the values of the pool sandbox while the daemon and the processes it
+// starts get the requested ones. The container ID is empty if the pool sandbox was left untouched, an error
+// once it was renamed leaves the sandbox behind like a failed create.
+func (d *DockerClient) ClaimPoolSandbox(ctx context.Context, poolSandboxId string, sandboxDto dto.CreateSandboxDTO) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "docker.ClaimPoolSandbox", sandboxDto.Id)
+	defer span.End()
+
+	// Restored volumes can only be mounted when the container is created
+	restoredVolumeBinds, err := d.getRestoredVolumeBinds(ctx, sandboxDto.Id)
+	if err != nil {
+		return "", err
+	}
+	if len(restoredVolumeBinds) > 0 {
+		return "", ErrPoolSandboxNotClaimable
+	}
+
+	// The regular create reports invalid secrets
+	for name := range sandboxDto.Secrets {
+		if sandboxenv.ValidateSecretName(name) != nil {
+			return "", ErrPoolSandboxNotClaimable
+		}
+	}
+
+	ct, err := d.ContainerInspect(ctx, poolSandboxId)
+	if err != nil {
+		return "", err
+	}
+
+	paused := ct.State.Paused
+	if ct.State.Running && !paused {
+		return "", fmt.Errorf("pool sandbox %s is running", poolSandboxId)
+	}
+
+	d.cache.SetSandboxState(ctx, sandboxDto.Id, enums.SandboxStateCreating)
+
+	err = d.apiClient.ContainerRename(ctx, poolSandboxId, sandboxDto.Id)
+	if err != nil {
+		return "", err
+	}
+
+	// A paused container can't change networks, paused pools are refused with network isolation
+	if !paused {
+		err = d.sandboxNetwork.MoveNetwork(ctx, ct.ID, poolSandboxId, sandboxDto.Id)
+		if err != nil {
+			return ct.ID, err
+		}
+	}
+
+	_, err = d.sandboxEnv.Update(sandboxDto.Id, func(env *sandboxenv.SandboxEnv) {
+		env.Env = map[string]string{
+			"DAYTONA_SANDBOX_ID":       sandboxDto.Id,
+			"DAYTONA_SANDBOX_SNAPSHOT": sandboxDto.Snapshot,
+			"DAYTONA_SANDBOX_USER":     sandboxDto.OsUser,
+		}
+		for key, value := range sandboxDto.Env {
+			env.Env[key] = value
+		}
+		env.Secrets = sandboxDto.Secrets
+	})
+	if err != nil {
+		return ct.ID, fmt.Errorf("failed to store env of sandbox %s: %w", sandboxDto.Id, err)
+	}
+
+	err = d.storeLifecycleHooks(sandboxDto)
+	if err != nil {
+		return ct.ID, fmt.Errorf("failed to store lifecycle hooks of sandbox %s: %w", sandboxDto.Id, err)
+	}
+
+	if paused {
+		err = d.resumePoolSandbox(ctx, sandboxDto.Id)
+	} else {
+		err = d.Start(ctx, sandboxDto.Id)
+	}
+	if err != nil {
+		return ct.ID, err
+	}
+
+	d.applyCreatePolicies(ctx, sandboxDto)
+
+	log.Infof("Sandbox %s created from pool sandbox %s", sandboxDto.Id, poolSandboxId)
+
+	return ct.ID, nil
+}
+
+// resumePoolSandbox resumes the sandbox and restarts the daemon, which still runs with the env of the pool
+// sandbox, post create hooks are not run since the sandbox was created already
+func (d *DockerClient) resumePoolSandbox(ctx context.Context, sandboxId string) error {
+	err := d.Resume(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	err = d.RestartDaemon(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	ct, err := d.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	return d.injectSandboxSecrets(ctx, &ct)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// Events are dropped for subscribers that fall this far behind
+const subscriberBufferSize = 256
+
+type Event struct {
+	Type      enums.EventType `json:"type"`
+	SandboxId string          `json:"sandboxId,omitempty"`
+	Snapshot  string          `json:"snapshot,omitempty"`
+	State     string          `json:"state,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+} //	@name	Event
+
+// Broker fans out runner events to all current subscribers. Publishing never blocks.
+type Broker struct {
+	mutex       sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+func (b *Broker) Publish(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Warnf("Dropping %s event for slow subscriber", event.Type)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving all events published from now on and a function that unsubscribes it
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	b.mutex.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			delete(b.subscribers, ch)
+			b.mutex.Unlock()
+			close(ch)
+		})
+	}
+}
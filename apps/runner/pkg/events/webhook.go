@@ -0,0 +1,174 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const (
+	WEBHOOK_EVENT_HEADER     = "X-Daytona-Event"
+	WEBHOOK_TIMESTAMP_HEADER = "X-Daytona-Timestamp"
+	WEBHOOK_SIGNATURE_HEADER = "X-Daytona-Signature"
+
+	webhookInitialBackoff = 1 * time.Second
+	webhookMaxBackoff     = 1 * time.Minute
+)
+
+type WebhookConfig struct {
+	Url        string
+	Secret     string
+	MaxRetries int
+	// AllowInsecure permits plain HTTP webhook URLs, intended for development only
+	AllowInsecure bool
+}
+
+// WebhookDispatcher delivers runner events to a webhook as signed JSON payloads.
+// The signature is the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+type WebhookDispatcher struct {
+	url        string
+	secret     []byte
+	maxRetries int
+	client     *http.Client
+}
+
+func NewWebhookDispatcher(config WebhookConfig) (*WebhookDispatcher, error) {
+	parsedUrl, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	if parsedUrl.Scheme != "https" && !(config.AllowInsecure && parsedUrl.Scheme == "http") {
+		return nil, errors.New("webhook URL must use https")
+	}
+
+	if config.Secret == "" {
+		return nil, errors.New("webhook secret is required")
+	}
+
+	maxRetries := config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 5
+	}
+
+	return &WebhookDispatcher{
+		url:        parsedUrl.String(),
+		secret:     []byte(config.Secret),
+		maxRetries: maxRetries,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Start subscribes to the broker and delivers events in order until the context is cancelled
+func (w *WebhookDispatcher) Start(ctx context.Context, broker *Broker) {
+	eventChan, unsubscribe := broker.Subscribe()
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				if !isWebhookEvent(event) {
+					continue
+				}
+				w.deliver(ctx, event)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Snapshot progress updates are too chatty for webhooks, only their outcome is delivered
+func isWebhookEvent(event Event) bool {
+	if event.Type == enums.EventTypeSnapshotPull || event.Type == enums.EventTypeSnapshotBuild {
+		return event.State != enums.SnapshotOperationStateInProgress.String()
+	}
+
+	return true
+}
+
+func (w *WebhookDispatcher) deliver(ctx context.Context, event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("Failed to serialize %s webhook payload: %v", event.Type, err)
+		return
+	}
+
+	backoff := webhookInitialBackoff
+	for attempt := 1; attempt <= w.maxRetries; attempt++ {
+		retry, err := w.send(ctx, event, body)
+		if err == nil {
+			return
+		}
+
+		if !retry || attempt == w.maxRetries {
+			log.Errorf("Failed to deliver %s webhook after %d attempt(s): %v", event.Type, attempt, err)
+			return
+		}
+
+		log.Warnf("Failed to deliver %s webhook (attempt %d), retrying in %s: %v", event.Type, attempt, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff = min(backoff*2, webhookMaxBackoff)
+	}
+}
+
+// send posts the payload once and reports whether a failed delivery is worth retrying
+func (w *WebhookDispatcher) send(ctx context.Context, event Event, body []byte) (bool, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WEBHOOK_EVENT_HEADER, event.Type.String())
+	req.Header.Set(WEBHOOK_TIMESTAMP_HEADER, timestamp)
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, "sha256="+w.sign(timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+}
+
+func (w *WebhookDispatcher) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrKeyReused = errors.New("idempotency key was already used for a different request")
+
+type StoreConfig struct {
+	// TTL is how long the response of a request is replayed for retries with the same key
+	TTL time.Duration
+	// MaxResponseSize in bytes, larger responses are not stored and retries run the request again
+	MaxResponseSize int64
+}
+
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+type record struct {
+	fingerprint string
+	// done is closed once the request finished, response is nil if it is not replayed
+	done      chan struct{}
+	response  *Response
+	expiresAt time.Time
+}
+
+// Store keeps the responses of requests made with an idempotency key so retries of the control plane, e.g.
+// after a network blip, get the original response instead of running the request again
+type Store struct {
+	ttl             time.Duration
+	maxResponseSize int64
+
+	mutex   sync.Mutex
+	records map[string]*record
+}
+
+func NewStore(config StoreConfig) *Store {
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	maxResponseSize := config.MaxResponseSize
+	if maxResponseSize <= 0 {
+		maxResponseSize = 1024 * 1024
+	}
+
+	return &Store{
+		ttl:             ttl,
+		maxResponseSize: maxResponseSize,
+		records:         make(map[string]*record),
+	}
+}
+
+func (s *Store) MaxResponseSize() int64 {
+	return s.maxResponseSize
+}
+
+// Begin returns the stored response of the key, waiting for a request with the key that is still running.
+// Without a stored response the key is claimed and finish has to be called with the response to store, or nil
+// if retries should run the request again. The fingerprint identifies the request, a key can't be reused for
+// another request.
+func (s *Store) Begin(ctx context.Context, key string, fingerprint string) (*Response, func(*Response), error) {
+	for {
+		s.mutex.Lock()
+
+		r, ok := s.records[key]
+		if ok && r.response != nil && time.Now().After(r.expiresAt) {
+			delete(s.records, key)
+			ok = false
+		}
+
+		if !ok {
+			r = &record{
+				fingerprint: fingerprint,
+				done:        make(chan struct{}),
+			}
+			s.records[key] = r
+			s.mutex.Unlock()
+
+			return nil, func(response *Response) {
+				s.finish(key, r, response)
+			}, nil
+		}
+
+		s.mutex.Unlock()
+
+		if r.fingerprint != fingerprint {
+			return nil, nil, ErrKeyReused
+		}
+
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+
+		if r.response != nil {
+			return r.response, nil, nil
+		}
+
+		// The request isn't replayed, the retry claims the key again
+	}
+}
+
+func (s *Store) finish(key string, r *record, response *Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if response != nil {
+		r.response = response
+		r.expiresAt = time.Now().Add(s.ttl)
+	} else if s.records[key] == r {
+		delete(s.records, key)
+	}
+
+	close(r.done)
+}
+
+func (s *Store) StartCleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(min(s.ttl, time.Hour))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.removeExpired()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *Store) removeExpired() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for key, r := range s.records {
+		if r.response != nil && now.After(r.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/daytonaio/runner/pkg/api/dto"
+)
+
+// A hook log is rotated once it grows past this, the previous generation is kept
+const maxLogSize = 1024 * 1024
+
+// Only the most recent warnings are kept, older ones are in the hook log
+const maxWarnings = 20
+
+// Record holds the hooks of a sandbox and what came of them
+type Record struct {
+	Hooks dto.SandboxHooksDTO `json:"hooks"`
+	// PostCreateDone is set once the post create hooks ran, they never run again
+	PostCreateDone bool                    `json:"postCreateDone,omitempty"`
+	Warnings       []dto.SandboxWarningDTO `json:"warnings,omitempty"`
+}
+
+// Store persists the lifecycle hooks and the hook output of each sandbox, a record and a log file per sandbox
+type Store struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Get returns the record of the sandbox, nil if it has no hooks
+func (s *Store) Get(sandboxId string) (*Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.read(sandboxId)
+}
+
+// Update applies the change to the record of the sandbox, a sandbox without a record gets an empty one
+func (s *Store) Update(sandboxId string, update func(record *Record)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record, err := s.read(sandboxId)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &Record{}
+	}
+
+	update(record)
+
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(s.getRecordPath(sandboxId), raw, 0600)
+}
+
+// AddWarning records a failed hook, only the latest warnings are kept
+func (s *Store) AddWarning(sandboxId string, warning dto.SandboxWarningDTO) error {
+	return s.Update(sandboxId, func(record *Record) {
+		record.Warnings = append(record.Warnings, warning)
+		if len(record.Warnings) > maxWarnings {
+			record.Warnings = record.Warnings[len(record.Warnings)-maxWarnings:]
+		}
+	})
+}
+
+// OpenLogWriter opens the hook log of the sandbox for appending, the log is rotated first if it is too large
+func (s *Store) OpenLogWriter(sandboxId string) (io.WriteCloser, error) {
+	err := os.MkdirAll(s.dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	logPath := s.getLogPath(sandboxId)
+	info, err := os.Stat(logPath)
+	if err == nil && info.Size() > maxLogSize {
+		err = os.Rename(logPath, logPath+".1")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}
+
+// ReadLog writes the rotated and the current hook log of the sandbox to w
+func (s *Store) ReadLog(sandboxId string, w io.Writer) error {
+	logPath := s.getLogPath(sandboxId)
+
+	found := false
+	for _, path := range []string{logPath + ".1", logPath} {
+		file, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+
+		found = true
+		_, err = io.Copy(w, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return os.ErrNotExist
+	}
+
+	return nil
+}
+
+func (s *Store) Remove(sandboxId string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	logPath := s.getLogPath(sandboxId)
+	for _, path := range []string{s.getRecordPath(sandboxId), logPath, logPath + ".1"} {
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// read expects the caller to hold the mutex
+func (s *Store) read(sandboxId string) (*Record, error) {
+	raw, err := os.ReadFile(s.getRecordPath(sandboxId))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record Record
+	err = json.Unmarshal(raw, &record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lifecycle hooks of sandbox %s: %w", sandboxId, err)
+	}
+
+	return &record, nil
+}
+
+func (s *Store) getRecordPath(sandboxId string) string {
+	return filepath.Join(s.dir, filepath.Base(sandboxId)+".json")
+}
+
+func (s *Store) getLogPath(sandboxId string) string {
+	return filepath.Join(s.dir, filepath.Base(sandboxId)+".log")
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package logging holds the loggers of the runner. Every package logs through the logger of its module, modules log
+// at the runner level unless a level is set for them.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MODULE_FIELD is the field holding the module of every entry
+const MODULE_FIELD = "module"
+
+var (
+	mutex        sync.Mutex
+	level        = logrus.WarnLevel
+	output       io.Writer
+	hooks        []logrus.Hook
+	modules      = make(map[string]*logrus.Logger)
+	moduleLevels = make(map[string]logrus.Level)
+)
+
+// Module returns the logger of the module, packages keep it in their log variable
+func Module(name string) *logrus.Entry {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	logger, ok := modules[name]
+	if !ok {
+		standard := logrus.StandardLogger()
+
+		logger = logrus.New()
+		logger.SetFormatter(standard.Formatter)
+		for _, hook := range hooks {
+			logger.AddHook(hook)
+		}
+		if output != nil {
+			logger.SetOutput(output)
+		}
+		logger.SetLevel(getModuleLevel(name))
+
+		modules[name] = logger
+	}
+
+	return logger.WithField(MODULE_FIELD, name)
+}
+
+// SetOutput sets the writer of all modules and of the standard logrus logger
+func SetOutput(writer io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	output = writer
+	logrus.SetOutput(writer)
+	for _, logger := range modules {
+		logger.SetOutput(writer)
+	}
+}
+
+// AddHook adds the hook to all modules and to the standard logrus logger, hooks only see entries at or above the level
+// of the module
+func AddHook(hook logrus.Hook) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	hooks = append(hooks, hook)
+	logrus.AddHook(hook)
+	for _, logger := range modules {
+		logger.AddHook(hook)
+	}
+}
+
+// SetLevel sets the level of the runner, modules with a level of their own keep it
+func SetLevel(newLevel logrus.Level) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	level = newLevel
+	logrus.SetLevel(newLevel)
+	applyLevels()
+}
+
+// GetLevel returns the level of the runner
+func GetLevel() logrus.Level {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return level
+}
+
+// SetModuleLevel sets the level of a single module
+func SetModuleLevel(module string, newLevel logrus.Level) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := modules[module]; !ok {
+		return fmt.Errorf("unknown log module %s, known modules are %s", module, strings.Join(getModuleNames(), ", "))
+	}
+
+	moduleLevels[module] = newLevel
+	applyLevels()
+
+	return nil
+}
+
+// ResetModuleLevel makes the module log at the runner level again
+func ResetModuleLevel(module string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := modules[module]; !ok {
+		return fmt.Errorf("unknown log module %s, known modules are %s", module, strings.Join(getModuleNames(), ", "))
+	}
+
+	delete(moduleLevels, module)
+	applyLevels()
+
+	return nil
+}
+
+// ChangeLevel sets the level of the module, or of the runner if the module is empty, and restores the previous level
+// once the duration elapsed unless the level was changed again in the meantime. A nil level resets the module to
+// the runner level, a duration of 0 keeps the new level.
+func ChangeLevel(module string, newLevel *logrus.Level, duration time.Duration) error {
+	if module == "" {
+		if newLevel == nil {
+			return errors.New("the runner level can't be reset")
+		}
+
+		mutex.Lock()
+		previous := level
+		mutex.Unlock()
+
+		SetLevel(*newLevel)
+
+		if duration > 0 {
+			time.AfterFunc(duration, func() {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if level == *newLevel {
+					level = previous
+					logrus.SetLevel(previous)
+					applyLevels()
+				}
+			})
+		}
+
+		return nil
+	}
+
+	mutex.Lock()
+	previous, overridden := moduleLevels[module]
+	mutex.Unlock()
+
+	var err error
+	if newLevel == nil {
+		err = ResetModuleLevel(module)
+	} else {
+		err = SetModuleLevel(module, *newLevel)
+	}
+	if err != nil || duration <= 0 {
+		return err
+	}
+
+	time.AfterFunc(duration, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		current, currentOverridden := moduleLevels[module]
+		if currentOverridden != (newLevel != nil) || (newLevel != nil && current != *newLevel) {
+			return
+		}
+
+		if overridden {
+			moduleLevels[module] = previous
+		} else {
+			delete(moduleLevels, module)
+		}
+		applyLevels()
+	})
+
+	return nil
+}
+
+// SetModuleLevels replaces the levels of all modules, e.g. when the configuration is reloaded
+func SetModuleLevels(levels map[string]logrus.Level) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	moduleLevels = make(map[string]logrus.Level, len(levels))
+	for module, moduleLevel := range levels {
+		moduleLevels[module] = moduleLevel
+	}
+	applyLevels()
+}
+
+// GetModuleLevels returns the level every module logs at
+func GetModuleLevels() map[string]logrus.Level {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	levels := make(map[string]logrus.Level, len(modules))
+	for module := range modules {
+		levels[module] = getModuleLevel(module)
+	}
+
+	return levels
+}
+
+// GetModuleLevelOverrides returns the modules with a level of their own
+func GetModuleLevelOverrides() map[string]logrus.Level {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	levels := make(map[string]logrus.Level, len(moduleLevels))
+	for module, moduleLevel := range moduleLevels {
+		levels[module] = moduleLevel
+	}
+
+	return levels
+}
+
+// ParseModuleLevels parses entries in the "module=level" format, e.g. "docker=debug"
+func ParseModuleLevels(entries []string) (map[string]logrus.Level, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	levels := make(map[string]logrus.Level, len(entries))
+
+	for _, entry := range entries {
+		module, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid module log level %q", entry)
+		}
+
+		moduleLevel, err := logrus.ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid module log level %q: %w", entry, err)
+		}
+
+		module = strings.TrimSpace(module)
+		if _, ok := modules[module]; !ok {
+			return nil, fmt.Errorf("unknown log module %s, known modules are %s", module, strings.Join(getModuleNames(), ", "))
+		}
+
+		levels[module] = moduleLevel
+	}
+
+	return levels, nil
+}
+
+// getModuleLevel must be called with the mutex held
+func getModuleLevel(module string) logrus.Level {
+	moduleLevel, ok := moduleLevels[module]
+	if ok {
+		return moduleLevel
+	}
+
+	return level
+}
+
+// applyLevels must be called with the mutex held
+func applyLevels() {
+	for module, logger := range modules {
+		logger.SetLevel(getModuleLevel(module))
+	}
+}
+
+// getModuleNames must be called with the mutex held
+func getModuleNames() []string {
+	names := make([]string, 0, len(modules))
+	for module := range modules {
+		names = append(names, module)
+	}
+	sort.Strings(names)
+
+	return names
+}
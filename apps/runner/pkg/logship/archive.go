@@ -0,0 +1,167 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logship
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+// maxArchiveSize is the compressed size an archive is uploaded at before its interval elapsed
+const maxArchiveSize = 64 * 1024 * 1024
+
+type ArchiveSinkConfig struct {
+	// Prefix is the object path under which the archives of the runner are stored, defaults to runner-logs
+	Prefix string
+	// Interval is how often an archive is uploaded, defaults to 5 minutes
+	Interval time.Duration
+}
+
+// ArchiveSink rolls the records into gzipped JSON lines archives in object storage, stored as
+// <prefix>/<hostname>/<start time>.jsonl.gz. An archive that failed to upload is retried before new records are
+// accepted, so an unavailable object store backs up into the buffer of the shipper.
+type ArchiveSink struct {
+	prefix   string
+	interval time.Duration
+
+	mutex     sync.Mutex
+	current   *archive
+	completed *archive
+}
+
+type archive struct {
+	startedAt time.Time
+	buffer    bytes.Buffer
+	writer    *gzip.Writer
+}
+
+func NewArchiveSink(config ArchiveSinkConfig) (*ArchiveSink, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := config.Prefix
+	if prefix == "" {
+		prefix = "runner-logs"
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	return &ArchiveSink{
+		prefix:   path.Join(prefix, hostname),
+		interval: interval,
+	}, nil
+}
+
+func (s *ArchiveSink) Name() string {
+	return "s3"
+}
+
+func (s *ArchiveSink) Send(ctx context.Context, records []Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.completed != nil {
+		err := s.upload(ctx, s.completed)
+		if err != nil {
+			return err
+		}
+		s.completed = nil
+	}
+
+	if s.current == nil {
+		s.current = &archive{
+			startedAt: time.Now(),
+		}
+		s.current.writer = gzip.NewWriter(&s.current.buffer)
+	}
+
+	encoder := json.NewEncoder(s.current.writer)
+	for _, record := range records {
+		err := encoder.Encode(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	if time.Since(s.current.startedAt) < s.interval && s.current.buffer.Len() < maxArchiveSize {
+		return nil
+	}
+
+	err := s.complete()
+	if err != nil {
+		return err
+	}
+
+	err = s.upload(ctx, s.completed)
+	if err != nil {
+		// The records are in the archive now, it is uploaded again with the next batch
+		log.Warnf("Failed to upload log archive, retrying with the next records: %v", err)
+		return nil
+	}
+	s.completed = nil
+
+	return nil
+}
+
+func (s *ArchiveSink) Close(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.completed != nil {
+		err := s.upload(ctx, s.completed)
+		if err != nil {
+			return err
+		}
+		s.completed = nil
+	}
+
+	if s.current == nil {
+		return nil
+	}
+
+	err := s.complete()
+	if err != nil {
+		return err
+	}
+
+	return s.upload(ctx, s.completed)
+}
+
+// complete must be called with the mutex held and no completed archive pending
+func (s *ArchiveSink) complete() error {
+	err := s.current.writer.Close()
+	if err != nil {
+		return err
+	}
+
+	s.completed = s.current
+	s.current = nil
+
+	return nil
+}
+
+func (s *ArchiveSink) upload(ctx context.Context, a *archive) error {
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	objectPath := path.Join(s.prefix, fmt.Sprintf("%s.jsonl.gz", a.startedAt.UTC().Format("20060102T150405.000Z")))
+
+	return storageClient.PutObjectStream(ctx, objectPath, bytes.NewReader(a.buffer.Bytes()), int64(a.buffer.Len()))
+}
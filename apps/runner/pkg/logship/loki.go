@@ -0,0 +1,145 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const lokiTenantHeader = "X-Scope-OrgID"
+
+type LokiSinkConfig struct {
+	// Url is the base URL of Loki, credentials in it are sent with basic auth
+	Url string
+	// Tenant is sent as the tenant of multi-tenant Loki deployments
+	Tenant string
+}
+
+// LokiSink pushes records with the Loki push API. Records are grouped into streams by their source, module and level,
+// everything else goes into the JSON line to keep the label cardinality low.
+type LokiSink struct {
+	pushUrl  string
+	tenant   string
+	hostname string
+	client   *http.Client
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiLine struct {
+	Message string            `json:"msg"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func NewLokiSink(config LokiSinkConfig) (*LokiSink, error) {
+	parsedUrl, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Loki URL: %w", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LokiSink{
+		pushUrl:  parsedUrl.JoinPath("loki/api/v1/push").String(),
+		tenant:   config.Tenant,
+		hostname: hostname,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *LokiSink) Name() string {
+	return "loki"
+}
+
+func (s *LokiSink) Send(ctx context.Context, records []Record) error {
+	streams := make(map[string]*lokiStream)
+	keys := make([]string, 0)
+
+	for _, record := range records {
+		key := strings.Join([]string{record.Source, record.Module, record.Level}, "/")
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{
+				Stream: map[string]string{
+					"job":    "daytona-runner",
+					"host":   s.hostname,
+					"source": record.Source,
+					"level":  record.Level,
+				},
+			}
+			if record.Module != "" {
+				stream.Stream["module"] = record.Module
+			}
+			streams[key] = stream
+			keys = append(keys, key)
+		}
+
+		line, err := json.Marshal(lokiLine{
+			Message: record.Message,
+			Fields:  record.Fields,
+		})
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(record.Time.UnixNano(), 10), string(line)})
+	}
+
+	request := lokiPushRequest{
+		Streams: make([]lokiStream, 0, len(keys)),
+	}
+	for _, key := range keys {
+		request.Streams = append(request.Streams, *streams[key])
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.pushUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if s.tenant != "" {
+		req.Header.Set(lokiTenantHeader, s.tenant)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("loki responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *LokiSink) Close(ctx context.Context) error {
+	return nil
+}
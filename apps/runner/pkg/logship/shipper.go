@@ -0,0 +1,322 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logship
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Sources of shipped records
+const (
+	SOURCE_RUNNER         = "runner"
+	SOURCE_OPERATION      = "operation"
+	SOURCE_LIFECYCLE_HOOK = "lifecycle_hook"
+)
+
+const (
+	maxSendBackoff  = 1 * time.Minute
+	dropLogInterval = 1 * time.Minute
+)
+
+type Record struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Source  string            `json:"source"`
+	Module  string            `json:"module,omitempty"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// Sink delivers batches of records to an external system
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, records []Record) error
+	// Close delivers what the sink still buffers
+	Close(ctx context.Context) error
+}
+
+type ShipperConfig struct {
+	Sink Sink
+	// BufferSize is the number of records waiting to be sent, records logged while it is full are dropped. Defaults
+	// to 10000.
+	BufferSize int
+	// BatchSize is the maximum number of records sent at once, defaults to 1000
+	BatchSize int
+	// FlushInterval is how long records wait for a batch to fill up, defaults to 5 seconds
+	FlushInterval time.Duration
+}
+
+// Shipper sends the runner log and the operation logs to a sink. Logging never waits on the sink: records are
+// buffered, a failed batch is retried with backoff while new records queue up behind it and records that don't fit
+// into the buffer are dropped and counted.
+type Shipper struct {
+	sink          Sink
+	batchSize     int
+	flushInterval time.Duration
+
+	records chan Record
+	done    chan struct{}
+	cancel  context.CancelFunc
+
+	mutex      sync.Mutex
+	dropped    int
+	lastDropAt time.Time
+}
+
+func NewShipper(config ShipperConfig) *Shipper {
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 10000
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = 5 * time.Second
+	}
+
+	return &Shipper{
+		sink:          config.Sink,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		records:       make(chan Record, bufferSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start ships the records until Close is called, the runner log is shipped at the levels its modules log at
+func (s *Shipper) Start() {
+	if s == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	logging.AddHook(&hook{shipper: s})
+
+	go s.run(ctx)
+}
+
+// Close sends the buffered records and waits for the sink to deliver them until the context is done
+func (s *Shipper) Close(ctx context.Context) {
+	if s == nil || s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		log.Warnf("Gave up shipping the remaining logs to %s: %v", s.sink.Name(), ctx.Err())
+		return
+	}
+
+	err := s.sink.Close(ctx)
+	if err != nil {
+		log.Warnf("Failed to flush the %s log sink: %v", s.sink.Name(), err)
+	}
+}
+
+// Writer returns a writer shipping every line written to it as a record of the source with the fields
+func (s *Shipper) Writer(source string, fields map[string]string) io.WriteCloser {
+	if s == nil {
+		return nopWriteCloser{io.Discard}
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := strings.TrimRight(scanner.Text(), "\r")
+			if line == "" {
+				continue
+			}
+
+			s.enqueue(Record{
+				Time:    time.Now(),
+				Level:   logrus.InfoLevel.String(),
+				Source:  source,
+				Message: line,
+				Fields:  fields,
+			})
+		}
+
+		// Keep draining so writers never block on an overlong line
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	return writer
+}
+
+func (s *Shipper) enqueue(record Record) {
+	select {
+	case s.records <- record:
+		return
+	default:
+	}
+
+	common.LogShipDroppedCount.WithLabelValues(s.sink.Name()).Inc()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.dropped++
+	if time.Since(s.lastDropAt) < dropLogInterval {
+		return
+	}
+
+	// Written to the output directly, a log entry would be dropped as well
+	fmt.Fprintf(logrus.StandardLogger().Out, "Log shipping to %s can't keep up, dropped %d records\n", s.sink.Name(), s.dropped)
+	s.dropped = 0
+	s.lastDropAt = time.Now()
+}
+
+func (s *Shipper) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, s.batchSize)
+	for {
+		select {
+		case record := <-s.records:
+			batch = append(batch, record)
+			if len(batch) < s.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		case <-ctx.Done():
+			s.drain(batch)
+			return
+		}
+
+		s.send(ctx, batch)
+		batch = make([]Record, 0, s.batchSize)
+	}
+}
+
+// send retries the batch until it is delivered or the shipper is closed, records keep queueing in the buffer
+func (s *Shipper) send(ctx context.Context, batch []Record) {
+	backoff := time.Second
+	for {
+		err := s.sink.Send(ctx, batch)
+		if err == nil {
+			common.LogShipRecordCount.WithLabelValues(s.sink.Name()).Add(float64(len(batch)))
+			return
+		}
+
+		if ctx.Err() != nil {
+			s.drain(batch)
+			return
+		}
+
+		log.Warnf("Failed to ship %d log records to %s, retrying in %s: %v", len(batch), s.sink.Name(), backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			s.drain(batch)
+			return
+		}
+
+		backoff = min(backoff*2, maxSendBackoff)
+	}
+}
+
+// drain makes a last attempt to send the batch and the buffered records once the shipper is closed
+func (s *Shipper) drain(batch []Record) {
+	for {
+		select {
+		case record := <-s.records:
+			batch = append(batch, record)
+			if len(batch) < s.batchSize {
+				continue
+			}
+		default:
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := s.sink.Send(ctx, batch)
+		cancel()
+		if err != nil {
+			common.LogShipDroppedCount.WithLabelValues(s.sink.Name()).Add(float64(len(batch)))
+			return
+		}
+		common.LogShipRecordCount.WithLabelValues(s.sink.Name()).Add(float64(len(batch)))
+
+		if len(batch) < s.batchSize {
+			return
+		}
+		batch = make([]Record, 0, s.batchSize)
+	}
+}
+
+// hook turns runner log entries into records
+type hook struct {
+	shipper *Shipper
+}
+
+func (h *hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *hook) Fire(entry *logrus.Entry) error {
+	record := Record{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Source:  SOURCE_RUNNER,
+		Message: entry.Message,
+	}
+
+	for key, value := range entry.Data {
+		if key == logging.MODULE_FIELD {
+			record.Module = fmt.Sprint(value)
+			continue
+		}
+
+		if record.Fields == nil {
+			record.Fields = make(map[string]string, len(entry.Data))
+		}
+		record.Fields[key] = fmt.Sprint(value)
+	}
+
+	h.shipper.enqueue(record)
+
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logship
+
+import (
+	"fmt"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+const (
+	SINK_LOKI   = "loki"
+	SINK_SYSLOG = "syslog"
+	SINK_S3     = "s3"
+)
+
+// NewShipperFromConfig returns the shipper of the configured sink, or nil if log shipping is not configured
+func NewShipperFromConfig(cfg *config.Config) (*Shipper, error) {
+	var sink Sink
+	var err error
+
+	switch cfg.LogShipSink {
+	case "":
+		return nil, nil
+	case SINK_LOKI:
+		sink, err = NewLokiSink(LokiSinkConfig{
+			Url:    cfg.LogShipLokiUrl,
+			Tenant: cfg.LogShipLokiTenant,
+		})
+	case SINK_SYSLOG:
+		sink, err = NewSyslogSink(cfg.LogShipSyslogAddr)
+	case SINK_S3:
+		// Fail on startup instead of backing up the buffer until the first archive is uploaded
+		_, err = storage.GetObjectStorageClient()
+		if err == nil {
+			sink, err = NewArchiveSink(ArchiveSinkConfig{
+				Prefix:   cfg.LogShipPrefix,
+				Interval: cfg.LogArchiveInterval,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown log ship sink %s", cfg.LogShipSink)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up log shipping to %s: %w", cfg.LogShipSink, err)
+	}
+
+	return NewShipper(ShipperConfig{
+		Sink:          sink,
+		BufferSize:    cfg.LogShipBufferSize,
+		BatchSize:     cfg.LogShipBatchSize,
+		FlushInterval: cfg.LogShipInterval,
+	}), nil
+}
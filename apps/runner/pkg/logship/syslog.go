@@ -0,0 +1,148 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logship
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const syslogTag = "daytona-runner"
+
+// SyslogSink writes records to a syslog daemon, the connection is dialed again after a failed write
+type SyslogSink struct {
+	network string
+	address string
+
+	mutex  sync.Mutex
+	writer *syslog.Writer
+}
+
+// NewSyslogSink takes an address like udp://host:514, tcp://host:514 or unix:///dev/log, the local syslog daemon is
+// used if it is empty
+func NewSyslogSink(address string) (*SyslogSink, error) {
+	sink := &SyslogSink{}
+
+	if address != "" {
+		parsedUrl, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog address: %w", err)
+		}
+
+		switch parsedUrl.Scheme {
+		case "udp", "tcp":
+			sink.network = parsedUrl.Scheme
+			sink.address = parsedUrl.Host
+		case "unix", "unixgram":
+			sink.network = parsedUrl.Scheme
+			sink.address = parsedUrl.Path
+		default:
+			return nil, fmt.Errorf("unsupported syslog network %s", parsedUrl.Scheme)
+		}
+	}
+
+	return sink, nil
+}
+
+func (s *SyslogSink) Name() string {
+	return "syslog"
+}
+
+// Send writes the records one by one. If a write fails the connection is dialed again and the remaining records are
+// written once more, the batch is failed if that doesn't work either.
+func (s *SyslogSink) Send(ctx context.Context, records []Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sent := 0
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.writer == nil {
+			writer, err := syslog.Dial(s.network, s.address, syslog.LOG_INFO|syslog.LOG_DAEMON, syslogTag)
+			if err != nil {
+				return err
+			}
+			s.writer = writer
+		}
+
+		var err error
+		for sent < len(records) {
+			err = s.write(records[sent])
+			if err != nil {
+				break
+			}
+			sent++
+		}
+		if err == nil {
+			return nil
+		}
+
+		s.writer.Close()
+		s.writer = nil
+
+		if attempt == 1 {
+			return fmt.Errorf("failed to write to syslog after %d of %d records: %w", sent, len(records), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) write(record Record) error {
+	line := formatSyslogLine(record)
+
+	switch record.Level {
+	case "panic", "fatal":
+		return s.writer.Crit(line)
+	case "error":
+		return s.writer.Err(line)
+	case "warning":
+		return s.writer.Warning(line)
+	case "debug", "trace":
+		return s.writer.Debug(line)
+	default:
+		return s.writer.Info(line)
+	}
+}
+
+func (s *SyslogSink) Close(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.writer == nil {
+		return nil
+	}
+
+	err := s.writer.Close()
+	s.writer = nil
+
+	return err
+}
+
+// formatSyslogLine formats the record in logfmt, syslog adds the time and the host
+func formatSyslogLine(record Record) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "source=%s", record.Source)
+	if record.Module != "" {
+		fmt.Fprintf(&builder, " module=%s", record.Module)
+	}
+	fmt.Fprintf(&builder, " msg=%q", record.Message)
+
+	keys := make([]string, 0, len(record.Fields))
+	for key := range record.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&builder, " %s=%q", key, record.Fields[key])
+	}
+
+	return builder.String()
+}
@@ -20,10 +20,50 @@ type SystemMetrics struct {
 	LastUpdated     time.Time `json:"last_updated"`
 }
 
+type SandboxResources struct {
+	Cpu    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+	Swap   int64 `json:"swap"`
+}
+
+// BackupProgress tracks a running object storage backup or restore
+type BackupProgress struct {
+	Phase            enums.BackupPhase `json:"phase"`
+	BytesTransferred int64             `json:"bytesTransferred"`
+}
+
+// RepositoryStatus tracks the clone of the repository a sandbox is created with
+type RepositoryStatus struct {
+	Phase enums.RepositoryPhase `json:"phase"`
+	// Step is the git step the clone is at, e.g. Receiving objects, and Percent the progress of the step
+	Step    string `json:"step,omitempty"`
+	Percent int    `json:"percent"`
+	Commit  string `json:"commit,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SandboxExpiration schedules the stop or destruction of a sandbox created with a TTL
+type SandboxExpiration struct {
+	ExpiresAt time.Time          `json:"expiresAt"`
+	Action    enums.ExpiryAction `json:"action"`
+	// Warned is set once the expiry warning event was published
+	Warned bool `json:"warned"`
+}
+
 type CacheData struct {
 	SandboxState      enums.SandboxState
 	BackupState       enums.BackupState
 	BackupErrorReason *string
 	DestructionTime   *time.Time
 	SystemMetrics     *SystemMetrics
+	Resources         *SandboxResources
+	Expiration        *SandboxExpiration
+	// PullQueuePosition is set while the snapshot pull of the sandbox waits for a free pull slot, it is not persisted
+	PullQueuePosition *int `json:"-"`
+	// BackupProgress is set while an object storage backup or restore is running, it is not persisted
+	BackupProgress *BackupProgress `json:"-"`
+	// DaemonHealth is the last probe result of the daemon of a started sandbox, it is not persisted
+	DaemonHealth enums.DaemonHealth `json:"-"`
+	// Repository is set once the sandbox is created with a repository to clone, it is not persisted
+	Repository *RepositoryStatus `json:"-"`
 }
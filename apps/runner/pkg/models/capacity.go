@@ -0,0 +1,18 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package models
+
+type HostCapacity struct {
+	TotalCpu        int64    `json:"total_cpu"`
+	TotalMemoryGiB  int64    `json:"total_memory_gib"`
+	TotalDiskGiB    int64    `json:"total_disk_gib"`
+	DockerVersion   string   `json:"docker_version"`
+	DefaultRuntime  string   `json:"default_runtime"`
+	Runtimes        []string `json:"runtimes"`
+	Architecture    string   `json:"architecture"`
+	OperatingSystem string   `json:"operating_system"`
+	KernelVersion   string   `json:"kernel_version"`
+	Rootless        bool     `json:"rootless"`
+	UsernsRemap     bool     `json:"userns_remap"`
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// BuildStatus is the outcome of the latest build or pull of a snapshot
+type BuildStatus string
+
+const (
+	BuildStatusBuilding  BuildStatus = "BUILDING"
+	BuildStatusSucceeded BuildStatus = "SUCCEEDED"
+	BuildStatusFailed    BuildStatus = "FAILED"
+)
+
+func (s BuildStatus) String() string {
+	return string(s)
+}
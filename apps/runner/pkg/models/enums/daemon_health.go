@@ -0,0 +1,17 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type DaemonHealth string
+
+const (
+	DaemonHealthUnknown    DaemonHealth = "UNKNOWN"
+	DaemonHealthHealthy    DaemonHealth = "HEALTHY"
+	DaemonHealthUnhealthy  DaemonHealth = "UNHEALTHY"
+	DaemonHealthRestarting DaemonHealth = "RESTARTING"
+)
+
+func (h DaemonHealth) String() string {
+	return string(h)
+}
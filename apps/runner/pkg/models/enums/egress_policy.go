@@ -0,0 +1,16 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type EgressPolicyMode string
+
+const (
+	EgressPolicyModeAllowAll  EgressPolicyMode = "ALLOW_ALL"
+	EgressPolicyModeDenyAll   EgressPolicyMode = "DENY_ALL"
+	EgressPolicyModeAllowList EgressPolicyMode = "ALLOWLIST"
+)
+
+func (m EgressPolicyMode) String() string {
+	return string(m)
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type EventType string
+
+const (
+	EventTypeSandboxStateChanged EventType = "sandbox.state_changed"
+	EventTypeBackupStateChanged  EventType = "backup.state_changed"
+	EventTypeSnapshotPull        EventType = "snapshot.pull"
+	EventTypeSnapshotBuild       EventType = "snapshot.build"
+	EventTypeSandboxDiskUsage    EventType = "sandbox.disk_usage_exceeded"
+	EventTypeSandboxExpiring     EventType = "sandbox.expiring"
+	EventTypeSandboxExpired      EventType = "sandbox.expired"
+	EventTypeDaemonHealthChanged EventType = "sandbox.daemon_health_changed"
+	EventTypeSandboxWarning      EventType = "sandbox.warning"
+	EventTypeSandboxRestarted    EventType = "sandbox.restarted"
+	EventTypeSandboxCrashLoop    EventType = "sandbox.crash_loop"
+	EventTypeSandboxMaintenance  EventType = "sandbox.maintenance_scheduled"
+	EventTypeMaintenanceCancel   EventType = "sandbox.maintenance_cancelled"
+)
+
+func (t EventType) String() string {
+	return string(t)
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type ExpiryAction string
+
+const (
+	ExpiryActionStop    ExpiryAction = "STOP"
+	ExpiryActionDestroy ExpiryAction = "DESTROY"
+)
+
+func (a ExpiryAction) String() string {
+	return string(a)
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// HealthStatus values mirror the grpc.health.v1 serving statuses
+type HealthStatus string
+
+const (
+	HealthStatusServing        HealthStatus = "SERVING"
+	HealthStatusNotServing     HealthStatus = "NOT_SERVING"
+	HealthStatusServiceUnknown HealthStatus = "SERVICE_UNKNOWN"
+)
+
+func (s HealthStatus) String() string {
+	return string(s)
+}
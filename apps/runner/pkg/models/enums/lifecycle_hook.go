@@ -0,0 +1,31 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type LifecycleHookStage string
+
+const (
+	LifecycleHookStagePostCreate LifecycleHookStage = "POST_CREATE"
+	LifecycleHookStagePostStart  LifecycleHookStage = "POST_START"
+	LifecycleHookStagePreStop    LifecycleHookStage = "PRE_STOP"
+)
+
+func (s LifecycleHookStage) String() string {
+	return string(s)
+}
+
+// HookFailurePolicy decides what a failed lifecycle hook does to the transition it runs in
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyIgnore HookFailurePolicy = "IGNORE"
+	// HookFailurePolicyWarn records a warning on the sandbox and continues
+	HookFailurePolicyWarn HookFailurePolicy = "WARN"
+	// HookFailurePolicyFail fails the create, start or stop the hook runs in
+	HookFailurePolicyFail HookFailurePolicy = "FAIL"
+)
+
+func (p HookFailurePolicy) String() string {
+	return string(p)
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type OperationType string
+
+const (
+	OperationTypeCreate           OperationType = "create"
+	OperationTypePull             OperationType = "pull"
+	OperationTypeBuild            OperationType = "build"
+	OperationTypeMigrate          OperationType = "migrate"
+	OperationTypeReceiveMigration OperationType = "receive_migration"
+)
+
+func (t OperationType) String() string {
+	return string(t)
+}
+
+type OperationState string
+
+const (
+	OperationStateRunning   OperationState = "running"
+	OperationStateSucceeded OperationState = "succeeded"
+	OperationStateFailed    OperationState = "failed"
+	OperationStateCancelled OperationState = "cancelled"
+)
+
+func (s OperationState) String() string {
+	return string(s)
+}
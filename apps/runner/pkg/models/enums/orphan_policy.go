@@ -0,0 +1,17 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// OrphanPolicy is what reconciliation does with sandbox containers that have no cache entry
+type OrphanPolicy string
+
+const (
+	OrphanPolicyAdopt  OrphanPolicy = "adopt"
+	OrphanPolicyRemove OrphanPolicy = "remove"
+	OrphanPolicyIgnore OrphanPolicy = "ignore"
+)
+
+func (p OrphanPolicy) String() string {
+	return string(p)
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// PreviewAccessLevel controls who can reach an exposed sandbox port through its preview URL
+type PreviewAccessLevel string
+
+const (
+	// PreviewAccessLevelPublic needs no token
+	PreviewAccessLevelPublic PreviewAccessLevel = "PUBLIC"
+	// PreviewAccessLevelAuthenticated accepts the runner API token, scoped tokens and proxy tokens of the sandbox
+	PreviewAccessLevelAuthenticated PreviewAccessLevel = "AUTHENTICATED"
+	// PreviewAccessLevelOwner only accepts proxy tokens of the sandbox, which are issued to its owner
+	PreviewAccessLevelOwner PreviewAccessLevel = "OWNER"
+)
+
+func (l PreviewAccessLevel) String() string {
+	return string(l)
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// RepositoryPhase is the step the repository bootstrap of a sandbox is at
+type RepositoryPhase string
+
+const (
+	RepositoryPhaseCloning RepositoryPhase = "CLONING"
+	RepositoryPhaseCopying RepositoryPhase = "COPYING"
+	RepositoryPhaseReady   RepositoryPhase = "READY"
+	RepositoryPhaseFailed  RepositoryPhase = "FAILED"
+)
+
+func (p RepositoryPhase) String() string {
+	return string(p)
+}
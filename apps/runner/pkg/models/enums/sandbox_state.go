@@ -15,6 +15,9 @@ const (
 	SandboxStateStarting        SandboxState = "starting"
 	SandboxStateStopping        SandboxState = "stopping"
 	SandboxStateResizing        SandboxState = "resizing"
+	SandboxStatePausing         SandboxState = "pausing"
+	SandboxStatePaused          SandboxState = "paused"
+	SandboxStateResuming        SandboxState = "resuming"
 	SandboxStateError           SandboxState = "error"
 	SandboxStateUnknown         SandboxState = "unknown"
 	SandboxStatePullingSnapshot SandboxState = "pulling_snapshot"
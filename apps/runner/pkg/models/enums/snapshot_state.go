@@ -16,3 +16,50 @@ const (
 func (s BackupState) String() string {
 	return string(s)
 }
+
+// CanTransitionTo reports whether a backup can move from the current state to the next one.
+// A new backup can only be started once the previous one has finished, failed or been canceled.
+func (s BackupState) CanTransitionTo(next BackupState) bool {
+	if s == next {
+		return true
+	}
+
+	switch s {
+	case BackupStateNone, BackupStateCompleted, BackupStateFailed:
+		return next == BackupStatePending || next == BackupStateInProgress
+	case BackupStatePending:
+		return next == BackupStateInProgress || next == BackupStateNone || next == BackupStateFailed
+	case BackupStateInProgress:
+		return next == BackupStateCompleted || next == BackupStateFailed || next == BackupStateNone
+	}
+
+	return false
+}
+
+// BackupPhase is the step an object storage backup or restore is currently at
+type BackupPhase string
+
+const (
+	BackupPhaseCommitting        BackupPhase = "COMMITTING"
+	BackupPhaseExportingSnapshot BackupPhase = "EXPORTING_SNAPSHOT"
+	BackupPhaseExportingVolumes  BackupPhase = "EXPORTING_VOLUMES"
+	BackupPhaseRestoringSnapshot BackupPhase = "RESTORING_SNAPSHOT"
+	BackupPhaseRestoringVolumes  BackupPhase = "RESTORING_VOLUMES"
+)
+
+func (p BackupPhase) String() string {
+	return string(p)
+}
+
+type SnapshotOperationState string
+
+const (
+	SnapshotOperationStateStarted    SnapshotOperationState = "STARTED"
+	SnapshotOperationStateInProgress SnapshotOperationState = "IN_PROGRESS"
+	SnapshotOperationStateCompleted  SnapshotOperationState = "COMPLETED"
+	SnapshotOperationStateFailed     SnapshotOperationState = "FAILED"
+)
+
+func (s SnapshotOperationState) String() string {
+	return string(s)
+}
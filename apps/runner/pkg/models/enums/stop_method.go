@@ -0,0 +1,18 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+// StopMethod is how the container of a stopped sandbox came to a halt
+type StopMethod string
+
+const (
+	StopMethodGraceful   StopMethod = "graceful"
+	StopMethodKilled     StopMethod = "killed"
+	StopMethodForced     StopMethod = "forced"
+	StopMethodNotRunning StopMethod = "not_running"
+)
+
+func (m StopMethod) String() string {
+	return string(m)
+}
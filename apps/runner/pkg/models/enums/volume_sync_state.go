@@ -0,0 +1,26 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type VolumeSyncState string
+
+const (
+	VolumeSyncStateNone       VolumeSyncState = "NONE"
+	VolumeSyncStateInProgress VolumeSyncState = "IN_PROGRESS"
+	VolumeSyncStateCompleted  VolumeSyncState = "COMPLETED"
+	VolumeSyncStateFailed     VolumeSyncState = "FAILED"
+)
+
+func (s VolumeSyncState) String() string {
+	return string(s)
+}
+
+type VolumeSyncDirection string
+
+const (
+	// VolumeSyncDirectionPull downloads the bucket into the local volume
+	VolumeSyncDirectionPull VolumeSyncDirection = "pull"
+	// VolumeSyncDirectionPush uploads local changes back to the bucket
+	VolumeSyncDirectionPush VolumeSyncDirection = "push"
+)
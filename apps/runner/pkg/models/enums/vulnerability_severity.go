@@ -0,0 +1,34 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package enums
+
+type VulnerabilitySeverity string
+
+const (
+	VulnerabilitySeverityUnknown  VulnerabilitySeverity = "UNKNOWN"
+	VulnerabilitySeverityLow      VulnerabilitySeverity = "LOW"
+	VulnerabilitySeverityMedium   VulnerabilitySeverity = "MEDIUM"
+	VulnerabilitySeverityHigh     VulnerabilitySeverity = "HIGH"
+	VulnerabilitySeverityCritical VulnerabilitySeverity = "CRITICAL"
+)
+
+func (s VulnerabilitySeverity) String() string {
+	return string(s)
+}
+
+// Rank orders severities from least to most severe, unknown severities rank lowest
+func (s VulnerabilitySeverity) Rank() int {
+	switch s {
+	case VulnerabilitySeverityLow:
+		return 1
+	case VulnerabilitySeverityMedium:
+		return 2
+	case VulnerabilitySeverityHigh:
+		return 3
+	case VulnerabilitySeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
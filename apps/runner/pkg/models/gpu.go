@@ -0,0 +1,12 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package models
+
+type GPUInfo struct {
+	Index     int    `json:"index"`
+	Uuid      string `json:"uuid"`
+	Name      string `json:"name"`
+	MemoryMiB int64  `json:"memory_mib"`
+	Allocated bool   `json:"allocated"`
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package previewtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/util"
+	"golang.org/x/crypto/acme"
+)
+
+// The certificate is renewed this long before it expires, Let's Encrypt certificates are valid for 90 days
+const renewBefore = 30 * 24 * time.Hour
+
+const orderTimeout = 10 * time.Minute
+
+type AcmeConfig struct {
+	Domain string
+	// DirectoryUrl defaults to Let's Encrypt
+	DirectoryUrl string
+	Email        string
+	// DNSHook is run with "present" or "cleanup", the TXT record name and the record value. It must only
+	// return once the record is visible to the ACME server.
+	DNSHook string
+	// CacheDir keeps the account key and the certificate across restarts
+	CacheDir string
+}
+
+type acmeSource struct {
+	domain       string
+	directoryUrl string
+	email        string
+	dnsHook      string
+	cacheDir     string
+}
+
+func newAcmeSource(config AcmeConfig) (*acmeSource, error) {
+	if config.DNSHook == "" {
+		return nil, errors.New("an ACME DNS hook is required")
+	}
+
+	directoryUrl := config.DirectoryUrl
+	if directoryUrl == "" {
+		directoryUrl = acme.LetsEncryptURL
+	}
+
+	return &acmeSource{
+		domain:       config.Domain,
+		directoryUrl: directoryUrl,
+		email:        config.Email,
+		dnsHook:      config.DNSHook,
+		cacheDir:     config.CacheDir,
+	}, nil
+}
+
+func (s *acmeSource) stale(current *tls.Certificate) bool {
+	return current == nil || time.Until(current.Leaf.NotAfter) < renewBefore
+}
+
+func (s *acmeSource) loadCached() (*tls.Certificate, error) {
+	certificate, err := tls.LoadX509KeyPair(filepath.Join(s.cacheDir, "cert.pem"), filepath.Join(s.cacheDir, "key.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(certificate.Leaf.DNSNames, "*."+s.domain) {
+		return nil, fmt.Errorf("the cached certificate is not valid for *.%s", s.domain)
+	}
+
+	return &certificate, nil
+}
+
+func (s *acmeSource) obtain(ctx context.Context) (*tls.Certificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, orderTimeout)
+	defer cancel()
+
+	accountKey, err := s.getAccountKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{Key: accountKey, DirectoryURL: s.directoryUrl}
+
+	account := &acme.Account{}
+	if s.email != "" {
+		account.Contact = []string{"mailto:" + s.email}
+	}
+
+	_, err = client.Register(ctx, account, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("failed to register the ACME account: %w", err)
+	}
+
+	wildcard := "*." + s.domain
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(wildcard))
+	if err != nil {
+		return nil, fmt.Errorf("failed to order a certificate for %s: %w", wildcard, err)
+	}
+
+	for _, authzUrl := range order.AuthzURLs {
+		err = s.authorize(ctx, client, authzUrl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("certificate order for %s failed: %w", wildcard, err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{wildcard}}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize the certificate order for %s: %w", wildcard, err)
+	}
+
+	err = s.store(chain, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadCached()
+}
+
+// authorize proves control of the domain by publishing the challenge value in a TXT record through the DNS hook
+func (s *acmeSource) authorize(ctx context.Context, client *acme.Client, authzUrl string) error {
+	authz, err := client.GetAuthorization(ctx, authzUrl)
+	if err != nil {
+		return err
+	}
+
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("the ACME server offers no dns-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	value, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return err
+	}
+
+	recordName := "_acme-challenge." + authz.Identifier.Value
+
+	err = s.runDNSHook(ctx, "present", recordName, value)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := s.runDNSHook(context.Background(), "cleanup", recordName, value)
+		if err != nil {
+			log.Warnf("Failed to clean up the ACME challenge record %s: %v", recordName, err)
+		}
+	}()
+
+	_, err = client.Accept(ctx, challenge)
+	if err != nil {
+		return fmt.Errorf("failed to accept the dns-01 challenge for %s: %w", authz.Identifier.Value, err)
+	}
+
+	_, err = client.WaitAuthorization(ctx, authz.URI)
+	if err != nil {
+		return fmt.Errorf("dns-01 challenge for %s failed: %w", authz.Identifier.Value, err)
+	}
+
+	return nil
+}
+
+func (s *acmeSource) runDNSHook(ctx context.Context, action string, recordName string, value string) error {
+	output, err := exec.CommandContext(ctx, s.dnsHook, action, recordName, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ACME DNS hook %s failed: %w: %s", action, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func (s *acmeSource) getAccountKey() (crypto.Signer, error) {
+	path := filepath.Join(s.cacheDir, "account.key")
+
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode the ACME account key %s", path)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPem, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFile(path, keyPem)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *acmeSource) store(chain [][]byte, key *ecdsa.PrivateKey) error {
+	var certPem []byte
+	for _, der := range chain {
+		certPem = append(certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	keyPem, err := encodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	// A crash between the writes leaves a mismatched pair, loading it fails and a new certificate is ordered
+	err = writeFile(filepath.Join(s.cacheDir, "key.pem"), keyPem)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(s.cacheDir, "cert.pem"), certPem)
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(path, data, 0600)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package previewtls
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/services"
+)
+
+const checkInterval = time.Minute
+
+// A failed ACME order counts against the rate limits of the ACME server, so failures are retried slowly
+const retryBackoff = 15 * time.Minute
+
+type certificateSource interface {
+	obtain(ctx context.Context) (*tls.Certificate, error)
+	// stale reports whether the certificate has to be obtained again, current is nil before the first certificate
+	stale(current *tls.Certificate) bool
+}
+
+// Manager serves the wildcard certificate of the preview domain and only completes handshakes for preview
+// hostnames, so connections are routed by SNI before any request is read
+type Manager struct {
+	domain string
+	source certificateSource
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	nextAttempt time.Time
+}
+
+// NewFileManager serves the wildcard certificate from the files, the files are reloaded when they change
+func NewFileManager(domain string, certFile string, keyFile string) (*Manager, error) {
+	m := &Manager{
+		domain: domain,
+		source: &fileSource{certFile: certFile, keyFile: keyFile},
+	}
+
+	certificate, err := m.source.obtain(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	m.certificate = certificate
+
+	return m, nil
+}
+
+// NewAcmeManager obtains the wildcard certificate with an ACME DNS-01 challenge. A certificate cached by a
+// previous run is served right away, otherwise handshakes fail until Start obtained one.
+func NewAcmeManager(config AcmeConfig) (*Manager, error) {
+	source, err := newAcmeSource(config)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		domain: config.Domain,
+		source: source,
+	}
+
+	certificate, err := source.loadCached()
+	if err != nil {
+		log.Warnf("Failed to load the cached preview certificate: %v", err)
+	} else {
+		m.certificate = certificate
+	}
+
+	return m, nil
+}
+
+// Start reloads or renews the certificate in the background until the context is cancelled
+func (m *Manager) Start(ctx context.Context) {
+	go func() {
+		m.refresh(ctx)
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.refresh(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	_, _, ok := services.ParsePreviewHost(hello.ServerName, m.domain)
+	if !ok {
+		return nil, fmt.Errorf("no certificate for server name %q", hello.ServerName)
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.certificate == nil {
+		return nil, errors.New("the preview certificate is not available yet")
+	}
+
+	return m.certificate, nil
+}
+
+// TLSConfig returns the config of the preview listener
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+func (m *Manager) refresh(ctx context.Context) {
+	m.mutex.RLock()
+	current := m.certificate
+	nextAttempt := m.nextAttempt
+	m.mutex.RUnlock()
+
+	if time.Now().Before(nextAttempt) || !m.source.stale(current) {
+		return
+	}
+
+	certificate, err := m.source.obtain(ctx)
+	if err != nil {
+		log.Errorf("Failed to obtain the preview certificate, retrying in %s: %v", retryBackoff, err)
+		m.mutex.Lock()
+		m.nextAttempt = time.Now().Add(retryBackoff)
+		m.mutex.Unlock()
+		return
+	}
+
+	m.mutex.Lock()
+	m.certificate = certificate
+	m.nextAttempt = time.Time{}
+	m.mutex.Unlock()
+
+	log.Infof("Loaded the preview certificate for *.%s, valid until %s", m.domain, certificate.Leaf.NotAfter.Format(time.RFC3339))
+}
+
+type fileSource struct {
+	certFile string
+	keyFile  string
+
+	mutex   sync.Mutex
+	modTime time.Time
+}
+
+func (s *fileSource) obtain(ctx context.Context) (*tls.Certificate, error) {
+	info, err := os.Stat(s.certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the preview certificate: %w", err)
+	}
+
+	s.mutex.Lock()
+	s.modTime = info.ModTime()
+	s.mutex.Unlock()
+
+	return &certificate, nil
+}
+
+func (s *fileSource) stale(current *tls.Certificate) bool {
+	if current == nil {
+		return true
+	}
+
+	info, err := os.Stat(s.certFile)
+	if err != nil {
+		log.Warnf("Failed to stat the preview certificate file: %v", err)
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return !info.ModTime().Equal(s.modTime)
+}
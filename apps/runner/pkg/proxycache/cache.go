@@ -0,0 +1,242 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package proxycache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const bodyDirPrefix = "proxy-cache-"
+
+type CacheConfig struct {
+	// MaxSize is the total size of the cached bodies in bytes
+	MaxSize      int64
+	MaxEntrySize int64
+	// Dir keeps the bodies on disk instead of in memory, bodies left behind by a previous run are removed
+	Dir string
+}
+
+type Entry struct {
+	Status    int
+	Header    http.Header
+	StoredAt  time.Time
+	ExpiresAt time.Time
+}
+
+type item struct {
+	key   string
+	entry Entry
+	size  int64
+	// body is nil if the body is stored in bodyPath
+	body     []byte
+	bodyPath string
+}
+
+// Cache is an LRU cache of proxied responses bounded by the total size of their bodies
+type Cache struct {
+	maxSize      int64
+	maxEntrySize int64
+	dir          string
+
+	sequence atomic.Uint64
+
+	mutex sync.Mutex
+	size  int64
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+func NewCache(config CacheConfig) (*Cache, error) {
+	c := &Cache{
+		maxSize:      config.MaxSize,
+		maxEntrySize: min(config.MaxEntrySize, config.MaxSize),
+		lru:          list.New(),
+		items:        make(map[string]*list.Element),
+	}
+
+	if config.Dir == "" {
+		return c, nil
+	}
+
+	err := os.MkdirAll(config.Dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	// The index is only kept in memory, so the bodies of a previous run are unreachable
+	previous, err := filepath.Glob(filepath.Join(config.Dir, bodyDirPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range previous {
+		err = os.RemoveAll(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.dir, err = os.MkdirTemp(config.Dir, bodyDirPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Key returns the key of the request to the sandbox port. Responses are stored per encoding the client
+// accepts, so a compressed body is never served to a client that can't decode it.
+func Key(sandboxId string, port string, r *http.Request) string {
+	return fmt.Sprintf("%s/%s %s %s", sandboxId, port, r.URL.RequestURI(), r.Header.Get("Accept-Encoding"))
+}
+
+func (c *Cache) MaxEntrySize() int64 {
+	return c.maxEntrySize
+}
+
+// StartCleanup drops the responses of sandboxes once they are destroyed
+func (c *Cache) StartCleanup(ctx context.Context, broker *events.Broker) {
+	eventChan, unsubscribe := broker.Subscribe()
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				if event.Type != enums.EventTypeSandboxStateChanged || event.State != enums.SandboxStateDestroyed.String() {
+					continue
+				}
+
+				c.RemoveSandbox(event.SandboxId)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Get returns a fresh response and its body, expired responses are removed
+func (c *Cache) Get(key string) (Entry, io.ReadCloser, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return Entry{}, nil, false
+	}
+
+	cached := element.Value.(*item)
+	if time.Now().After(cached.entry.ExpiresAt) {
+		c.remove(element)
+		return Entry{}, nil, false
+	}
+
+	var body io.ReadCloser
+	if cached.body != nil {
+		body = io.NopCloser(bytes.NewReader(cached.body))
+	} else {
+		// An open file stays readable when the entry is evicted and the file is removed
+		file, err := os.Open(cached.bodyPath)
+		if err != nil {
+			log.Warnf("Failed to open cached response body: %v", err)
+			c.remove(element)
+			return Entry{}, nil, false
+		}
+		body = file
+	}
+
+	c.lru.MoveToFront(element)
+
+	return cached.entry, body, true
+}
+
+// Put stores the response, least recently used responses are evicted to make room for it
+func (c *Cache) Put(key string, entry Entry, body []byte) {
+	size := int64(len(body))
+	if size > c.maxEntrySize {
+		return
+	}
+
+	cached := &item{key: key, entry: entry, size: size, body: body}
+	if c.dir != "" {
+		cached.bodyPath = filepath.Join(c.dir, strconv.FormatUint(c.sequence.Add(1), 10))
+		cached.body = nil
+
+		err := os.WriteFile(cached.bodyPath, body, 0600)
+		if err != nil {
+			log.Warnf("Failed to write cached response body: %v", err)
+			return
+		}
+	} else if cached.body == nil {
+		cached.body = []byte{}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+
+	for c.size+size > c.maxSize && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+
+	c.items[key] = c.lru.PushFront(cached)
+	c.size += size
+}
+
+func (c *Cache) RemoveSandbox(sandboxId string) {
+	c.removePrefix(sandboxId + "/")
+}
+
+func (c *Cache) RemovePort(sandboxId string, port string) {
+	c.removePrefix(fmt.Sprintf("%s/%s ", sandboxId, port))
+}
+
+func (c *Cache) removePrefix(prefix string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, element := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+		}
+	}
+}
+
+// remove expects the caller to hold the mutex
+func (c *Cache) remove(element *list.Element) {
+	cached := element.Value.(*item)
+
+	c.lru.Remove(element)
+	delete(c.items, cached.key)
+	c.size -= cached.size
+
+	if cached.bodyPath != "" {
+		err := os.Remove(cached.bodyPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warnf("Failed to remove cached response body: %v", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package proxycache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsCacheableRequest reports whether the response to the request may be served from or stored in the cache
+func IsCacheableRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet || r.Header.Get("Range") != "" || r.Header.Get("Upgrade") != "" {
+		return false
+	}
+
+	_, noStore := parseCacheControl(r.Header)["no-store"]
+	return !noStore
+}
+
+// SkipsLookup reports whether the client asked for a response from the sandbox instead of a cached one
+func SkipsLookup(r *http.Request) bool {
+	directives := parseCacheControl(r.Header)
+	if _, ok := directives["no-cache"]; ok {
+		return true
+	}
+
+	return directives["max-age"] == "0" || r.Header.Get("Pragma") == "no-cache"
+}
+
+// Freshness returns how long a shared cache may serve the response to the request, 0 if it must not be stored
+func Freshness(r *http.Request, status int, header http.Header, now time.Time) time.Duration {
+	if status != http.StatusOK || header.Get("Set-Cookie") != "" {
+		return 0
+	}
+
+	for _, vary := range header.Values("Vary") {
+		for _, field := range strings.Split(vary, ",") {
+			// The key only covers the accepted encodings
+			if !strings.EqualFold(strings.TrimSpace(field), "Accept-Encoding") {
+				return 0
+			}
+		}
+	}
+
+	directives := parseCacheControl(header)
+	for _, directive := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[directive]; ok {
+			return 0
+		}
+	}
+
+	// Responses to requests with credentials are only shared if the sandbox explicitly allows it
+	_, public := directives["public"]
+	_, sharedMaxAge := directives["s-maxage"]
+	if r.Header.Get("Authorization") != "" && !public && !sharedMaxAge {
+		return 0
+	}
+
+	var lifetime time.Duration
+	if maxAge, ok := parseSeconds(directives["s-maxage"]); ok {
+		lifetime = maxAge
+	} else if maxAge, ok := parseSeconds(directives["max-age"]); ok {
+		lifetime = maxAge
+	} else if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		lifetime = expires.Sub(date)
+	}
+
+	if age, ok := parseSeconds(header.Get("Age")); ok {
+		lifetime -= age
+	}
+
+	return max(lifetime, 0)
+}
+
+func parseCacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, argument, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+			directives[strings.ToLower(name)] = strings.Trim(argument, `"`)
+		}
+	}
+
+	return directives
+}
+
+func parseSeconds(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package proxytoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid proxy token")
+	ErrExpiredToken = errors.New("proxy token expired")
+	ErrRevokedToken = errors.New("proxy token revoked")
+)
+
+type claims struct {
+	SandboxId string `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Issuer signs sandbox scoped proxy tokens with HMAC-SHA256.
+// Refreshing a sandbox token revokes every token issued for that sandbox before it.
+// Revocations are kept in memory, after a restart older tokens stay valid until they expire.
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+
+	mutex     sync.RWMutex
+	notBefore map[string]time.Time
+}
+
+func NewIssuer(secret string, ttl time.Duration) (*Issuer, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("proxy token secret must be at least 32 characters long")
+	}
+
+	return &Issuer{
+		secret:    []byte(secret),
+		ttl:       ttl,
+		notBefore: make(map[string]time.Time),
+	}, nil
+}
+
+// Refresh revokes previous tokens of the sandbox and issues a new one
+func (i *Issuer) Refresh(sandboxId string) (string, time.Time, error) {
+	now := time.Now()
+
+	i.mutex.Lock()
+	i.notBefore[sandboxId] = now
+	i.mutex.Unlock()
+
+	expiresAt := now.Add(i.ttl)
+
+	payload, err := json.Marshal(claims{
+		SandboxId: sandboxId,
+		IssuedAt:  now.UnixNano(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encodedPayload + "." + i.sign(encodedPayload), expiresAt, nil
+}
+
+// Validate checks the token signature, expiry and revocation and that it was issued for the sandbox
+func (i *Issuer) Validate(token string, sandboxId string) error {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(i.sign(encodedPayload))) {
+		return ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	var c claims
+	err = json.Unmarshal(payload, &c)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if c.SandboxId != sandboxId {
+		return fmt.Errorf("%w: token was issued for another sandbox", ErrInvalidToken)
+	}
+
+	if time.Now().Unix() >= c.ExpiresAt {
+		return ErrExpiredToken
+	}
+
+	i.mutex.RLock()
+	notBefore, ok := i.notBefore[sandboxId]
+	i.mutex.RUnlock()
+
+	if ok && c.IssuedAt < notBefore.UnixNano() {
+		return ErrRevokedToken
+	}
+
+	return nil
+}
+
+func (i *Issuer) sign(encodedPayload string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
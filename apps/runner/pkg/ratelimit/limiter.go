@@ -0,0 +1,182 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Buckets of callers that were not seen for this long are dropped
+const idleBucketTTL = 10 * time.Minute
+
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type LimiterConfig struct {
+	// Default applies to every route without its own limit, all those routes share one bucket per caller
+	Default Limit
+	// Routes maps "METHOD /route" to its limit, every route has its own bucket per caller
+	Routes map[string]Limit
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// Limiter keeps a token bucket per caller so a single caller can't monopolize the runner
+// A limiter without a default or route limits allows every request.
+type Limiter struct {
+	mutex        sync.Mutex
+	defaultLimit Limit
+	routes       map[string]Limit
+	buckets      map[string]*bucket
+}
+
+func NewLimiter(config LimiterConfig) *Limiter {
+	return &Limiter{
+		defaultLimit: config.Default,
+		routes:       config.Routes,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of the caller for the route.
+// If the bucket is empty it returns how long the caller has to wait for the next token.
+func (l *Limiter) Allow(caller string, route string) (bool, time.Duration) {
+	l.mutex.Lock()
+
+	limit, ok := l.routes[route]
+	bucketKey := caller + " " + route
+	if !ok {
+		limit = l.defaultLimit
+		bucketKey = caller
+	}
+
+	if limit.Rate <= 0 {
+		l.mutex.Unlock()
+		return true, 0
+	}
+
+	b, ok := l.buckets[bucketKey]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), getBurst(limit))}
+		l.buckets[bucketKey] = b
+	}
+	b.lastUsed = time.Now()
+	l.mutex.Unlock()
+
+	reservation := b.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+
+	return true, 0
+}
+
+// SetConfig replaces the limits, callers start over with full buckets
+func (l *Limiter) SetConfig(config LimiterConfig) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.defaultLimit = config.Default
+	l.routes = config.Routes
+	l.buckets = make(map[string]*bucket)
+}
+
+func (l *Limiter) StartCleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(idleBucketTTL)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				l.removeIdleBuckets()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (l *Limiter) removeIdleBuckets() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for key, b := range l.buckets {
+		if time.Since(b.lastUsed) > idleBucketTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// ParseConfig builds the limiter config from the default limit and route limits in the ParseRouteLimits format
+func ParseConfig(defaultRate float64, defaultBurst int, routeLimits []string) (LimiterConfig, error) {
+	routes, err := ParseRouteLimits(routeLimits)
+	if err != nil {
+		return LimiterConfig{}, err
+	}
+
+	return LimiterConfig{
+		Default: Limit{
+			Rate:  defaultRate,
+			Burst: defaultBurst,
+		},
+		Routes: routes,
+	}, nil
+}
+
+// ParseRouteLimits parses entries in the "METHOD /route=rate[:burst]" format, e.g. "POST /sandboxes=5:10"
+func ParseRouteLimits(entries []string) (map[string]Limit, error) {
+	routes := make(map[string]Limit, len(entries))
+
+	for _, entry := range entries {
+		route, rawLimit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route rate limit %q", entry)
+		}
+
+		rawRate, rawBurst, hasBurst := strings.Cut(rawLimit, ":")
+
+		var limit Limit
+		var err error
+		limit.Rate, err = strconv.ParseFloat(strings.TrimSpace(rawRate), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate in route rate limit %q: %w", entry, err)
+		}
+
+		if hasBurst {
+			limit.Burst, err = strconv.Atoi(strings.TrimSpace(rawBurst))
+			if err != nil {
+				return nil, fmt.Errorf("invalid burst in route rate limit %q: %w", entry, err)
+			}
+		}
+
+		routes[strings.Join(strings.Fields(route), " ")] = limit
+	}
+
+	return routes, nil
+}
+
+// getBurst defaults the burst to one second worth of requests
+func getBurst(limit Limit) int {
+	if limit.Burst > 0 {
+		return limit.Burst
+	}
+
+	return max(1, int(math.Ceil(limit.Rate)))
+}
@@ -4,28 +4,89 @@
 package runner
 
 import (
-	"log"
-
+	"github.com/daytonaio/runner/pkg/apitoken"
+	"github.com/daytonaio/runner/pkg/audit"
+	"github.com/daytonaio/runner/pkg/backend"
 	"github.com/daytonaio/runner/pkg/cache"
 	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/idempotency"
 	"github.com/daytonaio/runner/pkg/netrules"
+	"github.com/daytonaio/runner/pkg/proxycache"
+	"github.com/daytonaio/runner/pkg/proxytoken"
+	"github.com/daytonaio/runner/pkg/ratelimit"
+	"github.com/daytonaio/runner/pkg/sandboxnet"
 	"github.com/daytonaio/runner/pkg/services"
 )
 
 type RunnerInstanceConfig struct {
-	Cache           cache.IRunnerCache
-	Docker          *docker.DockerClient
-	SandboxService  *services.SandboxService
-	MetricsService  *services.MetricsService
-	NetRulesManager *netrules.NetRulesManager
+	Cache            cache.IRunnerCache
+	Docker           *docker.DockerClient
+	Backend          backend.Backend
+	SandboxService   *services.SandboxService
+	MetricsService   *services.MetricsService
+	HealthService    *services.HealthService
+	DrainService     *services.DrainService
+	IdleService      *services.IdleService
+	SnapshotGC       *services.SnapshotGCService
+	Prewarm          *services.PrewarmService
+	WarmPool         *services.WarmPool
+	BuildLogs        *services.BuildLogService
+	Ports            *services.PortService
+	SshAccess        *services.SshAccessService
+	ProxyCache       *proxycache.Cache
+	CleanupService   *services.CleanupService
+	Events           *events.Broker
+	ProxyTokenIssuer *proxytoken.Issuer
+	NetRulesManager  *netrules.NetRulesManager
+	SandboxNetwork   *sandboxnet.Manager
+	RateLimiter      *ratelimit.Limiter
+	AuditLogger      *audit.Logger
+	Authenticator    *apitoken.Authenticator
+	AuthPolicy       *apitoken.Policy
+	Idempotency      *idempotency.Store
+	Operations       *services.OperationService
+	Usage            *services.UsageMeter
+	Labels           *services.RunnerLabels
+	Maintenance      *services.MaintenanceService
+	Migration        *services.MigrationService
 }
 
 type Runner struct {
-	Cache           cache.IRunnerCache
-	Docker          *docker.DockerClient
-	SandboxService  *services.SandboxService
-	MetricsService  *services.MetricsService
-	NetRulesManager *netrules.NetRulesManager
+	Cache          cache.IRunnerCache
+	Docker         *docker.DockerClient
+	Backend        backend.Backend
+	SandboxService *services.SandboxService
+	MetricsService *services.MetricsService
+	HealthService  *services.HealthService
+	DrainService   *services.DrainService
+	IdleService    *services.IdleService
+	SnapshotGC     *services.SnapshotGCService
+	Prewarm        *services.PrewarmService
+	WarmPool       *services.WarmPool
+	BuildLogs      *services.BuildLogService
+	Ports          *services.PortService
+	// SshAccess is nil when the SSH gateway is disabled
+	SshAccess *services.SshAccessService
+	// ProxyCache is nil when proxy response caching is disabled
+	ProxyCache     *proxycache.Cache
+	CleanupService *services.CleanupService
+	Events         *events.Broker
+	// ProxyTokenIssuer is nil when proxy tokens are not configured
+	ProxyTokenIssuer *proxytoken.Issuer
+	NetRulesManager  *netrules.NetRulesManager
+	SandboxNetwork   *sandboxnet.Manager
+	RateLimiter      *ratelimit.Limiter
+	AuditLogger      *audit.Logger
+	// Authenticator accepts the API tokens and the scoped tokens signed with them
+	Authenticator *apitoken.Authenticator
+	AuthPolicy    *apitoken.Policy
+	Idempotency   *idempotency.Store
+	Operations    *services.OperationService
+	Usage         *services.UsageMeter
+	Labels        *services.RunnerLabels
+	Maintenance   *services.MaintenanceService
+	Migration     *services.MigrationService
 }
 
 var runner *Runner
@@ -41,11 +102,36 @@ func GetInstance(config *RunnerInstanceConfig) *Runner {
 		}
 
 		runner = &Runner{
-			Cache:           config.Cache,
-			Docker:          config.Docker,
-			SandboxService:  config.SandboxService,
-			MetricsService:  config.MetricsService,
-			NetRulesManager: config.NetRulesManager,
+			Cache:            config.Cache,
+			Docker:           config.Docker,
+			Backend:          config.Backend,
+			SandboxService:   config.SandboxService,
+			MetricsService:   config.MetricsService,
+			HealthService:    config.HealthService,
+			DrainService:     config.DrainService,
+			IdleService:      config.IdleService,
+			SnapshotGC:       config.SnapshotGC,
+			Prewarm:          config.Prewarm,
+			WarmPool:         config.WarmPool,
+			BuildLogs:        config.BuildLogs,
+			Ports:            config.Ports,
+			SshAccess:        config.SshAccess,
+			ProxyCache:       config.ProxyCache,
+			CleanupService:   config.CleanupService,
+			Events:           config.Events,
+			ProxyTokenIssuer: config.ProxyTokenIssuer,
+			NetRulesManager:  config.NetRulesManager,
+			SandboxNetwork:   config.SandboxNetwork,
+			RateLimiter:      config.RateLimiter,
+			AuditLogger:      config.AuditLogger,
+			Authenticator:    config.Authenticator,
+			AuthPolicy:       config.AuthPolicy,
+			Idempotency:      config.Idempotency,
+			Operations:       config.Operations,
+			Usage:            config.Usage,
+			Labels:           config.Labels,
+			Maintenance:      config.Maintenance,
+			Migration:        config.Migration,
 		}
 	}
 
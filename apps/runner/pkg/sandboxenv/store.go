@@ -0,0 +1,138 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxenv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/daytonaio/runner/internal/util"
+)
+
+// Secret names become file names, leading dots are refused so secrets can't hide from listings
+var secretNameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SandboxEnv holds the variables and secrets set on a sandbox after it was created
+type SandboxEnv struct {
+	Env     map[string]string `json:"env,omitempty"`
+	Secrets map[string]string `json:"secrets,omitempty"`
+}
+
+// Store persists the env of each sandbox in a file only readable by the runner, one file per sandbox
+type Store struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func ValidateEnvKey(key string) error {
+	if !envKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid environment variable name %q", key)
+	}
+	return nil
+}
+
+func ValidateSecretName(name string) error {
+	if !secretNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid secret name %q, only letters, digits, '_', '.' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// Get returns the env of the sandbox, a sandbox without a file has an empty env
+func (s *Store) Get(sandboxId string) (*SandboxEnv, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.read(sandboxId)
+}
+
+// Update applies the change to the env of the sandbox and persists the result
+func (s *Store) Update(sandboxId string, update func(env *SandboxEnv)) (*SandboxEnv, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	env, err := s.read(sandboxId)
+	if err != nil {
+		return nil, err
+	}
+
+	update(env)
+
+	if len(env.Env) == 0 && len(env.Secrets) == 0 {
+		err = os.Remove(s.getFilePath(sandboxId))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return env, nil
+	}
+
+	raw, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(s.dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return env, util.WriteFileAtomic(s.getFilePath(sandboxId), raw, 0600)
+}
+
+func (s *Store) Remove(sandboxId string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.getFilePath(sandboxId))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// read expects the caller to hold the mutex
+func (s *Store) read(sandboxId string) (*SandboxEnv, error) {
+	env := &SandboxEnv{
+		Env:     make(map[string]string),
+		Secrets: make(map[string]string),
+	}
+
+	raw, err := os.ReadFile(s.getFilePath(sandboxId))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return env, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse env of sandbox %s: %w", sandboxId, err)
+	}
+
+	if env.Env == nil {
+		env.Env = make(map[string]string)
+	}
+	if env.Secrets == nil {
+		env.Secrets = make(map[string]string)
+	}
+
+	return env, nil
+}
+
+func (s *Store) getFilePath(sandboxId string) string {
+	return filepath.Join(s.dir, filepath.Base(sandboxId)+".json")
+}
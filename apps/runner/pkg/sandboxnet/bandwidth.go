@@ -0,0 +1,140 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/docker/docker/api/types"
+)
+
+// Matches the peer index in `ip -o link show eth0` output, e.g. "12: eth0@if13: <BROADCAST,..."
+var vethPeerRegex = regexp.MustCompile(`^\d+:\s+eth0@if(\d+):`)
+
+// ApplyBandwidthLimits shapes the traffic of a running sandbox according to its bandwidth labels.
+// The limits are set on the host side of the veth, where the sandbox can't remove them even if it is privileged.
+// Traffic into the sandbox leaves the host veth and is shaped with TBF, traffic out of the sandbox enters it and is policed.
+func (m *Manager) ApplyBandwidthLimits(ctx context.Context, ct *types.ContainerJSON) error {
+	ingressMbps, err := getBandwidthLabel(ct, constants.INGRESS_BANDWIDTH_LABEL)
+	if err != nil {
+		return err
+	}
+
+	egressMbps, err := getBandwidthLabel(ct, constants.EGRESS_BANDWIDTH_LABEL)
+	if err != nil {
+		return err
+	}
+
+	if ingressMbps == 0 && egressMbps == 0 {
+		return nil
+	}
+
+	if ct.State == nil || ct.State.Pid == 0 {
+		return fmt.Errorf("sandbox %s is not running", ct.Name)
+	}
+
+	veth, err := getHostVeth(ctx, ct.State.Pid)
+	if err != nil {
+		return err
+	}
+
+	if ingressMbps > 0 {
+		rate := formatRate(ingressMbps)
+		err = runTc(ctx, "qdisc", "replace", "dev", veth, "root", "tbf", "rate", rate, "burst", formatBurst(ingressMbps), "latency", "50ms")
+		if err != nil {
+			return fmt.Errorf("failed to limit ingress bandwidth: %w", err)
+		}
+	}
+
+	if egressMbps > 0 {
+		err = runTc(ctx, "qdisc", "replace", "dev", veth, "handle", "ffff:", "ingress")
+		if err != nil {
+			return fmt.Errorf("failed to limit egress bandwidth: %w", err)
+		}
+
+		err = runTc(ctx, "filter", "replace", "dev", veth, "parent", "ffff:", "protocol", "all", "prio", "1", "u32", "match", "u32", "0", "0",
+			"police", "rate", formatRate(egressMbps), "burst", formatBurst(egressMbps), "drop", "flowid", ":1")
+		if err != nil {
+			return fmt.Errorf("failed to limit egress bandwidth: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func getBandwidthLabel(ct *types.ContainerJSON, label string) (int64, error) {
+	if ct.Config == nil {
+		return 0, nil
+	}
+
+	value, ok := ct.Config.Labels[label]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	mbps, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s label: %w", label, err)
+	}
+
+	return mbps, nil
+}
+
+// getHostVeth finds the host side of the veth pair by reading the peer index of eth0 in the container network namespace
+func getHostVeth(ctx context.Context, pid int) (string, error) {
+	output, err := exec.CommandContext(ctx, "nsenter", "-t", strconv.Itoa(pid), "-n", "ip", "-o", "link", "show", "eth0").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read sandbox interface: %w", err)
+	}
+
+	matches := vethPeerRegex.FindStringSubmatch(strings.TrimSpace(string(output)))
+	if len(matches) != 2 {
+		return "", fmt.Errorf("unexpected sandbox interface: %s", string(output))
+	}
+
+	interfaces, err := filepath.Glob("/sys/class/net/*/ifindex")
+	if err != nil {
+		return "", err
+	}
+
+	for _, ifindexPath := range interfaces {
+		ifindex, err := os.ReadFile(ifindexPath)
+		if err != nil {
+			continue
+		}
+
+		if strings.TrimSpace(string(ifindex)) == matches[1] {
+			return filepath.Base(filepath.Dir(ifindexPath)), nil
+		}
+	}
+
+	return "", fmt.Errorf("host interface with index %s not found", matches[1])
+}
+
+func runTc(ctx context.Context, args ...string) error {
+	output, err := exec.CommandContext(ctx, "tc", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func formatRate(mbps int64) string {
+	return fmt.Sprintf("%dmbit", mbps)
+}
+
+// formatBurst allows bursts of 100ms at the configured rate but never less than 32KiB
+func formatBurst(mbps int64) string {
+	burst := max(mbps*1000*1000/8/10, 32*1024)
+	return strconv.FormatInt(burst, 10)
+}
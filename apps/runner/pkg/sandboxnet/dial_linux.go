@@ -0,0 +1,67 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// DialInNamespace connects to the address from within the network namespace of the process, so ports a sandbox
+// only listens on at localhost are reachable. The socket stays in the namespace once it is created.
+func DialInNamespace(ctx context.Context, pid int, address string) (net.Conn, error) {
+	sandboxNs, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the network namespace of process %d: %w", pid, err)
+	}
+	defer sandboxNs.Close()
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	resultChan := make(chan dialResult, 1)
+
+	// The namespace is switched on a thread of its own, the thread stays locked and is discarded with the
+	// goroutine if it can't switch back to the namespace of the runner
+	go func() {
+		runtime.LockOSThread()
+
+		hostNs, err := os.Open("/proc/thread-self/ns/net")
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultChan <- dialResult{err: fmt.Errorf("failed to open the network namespace of the runner: %w", err)}
+			return
+		}
+		defer hostNs.Close()
+
+		err = unix.Setns(int(sandboxNs.Fd()), unix.CLONE_NEWNET)
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultChan <- dialResult{err: fmt.Errorf("failed to enter the network namespace of process %d: %w", pid, err)}
+			return
+		}
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+
+		restoreErr := unix.Setns(int(hostNs.Fd()), unix.CLONE_NEWNET)
+		if restoreErr != nil {
+			log.Errorf("Failed to leave the network namespace of process %d: %v", pid, restoreErr)
+		} else {
+			runtime.UnlockOSThread()
+		}
+
+		resultChan <- dialResult{conn: conn, err: err}
+	}()
+
+	result := <-resultChan
+	return result.conn, result.err
+}
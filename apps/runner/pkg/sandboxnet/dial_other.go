@@ -0,0 +1,17 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+//go:build !linux
+
+package sandboxnet
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// DialInNamespace needs network namespaces, the runner itself only runs on Linux
+func DialInNamespace(_ context.Context, _ int, _ string) (net.Conn, error) {
+	return nil, errors.New("dialing into the network namespace of a sandbox is only supported on Linux")
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// Domains in allowlists are re-resolved on this interval so rules follow DNS changes
+const domainRefreshInterval = 5 * time.Minute
+
+type EgressPolicy struct {
+	Mode    enums.EgressPolicyMode
+	Cidrs   []string
+	Domains []string
+}
+
+// ApplyEgressPolicy enforces the policy on outgoing traffic of the sandbox container.
+// The rules are keyed by the short container ID like the rest of the sandbox network rules.
+func (m *Manager) ApplyEgressPolicy(ctx context.Context, sandboxId string, policy EgressPolicy) error {
+	ct, err := m.apiClient.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		return err
+	}
+
+	containerShortId := ct.ID[:12]
+
+	switch policy.Mode {
+	case enums.EgressPolicyModeAllowAll:
+		m.policies.Remove(sandboxId)
+		return m.netRulesManager.DeleteNetworkRules(containerShortId)
+	case enums.EgressPolicyModeDenyAll, enums.EgressPolicyModeAllowList:
+	default:
+		return fmt.Errorf("unknown egress policy mode: %s", policy.Mode)
+	}
+
+	sourceIp := GetContainerIP(ct.NetworkSettings.Networks)
+	if sourceIp == "" {
+		return errors.New("sandbox does not have an IP address")
+	}
+
+	allowList := ""
+	if policy.Mode == enums.EgressPolicyModeAllowList {
+		allowList, err = resolveAllowList(ctx, policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.netRulesManager.SetNetWorkRules(containerShortId, sourceIp, allowList)
+	if err != nil {
+		return err
+	}
+
+	if len(policy.Domains) > 0 {
+		m.policies.Set(sandboxId, policy)
+	} else {
+		m.policies.Remove(sandboxId)
+	}
+
+	return nil
+}
+
+// StartDomainRefresh periodically re-applies allowlists that contain domains
+func (m *Manager) StartDomainRefresh(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(domainRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for sandboxId, policy := range m.policies.Items() {
+					err := m.ApplyEgressPolicy(ctx, sandboxId, policy)
+					if err != nil {
+						log.Warnf("Failed to refresh egress policy of sandbox %s: %v", sandboxId, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// resolveAllowList turns the CIDRs and the IPv4 addresses of the domains into the comma-separated list used by netrules
+func resolveAllowList(ctx context.Context, policy EgressPolicy) (string, error) {
+	networks := make([]string, 0, len(policy.Cidrs)+len(policy.Domains))
+	for _, cidr := range policy.Cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR %s: %w", cidr, err)
+		}
+		networks = append(networks, ipNet.String())
+	}
+
+	for _, domain := range policy.Domains {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, domain)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", domain, err)
+		}
+
+		for _, addr := range addrs {
+			if ip := addr.IP.To4(); ip != nil {
+				networks = append(networks, ip.String()+"/32")
+			}
+		}
+	}
+
+	return strings.Join(networks, ","), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxnet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daytonaio/runner/pkg/netrules"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	cmap "github.com/orcaman/concurrent-map/v2"
+)
+
+// NetworkPrefix is the prefix of the dedicated bridge network of every isolated sandbox
+const NetworkPrefix = "daytona-sb-"
+
+// SANDBOX_NETWORK_LABEL marks a network with the ID of the sandbox that owns it
+const SANDBOX_NETWORK_LABEL = "daytona.sandbox_network"
+
+type ManagerConfig struct {
+	ApiClient       client.APIClient
+	NetRulesManager *netrules.NetRulesManager
+	// Isolation gives every sandbox its own bridge network so sandboxes can't reach each other.
+	// The daemon default-address-pools must hand out small enough subnets for the expected number of sandboxes.
+	Isolation bool
+}
+
+// Manager owns the per-sandbox networks and the egress policies applied to them
+type Manager struct {
+	apiClient       client.APIClient
+	netRulesManager *netrules.NetRulesManager
+	isolation       bool
+	policies        cmap.ConcurrentMap[string, EgressPolicy]
+}
+
+func NewManager(config ManagerConfig) *Manager {
+	return &Manager{
+		apiClient:       config.ApiClient,
+		netRulesManager: config.NetRulesManager,
+		isolation:       config.Isolation,
+		policies:        cmap.New[EgressPolicy](),
+	}
+}
+
+func GetNetworkName(sandboxId string) string {
+	return NetworkPrefix + sandboxId
+}
+
+// EnsureNetwork creates the dedicated network of the sandbox and returns its name.
+// An empty name is returned when isolation is disabled and the sandbox should use the shared network.
+func (m *Manager) EnsureNetwork(ctx context.Context, sandboxId string) (string, error) {
+	if !m.isolation {
+		return "", nil
+	}
+
+	networkName := GetNetworkName(sandboxId)
+
+	_, err := m.apiClient.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if err == nil {
+		return networkName, nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return "", err
+	}
+
+	_, err = m.apiClient.NetworkCreate(ctx, networkName, network.CreateOptions{
+		Driver: "bridge",
+		Options: map[string]string{
+			// There is only one sandbox on the bridge, inter-container traffic is never expected
+			"com.docker.network.bridge.enable_icc": "false",
+		},
+		Labels: map[string]string{
+			SANDBOX_NETWORK_LABEL: sandboxId,
+		},
+	})
+	if err != nil && !errdefs.IsConflict(err) {
+		return "", fmt.Errorf("failed to create sandbox network: %w", err)
+	}
+
+	return networkName, nil
+}
+
+// RemoveNetwork removes the dedicated network of the sandbox if it has one
+func (m *Manager) RemoveNetwork(ctx context.Context, sandboxId string) error {
+	m.policies.Remove(sandboxId)
+
+	if !m.isolation {
+		return nil
+	}
+
+	err := m.apiClient.NetworkRemove(ctx, GetNetworkName(sandboxId))
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to remove sandbox network: %w", err)
+	}
+
+	return nil
+}
+
+// MoveNetwork connects the stopped container to the network of another sandbox and removes the network of the
+// sandbox it belonged to, for a container that is renamed to another sandbox
+func (m *Manager) MoveNetwork(ctx context.Context, containerId string, fromSandboxId string, toSandboxId string) error {
+	if !m.isolation {
+		return nil
+	}
+
+	networkName, err := m.EnsureNetwork(ctx, toSandboxId)
+	if err != nil {
+		return err
+	}
+
+	err = m.apiClient.NetworkConnect(ctx, networkName, containerId, nil)
+	if err != nil && !errdefs.IsConflict(err) {
+		return fmt.Errorf("failed to connect the sandbox network: %w", err)
+	}
+
+	err = m.apiClient.NetworkDisconnect(ctx, GetNetworkName(fromSandboxId), containerId, true)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to disconnect the previous sandbox network: %w", err)
+	}
+
+	return m.RemoveNetwork(ctx, fromSandboxId)
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sandboxnet
+
+import "github.com/docker/docker/api/types/network"
+
+// GetContainerIP returns the address of the container on its first network.
+// Sandboxes on a dedicated network have no address on the default bridge.
+func GetContainerIP(networks map[string]*network.EndpointSettings) string {
+	for _, endpoint := range networks {
+		if endpoint != nil && endpoint.IPAddress != "" {
+			return endpoint.IPAddress
+		}
+	}
+
+	return ""
+}
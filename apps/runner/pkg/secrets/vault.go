@@ -0,0 +1,163 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultReferencePrefix marks config values fetched from Vault, e.g. "vault:secret/data/runner#api_token"
+const VaultReferencePrefix = "vault:"
+
+// ParseVaultReference splits a "vault:<path>#<key>" reference into the secret path and key
+func ParseVaultReference(value string) (string, string, bool, error) {
+	reference, found := strings.CutPrefix(value, VaultReferencePrefix)
+	if !found {
+		return "", "", false, nil
+	}
+
+	secretPath, key, found := strings.Cut(reference, "#")
+	if !found || secretPath == "" || key == "" {
+		return "", "", true, fmt.Errorf("invalid Vault reference %q, expected vault:<path>#<key>", value)
+	}
+
+	return strings.Trim(secretPath, "/"), key, true, nil
+}
+
+// VaultClient reads secrets from Vault KV engines with a Vault token
+type VaultClient struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+}
+
+func NewVaultClient(address string, token string, namespace string) *VaultClient {
+	return &VaultClient{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		namespace: namespace,
+	}
+}
+
+// ReadSecret returns a key of the secret at the API path, e.g. "secret/data/runner" for the KV v2 engine
+// mounted at secret. Secrets of both KV v1 and v2 engines are supported.
+func (v *VaultClient) ReadSecret(ctx context.Context, secretPath string, key string) (string, error) {
+	var response struct {
+		Data map[string]any `json:"data"`
+	}
+
+	err := v.do(ctx, http.MethodGet, "/v1/"+secretPath, nil, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", secretPath, err)
+	}
+
+	data := response.Data
+	// KV v2 nests the secret data next to its metadata
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("Vault secret %s has no %s string key", secretPath, key)
+	}
+
+	return value, nil
+}
+
+// RenewToken extends the lease of the Vault token and returns the new lease duration
+func (v *VaultClient) RenewToken(ctx context.Context) (time.Duration, error) {
+	var response struct {
+		Auth struct {
+			LeaseDuration int  `json:"lease_duration"`
+			Renewable     bool `json:"renewable"`
+		} `json:"auth"`
+	}
+
+	err := v.do(ctx, http.MethodPost, "/v1/auth/token/renew-self", map[string]any{}, &response)
+	if err != nil {
+		return 0, fmt.Errorf("failed to renew Vault token: %w", err)
+	}
+
+	if !response.Auth.Renewable {
+		return 0, errors.New("Vault token is not renewable")
+	}
+
+	return time.Duration(response.Auth.LeaseDuration) * time.Second, nil
+}
+
+// StartRenewal renews the Vault token on every interval so it doesn't expire while the runner is up
+func (v *VaultClient) StartRenewal(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				leaseDuration, err := v.RenewToken(ctx)
+				if err != nil {
+					log.Error(err)
+					continue
+				}
+
+				if leaseDuration > 0 && leaseDuration < interval {
+					log.Warnf("Vault token lease of %s is shorter than the renewal interval of %s", leaseDuration, interval)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (v *VaultClient) do(ctx context.Context, method string, path string, body any, response any) error {
+	var requestBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, v.address+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault request failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package securityprofile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Matches the profile declaration, e.g. "profile daytona-strict flags=(attach_disconnected) {"
+var appArmorProfileRegex = regexp.MustCompile(`(?m)^\s*profile\s+("[^"]+"|[^\s{]+)`)
+
+// parseAppArmorName returns the name the AppArmor profile is loaded as, Docker references it by that name
+func parseAppArmorName(source string) (string, error) {
+	matches := appArmorProfileRegex.FindAllStringSubmatch(source, -1)
+	if len(matches) == 0 {
+		return "", errors.New("invalid AppArmor profile: no profile declaration found")
+	}
+
+	// Hats and child profiles are nested in the first profile, only the first one is applied to the container
+	return strings.Trim(matches[0][1], `"`), nil
+}
+
+func (s *Store) loadAppArmor(ctx context.Context, source string) error {
+	return s.runAppArmorParser(ctx, "--replace", source)
+}
+
+func (s *Store) runAppArmorParser(ctx context.Context, action string, source string) error {
+	parserPath, err := exec.LookPath(s.apparmorParserPath)
+	if err != nil {
+		return fmt.Errorf("AppArmor is not available on this runner: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	// The profile is read from stdin so no copy of it is left outside of the store
+	cmd := exec.CommandContext(ctx, parserPath, action)
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("apparmor_parser %s failed: %w: %s", action, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
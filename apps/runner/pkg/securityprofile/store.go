@@ -0,0 +1,284 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package securityprofile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/util"
+)
+
+// Profile names become file names, leading dots are refused so profiles can't hide from listings
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+var (
+	ErrExists   = errors.New("already exists")
+	ErrNotFound = errors.New("security profile not found")
+)
+
+// Profile holds the seccomp and AppArmor policies sandboxes reference by name on create
+type Profile struct {
+	Name string `json:"name"`
+	// Seccomp is the seccomp profile in the JSON format of Docker
+	Seccomp string `json:"seccomp,omitempty"`
+	// AppArmor is the source of the AppArmor profile and AppArmorName the name it is loaded into the kernel as
+	AppArmor     string    `json:"appArmor,omitempty"`
+	AppArmorName string    `json:"appArmorName,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Store persists the security profiles uploaded to the runner, one file per profile, and keeps their AppArmor
+// profiles loaded into the kernel
+type Store struct {
+	dir                string
+	apparmorParserPath string
+	mutex              sync.Mutex
+}
+
+func NewStore(dir string, apparmorParserPath string) *Store {
+	if apparmorParserPath == "" {
+		apparmorParserPath = "apparmor_parser"
+	}
+
+	return &Store{
+		dir:                dir,
+		apparmorParserPath: apparmorParserPath,
+	}
+}
+
+func ValidateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("invalid security profile name %q, only letters, digits, '_', '.' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// Validate checks the name and the policies of the profile and sets the name its AppArmor profile is loaded as
+func Validate(profile *Profile) error {
+	err := ValidateName(profile.Name)
+	if err != nil {
+		return err
+	}
+
+	if profile.Seccomp == "" && profile.AppArmor == "" {
+		return errors.New("a security profile needs a seccomp or an AppArmor profile")
+	}
+
+	if profile.Seccomp != "" {
+		err = validateSeccomp(profile.Seccomp)
+		if err != nil {
+			return err
+		}
+	}
+
+	if profile.AppArmor != "" {
+		profile.AppArmorName, err = parseAppArmorName(profile.AppArmor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add validates the profile, loads its AppArmor profile and persists it. Profiles are never replaced since
+// sandboxes created with the previous version would keep its seccomp policy.
+func (s *Store) Add(ctx context.Context, profile *Profile) error {
+	err := Validate(profile)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	profiles, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range profiles {
+		if existing.Name == profile.Name {
+			return fmt.Errorf("security profile %s %w", profile.Name, ErrExists)
+		}
+
+		// Deleting either profile would unload the AppArmor profile of the other
+		if profile.AppArmorName != "" && existing.AppArmorName == profile.AppArmorName {
+			return fmt.Errorf("AppArmor profile %s of security profile %s %w", profile.AppArmorName, existing.Name, ErrExists)
+		}
+	}
+
+	if profile.AppArmor != "" {
+		err = s.loadAppArmor(ctx, profile.AppArmor)
+		if err != nil {
+			return err
+		}
+	}
+
+	profile.CreatedAt = time.Now()
+
+	raw, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(s.getPath(profile.Name), raw, 0600)
+}
+
+// Get returns the profile, ErrNotFound if it doesn't exist
+func (s *Store) Get(name string) (*Profile, error) {
+	err := ValidateName(name)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.read(name)
+}
+
+// List returns the profiles sorted by name
+func (s *Store) List() ([]Profile, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.list()
+}
+
+// Delete unloads the AppArmor profile of the profile and removes it
+func (s *Store) Delete(ctx context.Context, name string) error {
+	err := ValidateName(name)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	profile, err := s.read(name)
+	if err != nil {
+		return err
+	}
+
+	if profile.AppArmor != "" {
+		err = s.runAppArmorParser(ctx, "--remove", profile.AppArmor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(s.getPath(name))
+}
+
+// LoadAppArmorProfiles loads the AppArmor profiles of all profiles into the kernel, they don't survive a reboot
+func (s *Store) LoadAppArmorProfiles(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	profiles, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, profile := range profiles {
+		if profile.AppArmor == "" {
+			continue
+		}
+
+		err = s.loadAppArmor(ctx, profile.AppArmor)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("security profile %s: %w", profile.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// list expects the caller to hold the mutex
+func (s *Store) list() ([]Profile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Profile{}, nil
+		}
+		return nil, err
+	}
+
+	profiles := make([]Profile, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		profile, err := s.read(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+// read expects the caller to hold the mutex
+func (s *Store) read(name string) (*Profile, error) {
+	raw, err := os.ReadFile(s.getPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	var profile Profile
+	err = json.Unmarshal(raw, &profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse security profile %s: %w", name, err)
+	}
+
+	return &profile, nil
+}
+
+func (s *Store) getPath(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name)+".json")
+}
+
+func validateSeccomp(seccomp string) error {
+	var profile struct {
+		DefaultAction string `json:"defaultAction"`
+	}
+
+	err := json.Unmarshal([]byte(seccomp), &profile)
+	if err != nil {
+		return fmt.Errorf("invalid seccomp profile: %w", err)
+	}
+
+	if profile.DefaultAction == "" {
+		return errors.New("invalid seccomp profile: defaultAction is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+// rotatedBuildLogSuffix marks the previous generation of a rotated build log
+const rotatedBuildLogSuffix = ".1"
+
+// activeBuildLogPeriod protects logs written to recently from the total size limit, their build may still be running
+const activeBuildLogPeriod = 10 * time.Minute
+
+var ErrBuildLogNotArchived = errors.New("build log is not archived")
+
+type BuildLogServiceConfig struct {
+	Dir string
+	// Interval between retention runs, 0 disables rotation and retention
+	Interval time.Duration
+	// MaxSize is the size a build log is rotated at
+	MaxSize int64
+	// MaxAge removes logs not written to for longer than this
+	MaxAge time.Duration
+	// MaxTotalSize removes the oldest logs once all logs together are larger than this, 0 means unlimited
+	MaxTotalSize int64
+	// Archive uploads completed logs to object storage, logs are also uploaded before retention removes them
+	Archive      bool
+	ObjectPrefix string
+}
+
+// BuildLogService rotates the build logs, removes old ones and archives them to object storage
+type BuildLogService struct {
+	dir          string
+	interval     time.Duration
+	maxSize      int64
+	maxAge       time.Duration
+	maxTotalSize int64
+	archive      bool
+	objectPrefix string
+	// ctx outlives the request whose build completed
+	ctx context.Context
+}
+
+type buildLogFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func NewBuildLogService(config BuildLogServiceConfig) *BuildLogService {
+	return &BuildLogService{
+		dir:          config.Dir,
+		interval:     config.Interval,
+		maxSize:      config.MaxSize,
+		maxAge:       config.MaxAge,
+		maxTotalSize: config.MaxTotalSize,
+		archive:      config.Archive,
+		objectPrefix: config.ObjectPrefix,
+		ctx:          context.Background(),
+	}
+}
+
+func (s *BuildLogService) StartRetention(ctx context.Context) {
+	s.ctx = ctx
+
+	if s.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := s.enforceRetention(ctx)
+				if err != nil {
+					log.Errorf("Build log retention failed: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Archive uploads the build log of the snapshot in the background once its build or push completed
+func (s *BuildLogService) Archive(snapshotRef string) {
+	if !s.archive {
+		return
+	}
+
+	logPath, err := config.GetBuildLogPath(snapshotRef)
+	if err != nil {
+		log.Warnf("Failed to archive build log of %s: %v", snapshotRef, err)
+		return
+	}
+
+	go func() {
+		err := s.upload(s.ctx, logPath)
+		if err != nil {
+			log.Warnf("Failed to archive build log of %s: %v", snapshotRef, err)
+		}
+	}()
+}
+
+// OpenArchived opens the archived build log of the snapshot, ErrBuildLogNotArchived is returned if
+// archival is disabled
+func (s *BuildLogService) OpenArchived(ctx context.Context, snapshotRef string) (io.ReadCloser, error) {
+	if !s.archive {
+		return nil, ErrBuildLogNotArchived
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return storageClient.GetObjectStream(ctx, path.Join(s.objectPrefix, config.GetBuildLogId(snapshotRef)))
+}
+
+// enforceRetention rotates logs larger than the maximum size, then removes logs older than the maximum
+// age and finally the oldest logs until the total size fits
+func (s *BuildLogService) enforceRetention(ctx context.Context) error {
+	files, err := s.listLogs()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if s.maxSize <= 0 || file.size <= s.maxSize || strings.HasSuffix(file.path, rotatedBuildLogSuffix) {
+			continue
+		}
+
+		// The previous generation is replaced, archive it first
+		if s.archive {
+			err = s.upload(ctx, file.path+rotatedBuildLogSuffix)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Warnf("Failed to archive build log %s, not rotating it: %v", file.path+rotatedBuildLogSuffix, err)
+				continue
+			}
+		}
+
+		// A build still writing keeps writing to the rotated log through its open file
+		err = os.Rename(file.path, file.path+rotatedBuildLogSuffix)
+		if err != nil {
+			log.Warnf("Failed to rotate build log %s: %v", file.path, err)
+			continue
+		}
+		log.Debugf("Rotated build log %s (%d bytes)", file.path, file.size)
+	}
+
+	files, err = s.listLogs()
+	if err != nil {
+		return err
+	}
+
+	// Oldest first
+	slices.SortFunc(files, func(a, b buildLogFile) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	var totalSize int64
+	for _, file := range files {
+		totalSize += file.size
+	}
+
+	removed := 0
+	for _, file := range files {
+		expired := s.maxAge > 0 && time.Since(file.modTime) > s.maxAge
+		overLimit := s.maxTotalSize > 0 && totalSize > s.maxTotalSize && time.Since(file.modTime) > activeBuildLogPeriod
+		if !expired && !overLimit {
+			continue
+		}
+
+		if s.archive {
+			err = s.upload(ctx, file.path)
+			if err != nil {
+				log.Warnf("Failed to archive build log %s, keeping it: %v", file.path, err)
+				continue
+			}
+		}
+
+		err = os.Remove(file.path)
+		if err != nil {
+			log.Warnf("Failed to remove build log %s: %v", file.path, err)
+			continue
+		}
+
+		totalSize -= file.size
+		removed++
+	}
+
+	if removed > 0 {
+		log.Infof("Build log retention removed %d logs", removed)
+	}
+
+	return nil
+}
+
+func (s *BuildLogService) listLogs() ([]buildLogFile, error) {
+	var files []buildLogFile
+
+	err := filepath.WalkDir(s.dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+
+		files = append(files, buildLogFile{path: filePath, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+
+	return files, err
+}
+
+// upload stores the log below the object prefix at its path relative to the build log directory
+func (s *BuildLogService) upload(ctx context.Context, logPath string) error {
+	relativePath, err := filepath.Rel(s.dir, logPath)
+	if err != nil {
+		return err
+	}
+
+	storageClient, err := storage.GetObjectStorageClient()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(logPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return storageClient.PutObjectStream(ctx, path.Join(s.objectPrefix, filepath.ToSlash(relativePath)), file, info.Size())
+}
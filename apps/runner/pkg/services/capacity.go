@@ -0,0 +1,55 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"syscall"
+
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models"
+)
+
+// GetHostCapacity returns the host totals and Docker engine details the control plane schedules against.
+// Current allocations are served from the cached system metrics.
+func (m *MetricsService) GetHostCapacity(ctx context.Context) (*models.HostCapacity, error) {
+	info, err := m.docker.ApiClient().Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker info: %w", err)
+	}
+
+	var stat syscall.Statfs_t
+	err = syscall.Statfs("/", &stat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat root filesystem: %w", err)
+	}
+
+	runtimes := make([]string, 0, len(info.Runtimes))
+	for name := range info.Runtimes {
+		runtimes = append(runtimes, name)
+	}
+	sort.Strings(runtimes)
+
+	architecture := info.Architecture
+	if architecture == "" {
+		architecture = runtime.GOARCH
+	}
+
+	return &models.HostCapacity{
+		TotalCpu:        int64(info.NCPU),
+		TotalMemoryGiB:  info.MemTotal / (1024 * 1024 * 1024),
+		TotalDiskGiB:    int64(stat.Blocks * uint64(stat.Bsize) / (1024 * 1024 * 1024)),
+		DockerVersion:   info.ServerVersion,
+		DefaultRuntime:  info.DefaultRuntime,
+		Runtimes:        runtimes,
+		Architecture:    architecture,
+		OperatingSystem: info.OperatingSystem,
+		KernelVersion:   info.KernelVersion,
+		Rootless:        m.docker.Rootless(),
+		UsernsRemap:     docker.IsUsernsRemapEngine(info),
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/docker"
+)
+
+type CleanupServiceConfig struct {
+	Docker *docker.DockerClient
+	// Interval between automatic runs, 0 disables the background loop
+	Interval time.Duration
+	// Policies applied by automatic runs and the defaults of manual runs
+	Policies CleanupPolicies
+}
+
+// CleanupPolicies selects what a cleanup run removes
+type CleanupPolicies struct {
+	// ExitedContainerMaxAge removes sandboxes that exited longer ago than this, 0 keeps them
+	ExitedContainerMaxAge time.Duration
+	DanglingVolumes       bool
+	SandboxNetworks       bool
+}
+
+// CleanupService removes the exited sandboxes, volumes and networks long-lived runners accumulate
+type CleanupService struct {
+	docker   *docker.DockerClient
+	interval time.Duration
+	policies CleanupPolicies
+	// Only one run at a time, a manual run waits for a background run to finish
+	mutex sync.Mutex
+}
+
+func NewCleanupService(config CleanupServiceConfig) *CleanupService {
+	return &CleanupService{
+		docker:   config.Docker,
+		interval: config.Interval,
+		policies: config.Policies,
+	}
+}
+
+func (s *CleanupService) StartCleanup(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				result, err := s.Run(ctx, s.policies, false)
+				if err != nil {
+					log.Errorf("Cleanup failed: %v", err)
+					continue
+				}
+
+				if len(result.Containers) > 0 || len(result.Volumes) > 0 || len(result.Networks) > 0 {
+					log.Infof("Cleanup removed %d sandboxes, %d volumes and %d networks", len(result.Containers), len(result.Volumes), len(result.Networks))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Run applies the policies once, a dry run only reports what would be removed
+func (s *CleanupService) Run(ctx context.Context, policies CleanupPolicies, dryRun bool) (*dto.CleanupResponseDTO, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.docker.Cleanup(ctx, docker.CleanupOptions{
+		ExitedContainerMaxAge: policies.ExitedContainerMaxAge,
+		DanglingVolumes:       policies.DanglingVolumes,
+		SandboxNetworks:       policies.SandboxNetworks,
+		DryRun:                dryRun,
+	})
+}
+
+// Policies returns the configured policies
+func (s *CleanupService) Policies() CleanupPolicies {
+	return s.policies
+}
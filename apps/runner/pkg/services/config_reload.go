@@ -0,0 +1,150 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/daytonaio/runner/pkg/apitoken"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/logging"
+	"github.com/daytonaio/runner/pkg/ratelimit"
+	"github.com/sirupsen/logrus"
+)
+
+const filePollInterval = 10 * time.Second
+
+type ConfigReloadServiceConfig struct {
+	// EnvFilePath is re-read on every reload, a change to the file triggers a reload
+	EnvFilePath string
+	// ConfigFilePath is re-read by config.Load, a change to the file triggers a reload
+	ConfigFilePath string
+	Docker         *docker.DockerClient
+	RateLimiter    *ratelimit.Limiter
+	SnapshotGC     *SnapshotGCService
+	Authenticator  *apitoken.Authenticator
+}
+
+// ConfigReloadService applies changes to the reloadable settings on SIGHUP or when the env or config file changes:
+// the log levels, rate limits, snapshot GC settings, registry mirrors and the API token, which is fetched
+// from Vault again if it references a Vault secret. Other settings need a restart.
+type ConfigReloadService struct {
+	envFilePath    string
+	configFilePath string
+	docker         *docker.DockerClient
+	rateLimiter    *ratelimit.Limiter
+	snapshotGC     *SnapshotGCService
+	authenticator  *apitoken.Authenticator
+
+	envFileModTime    time.Time
+	configFileModTime time.Time
+}
+
+func NewConfigReloadService(config ConfigReloadServiceConfig) *ConfigReloadService {
+	return &ConfigReloadService{
+		envFilePath:       config.EnvFilePath,
+		configFilePath:    config.ConfigFilePath,
+		docker:            config.Docker,
+		rateLimiter:       config.RateLimiter,
+		snapshotGC:        config.SnapshotGC,
+		authenticator:     config.Authenticator,
+		envFileModTime:    getModTime(config.EnvFilePath),
+		configFileModTime: getModTime(config.ConfigFilePath),
+	}
+}
+
+func (s *ConfigReloadService) StartWatching(ctx context.Context) {
+	go func() {
+		hangupChannel := make(chan os.Signal, 1)
+		signal.Notify(hangupChannel, syscall.SIGHUP)
+		defer signal.Stop(hangupChannel)
+
+		ticker := time.NewTicker(filePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-hangupChannel:
+			case <-ticker.C:
+				envFileModTime := getModTime(s.envFilePath)
+				configFileModTime := getModTime(s.configFilePath)
+				if envFileModTime.Equal(s.envFileModTime) && configFileModTime.Equal(s.configFileModTime) {
+					continue
+				}
+				s.envFileModTime = envFileModTime
+				s.configFileModTime = configFileModTime
+			case <-ctx.Done():
+				return
+			}
+
+			err := s.Reload()
+			if err != nil {
+				log.Errorf("Failed to reload configuration, keeping the current settings: %v", err)
+				continue
+			}
+
+			log.Info("Configuration reloaded")
+		}
+	}()
+}
+
+// Reload re-reads the configuration and applies the reloadable settings. Nothing is applied if the
+// configuration is invalid.
+func (s *ConfigReloadService) Reload() error {
+	err := config.LoadEnvFile(s.envFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	logLevel := logging.GetLevel()
+	if cfg.LogLevel != "" {
+		logLevel, err = logrus.ParseLevel(cfg.LogLevel)
+		if err != nil {
+			return err
+		}
+	}
+
+	moduleLevels, err := logging.ParseModuleLevels(cfg.LogModuleLevels)
+	if err != nil {
+		return err
+	}
+
+	rateLimiterConfig, err := ratelimit.ParseConfig(cfg.RateLimit, cfg.RateLimitBurst, cfg.RateLimitRoutes)
+	if err != nil {
+		return err
+	}
+
+	err = s.authenticator.SetApiToken(cfg.ApiToken)
+	if err != nil {
+		return err
+	}
+
+	logging.SetLevel(logLevel)
+	logging.SetModuleLevels(moduleLevels)
+	s.rateLimiter.SetConfig(rateLimiterConfig)
+	s.snapshotGC.SetConfig(cfg.SnapshotGCInterval, cfg.SnapshotGCMinAge, cfg.SnapshotGCKeepList)
+	s.docker.SetRegistryMirrors(cfg.RegistryMirrors, cfg.PullThroughCacheUrl)
+
+	return nil
+}
+
+func getModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	daemonProbeInterval = 15 * time.Second
+	daemonProbeTimeout  = 5 * time.Second
+	// A single slow answer, e.g. while the sandbox is under heavy load, doesn't restart the daemon
+	daemonFailureThreshold = 3
+	// Restarts of a daemon that keeps crashing back off up to this
+	maxDaemonRestartBackoff = 5 * time.Minute
+	// A daemon that stays healthy this long after a restart gets the initial backoff again
+	daemonStableAfter = 10 * time.Minute
+)
+
+type supervisedDaemon struct {
+	failures     int
+	restarts     int
+	nextRestart  time.Time
+	healthySince time.Time
+}
+
+type DaemonSupervisorConfig struct {
+	Cache  cache.IRunnerCache
+	Docker *docker.DockerClient
+}
+
+// DaemonSupervisor probes the daemon of every started sandbox, restarts daemons that stopped answering and
+// records the result as the daemon health of the sandbox
+type DaemonSupervisor struct {
+	cache  cache.IRunnerCache
+	docker *docker.DockerClient
+
+	mutex   sync.Mutex
+	daemons map[string]*supervisedDaemon
+}
+
+func NewDaemonSupervisor(config DaemonSupervisorConfig) *DaemonSupervisor {
+	return &DaemonSupervisor{
+		cache:   config.Cache,
+		docker:  config.Docker,
+		daemons: make(map[string]*supervisedDaemon),
+	}
+}
+
+func (s *DaemonSupervisor) StartSupervision(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(daemonProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.probeDaemons(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *DaemonSupervisor) probeDaemons(ctx context.Context) {
+	containers, err := s.docker.ApiClient().ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", constants.ORGANIZATION_ID_LABEL)),
+	})
+	if err != nil {
+		log.Errorf("Failed to list sandboxes for daemon supervision: %v", err)
+		return
+	}
+
+	running := make(map[string]bool, len(containers))
+	var wg sync.WaitGroup
+	for _, ct := range containers {
+		// Sidecars and compose services don't run a daemon of their own
+		if len(ct.Names) == 0 || ct.Labels[constants.SIDECAR_OF_LABEL] != "" || ct.Labels[constants.COMPOSE_SANDBOX_LABEL] != "" {
+			continue
+		}
+
+		sandboxId := strings.TrimPrefix(ct.Names[0], "/")
+
+		// Starting, stopping and paused sandboxes are expected to have no answering daemon
+		data := s.cache.Get(ctx, sandboxId)
+		if data == nil || data.SandboxState != enums.SandboxStateStarted {
+			continue
+		}
+		running[sandboxId] = true
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.probeDaemon(ctx, sandboxId)
+		}()
+	}
+	wg.Wait()
+
+	// Forget daemons of sandboxes that are no longer started so a restarted sandbox starts with a clean record
+	s.mutex.Lock()
+	var forgotten []string
+	for sandboxId := range s.daemons {
+		if !running[sandboxId] {
+			delete(s.daemons, sandboxId)
+			forgotten = append(forgotten, sandboxId)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, sandboxId := range forgotten {
+		s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthUnknown)
+	}
+}
+
+func (s *DaemonSupervisor) probeDaemon(ctx context.Context, sandboxId string) {
+	probeCtx, cancel := context.WithTimeout(ctx, daemonProbeTimeout)
+	err := s.docker.ProbeDaemon(probeCtx, sandboxId)
+	cancel()
+
+	now := time.Now()
+
+	s.mutex.Lock()
+	daemon, ok := s.daemons[sandboxId]
+	if !ok {
+		daemon = &supervisedDaemon{}
+		s.daemons[sandboxId] = daemon
+	}
+
+	if err == nil {
+		if daemon.healthySince.IsZero() {
+			daemon.healthySince = now
+		}
+		if daemon.restarts > 0 && now.Sub(daemon.healthySince) >= daemonStableAfter {
+			daemon.restarts = 0
+		}
+		daemon.failures = 0
+		s.mutex.Unlock()
+
+		s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthHealthy)
+		return
+	}
+
+	daemon.failures++
+	daemon.healthySince = time.Time{}
+	restart := daemon.failures >= daemonFailureThreshold && !now.Before(daemon.nextRestart)
+	if restart {
+		daemon.restarts++
+		daemon.nextRestart = now.Add(getDaemonRestartBackoff(daemon.restarts))
+	}
+	s.mutex.Unlock()
+
+	log.Debugf("Daemon of sandbox %s failed probe: %v", sandboxId, err)
+
+	if !restart {
+		s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthUnhealthy)
+		return
+	}
+
+	log.Warnf("Restarting the daemon of sandbox %s after %d failed probes: %v", sandboxId, daemonFailureThreshold, err)
+	s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthRestarting)
+
+	err = s.docker.RestartDaemon(ctx, sandboxId)
+	if err != nil {
+		log.Errorf("Failed to restart the daemon of sandbox %s: %v", sandboxId, err)
+		common.ContainerOperationCount.WithLabelValues("daemon_restart", string(common.PrometheusOperationStatusFailure)).Inc()
+		s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthUnhealthy)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("daemon_restart", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	s.mutex.Lock()
+	daemon.failures = 0
+	daemon.healthySince = time.Now()
+	s.mutex.Unlock()
+
+	s.cache.SetDaemonHealth(ctx, sandboxId, enums.DaemonHealthHealthy)
+}
+
+func getDaemonRestartBackoff(restarts int) time.Duration {
+	backoff := daemonProbeInterval
+	for i := 1; i < restarts && backoff < maxDaemonRestartBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxDaemonRestartBackoff)
+}
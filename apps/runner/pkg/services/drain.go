@@ -0,0 +1,64 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DrainService tracks in-flight operations so the runner can stop accepting new work
+// and wait for running operations to finish before shutting down
+type DrainService struct {
+	draining atomic.Bool
+	inFlight atomic.Int64
+}
+
+func NewDrainService() *DrainService {
+	return &DrainService{}
+}
+
+func (s *DrainService) Drain() {
+	if s.draining.CompareAndSwap(false, true) {
+		log.Infof("Runner draining, %d operations in flight", s.inFlight.Load())
+	}
+}
+
+func (s *DrainService) IsDraining() bool {
+	return s.draining.Load()
+}
+
+func (s *DrainService) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+// StartOperation registers an in-flight operation, the returned function must be called once it finishes
+func (s *DrainService) StartOperation() func() {
+	s.inFlight.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.inFlight.Add(-1)
+		})
+	}
+}
+
+// Wait blocks until all in-flight operations finish or the context is done
+func (s *DrainService) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for s.inFlight.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
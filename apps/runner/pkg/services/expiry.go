@@ -0,0 +1,133 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/backend"
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const expiryCheckInterval = 30 * time.Second
+
+type ExpiryServiceConfig struct {
+	Cache   cache.IRunnerCache
+	Backend backend.Backend
+	Events  *events.Broker
+	// WarningLeadTime is how long before the expiry the warning event is published
+	WarningLeadTime time.Duration
+}
+
+// ExpiryService stops or destroys sandboxes whose TTL elapsed. Expirations are kept in the cache
+// so they survive runner restarts with the file cache.
+type ExpiryService struct {
+	cache           cache.IRunnerCache
+	backend         backend.Backend
+	events          *events.Broker
+	warningLeadTime time.Duration
+}
+
+func NewExpiryService(config ExpiryServiceConfig) *ExpiryService {
+	return &ExpiryService{
+		cache:           config.Cache,
+		backend:         config.Backend,
+		events:          config.Events,
+		warningLeadTime: config.WarningLeadTime,
+	}
+}
+
+func (s *ExpiryService) StartExpiryScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(expiryCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.handleExpirations(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *ExpiryService) handleExpirations(ctx context.Context) {
+	now := time.Now()
+
+	for _, sandboxId := range s.cache.List(ctx) {
+		data := s.cache.Get(ctx, sandboxId)
+		if data == nil || data.Expiration == nil {
+			continue
+		}
+
+		if data.SandboxState == enums.SandboxStateDestroyed || data.SandboxState == enums.SandboxStateDestroying {
+			s.cache.SetExpiration(ctx, sandboxId, nil)
+			continue
+		}
+
+		expiration := *data.Expiration
+
+		if !now.Before(expiration.ExpiresAt) {
+			s.expire(ctx, sandboxId, expiration.Action)
+			continue
+		}
+
+		if !expiration.Warned && now.Add(s.warningLeadTime).After(expiration.ExpiresAt) {
+			s.events.Publish(events.Event{
+				Type:      enums.EventTypeSandboxExpiring,
+				SandboxId: sandboxId,
+				Message:   fmt.Sprintf("sandbox TTL elapses at %s, the sandbox will be %s", expiration.ExpiresAt.UTC().Format(time.RFC3339), getExpiryActionVerb(expiration.Action)),
+			})
+
+			expiration.Warned = true
+			s.cache.SetExpiration(ctx, sandboxId, &expiration)
+		}
+	}
+}
+
+// expire applies the TTL action, failures are retried on the next check
+func (s *ExpiryService) expire(ctx context.Context, sandboxId string, action enums.ExpiryAction) {
+	log.Infof("TTL of sandbox %s elapsed, the sandbox will be %s", sandboxId, getExpiryActionVerb(action))
+
+	var err error
+	operation := "ttl_destroy"
+	if action == enums.ExpiryActionStop {
+		operation = "ttl_stop"
+		_, err = s.backend.Stop(ctx, sandboxId, dto.StopSandboxDTO{})
+	} else {
+		err = s.backend.Destroy(ctx, sandboxId)
+	}
+
+	if err != nil {
+		log.Errorf("Failed to apply the TTL of sandbox %s: %v", sandboxId, err)
+		common.ContainerOperationCount.WithLabelValues(operation, string(common.PrometheusOperationStatusFailure)).Inc()
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues(operation, string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	s.cache.SetExpiration(ctx, sandboxId, nil)
+
+	s.events.Publish(events.Event{
+		Type:      enums.EventTypeSandboxExpired,
+		SandboxId: sandboxId,
+		State:     action.String(),
+	})
+}
+
+func getExpiryActionVerb(action enums.ExpiryAction) string {
+	if action == enums.ExpiryActionStop {
+		return "stopped"
+	}
+
+	return "destroyed"
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/models"
+	"github.com/docker/docker/api/types/container"
+)
+
+// GetGPUs lists the NVIDIA GPUs on the host and marks the ones assigned to running sandboxes.
+// Hosts without nvidia-smi report no GPUs.
+func (m *MetricsService) GetGPUs(ctx context.Context) ([]models.GPUInfo, error) {
+	_, err := exec.LookPath("nvidia-smi")
+	if err != nil {
+		return []models.GPUInfo{}, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "nvidia-smi", "--query-gpu=index,uuid,name,memory.total", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GPUs: %w", err)
+	}
+
+	var gpus []models.GPUInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output: %s", line)
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GPU index: %w", err)
+		}
+
+		memoryMiB, err := strconv.ParseInt(strings.TrimSpace(fields[3]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GPU memory: %w", err)
+		}
+
+		gpus = append(gpus, models.GPUInfo{
+			Index:     index,
+			Uuid:      strings.TrimSpace(fields[1]),
+			Name:      strings.TrimSpace(fields[2]),
+			MemoryMiB: memoryMiB,
+		})
+	}
+
+	allocated, err := m.getAllocatedGPUDeviceIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range gpus {
+		gpus[i].Allocated = allocated[gpus[i].Uuid] || allocated[strconv.Itoa(gpus[i].Index)]
+	}
+
+	return gpus, nil
+}
+
+// getAllocatedGPUDeviceIds returns the device IDs requested by running sandboxes.
+// Count based requests are not pinned to a device so they are not included.
+func (m *MetricsService) getAllocatedGPUDeviceIds(ctx context.Context) (map[string]bool, error) {
+	containers, err := m.docker.ApiClient().ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	allocated := make(map[string]bool)
+	for _, containerItem := range containers {
+		info, err := m.docker.ContainerInspect(ctx, containerItem.ID)
+		if err != nil || info.HostConfig == nil {
+			continue
+		}
+
+		for _, deviceRequest := range info.HostConfig.DeviceRequests {
+			for _, deviceId := range deviceRequest.DeviceIDs {
+				allocated[deviceId] = true
+			}
+		}
+	}
+
+	return allocated, nil
+}
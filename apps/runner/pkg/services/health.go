@@ -0,0 +1,122 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const (
+	HealthComponentDocker = "docker"
+	HealthComponentCache  = "cache"
+	HealthComponentProxy  = "proxy"
+	HealthComponentRunner = "runner"
+)
+
+// System metrics are refreshed every 20 seconds, allow a few missed collections before reporting the cache as unhealthy
+const maxSystemMetricsAge = 2 * time.Minute
+
+const healthCheckTimeout = 3 * time.Second
+
+type ComponentHealth struct {
+	Status  enums.HealthStatus
+	Message string
+}
+
+type HealthService struct {
+	cache  cache.IRunnerCache
+	docker *docker.DockerClient
+	drain  *DrainService
+}
+
+func NewHealthService(cache cache.IRunnerCache, docker *docker.DockerClient, drain *DrainService) *HealthService {
+	return &HealthService{
+		cache:  cache,
+		docker: docker,
+		drain:  drain,
+	}
+}
+
+// Check returns the health of every runner component
+func (h *HealthService) Check(ctx context.Context) map[string]ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	dockerHealth := toComponentHealth(h.checkDocker(ctx))
+
+	// The toolbox proxy resolves sandbox targets through the Docker API, so it can only serve while Docker is reachable
+	proxyHealth := dockerHealth
+	if proxyHealth.Status != enums.HealthStatusServing {
+		proxyHealth.Message = fmt.Sprintf("sandbox targets cannot be resolved: %s", dockerHealth.Message)
+	}
+
+	return map[string]ComponentHealth{
+		HealthComponentDocker: dockerHealth,
+		HealthComponentCache:  toComponentHealth(h.checkCache(ctx)),
+		HealthComponentProxy:  proxyHealth,
+		HealthComponentRunner: toComponentHealth(h.checkRunner()),
+	}
+}
+
+// Overall reduces component health to the runner health, the runner serves only when all components do
+func Overall(components map[string]ComponentHealth) ComponentHealth {
+	for _, name := range []string{HealthComponentRunner, HealthComponentDocker, HealthComponentCache, HealthComponentProxy} {
+		if health := components[name]; health.Status != enums.HealthStatusServing {
+			return ComponentHealth{
+				Status:  enums.HealthStatusNotServing,
+				Message: fmt.Sprintf("%s: %s", name, health.Message),
+			}
+		}
+	}
+
+	return ComponentHealth{Status: enums.HealthStatusServing}
+}
+
+func (h *HealthService) checkRunner() error {
+	if h.drain.IsDraining() {
+		return errors.New("runner is draining")
+	}
+
+	return nil
+}
+
+func (h *HealthService) checkDocker(ctx context.Context) error {
+	_, err := h.docker.ApiClient().Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("docker daemon unreachable: %w", err)
+	}
+
+	return nil
+}
+
+func (h *HealthService) checkCache(ctx context.Context) error {
+	metrics := h.cache.GetSystemMetrics(ctx)
+	if metrics == nil {
+		return errors.New("system metrics not collected yet")
+	}
+
+	if age := time.Since(metrics.LastUpdated); age > maxSystemMetricsAge {
+		return fmt.Errorf("system metrics last updated %s ago", age.Round(time.Second))
+	}
+
+	return nil
+}
+
+func toComponentHealth(err error) ComponentHealth {
+	if err != nil {
+		return ComponentHealth{
+			Status:  enums.HealthStatusNotServing,
+			Message: err.Error(),
+		}
+	}
+
+	return ComponentHealth{Status: enums.HealthStatusServing}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/backend"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	idleCheckInterval = 1 * time.Minute
+	// Network traffic below this amount per check interval (e.g. DNS or keepalives) does not count as activity
+	idleNetworkThresholdBytes = 4 * 1024
+)
+
+type sandboxActivity struct {
+	lastActivity time.Time
+	networkBytes uint64
+}
+
+// IdleService stops sandboxes that had no proxy, exec or network activity for longer than
+// the idle timeout they were created with
+type IdleService struct {
+	docker   *docker.DockerClient
+	backend  backend.Backend
+	mutex    sync.Mutex
+	activity map[string]*sandboxActivity
+}
+
+func NewIdleService(docker *docker.DockerClient, backend backend.Backend) *IdleService {
+	return &IdleService{
+		docker:   docker,
+		backend:  backend,
+		activity: make(map[string]*sandboxActivity),
+	}
+}
+
+// RecordActivity marks the sandbox as active now
+func (s *IdleService) RecordActivity(sandboxId string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	a, ok := s.activity[sandboxId]
+	if !ok {
+		a = &sandboxActivity{}
+		s.activity[sandboxId] = a
+	}
+	a.lastActivity = time.Now()
+}
+
+func (s *IdleService) StartIdleDetection(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(idleCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.stopIdleSandboxes(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *IdleService) stopIdleSandboxes(ctx context.Context) {
+	containers, err := s.docker.ApiClient().ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", constants.IDLE_TIMEOUT_LABEL)),
+	})
+	if err != nil {
+		log.Errorf("Failed to list sandboxes for idle detection: %v", err)
+		return
+	}
+
+	running := make(map[string]bool, len(containers))
+	for _, ct := range containers {
+		running[ct.ID] = true
+
+		idleTimeoutMinutes, err := strconv.Atoi(ct.Labels[constants.IDLE_TIMEOUT_LABEL])
+		if err != nil || idleTimeoutMinutes <= 0 {
+			continue
+		}
+
+		idleFor := s.refreshActivity(ctx, ct.ID)
+		if idleFor < time.Duration(idleTimeoutMinutes)*time.Minute {
+			continue
+		}
+
+		log.Infof("Stopping sandbox %s after being idle for %s", ct.ID, idleFor.Round(time.Second))
+
+		_, err = s.backend.Stop(ctx, ct.ID, dto.StopSandboxDTO{})
+		if err != nil {
+			log.Errorf("Failed to stop idle sandbox %s: %v", ct.ID, err)
+			common.ContainerOperationCount.WithLabelValues("idle_stop", string(common.PrometheusOperationStatusFailure)).Inc()
+			continue
+		}
+
+		common.ContainerOperationCount.WithLabelValues("idle_stop", string(common.PrometheusOperationStatusSuccess)).Inc()
+		running[ct.ID] = false
+	}
+
+	// Forget sandboxes that are no longer running so a restarted sandbox gets a fresh idle window
+	s.mutex.Lock()
+	for sandboxId := range s.activity {
+		if !running[sandboxId] {
+			delete(s.activity, sandboxId)
+		}
+	}
+	s.mutex.Unlock()
+}
+
+// refreshActivity records network traffic since the last check as activity and returns how long the sandbox has been idle
+func (s *IdleService) refreshActivity(ctx context.Context, containerId string) time.Duration {
+	networkBytes, err := s.getNetworkBytes(ctx, containerId)
+	if err != nil {
+		log.Debugf("Failed to get network stats for sandbox %s: %v", containerId, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	a, ok := s.activity[containerId]
+	if !ok {
+		// Sandboxes seen for the first time (e.g. after a runner restart) start their idle window now
+		a = &sandboxActivity{lastActivity: time.Now(), networkBytes: networkBytes}
+		s.activity[containerId] = a
+		return 0
+	}
+
+	if err == nil {
+		if networkBytes >= a.networkBytes && networkBytes-a.networkBytes > idleNetworkThresholdBytes {
+			a.lastActivity = time.Now()
+		}
+		a.networkBytes = networkBytes
+	}
+
+	return time.Since(a.lastActivity)
+}
+
+func (s *IdleService) getNetworkBytes(ctx context.Context, containerId string) (uint64, error) {
+	response, err := s.docker.ApiClient().ContainerStatsOneShot(ctx, containerId)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	var stats container.StatsResponse
+	err = json.NewDecoder(response.Body).Decode(&stats)
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, network := range stats.Networks {
+		total += network.RxBytes + network.TxBytes
+	}
+
+	return total, nil
+}
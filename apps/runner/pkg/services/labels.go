@@ -0,0 +1,100 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+// LABEL_GPU tells whether the runner has GPUs, it is derived from the detected GPUs unless it is configured
+const LABEL_GPU = "gpu"
+
+// RunnerLabels holds the key/value labels of the runner the control plane schedules sandboxes with. Creates are
+// admitted only if the runner has the labels the sandbox requires.
+type RunnerLabels struct {
+	labels map[string]string
+}
+
+// NewRunnerLabels adds the derived labels to the configured ones, configured labels take precedence
+func NewRunnerLabels(configured map[string]string, hasGpus bool) *RunnerLabels {
+	labels := map[string]string{
+		LABEL_GPU: strconv.FormatBool(hasGpus),
+	}
+	maps.Copy(labels, configured)
+
+	return &RunnerLabels{
+		labels: labels,
+	}
+}
+
+// Get returns a copy of the labels
+func (l *RunnerLabels) Get() map[string]string {
+	if l == nil {
+		return nil
+	}
+
+	return maps.Clone(l.labels)
+}
+
+// Admit returns a ConstraintNotSatisfied error if the runner lacks a label the sandbox requires. Requesting GPUs
+// requires the gpu=true label. A required label with an empty value only has to be present.
+func (l *RunnerLabels) Admit(sandboxDto dto.CreateSandboxDTO) error {
+	if l == nil {
+		return nil
+	}
+
+	required := maps.Clone(sandboxDto.RequiredLabels)
+	if sandboxDto.GpuQuota > 0 || len(sandboxDto.GpuDeviceIds) > 0 {
+		if required == nil {
+			required = make(map[string]string)
+		}
+		if _, ok := required[LABEL_GPU]; !ok {
+			required[LABEL_GPU] = "true"
+		}
+	}
+
+	// Sorted so the same label is reported for the same request
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := l.labels[key]
+		if !ok {
+			return common.NewConstraintNotSatisfiedError(key, required[key], "")
+		}
+
+		if required[key] != "" && value != required[key] {
+			return common.NewConstraintNotSatisfiedError(key, required[key], value)
+		}
+	}
+
+	return nil
+}
+
+// ParseRunnerLabels parses labels in the "key=value" format, e.g. "region=eu-west"
+func ParseRunnerLabels(entries []string) (map[string]string, error) {
+	labels := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid runner label %q, labels have the key=value format", entry)
+		}
+
+		labels[key] = strings.TrimSpace(value)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+const maintenanceCheckInterval = 10 * time.Second
+
+type MaintenanceServiceConfig struct {
+	Cache  cache.IRunnerCache
+	Events *events.Broker
+	Drain  *DrainService
+	// NotifyLeadTime is how long before a window starts the sandboxes are notified unless the window sets its
+	// own, defaults to 15 minutes
+	NotifyLeadTime time.Duration
+}
+
+type MaintenanceWindow struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// Drain drains the runner once the window starts, e.g. before the host is replaced. Draining lasts until the
+	// runner restarts, also after the window ended.
+	Drain bool
+	// NotifyBefore overrides the notify lead time of the service
+	NotifyBefore time.Duration
+	Notified     bool
+}
+
+// MaintenanceService refuses new sandboxes during the scheduled maintenance window of the runner and notifies the
+// sandboxes ahead of it. Only one window is scheduled at a time and it is kept in memory, the control plane schedules
+// it again if the runner restarts before the window.
+type MaintenanceService struct {
+	cache          cache.IRunnerCache
+	events         *events.Broker
+	drain          *DrainService
+	notifyLeadTime time.Duration
+
+	mutex  sync.Mutex
+	window *MaintenanceWindow
+}
+
+func NewMaintenanceService(config MaintenanceServiceConfig) *MaintenanceService {
+	notifyLeadTime := config.NotifyLeadTime
+	if notifyLeadTime <= 0 {
+		notifyLeadTime = 15 * time.Minute
+	}
+
+	return &MaintenanceService{
+		cache:          config.Cache,
+		events:         config.Events,
+		drain:          config.Drain,
+		notifyLeadTime: notifyLeadTime,
+	}
+}
+
+func (s *MaintenanceService) StartScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(maintenanceCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Schedule replaces the scheduled window, sandboxes notified of the previous one are notified again
+func (s *MaintenanceService) Schedule(ctx context.Context, window MaintenanceWindow) error {
+	if !window.EndsAt.After(window.StartsAt) {
+		return common.NewBadRequestError(errors.New("the maintenance window must end after it starts"))
+	}
+
+	if !window.EndsAt.After(time.Now()) {
+		return common.NewBadRequestError(errors.New("the maintenance window already ended"))
+	}
+
+	if window.NotifyBefore <= 0 {
+		window.NotifyBefore = s.notifyLeadTime
+	}
+	window.Notified = false
+
+	s.mutex.Lock()
+	s.window = &window
+	s.mutex.Unlock()
+
+	log.Infof("Maintenance scheduled from %s to %s", window.StartsAt.UTC().Format(time.RFC3339), window.EndsAt.UTC().Format(time.RFC3339))
+
+	s.check(ctx)
+
+	return nil
+}
+
+// Cancel removes the scheduled window and returns false if there was none. A drain the window started is not undone.
+func (s *MaintenanceService) Cancel(ctx context.Context) bool {
+	s.mutex.Lock()
+	window := s.window
+	s.window = nil
+	s.mutex.Unlock()
+
+	if window == nil {
+		return false
+	}
+
+	log.Infof("Maintenance from %s to %s cancelled", window.StartsAt.UTC().Format(time.RFC3339), window.EndsAt.UTC().Format(time.RFC3339))
+
+	if window.Notified {
+		s.notify(ctx, enums.EventTypeMaintenanceCancel, "the runner maintenance was cancelled")
+	}
+
+	return true
+}
+
+// GetWindow returns a copy of the scheduled window, nil if there is none
+func (s *MaintenanceService) GetWindow() *MaintenanceWindow {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.window == nil {
+		return nil
+	}
+
+	window := *s.window
+	return &window
+}
+
+// Admit returns a MaintenanceInProgress error while a window is in progress
+func (s *MaintenanceService) Admit() error {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if s.window == nil || now.Before(s.window.StartsAt) || !now.Before(s.window.EndsAt) {
+		return nil
+	}
+
+	return common.NewMaintenanceInProgressError(s.window.EndsAt, s.window.Reason)
+}
+
+func (s *MaintenanceService) check(ctx context.Context) {
+	now := time.Now()
+
+	s.mutex.Lock()
+	window := s.window
+	if window == nil {
+		s.mutex.Unlock()
+		return
+	}
+
+	if !now.Before(window.EndsAt) {
+		s.window = nil
+		s.mutex.Unlock()
+
+		log.Infof("Maintenance window ended at %s", window.EndsAt.UTC().Format(time.RFC3339))
+		return
+	}
+
+	notify := !window.Notified && !now.Before(window.StartsAt.Add(-window.NotifyBefore))
+	window.Notified = window.Notified || notify
+	started := !now.Before(window.StartsAt)
+	s.mutex.Unlock()
+
+	if notify {
+		message := fmt.Sprintf("runner maintenance is scheduled from %s to %s, new sandboxes are refused during it", window.StartsAt.UTC().Format(time.RFC3339), window.EndsAt.UTC().Format(time.RFC3339))
+		if window.Drain {
+			message += " and the runner drains once it starts"
+		}
+		if window.Reason != "" {
+			message += ": " + window.Reason
+		}
+
+		s.notify(ctx, enums.EventTypeSandboxMaintenance, message)
+	}
+
+	if started && window.Drain && !s.drain.IsDraining() {
+		log.Infof("Maintenance window started, draining the runner")
+		s.drain.Drain()
+	}
+}
+
+// notify publishes the event to every sandbox of the runner
+func (s *MaintenanceService) notify(ctx context.Context, eventType enums.EventType, message string) {
+	for _, sandboxId := range s.cache.List(ctx) {
+		data := s.cache.Get(ctx, sandboxId)
+		if data == nil || data.SandboxState == enums.SandboxStateDestroyed || data.SandboxState == enums.SandboxStateDestroying {
+			continue
+		}
+
+		s.events.Publish(events.Event{
+			Type:      eventType,
+			SandboxId: sandboxId,
+			Message:   message,
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/backend"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// Proxy requests for migrated sandboxes are redirected to the destination runner this long, by then the control
+// plane routes them to the destination itself
+const migrationForwardRetention = time.Hour
+
+// How long a single wait for the import on the destination runner is held open
+const migrationWaitTimeout = 5 * time.Minute
+
+type MigrationServiceConfig struct {
+	Backend backend.Backend
+	Ports   *PortService
+	Labels  *RunnerLabels
+	// AllowInsecure permits plain HTTP destination runners, intended for development only
+	AllowInsecure bool
+}
+
+type migrationForward struct {
+	url        string
+	migratedAt time.Time
+}
+
+// MigrationService moves sandboxes between runners through object storage. The source runner exports the sandbox,
+// the destination runner imports it and takes over its exposed ports, then the source destroys its copy and
+// redirects the proxy requests that still reach it.
+type MigrationService struct {
+	backend       backend.Backend
+	ports         *PortService
+	labels        *RunnerLabels
+	allowInsecure bool
+	client        *http.Client
+
+	mutex    sync.Mutex
+	forwards map[string]migrationForward
+}
+
+func NewMigrationService(config MigrationServiceConfig) *MigrationService {
+	return &MigrationService{
+		backend:       config.Backend,
+		ports:         config.Ports,
+		labels:        config.Labels,
+		allowInsecure: config.AllowInsecure,
+		// Waits for the destination are bounded by migrationWaitTimeout
+		client:   &http.Client{Timeout: migrationWaitTimeout + 30*time.Second},
+		forwards: make(map[string]migrationForward),
+	}
+}
+
+// Migrate exports the sandbox and, if a destination is set, hands it over to the destination runner and destroys it
+// on this runner. The sandbox is resumed here if the destination fails to take it over.
+func (s *MigrationService) Migrate(ctx context.Context, sandboxId string, migrateDto dto.MigrateSandboxDTO) (string, error) {
+	var destination *url.URL
+	if migrateDto.Destination != nil {
+		var err error
+		destination, err = url.Parse(migrateDto.Destination.Url)
+		if err != nil {
+			return "", common.NewBadRequestError(fmt.Errorf("invalid destination URL: %w", err))
+		}
+
+		if destination.Scheme != "https" && !(s.allowInsecure && destination.Scheme == "http") {
+			return "", common.NewBadRequestError(errors.New("destination URL must use https"))
+		}
+	}
+
+	ports := make([]dto.ExposePortDTO, 0)
+	for _, exposed := range s.ports.List(sandboxId) {
+		ports = append(ports, dto.ExposePortDTO{
+			Port:        exposed.Port,
+			AccessLevel: string(exposed.AccessLevel),
+			Desktop:     exposed.Desktop,
+		})
+	}
+
+	migration, err := s.backend.ExportSandbox(ctx, sandboxId, migrateDto.ObjectPath, migrateDto.Live, ports)
+	if err != nil {
+		return "", err
+	}
+
+	if destination == nil {
+		return migrateDto.ObjectPath, nil
+	}
+
+	err = s.handOver(ctx, destination, migrateDto.Destination.Token, sandboxId, migrateDto.ObjectPath)
+	if err != nil {
+		s.backend.ResumeExportedSandbox(context.WithoutCancel(ctx), sandboxId, migration)
+		return "", fmt.Errorf("destination runner failed to take over sandbox %s: %w", sandboxId, err)
+	}
+
+	s.mutex.Lock()
+	s.forwards[sandboxId] = migrationForward{url: destination.String(), migratedAt: time.Now()}
+	s.mutex.Unlock()
+
+	// The sandbox runs on the destination already, a failed cleanup is left to the orphan reconciliation
+	err = s.backend.Destroy(context.WithoutCancel(ctx), sandboxId)
+	if err != nil {
+		log.Errorf("Failed to destroy sandbox %s after migrating it to %s: %v", sandboxId, destination, err)
+	}
+
+	log.Infof("Sandbox %s migrated to %s", sandboxId, destination)
+
+	return destination.String(), nil
+}
+
+// Receive imports a sandbox another runner exported for migration and exposes its ports again
+func (s *MigrationService) Receive(ctx context.Context, sandboxId string, receiveDto dto.ReceiveMigrationDTO) (string, error) {
+	migration, err := s.backend.ReadSandboxMigration(ctx, receiveDto.ObjectPath)
+	if err != nil {
+		return "", err
+	}
+
+	if migration.Sandbox.Id != sandboxId {
+		return "", common.NewBadRequestError(fmt.Errorf("%s holds sandbox %s, not %s", receiveDto.ObjectPath, migration.Sandbox.Id, sandboxId))
+	}
+
+	err = s.labels.Admit(migration.Sandbox)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.backend.ImportSandbox(ctx, receiveDto.ObjectPath, migration)
+	if err != nil {
+		return "", err
+	}
+
+	for _, port := range migration.Ports {
+		_, err = s.ports.Expose(sandboxId, port.Port, enums.PreviewAccessLevel(port.AccessLevel), port.Desktop)
+		if err != nil {
+			log.Errorf("Failed to expose port %d of migrated sandbox %s: %v", port.Port, sandboxId, err)
+		}
+	}
+
+	s.mutex.Lock()
+	delete(s.forwards, sandboxId)
+	s.mutex.Unlock()
+
+	return sandboxId, nil
+}
+
+// GetForward returns the URL of the runner the sandbox was migrated to
+func (s *MigrationService) GetForward(sandboxId string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	forward, ok := s.forwards[sandboxId]
+	if !ok {
+		return "", false
+	}
+
+	if time.Since(forward.migratedAt) > migrationForwardRetention {
+		delete(s.forwards, sandboxId)
+		return "", false
+	}
+
+	return forward.url, true
+}
+
+// handOver starts the import on the destination runner and waits until it finished
+func (s *MigrationService) handOver(ctx context.Context, destination *url.URL, token string, sandboxId string, objectPath string) error {
+	var operation dto.OperationDTO
+	err := s.request(ctx, http.MethodPost, destination.JoinPath("sandboxes", sandboxId, "migrate", "receive").String(), token, dto.ReceiveMigrationDTO{
+		ObjectPath: objectPath,
+	}, &operation)
+	if err != nil {
+		return err
+	}
+
+	waitUrl := destination.JoinPath("operations", operation.Id, "wait")
+	waitUrl.RawQuery = url.Values{"timeout": {migrationWaitTimeout.String()}}.Encode()
+
+	for operation.State == enums.OperationStateRunning.String() {
+		err = s.request(ctx, http.MethodGet, waitUrl.String(), token, nil, &operation)
+		if err != nil {
+			return err
+		}
+	}
+
+	if operation.State != enums.OperationStateSucceeded.String() {
+		return fmt.Errorf("import %s: %s", operation.State, operation.Error)
+	}
+
+	return nil
+}
+
+func (s *MigrationService) request(ctx context.Context, method string, url string, token string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(rawBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResponse common.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errorResponse) == nil && errorResponse.Message != "" {
+			return fmt.Errorf("destination runner responded with status %d: %s", resp.StatusCode, errorResponse.Message)
+		}
+
+		return fmt.Errorf("destination runner responded with status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+// Finished operations are kept this long for clients polling them
+const operationRetention = time.Hour
+
+type operation struct {
+	status         dto.OperationDTO
+	organizationId string
+	cancel         context.CancelFunc
+	done           chan struct{}
+	finishedAt     time.Time
+}
+
+// OperationService runs long operations in the background so requests don't have to be held open for minutes,
+// clients poll or wait for the operation instead
+type OperationService struct {
+	mutex      sync.Mutex
+	operations map[string]*operation
+	drain      *DrainService
+}
+
+func NewOperationService(drain *DrainService) *OperationService {
+	return &OperationService{
+		operations: make(map[string]*operation),
+		drain:      drain,
+	}
+}
+
+// Start runs the operation detached from the cancellation of ctx, CancelOperation cancels it instead. The result
+// is reported as the result of the operation. The operation is in flight until it finishes, so a draining runner
+// waits for it.
+func (s *OperationService) Start(ctx context.Context, operationType enums.OperationType, target string, organizationId string, run func(ctx context.Context) (string, error)) (dto.OperationDTO, error) {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return dto.OperationDTO{}, err
+	}
+
+	operationCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	op := &operation{
+		status: dto.OperationDTO{
+			Id:        hex.EncodeToString(id),
+			Type:      operationType.String(),
+			State:     enums.OperationStateRunning.String(),
+			Target:    target,
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		},
+		organizationId: organizationId,
+		cancel:         cancel,
+		done:           make(chan struct{}),
+	}
+
+	s.mutex.Lock()
+	s.operations[op.status.Id] = op
+	s.mutex.Unlock()
+
+	finishOperation := s.drain.StartOperation()
+
+	log.Infof("Started %s operation %s for %s", operationType, op.status.Id, target)
+
+	go func() {
+		defer finishOperation()
+		defer cancel()
+
+		result, err := run(operationCtx)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		op.finishedAt = time.Now()
+		op.status.FinishedAt = op.finishedAt.UTC().Format(time.RFC3339)
+		switch {
+		case err == nil:
+			op.status.State = enums.OperationStateSucceeded.String()
+			op.status.Result = result
+		case errors.Is(err, context.Canceled) || operationCtx.Err() != nil:
+			op.status.State = enums.OperationStateCancelled.String()
+			op.status.Error = err.Error()
+		default:
+			op.status.State = enums.OperationStateFailed.String()
+			op.status.Error = err.Error()
+			op.status.ErrorCode = common.GetErrorCode(err)
+		}
+		close(op.done)
+
+		log.Infof("%s operation %s for %s %s", operationType, op.status.Id, target, op.status.State)
+	}()
+
+	return op.status, nil
+}
+
+// Get returns the operation, operations of other organizations are not found
+func (s *OperationService) Get(operationId string, organizationId string) (dto.OperationDTO, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	op, err := s.get(operationId, organizationId)
+	if err != nil {
+		return dto.OperationDTO{}, err
+	}
+
+	return op.status, nil
+}
+
+// Wait returns the operation once it finished or the timeout elapsed, whichever comes first
+func (s *OperationService) Wait(ctx context.Context, operationId string, organizationId string, timeout time.Duration) (dto.OperationDTO, error) {
+	s.mutex.Lock()
+	op, err := s.get(operationId, organizationId)
+	s.mutex.Unlock()
+	if err != nil {
+		return dto.OperationDTO{}, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-op.done:
+	case <-timer.C:
+	case <-ctx.Done():
+		return dto.OperationDTO{}, ctx.Err()
+	}
+
+	return s.Get(operationId, organizationId)
+}
+
+// Cancel cancels a running operation, it is cancelled once the work in progress was aborted
+func (s *OperationService) Cancel(operationId string, organizationId string) (dto.OperationDTO, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	op, err := s.get(operationId, organizationId)
+	if err != nil {
+		return dto.OperationDTO{}, err
+	}
+
+	if op.status.State != enums.OperationStateRunning.String() {
+		return dto.OperationDTO{}, common.NewConflictError(fmt.Errorf("operation %s already %s", operationId, op.status.State))
+	}
+
+	op.cancel()
+
+	log.Infof("Cancelling %s operation %s for %s", op.status.Type, operationId, op.status.Target)
+
+	return op.status, nil
+}
+
+func (s *OperationService) get(operationId string, organizationId string) (*operation, error) {
+	op, ok := s.operations[operationId]
+	if !ok || (organizationId != "" && op.organizationId != organizationId) {
+		return nil, common.NewNotFoundError(fmt.Errorf("operation %s not found", operationId))
+	}
+
+	return op, nil
+}
+
+func (s *OperationService) StartCleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(operationRetention / 4)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.removeFinished()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *OperationService) removeFinished() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for operationId, op := range s.operations {
+		if !op.finishedAt.IsZero() && time.Since(op.finishedAt) > operationRetention {
+			delete(s.operations, operationId)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+type ExposedPort struct {
+	Port        int                      `json:"port"`
+	AccessLevel enums.PreviewAccessLevel `json:"accessLevel,omitempty"`
+	Desktop     bool                     `json:"desktop,omitempty"`
+	ExposedAt   time.Time                `json:"exposedAt"`
+}
+
+type PortServiceConfig struct {
+	// FilePath persists the exposed ports so they survive runner restarts
+	FilePath string
+	Events   *events.Broker
+}
+
+// PortService keeps the sandbox ports the proxy routes to. Ports are routed as soon as they are exposed,
+// the sandbox container isn't touched.
+type PortService struct {
+	filePath string
+	events   *events.Broker
+
+	mutex sync.RWMutex
+	ports map[string][]ExposedPort
+}
+
+func NewPortService(config PortServiceConfig) (*PortService, error) {
+	s := &PortService{
+		filePath: config.FilePath,
+		events:   config.Events,
+		ports:    make(map[string][]ExposedPort),
+	}
+
+	raw, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, &s.ports)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// StartCleanup drops the ports of sandboxes once they are destroyed
+func (s *PortService) StartCleanup(ctx context.Context) {
+	eventChan, unsubscribe := s.events.Subscribe()
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				if event.Type != enums.EventTypeSandboxStateChanged || event.State != enums.SandboxStateDestroyed.String() {
+					continue
+				}
+
+				err := s.RemoveSandbox(event.SandboxId)
+				if err != nil {
+					log.Warnf("Failed to remove exposed ports of sandbox %s: %v", event.SandboxId, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Expose routes the port of the sandbox through the proxy with the access level, exposing a port again only
+// changes its access level and desktop flag
+func (s *PortService) Expose(sandboxId string, port int, accessLevel enums.PreviewAccessLevel, desktop bool) (ExposedPort, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.ports[sandboxId]
+	ports := slices.Clone(previous)
+
+	index := slices.IndexFunc(ports, func(exposed ExposedPort) bool {
+		return exposed.Port == port
+	})
+	if index >= 0 && ports[index].AccessLevel == accessLevel && ports[index].Desktop == desktop {
+		return withDefaultAccessLevel(ports[index]), nil
+	}
+
+	if index >= 0 {
+		ports[index].AccessLevel = accessLevel
+		ports[index].Desktop = desktop
+	} else {
+		ports = append(ports, ExposedPort{Port: port, AccessLevel: accessLevel, Desktop: desktop, ExposedAt: time.Now().UTC()})
+		index = len(ports) - 1
+	}
+	exposed := ports[index]
+	s.ports[sandboxId] = ports
+
+	err := s.persist()
+	if err != nil {
+		s.ports[sandboxId] = previous
+		return ExposedPort{}, err
+	}
+
+	return withDefaultAccessLevel(exposed), nil
+}
+
+// Unexpose stops routing the port and reports whether it was exposed
+func (s *PortService) Unexpose(sandboxId string, port int) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.ports[sandboxId]
+	index := slices.IndexFunc(previous, func(exposed ExposedPort) bool {
+		return exposed.Port == port
+	})
+	if index < 0 {
+		return false, nil
+	}
+
+	s.ports[sandboxId] = slices.Delete(slices.Clone(previous), index, index+1)
+	if len(s.ports[sandboxId]) == 0 {
+		delete(s.ports, sandboxId)
+	}
+
+	err := s.persist()
+	if err != nil {
+		s.ports[sandboxId] = previous
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *PortService) IsExposed(sandboxId string, port int) bool {
+	_, ok := s.Get(sandboxId, port)
+	return ok
+}
+
+func (s *PortService) Get(sandboxId string, port int) (ExposedPort, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, exposed := range s.ports[sandboxId] {
+		if exposed.Port == port {
+			return withDefaultAccessLevel(exposed), true
+		}
+	}
+
+	return ExposedPort{}, false
+}
+
+// List returns the exposed ports of the sandbox sorted by port
+func (s *PortService) List(sandboxId string) []ExposedPort {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ports := make([]ExposedPort, 0, len(s.ports[sandboxId]))
+	for _, exposed := range s.ports[sandboxId] {
+		ports = append(ports, withDefaultAccessLevel(exposed))
+	}
+	slices.SortFunc(ports, func(a, b ExposedPort) int {
+		return a.Port - b.Port
+	})
+
+	return ports
+}
+
+func (s *PortService) RemoveSandbox(sandboxId string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, ok := s.ports[sandboxId]
+	if !ok {
+		return nil
+	}
+
+	delete(s.ports, sandboxId)
+
+	err := s.persist()
+	if err != nil {
+		s.ports[sandboxId] = previous
+		return err
+	}
+
+	return nil
+}
+
+// persist writes the ports to the file, the caller must hold the write lock
+func (s *PortService) persist() error {
+	raw, err := json.Marshal(s.ports)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(s.filePath, raw, 0600)
+}
+
+// withDefaultAccessLevel fills in the access level of ports exposed before access levels existed
+func withDefaultAccessLevel(exposed ExposedPort) ExposedPort {
+	if exposed.AccessLevel == "" {
+		exposed.AccessLevel = enums.PreviewAccessLevelAuthenticated
+	}
+	return exposed
+}
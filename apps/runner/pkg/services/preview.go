@@ -0,0 +1,45 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// GetPreviewHost returns the hostname of the preview of the sandbox port, <port>-<sandboxId>.<domain>.
+// The domain needs a wildcard DNS record pointing at the runner.
+func GetPreviewHost(sandboxId string, port int, domain string) string {
+	return fmt.Sprintf("%d-%s.%s", port, strings.ToLower(sandboxId), strings.ToLower(domain))
+}
+
+// ParsePreviewHost returns the sandbox and port a preview hostname, optionally with a port, belongs to
+func ParsePreviewHost(host string, domain string) (string, int, bool) {
+	if domain == "" {
+		return "", 0, false
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	label, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(domain))
+	if !ok || strings.Contains(label, ".") {
+		return "", 0, false
+	}
+
+	portLabel, sandboxId, ok := strings.Cut(label, "-")
+	if !ok || sandboxId == "" {
+		return "", 0, false
+	}
+
+	port, err := strconv.Atoi(portLabel)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, false
+	}
+
+	return sandboxId, port, true
+}
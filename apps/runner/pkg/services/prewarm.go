@@ -0,0 +1,171 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+type PrewarmServiceConfig struct {
+	Docker *docker.DockerClient
+	Pins   *SnapshotPins
+}
+
+// PrewarmService pulls snapshots ahead of the sandboxes that use them and pins them so garbage
+// collection keeps them until they are unpinned
+type PrewarmService struct {
+	docker *docker.DockerClient
+	pins   *SnapshotPins
+	// ctx outlives the request that started a prewarm
+	ctx context.Context
+
+	mutex    sync.Mutex
+	statuses map[string]*dto.PrewarmSnapshotStatusDTO
+}
+
+func NewPrewarmService(config PrewarmServiceConfig) *PrewarmService {
+	return &PrewarmService{
+		docker:   config.Docker,
+		pins:     config.Pins,
+		ctx:      context.Background(),
+		statuses: make(map[string]*dto.PrewarmSnapshotStatusDTO),
+	}
+}
+
+// StartPrewarm pulls the pinned snapshots that are missing locally, e.g. after the Docker data was wiped.
+// Registry credentials aren't persisted with the pins so these pulls are anonymous.
+func (s *PrewarmService) StartPrewarm(ctx context.Context) {
+	s.ctx = ctx
+
+	for _, snapshot := range s.pins.List() {
+		exists, err := s.docker.ImageExists(ctx, snapshot, true)
+		if err != nil {
+			log.Warnf("Failed to check pinned snapshot %s: %v", snapshot, err)
+			continue
+		}
+
+		if !exists {
+			s.start(snapshot, nil, "")
+		}
+	}
+}
+
+// Prewarm pins the snapshots and pulls them in the background, the returned statuses are the
+// initial ones
+func (s *PrewarmService) Prewarm(snapshots []string, reg *dto.RegistryDTO, platform string) ([]dto.PrewarmSnapshotStatusDTO, error) {
+	if _, err := docker.ParsePlatform(platform); err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		ref, err := NormalizeSnapshotRef(snapshot)
+		if err != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid snapshot %s: %w", snapshot, err))
+		}
+		normalized = append(normalized, ref)
+	}
+
+	statuses := make([]dto.PrewarmSnapshotStatusDTO, 0, len(normalized))
+	for _, snapshot := range normalized {
+		// Pinned before the pull so garbage collection can't remove the snapshot right after it arrives
+		err := s.pins.Pin(snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pin snapshot %s: %w", snapshot, err)
+		}
+
+		statuses = append(statuses, s.start(snapshot, reg, platform))
+	}
+
+	return statuses, nil
+}
+
+// Unpin makes the snapshot eligible for garbage collection again and reports whether it was pinned
+func (s *PrewarmService) Unpin(snapshot string) (bool, error) {
+	ref, err := NormalizeSnapshotRef(snapshot)
+	if err != nil {
+		return false, common.NewBadRequestError(fmt.Errorf("invalid snapshot %s: %w", snapshot, err))
+	}
+
+	return s.pins.Unpin(ref)
+}
+
+// Status returns the progress of the prewarms since the runner started and the remaining pins
+func (s *PrewarmService) Status() []dto.PrewarmSnapshotStatusDTO {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := []dto.PrewarmSnapshotStatusDTO{}
+	for _, snapshot := range s.pins.List() {
+		status, ok := s.statuses[snapshot]
+		if !ok {
+			result = append(result, dto.PrewarmSnapshotStatusDTO{Snapshot: snapshot, Pinned: true})
+			continue
+		}
+
+		result = append(result, s.withPin(status))
+	}
+
+	// Snapshots unpinned after their prewarm keep reporting it
+	for snapshot, status := range s.statuses {
+		if !s.pins.IsPinned(snapshot) {
+			result = append(result, s.withPin(status))
+		}
+	}
+
+	return result
+}
+
+// start pulls the snapshot in the background unless a pull of it is already running
+func (s *PrewarmService) start(snapshot string, reg *dto.RegistryDTO, platform string) dto.PrewarmSnapshotStatusDTO {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status, ok := s.statuses[snapshot]
+	if ok && status.State == enums.SnapshotOperationStateStarted.String() {
+		return s.withPin(status)
+	}
+
+	status = &dto.PrewarmSnapshotStatusDTO{
+		Snapshot:  snapshot,
+		State:     enums.SnapshotOperationStateStarted.String(),
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	s.statuses[snapshot] = status
+
+	go func() {
+		err := s.docker.PullImage(s.ctx, snapshot, reg, platform)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		status.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+		if err != nil {
+			log.Warnf("Failed to prewarm snapshot %s: %v", snapshot, err)
+			status.State = enums.SnapshotOperationStateFailed.String()
+			status.Error = err.Error()
+			return
+		}
+
+		log.Infof("Prewarmed snapshot %s", snapshot)
+		status.State = enums.SnapshotOperationStateCompleted.String()
+	}()
+
+	return s.withPin(status)
+}
+
+// withPin copies the status with the current pin, the caller must hold the mutex
+func (s *PrewarmService) withPin(status *dto.PrewarmSnapshotStatusDTO) dto.PrewarmSnapshotStatusDTO {
+	result := *status
+	result.Pinned = s.pins.IsPinned(status.Snapshot)
+	return result
+}
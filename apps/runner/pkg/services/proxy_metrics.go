@@ -0,0 +1,41 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StartProxyMetricsCleanup drops the proxy metric series of sandboxes once they are destroyed so the
+// number of series doesn't grow with every sandbox the runner ever served
+func StartProxyMetricsCleanup(ctx context.Context, broker *events.Broker) {
+	eventChan, unsubscribe := broker.Subscribe()
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				if event.Type != enums.EventTypeSandboxStateChanged || event.State != enums.SandboxStateDestroyed.String() {
+					continue
+				}
+
+				labels := prometheus.Labels{"sandbox_id": event.SandboxId}
+				common.ProxyRequestDuration.DeletePartialMatch(labels)
+				common.ProxyResponseSize.DeletePartialMatch(labels)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/backend"
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+type ReconcileServiceConfig struct {
+	Cache          cache.IRunnerCache
+	Docker         *docker.DockerClient
+	Backend        backend.Backend
+	SandboxService *SandboxService
+	OrphanPolicy   enums.OrphanPolicy
+	// Interval is how often reconciliation runs after the startup run, 0 only reconciles on startup
+	Interval time.Duration
+}
+
+// ReconcileService brings the cache in line with the sandbox containers in Docker. Cache entries are
+// corrected to the observed state, entries whose container is gone are marked destroyed and containers
+// without a cache entry are handled according to the orphan policy.
+type ReconcileService struct {
+	cache          cache.IRunnerCache
+	docker         *docker.DockerClient
+	backend        backend.Backend
+	sandboxService *SandboxService
+	orphanPolicy   enums.OrphanPolicy
+	interval       time.Duration
+}
+
+type reconcileSummary struct {
+	corrected int
+	destroyed int
+	adopted   int
+	removed   int
+	ignored   int
+}
+
+func NewReconcileService(config ReconcileServiceConfig) *ReconcileService {
+	return &ReconcileService{
+		cache:          config.Cache,
+		docker:         config.Docker,
+		backend:        config.Backend,
+		sandboxService: config.SandboxService,
+		orphanPolicy:   config.OrphanPolicy,
+		interval:       config.Interval,
+	}
+}
+
+// StartReconciliation reconciles once before returning, so the API never serves a stale cache after a
+// restart, and then on every interval
+func (s *ReconcileService) StartReconciliation(ctx context.Context) {
+	err := s.reconcile(ctx, true)
+	if err != nil {
+		log.Errorf("Failed to reconcile sandboxes on startup: %v", err)
+	}
+
+	if s.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := s.reconcile(ctx, false)
+				if err != nil {
+					log.Errorf("Failed to reconcile sandboxes: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// reconcile compares the cache with Docker. On startup no operation can be in flight, so transitional
+// states left behind by a crash are corrected too.
+func (s *ReconcileService) reconcile(ctx context.Context, startup bool) error {
+	observed, err := s.sandboxService.listSandboxContainers(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	cachedIds := s.cache.List(ctx)
+	cached := make(map[string]bool, len(cachedIds))
+	for _, sandboxId := range cachedIds {
+		cached[sandboxId] = true
+	}
+
+	orphanPolicy := s.orphanPolicy
+	if startup && orphanPolicy == enums.OrphanPolicyRemove && len(cachedIds) == 0 {
+		// An empty cache on startup is what the in-memory cache always looks like, removing every
+		// container would destroy all sandboxes on the runner
+		log.Warn("Cache is empty on startup, adopting orphaned containers instead of removing them")
+		orphanPolicy = enums.OrphanPolicyAdopt
+	}
+
+	summary := reconcileSummary{}
+
+	for sandboxId, sandbox := range observed {
+		if !cached[sandboxId] {
+			s.handleOrphan(ctx, sandboxId, sandbox, orphanPolicy, &summary)
+			continue
+		}
+
+		previous := s.cache.Get(ctx, sandboxId).SandboxState
+		if s.sandboxService.reconcileSandboxState(ctx, sandboxId, sandbox.state, true, startup) != previous {
+			log.Infof("Corrected state of sandbox %s from %s to %s", sandboxId, previous, sandbox.state)
+			summary.corrected++
+		}
+	}
+
+	for _, sandboxId := range cachedIds {
+		if _, ok := observed[sandboxId]; ok {
+			continue
+		}
+
+		previous := s.cache.Get(ctx, sandboxId).SandboxState
+		if s.sandboxService.reconcileSandboxState(ctx, sandboxId, enums.SandboxStateDestroyed, false, startup) != previous {
+			log.Infof("Container of sandbox %s is gone, marked as destroyed (was %s)", sandboxId, previous)
+			summary.destroyed++
+		}
+	}
+
+	if summary != (reconcileSummary{}) {
+		log.Infof("Reconciled sandboxes: %d corrected, %d marked destroyed, %d orphans adopted, %d orphans removed, %d orphans ignored",
+			summary.corrected, summary.destroyed, summary.adopted, summary.removed, summary.ignored)
+	}
+
+	return nil
+}
+
+func (s *ReconcileService) handleOrphan(ctx context.Context, sandboxId string, sandbox observedSandbox, policy enums.OrphanPolicy, summary *reconcileSummary) {
+	switch policy {
+	case enums.OrphanPolicyAdopt:
+		log.Infof("Adopting orphaned sandbox %s in state %s", sandboxId, sandbox.state)
+		s.cache.SetSandboxState(ctx, sandboxId, sandbox.state)
+		summary.adopted++
+	case enums.OrphanPolicyRemove:
+		log.Infof("Removing orphaned sandbox %s", sandboxId)
+
+		var err error
+		if _, ok := sandbox.labels[constants.COMPOSE_SANDBOX_LABEL]; ok {
+			err = s.docker.DestroyCompose(ctx, sandboxId)
+		} else {
+			err = s.backend.Destroy(ctx, sandboxId)
+		}
+		if err != nil {
+			log.Errorf("Failed to remove orphaned sandbox %s: %v", sandboxId, err)
+			return
+		}
+
+		summary.removed++
+	default:
+		log.Warnf("Found orphaned sandbox %s in state %s", sandboxId, sandbox.state)
+		summary.ignored++
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/daytonaio/runner/internal"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+)
+
+const (
+	CONTROL_PLANE_REQUEST_REGISTER  = "register"
+	CONTROL_PLANE_REQUEST_HEARTBEAT = "heartbeat"
+)
+
+const (
+	minRegistrationBackoff = 1 * time.Second
+	maxRegistrationBackoff = 2 * time.Minute
+)
+
+// errRunnerNotRegistered is returned for heartbeats the control plane doesn't know the runner of, e.g. after it
+// removed a runner that missed too many heartbeats
+var errRunnerNotRegistered = errors.New("the control plane doesn't know the runner")
+
+type RegistrationConfig struct {
+	// ControlPlaneUrl is the base URL of the control plane API
+	ControlPlaneUrl string
+	Token           string
+	// Name defaults to the hostname
+	Name string
+	// Address is the URL the control plane reaches the runner API at
+	Address string
+	Labels  *RunnerLabels
+	// HeartbeatInterval defaults to 30 seconds, the control plane may override it on registration
+	HeartbeatInterval time.Duration
+	// AllowInsecure permits a plain HTTP control plane URL, intended for development only
+	AllowInsecure bool
+	Metrics       *MetricsService
+	Health        *HealthService
+	Drain         *DrainService
+}
+
+// RegistrationClient announces the runner to the control plane on startup and keeps it informed about the health and
+// utilization of the runner with heartbeats. Retries are jittered so runners restarted together don't hit the control
+// plane at the same time.
+type RegistrationClient struct {
+	registerUrl       string
+	controlPlaneUrl   *url.URL
+	token             string
+	name              string
+	address           string
+	labels            *RunnerLabels
+	heartbeatInterval time.Duration
+	metrics           *MetricsService
+	health            *HealthService
+	drain             *DrainService
+	client            *http.Client
+}
+
+func NewRegistrationClient(config RegistrationConfig) (*RegistrationClient, error) {
+	controlPlaneUrl, err := url.Parse(config.ControlPlaneUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control plane URL: %w", err)
+	}
+
+	if controlPlaneUrl.Scheme != "https" && !(config.AllowInsecure && controlPlaneUrl.Scheme == "http") {
+		return nil, errors.New("control plane URL must use https")
+	}
+
+	if config.Address == "" {
+		return nil, errors.New("the runner address is required to register with the control plane")
+	}
+
+	name := config.Name
+	if name == "" {
+		name, err = os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	heartbeatInterval := config.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = 30 * time.Second
+	}
+
+	return &RegistrationClient{
+		registerUrl:       controlPlaneUrl.JoinPath("runners", "register").String(),
+		controlPlaneUrl:   controlPlaneUrl,
+		token:             config.Token,
+		name:              name,
+		address:           config.Address,
+		labels:            config.Labels,
+		heartbeatInterval: heartbeatInterval,
+		metrics:           config.Metrics,
+		health:            config.Health,
+		drain:             config.Drain,
+		client:            &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Start registers the runner and sends heartbeats until the context is cancelled. The runner registers again if the
+// control plane stops recognizing it.
+func (c *RegistrationClient) Start(ctx context.Context) {
+	if c == nil {
+		return
+	}
+
+	go func() {
+		for {
+			registration, ok := c.registerWithRetry(ctx)
+			if !ok {
+				return
+			}
+
+			interval := c.heartbeatInterval
+			if registration.HeartbeatIntervalSeconds > 0 {
+				interval = time.Duration(registration.HeartbeatIntervalSeconds) * time.Second
+			}
+
+			log.Infof("Registered with the control plane as runner %s, sending heartbeats every %s", registration.Id, interval)
+
+			if !c.sendHeartbeats(ctx, registration.Id, interval) {
+				return
+			}
+		}
+	}()
+}
+
+// registerWithRetry returns false if the context was cancelled before the registration succeeded
+func (c *RegistrationClient) registerWithRetry(ctx context.Context) (dto.RunnerRegistrationResponseDTO, bool) {
+	backoff := minRegistrationBackoff
+	for {
+		registration, err := c.register(ctx)
+		if err == nil {
+			return registration, true
+		}
+
+		if ctx.Err() != nil {
+			return registration, false
+		}
+
+		delay := fullJitter(backoff)
+		log.Warnf("Failed to register with the control plane, retrying in %s: %v", delay.Round(time.Millisecond), err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return registration, false
+		}
+
+		backoff = min(backoff*2, maxRegistrationBackoff)
+	}
+}
+
+// sendHeartbeats returns true if the runner has to register again and false once the context is cancelled. Failed
+// heartbeats are retried with backoff, but never later than the next regular heartbeat.
+func (c *RegistrationClient) sendHeartbeats(ctx context.Context, runnerId string, interval time.Duration) bool {
+	delay := intervalJitter(interval)
+	backoff := minRegistrationBackoff
+
+	for {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return false
+		}
+
+		err := c.heartbeat(ctx, runnerId)
+		if err == nil {
+			delay = intervalJitter(interval)
+			backoff = minRegistrationBackoff
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if errors.Is(err, errRunnerNotRegistered) {
+			log.Warnf("The control plane doesn't know runner %s anymore, registering again", runnerId)
+			return true
+		}
+
+		delay = min(fullJitter(backoff), interval)
+		backoff = min(backoff*2, interval)
+
+		log.Warnf("Failed to send heartbeat to the control plane, retrying in %s: %v", delay.Round(time.Millisecond), err)
+	}
+}
+
+func (c *RegistrationClient) register(ctx context.Context) (dto.RunnerRegistrationResponseDTO, error) {
+	var registration dto.RunnerRegistrationResponseDTO
+
+	request := dto.RunnerRegistrationDTO{
+		Name:    c.name,
+		Address: c.address,
+		Version: internal.Version,
+		Labels:  c.labels.Get(),
+		Gpus:    make([]dto.GpuInfoDTO, 0),
+	}
+
+	capacity, err := c.metrics.GetHostCapacity(ctx)
+	if err != nil {
+		// The capacity reaches the control plane with the next registration, e.g. once Docker is up
+		log.Warnf("Registering without host capacity: %v", err)
+	} else {
+		request.Capacity = &dto.RunnerCapacity{
+			TotalCpu:        capacity.TotalCpu,
+			TotalMemoryGiB:  capacity.TotalMemoryGiB,
+			TotalDiskGiB:    capacity.TotalDiskGiB,
+			DockerVersion:   capacity.DockerVersion,
+			DefaultRuntime:  capacity.DefaultRuntime,
+			Runtimes:        capacity.Runtimes,
+			Architecture:    capacity.Architecture,
+			OperatingSystem: capacity.OperatingSystem,
+			KernelVersion:   capacity.KernelVersion,
+			Rootless:        capacity.Rootless,
+			UsernsRemap:     capacity.UsernsRemap,
+		}
+	}
+
+	gpus, err := c.metrics.GetGPUs(ctx)
+	if err != nil {
+		log.Warnf("Registering without GPUs: %v", err)
+	}
+	for _, gpu := range gpus {
+		request.Gpus = append(request.Gpus, dto.GpuInfoDTO{
+			Index:     gpu.Index,
+			Uuid:      gpu.Uuid,
+			Name:      gpu.Name,
+			MemoryMiB: gpu.MemoryMiB,
+			Allocated: gpu.Allocated,
+		})
+	}
+
+	resp, err := c.post(ctx, c.registerUrl, request)
+	if err != nil {
+		common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_REGISTER, string(common.PrometheusOperationStatusFailure)).Inc()
+		return registration, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_REGISTER, string(common.PrometheusOperationStatusFailure)).Inc()
+		return registration, fmt.Errorf("control plane responded with status %d", resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&registration)
+	if err == nil && registration.Id == "" {
+		err = errors.New("the control plane returned no runner id")
+	}
+	if err != nil {
+		common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_REGISTER, string(common.PrometheusOperationStatusFailure)).Inc()
+		return registration, fmt.Errorf("invalid registration response: %w", err)
+	}
+
+	common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_REGISTER, string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	return registration, nil
+}
+
+func (c *RegistrationClient) heartbeat(ctx context.Context, runnerId string) error {
+	components := c.health.Check(ctx)
+	overall := Overall(components)
+
+	request := dto.RunnerHeartbeatDTO{
+		Id:         runnerId,
+		Version:    internal.Version,
+		Status:     string(overall.Status),
+		Components: make(map[string]dto.ComponentHealthDTO, len(components)),
+		Draining:   c.drain.IsDraining(),
+		InFlight:   c.drain.InFlight(),
+		Labels:     c.labels.Get(),
+		SentAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	for name, health := range components {
+		request.Components[name] = dto.ComponentHealthDTO{
+			Status:  string(health.Status),
+			Message: health.Message,
+		}
+	}
+
+	cpuUsage, ramUsage, diskUsage, allocatedCpu, allocatedMemory, allocatedDisk, snapshotCount := c.metrics.GetCachedSystemMetrics(ctx)
+	request.Metrics = &dto.RunnerMetrics{
+		CurrentCpuUsagePercentage:    cpuUsage,
+		CurrentMemoryUsagePercentage: ramUsage,
+		CurrentDiskUsagePercentage:   diskUsage,
+		CurrentAllocatedCpu:          allocatedCpu,
+		CurrentAllocatedMemoryGiB:    allocatedMemory,
+		CurrentAllocatedDiskGiB:      allocatedDisk,
+		CurrentSnapshotCount:         snapshotCount,
+	}
+
+	resp, err := c.post(ctx, c.controlPlaneUrl.JoinPath("runners", runnerId, "heartbeat").String(), request)
+	if err != nil {
+		common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_HEARTBEAT, string(common.PrometheusOperationStatusFailure)).Inc()
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_HEARTBEAT, string(common.PrometheusOperationStatusFailure)).Inc()
+
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			return errRunnerNotRegistered
+		}
+
+		return fmt.Errorf("control plane responded with status %d", resp.StatusCode)
+	}
+
+	common.ControlPlaneRequestCount.WithLabelValues(CONTROL_PLANE_REQUEST_HEARTBEAT, string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	return nil
+}
+
+func (c *RegistrationClient) post(ctx context.Context, url string, body any) (*http.Response, error) {
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(rawBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return c.client.Do(req)
+}
+
+// fullJitter picks a random delay up to the backoff
+func fullJitter(backoff time.Duration) time.Duration {
+	return time.Duration(rand.Int64N(int64(backoff))) + time.Millisecond
+}
+
+// intervalJitter spreads the interval by up to 10 percent in either direction
+func intervalJitter(interval time.Duration) time.Duration {
+	spread := int64(interval) / 10
+	if spread <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration(rand.Int64N(2*spread)-spread)
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/cache"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	dockerevents "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	defaultMaxRestarts    = 5
+	defaultRestartBackoff = 1 * time.Second
+	maxRestartBackoff     = 5 * time.Minute
+)
+
+type RestartServiceConfig struct {
+	Cache  cache.IRunnerCache
+	Docker *docker.DockerClient
+	Events *events.Broker
+	// CrashLoopWindow is the time the restarts of a sandbox are counted in, a sandbox restarted more often than
+	// its policy allows within it is parked in the error state
+	CrashLoopWindow time.Duration
+}
+
+// RestartService restarts sandboxes with a restart policy when they exit on their own. Exits the runner caused, e.g.
+// stops and destroys, set another state before the container exits and are not restarted.
+type RestartService struct {
+	cache           cache.IRunnerCache
+	docker          *docker.DockerClient
+	events          *events.Broker
+	crashLoopWindow time.Duration
+
+	mutex sync.Mutex
+	// restarts holds the restart times of every sandbox within the crash loop window
+	restarts map[string][]time.Time
+	// pending holds the restarts waiting for their backoff, a state change other than the exit cancels them
+	pending map[string]pendingRestart
+}
+
+type pendingRestart struct {
+	since  time.Time
+	cancel context.CancelFunc
+}
+
+func NewRestartService(config RestartServiceConfig) *RestartService {
+	crashLoopWindow := config.CrashLoopWindow
+	if crashLoopWindow <= 0 {
+		crashLoopWindow = 10 * time.Minute
+	}
+
+	return &RestartService{
+		cache:           config.Cache,
+		docker:          config.Docker,
+		events:          config.Events,
+		crashLoopWindow: crashLoopWindow,
+		restarts:        make(map[string][]time.Time),
+		pending:         make(map[string]pendingRestart),
+	}
+}
+
+func (s *RestartService) StartSupervision(ctx context.Context) {
+	go s.watchStateChanges(ctx)
+
+	go func() {
+		for {
+			err := s.watchExits(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Warnf("Restart supervision lost the Docker events stream, reopening it in 2 seconds: %v", err)
+
+			select {
+			case <-time.After(2 * time.Second):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// watchExits handles the exits of sandboxes with a restart policy until the events stream ends
+func (s *RestartService) watchExits(ctx context.Context) error {
+	eventsChan, errsChan := s.docker.ApiClient().Events(ctx, dockerevents.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", "container"),
+			filters.Arg("event", "die"),
+			filters.Arg("label", constants.RESTART_POLICY_LABEL),
+		),
+	})
+
+	for {
+		select {
+		case event := <-eventsChan:
+			exitCode, _ := strconv.Atoi(event.Actor.Attributes["exitCode"])
+			s.handleExit(ctx, event.Actor.Attributes["name"], exitCode, docker.GetRestartPolicy(event.Actor.Attributes))
+		case err := <-errsChan:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// watchStateChanges cancels the pending restart of a sandbox started, stopped or destroyed in the meantime
+func (s *RestartService) watchStateChanges(ctx context.Context) {
+	eventsChan, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case event := <-eventsChan:
+			if event.Type != enums.EventTypeSandboxStateChanged || event.State == enums.SandboxStateStopped.String() {
+				continue
+			}
+
+			s.mutex.Lock()
+			pending, ok := s.pending[event.SandboxId]
+			if ok && event.Timestamp.After(pending.since) {
+				pending.cancel()
+				delete(s.pending, event.SandboxId)
+				log.Infof("Cancelled the restart of sandbox %s, it is %s", event.SandboxId, event.State)
+			}
+			s.mutex.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *RestartService) handleExit(ctx context.Context, sandboxId string, exitCode int, policy *dto.RestartPolicyDTO) {
+	if sandboxId == "" || policy == nil {
+		return
+	}
+
+	if policy.Mode == docker.RESTART_POLICY_ON_FAILURE && exitCode == 0 {
+		return
+	}
+
+	if s.cache.Get(ctx, sandboxId).SandboxState != enums.SandboxStateStarted {
+		return
+	}
+
+	s.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateStopped)
+
+	backoff, ok := s.recordRestart(sandboxId, policy)
+	if !ok {
+		log.Warnf("Sandbox %s is crash looping, it exited with code %d after as many restarts as its policy allows within %s", sandboxId, exitCode, s.crashLoopWindow)
+		s.parkCrashLooping(ctx, sandboxId, exitCode)
+		return
+	}
+
+	restartCtx, cancel := context.WithCancel(ctx)
+
+	s.mutex.Lock()
+	if pending, ok := s.pending[sandboxId]; ok {
+		pending.cancel()
+	}
+	s.pending[sandboxId] = pendingRestart{
+		since:  time.Now(),
+		cancel: cancel,
+	}
+	s.mutex.Unlock()
+
+	log.Infof("Sandbox %s exited with code %d, restarting it in %s", sandboxId, exitCode, backoff)
+
+	go s.restart(restartCtx, sandboxId, exitCode, backoff)
+}
+
+// recordRestart returns the backoff of the next restart, false once the sandbox restarted as often as its policy
+// allows within the crash loop window
+func (s *RestartService) recordRestart(sandboxId string, policy *dto.RestartPolicyDTO) (time.Duration, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+
+	restarts := make([]time.Time, 0, len(s.restarts[sandboxId]))
+	for _, restartTime := range s.restarts[sandboxId] {
+		if now.Sub(restartTime) < s.crashLoopWindow {
+			restarts = append(restarts, restartTime)
+		}
+	}
+
+	maxRestarts := policy.MaxRestarts
+	if maxRestarts <= 0 {
+		maxRestarts = defaultMaxRestarts
+	}
+	if len(restarts) >= maxRestarts {
+		s.restarts[sandboxId] = restarts
+		return 0, false
+	}
+
+	backoff := defaultRestartBackoff
+	if policy.BackoffSeconds > 0 {
+		backoff = time.Duration(policy.BackoffSeconds) * time.Second
+	}
+	for range restarts {
+		backoff = min(backoff*2, maxRestartBackoff)
+	}
+
+	s.restarts[sandboxId] = append(restarts, now)
+
+	return backoff, true
+}
+
+func (s *RestartService) restart(ctx context.Context, sandboxId string, exitCode int, backoff time.Duration) {
+	select {
+	case <-time.After(backoff):
+	case <-ctx.Done():
+		return
+	}
+
+	// The pending restart is still this one unless the context was cancelled
+	s.mutex.Lock()
+	if ctx.Err() != nil {
+		s.mutex.Unlock()
+		return
+	}
+	s.pending[sandboxId].cancel()
+	delete(s.pending, sandboxId)
+	s.mutex.Unlock()
+
+	// Detached from the cancellation, which only applies to the backoff
+	ctx = context.WithoutCancel(ctx)
+
+	err := s.docker.Start(ctx, sandboxId)
+	if err != nil {
+		log.Errorf("Failed to restart sandbox %s: %v", sandboxId, err)
+		common.ContainerOperationCount.WithLabelValues("restart", string(common.PrometheusOperationStatusFailure)).Inc()
+		s.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+		return
+	}
+
+	common.ContainerOperationCount.WithLabelValues("restart", string(common.PrometheusOperationStatusSuccess)).Inc()
+
+	s.events.Publish(events.Event{
+		Type:      enums.EventTypeSandboxRestarted,
+		SandboxId: sandboxId,
+		Message:   fmt.Sprintf("sandbox exited with code %d and was restarted", exitCode),
+	})
+}
+
+// parkCrashLooping leaves the sandbox stopped in the error state, a start by the API resets its restarts
+func (s *RestartService) parkCrashLooping(ctx context.Context, sandboxId string, exitCode int) {
+	s.mutex.Lock()
+	delete(s.restarts, sandboxId)
+	s.mutex.Unlock()
+
+	s.cache.SetSandboxState(ctx, sandboxId, enums.SandboxStateError)
+
+	s.events.Publish(events.Event{
+		Type:      enums.EventTypeSandboxCrashLoop,
+		SandboxId: sandboxId,
+		Message:   fmt.Sprintf("sandbox kept exiting, last with code %d, and is no longer restarted", exitCode),
+	})
+}
@@ -6,6 +6,7 @@ package services
 import (
 	"context"
 
+	"github.com/daytonaio/runner/pkg/backend"
 	"github.com/daytonaio/runner/pkg/cache"
 	"github.com/daytonaio/runner/pkg/docker"
 	"github.com/daytonaio/runner/pkg/models"
@@ -13,19 +14,21 @@ import (
 )
 
 type SandboxService struct {
-	cache  cache.IRunnerCache
-	docker *docker.DockerClient
+	cache   cache.IRunnerCache
+	docker  *docker.DockerClient
+	backend backend.Backend
 }
 
-func NewSandboxService(cache cache.IRunnerCache, docker *docker.DockerClient) *SandboxService {
+func NewSandboxService(cache cache.IRunnerCache, docker *docker.DockerClient, backend backend.Backend) *SandboxService {
 	return &SandboxService{
-		cache:  cache,
-		docker: docker,
+		cache:   cache,
+		docker:  docker,
+		backend: backend,
 	}
 }
 
 func (s *SandboxService) GetSandboxStatesInfo(ctx context.Context, sandboxId string) *models.CacheData {
-	sandboxState, err := s.docker.DeduceSandboxState(ctx, sandboxId)
+	sandboxState, err := s.backend.DeduceSandboxState(ctx, sandboxId)
 	if err == nil {
 		s.cache.SetSandboxState(ctx, sandboxId, sandboxState)
 	}
@@ -47,7 +50,7 @@ func (s *SandboxService) RemoveDestroyedSandbox(ctx context.Context, sandboxId s
 	info := s.GetSandboxStatesInfo(ctx, sandboxId)
 
 	if info != nil && info.SandboxState != enums.SandboxStateDestroyed && info.SandboxState != enums.SandboxStateDestroying {
-		err := s.docker.Destroy(ctx, sandboxId)
+		err := s.backend.Destroy(ctx, sandboxId)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,198 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	DefaultSandboxListLimit = 100
+	MaxSandboxListLimit     = 1000
+)
+
+// States set while an operation is in flight, the container state can't tell them apart from the settled states
+var transitionalSandboxStates = []enums.SandboxState{
+	enums.SandboxStateCreating,
+	enums.SandboxStateRestoring,
+	enums.SandboxStateDestroying,
+	enums.SandboxStateStarting,
+	enums.SandboxStateStopping,
+	enums.SandboxStateResizing,
+	enums.SandboxStatePausing,
+	enums.SandboxStateResuming,
+	enums.SandboxStatePullingSnapshot,
+}
+
+// observedSandbox is the state of a sandbox according to its containers
+type observedSandbox struct {
+	state     enums.SandboxState
+	snapshot  string
+	labels    map[string]string
+	createdAt time.Time
+}
+
+type SandboxListFilter struct {
+	States []enums.SandboxState
+	// Labels are matched by key or key=value, only sandboxes with a container can match them
+	Labels   []string
+	Snapshot string
+	// Page starts at 1
+	Page  int
+	Limit int
+}
+
+// ListSandboxes lists the sandboxes known to the cache and the sandbox containers, correcting cached states
+// that diverged from the containers on the way
+func (s *SandboxService) ListSandboxes(ctx context.Context, filter SandboxListFilter) (*dto.ListSandboxesResponseDTO, error) {
+	observed, err := s.listSandboxContainers(ctx, filter.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxes := make(map[string]dto.SandboxSummaryDTO, len(observed))
+	for sandboxId, sandbox := range observed {
+		state := s.reconcileSandboxState(ctx, sandboxId, sandbox.state, true, false)
+
+		sandboxes[sandboxId] = dto.SandboxSummaryDTO{
+			Id:           sandboxId,
+			State:        state.String(),
+			Snapshot:     sandbox.snapshot,
+			Labels:       sandbox.labels,
+			CreatedAt:    &sandbox.createdAt,
+			HasContainer: true,
+		}
+	}
+
+	// Entries without a container can't match label filters
+	if len(filter.Labels) == 0 {
+		for _, sandboxId := range s.cache.List(ctx) {
+			if _, ok := sandboxes[sandboxId]; ok {
+				continue
+			}
+
+			state := s.reconcileSandboxState(ctx, sandboxId, enums.SandboxStateDestroyed, false, false)
+
+			sandboxes[sandboxId] = dto.SandboxSummaryDTO{
+				Id:    sandboxId,
+				State: state.String(),
+			}
+		}
+	}
+
+	items := make([]dto.SandboxSummaryDTO, 0, len(sandboxes))
+	for _, sandbox := range sandboxes {
+		if len(filter.States) > 0 && !slices.Contains(filter.States, enums.SandboxState(sandbox.State)) {
+			continue
+		}
+		if filter.Snapshot != "" && sandbox.Snapshot != filter.Snapshot {
+			continue
+		}
+		items = append(items, sandbox)
+	}
+
+	slices.SortFunc(items, func(a, b dto.SandboxSummaryDTO) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultSandboxListLimit
+	}
+	limit = min(limit, MaxSandboxListLimit)
+
+	page := max(filter.Page, 1)
+
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+
+	return &dto.ListSandboxesResponseDTO{
+		Items: items[start:end],
+		Total: len(items),
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
+
+// listSandboxContainers returns the sandboxes with containers matching the label filters. Sidecars and the
+// sandboxes of the warm pool are skipped and the service containers of a multi-container sandbox are reported
+// as that one sandbox, started if any of them runs.
+func (s *SandboxService) listSandboxContainers(ctx context.Context, labels []string) (map[string]observedSandbox, error) {
+	containerFilters := filters.NewArgs(filters.Arg("label", constants.ORGANIZATION_ID_LABEL))
+	for _, label := range labels {
+		containerFilters.Add("label", label)
+	}
+
+	containers, err := s.docker.ApiClient().ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: containerFilters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxes := make(map[string]observedSandbox, len(containers))
+	for _, ct := range containers {
+		if len(ct.Names) == 0 || ct.Labels[constants.SIDECAR_OF_LABEL] != "" || docker.IsPoolSandbox(ct.Names[0]) {
+			continue
+		}
+
+		sandbox := observedSandbox{
+			state:     docker.DeduceSandboxStateFromSummary(ct),
+			snapshot:  ct.Image,
+			labels:    ct.Labels,
+			createdAt: time.Unix(ct.Created, 0),
+		}
+
+		sandboxId := strings.TrimPrefix(ct.Names[0], "/")
+		if composeSandboxId := ct.Labels[constants.COMPOSE_SANDBOX_LABEL]; composeSandboxId != "" {
+			sandboxId = composeSandboxId
+			sandbox.snapshot = ""
+			sandbox.labels = map[string]string{
+				constants.ORGANIZATION_ID_LABEL: ct.Labels[constants.ORGANIZATION_ID_LABEL],
+				constants.COMPOSE_SANDBOX_LABEL: composeSandboxId,
+			}
+
+			if previous, ok := sandboxes[sandboxId]; ok && previous.state == enums.SandboxStateStarted {
+				continue
+			}
+		}
+
+		sandboxes[sandboxId] = sandbox
+	}
+
+	return sandboxes, nil
+}
+
+// reconcileSandboxState returns the state of the sandbox given the state observed in Docker and updates the
+// cache if it diverged. Operations in flight keep their transitional state, so do sandboxes without a
+// container that failed before it was created. With force transitional states are corrected as well, for
+// when no operation can be in flight.
+func (s *SandboxService) reconcileSandboxState(ctx context.Context, sandboxId string, observed enums.SandboxState, hasContainer bool, force bool) enums.SandboxState {
+	cached := s.cache.Get(ctx, sandboxId).SandboxState
+
+	if !force && slices.Contains(transitionalSandboxStates, cached) {
+		return cached
+	}
+
+	if !hasContainer && (cached == enums.SandboxStateError || cached == enums.SandboxStateDestroyed) {
+		return cached
+	}
+
+	if cached != observed {
+		s.cache.SetSandboxState(ctx, sandboxId, observed)
+	}
+
+	return observed
+}
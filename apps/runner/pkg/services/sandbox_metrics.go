@@ -0,0 +1,281 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/constants"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/docker/docker/api/types/container"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var sandboxMetricLabels = []string{"sandbox_id", "organization_id"}
+
+var (
+	sandboxCpuUsageDesc = prometheus.NewDesc(
+		"daytona_sandbox_cpu_usage_seconds_total",
+		"Total CPU time consumed by the sandbox in seconds",
+		sandboxMetricLabels, nil,
+	)
+	sandboxMemoryUsageDesc = prometheus.NewDesc(
+		"daytona_sandbox_memory_usage_bytes",
+		"Current memory usage of the sandbox in bytes, excluding page cache",
+		sandboxMetricLabels, nil,
+	)
+	sandboxMemoryLimitDesc = prometheus.NewDesc(
+		"daytona_sandbox_memory_limit_bytes",
+		"Memory limit of the sandbox in bytes",
+		sandboxMetricLabels, nil,
+	)
+	sandboxDiskUsageDesc = prometheus.NewDesc(
+		"daytona_sandbox_disk_usage_bytes",
+		"Size of the sandbox writable layer in bytes",
+		sandboxMetricLabels, nil,
+	)
+	sandboxDiskLimitDesc = prometheus.NewDesc(
+		"daytona_sandbox_disk_limit_bytes",
+		"Storage quota of the sandbox writable layer in bytes",
+		sandboxMetricLabels, nil,
+	)
+	sandboxNetworkReceiveDesc = prometheus.NewDesc(
+		"daytona_sandbox_network_receive_bytes_total",
+		"Total bytes received by the sandbox",
+		sandboxMetricLabels, nil,
+	)
+	sandboxNetworkTransmitDesc = prometheus.NewDesc(
+		"daytona_sandbox_network_transmit_bytes_total",
+		"Total bytes transmitted by the sandbox",
+		sandboxMetricLabels, nil,
+	)
+	sandboxNetworkReceiveRateDesc = prometheus.NewDesc(
+		"daytona_sandbox_network_receive_bytes_per_second",
+		"Receive throughput of the sandbox averaged over the last collection interval",
+		sandboxMetricLabels, nil,
+	)
+	sandboxNetworkTransmitRateDesc = prometheus.NewDesc(
+		"daytona_sandbox_network_transmit_bytes_per_second",
+		"Transmit throughput of the sandbox averaged over the last collection interval",
+		sandboxMetricLabels, nil,
+	)
+)
+
+type sandboxStats struct {
+	organizationId string
+	cpuSeconds     float64
+	memoryUsage    float64
+	memoryLimit    float64
+	diskUsage      float64
+	diskLimit      float64
+	networkRx      float64
+	networkTx      float64
+	networkRxRate  float64
+	networkTxRate  float64
+}
+
+// SandboxMetricsCollector exports per-sandbox resource usage to Prometheus.
+// Docker stats are refreshed in the background so scrapes never wait on the Docker API.
+type SandboxMetricsCollector struct {
+	docker      *docker.DockerClient
+	events      *events.Broker
+	mutex       sync.RWMutex
+	stats       map[string]sandboxStats
+	refreshedAt time.Time
+	// diskSoftThreshold is the percentage of the storage quota above which a disk usage event is published
+	diskSoftThreshold float64
+	diskExceeded      map[string]bool
+	// usage is metered from the same samples
+	usage *UsageMeter
+}
+
+func NewSandboxMetricsCollector(docker *docker.DockerClient, events *events.Broker, diskSoftThreshold int, usage *UsageMeter) *SandboxMetricsCollector {
+	return &SandboxMetricsCollector{
+		docker:            docker,
+		events:            events,
+		usage:             usage,
+		stats:             make(map[string]sandboxStats),
+		diskSoftThreshold: float64(diskSoftThreshold),
+		diskExceeded:      make(map[string]bool),
+	}
+}
+
+func (c *SandboxMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sandboxCpuUsageDesc
+	ch <- sandboxMemoryUsageDesc
+	ch <- sandboxMemoryLimitDesc
+	ch <- sandboxDiskUsageDesc
+	ch <- sandboxDiskLimitDesc
+	ch <- sandboxNetworkReceiveDesc
+	ch <- sandboxNetworkTransmitDesc
+	ch <- sandboxNetworkReceiveRateDesc
+	ch <- sandboxNetworkTransmitRateDesc
+}
+
+func (c *SandboxMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for sandboxId, stats := range c.stats {
+		ch <- prometheus.MustNewConstMetric(sandboxCpuUsageDesc, prometheus.CounterValue, stats.cpuSeconds, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxMemoryUsageDesc, prometheus.GaugeValue, stats.memoryUsage, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxMemoryLimitDesc, prometheus.GaugeValue, stats.memoryLimit, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxDiskUsageDesc, prometheus.GaugeValue, stats.diskUsage, sandboxId, stats.organizationId)
+		if stats.diskLimit > 0 {
+			ch <- prometheus.MustNewConstMetric(sandboxDiskLimitDesc, prometheus.GaugeValue, stats.diskLimit, sandboxId, stats.organizationId)
+		}
+		ch <- prometheus.MustNewConstMetric(sandboxNetworkReceiveDesc, prometheus.CounterValue, stats.networkRx, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxNetworkTransmitDesc, prometheus.CounterValue, stats.networkTx, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxNetworkReceiveRateDesc, prometheus.GaugeValue, stats.networkRxRate, sandboxId, stats.organizationId)
+		ch <- prometheus.MustNewConstMetric(sandboxNetworkTransmitRateDesc, prometheus.GaugeValue, stats.networkTxRate, sandboxId, stats.organizationId)
+	}
+}
+
+// StartCollection refreshes sandbox stats every 20 seconds, matching the system metrics interval
+func (c *SandboxMetricsCollector) StartCollection(ctx context.Context) {
+	go func() {
+		c.refresh(ctx)
+
+		ticker := time.NewTicker(20 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.refresh(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (c *SandboxMetricsCollector) refresh(ctx context.Context) {
+	containers, err := c.docker.ApiClient().ContainerList(ctx, container.ListOptions{Size: true})
+	if err != nil {
+		log.Errorf("Failed to list sandboxes for metrics: %v", err)
+		return
+	}
+
+	c.mutex.RLock()
+	previousStats := c.stats
+	elapsed := time.Since(c.refreshedAt).Seconds()
+	c.mutex.RUnlock()
+
+	stats := make(map[string]sandboxStats, len(containers))
+	for _, ct := range containers {
+		if len(ct.Names) == 0 {
+			continue
+		}
+		sandboxId := strings.TrimPrefix(ct.Names[0], "/")
+
+		s, err := c.getContainerStats(ctx, ct.ID)
+		if err != nil {
+			log.Debugf("Failed to get stats for sandbox %s: %v", sandboxId, err)
+			continue
+		}
+
+		s.organizationId = ct.Labels[constants.ORGANIZATION_ID_LABEL]
+		s.diskUsage = float64(ct.SizeRw)
+		if quotaGB, err := strconv.ParseInt(ct.Labels[constants.STORAGE_QUOTA_LABEL], 10, 64); err == nil {
+			s.diskLimit = float64(quotaGB * 1024 * 1024 * 1024)
+		}
+		c.checkDiskUsage(sandboxId, s)
+
+		// Counters reset when the sandbox restarts, the rate is only known from the second sample after that
+		if previous, ok := previousStats[sandboxId]; ok && elapsed > 0 && s.networkRx >= previous.networkRx && s.networkTx >= previous.networkTx {
+			s.networkRxRate = (s.networkRx - previous.networkRx) / elapsed
+			s.networkTxRate = (s.networkTx - previous.networkTx) / elapsed
+		}
+
+		stats[sandboxId] = s
+	}
+
+	refreshedAt := time.Now()
+
+	c.mutex.Lock()
+	c.stats = stats
+	c.refreshedAt = refreshedAt
+	c.mutex.Unlock()
+
+	c.usage.observe(refreshedAt, stats)
+
+	// Forget sandboxes that are gone so they are reported again if they come back over the threshold
+	for sandboxId := range c.diskExceeded {
+		if _, ok := stats[sandboxId]; !ok {
+			delete(c.diskExceeded, sandboxId)
+		}
+	}
+}
+
+// checkDiskUsage publishes an event when the sandbox crosses the soft disk threshold.
+// The event is sent once per crossing, usage has to drop below the threshold before it is sent again.
+// Only the refresh goroutine calls it so diskExceeded needs no locking.
+func (c *SandboxMetricsCollector) checkDiskUsage(sandboxId string, stats sandboxStats) {
+	if stats.diskLimit <= 0 || c.diskSoftThreshold <= 0 {
+		return
+	}
+
+	usagePercent := stats.diskUsage / stats.diskLimit * 100
+	if usagePercent < c.diskSoftThreshold {
+		delete(c.diskExceeded, sandboxId)
+		return
+	}
+
+	if c.diskExceeded[sandboxId] {
+		return
+	}
+	c.diskExceeded[sandboxId] = true
+
+	log.Warnf("Sandbox %s uses %.1f%% of its storage quota", sandboxId, usagePercent)
+
+	if c.events != nil {
+		c.events.Publish(events.Event{
+			Type:      enums.EventTypeSandboxDiskUsage,
+			SandboxId: sandboxId,
+			Message:   fmt.Sprintf("disk usage %.0f of %.0f bytes (%.1f%%) exceeds the soft threshold of %.0f%%", stats.diskUsage, stats.diskLimit, usagePercent, c.diskSoftThreshold),
+		})
+	}
+}
+
+func (c *SandboxMetricsCollector) getContainerStats(ctx context.Context, containerId string) (sandboxStats, error) {
+	response, err := c.docker.ApiClient().ContainerStatsOneShot(ctx, containerId)
+	if err != nil {
+		return sandboxStats{}, err
+	}
+	defer response.Body.Close()
+
+	var stats container.StatsResponse
+	err = json.NewDecoder(response.Body).Decode(&stats)
+	if err != nil {
+		return sandboxStats{}, err
+	}
+
+	memoryUsage := stats.MemoryStats.Usage
+	// Page cache can be reclaimed, report the working set like docker stats does
+	if inactiveFile, ok := stats.MemoryStats.Stats["inactive_file"]; ok && inactiveFile < memoryUsage {
+		memoryUsage -= inactiveFile
+	}
+
+	result := sandboxStats{
+		cpuSeconds:  float64(stats.CPUStats.CPUUsage.TotalUsage) / float64(time.Second),
+		memoryUsage: float64(memoryUsage),
+		memoryLimit: float64(stats.MemoryStats.Limit),
+	}
+
+	for _, network := range stats.Networks {
+		result.networkRx += float64(network.RxBytes)
+		result.networkTx += float64(network.TxBytes)
+	}
+
+	return result, nil
+}
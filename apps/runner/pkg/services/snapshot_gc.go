@@ -0,0 +1,135 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/docker"
+)
+
+type SnapshotGCServiceConfig struct {
+	Docker *docker.DockerClient
+	// Interval between automatic runs, 0 disables the background loop
+	Interval time.Duration
+	MinAge   time.Duration
+	KeepList []string
+	// Pins are never removed, nil if snapshots can't be pinned
+	Pins *SnapshotPins
+}
+
+// SnapshotGCService removes snapshots that are no longer used by any sandbox so runner disks don't fill up
+type SnapshotGCService struct {
+	docker *docker.DockerClient
+	pins   *SnapshotPins
+	// Only one run at a time, a manual prune waits for a background run to finish
+	mutex sync.Mutex
+
+	configMutex  sync.RWMutex
+	interval     time.Duration
+	minAge       time.Duration
+	keepList     []string
+	reconfigured chan struct{}
+}
+
+func NewSnapshotGCService(config SnapshotGCServiceConfig) *SnapshotGCService {
+	return &SnapshotGCService{
+		docker:       config.Docker,
+		pins:         config.Pins,
+		interval:     config.Interval,
+		minAge:       config.MinAge,
+		keepList:     config.KeepList,
+		reconfigured: make(chan struct{}, 1),
+	}
+}
+
+func (s *SnapshotGCService) StartGC(ctx context.Context) {
+	go func() {
+		for {
+			s.configMutex.RLock()
+			interval := s.interval
+			s.configMutex.RUnlock()
+
+			// The loop idles while disabled so a reload can enable it
+			var timer *time.Timer
+			var tick <-chan time.Time
+			if interval > 0 {
+				timer = time.NewTimer(interval)
+				tick = timer.C
+			}
+
+			select {
+			case <-tick:
+				result, err := s.Prune(ctx, s.MinAge(), false)
+				if err != nil {
+					log.Errorf("Snapshot garbage collection failed: %v", err)
+					break
+				}
+
+				if len(result.Removed) > 0 {
+					log.Infof("Snapshot garbage collection removed %d snapshots, reclaimed %d bytes", len(result.Removed), result.ReclaimedBytes)
+				}
+			case <-s.reconfigured:
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}()
+}
+
+// SetConfig applies new settings, a changed interval restarts the wait for the next run
+func (s *SnapshotGCService) SetConfig(interval time.Duration, minAge time.Duration, keepList []string) {
+	s.configMutex.Lock()
+	intervalChanged := interval != s.interval
+	s.interval = interval
+	s.minAge = minAge
+	s.keepList = keepList
+	s.configMutex.Unlock()
+
+	if intervalChanged {
+		select {
+		case s.reconfigured <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Prune removes unused snapshots older than minAge that are not on the keep list or pinned
+func (s *SnapshotGCService) Prune(ctx context.Context, minAge time.Duration, dryRun bool) (*dto.PruneSnapshotsResponseDTO, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.configMutex.RLock()
+	keepList := s.keepList
+	s.configMutex.RUnlock()
+
+	var pinned []string
+	if s.pins != nil {
+		pinned = s.pins.List()
+	}
+
+	return s.docker.PruneSnapshots(ctx, docker.PruneSnapshotsOptions{
+		MinAge:   minAge,
+		KeepList: keepList,
+		Pinned:   pinned,
+		DryRun:   dryRun,
+	})
+}
+
+func (s *SnapshotGCService) MinAge() time.Duration {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.minAge
+}
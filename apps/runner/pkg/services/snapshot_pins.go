@@ -0,0 +1,145 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/distribution/reference"
+
+	"github.com/daytonaio/runner/internal/util"
+)
+
+// SnapshotPins is the set of snapshots exempt from garbage collection. It is persisted so pins survive
+// runner restarts.
+type SnapshotPins struct {
+	filePath string
+
+	mutex sync.RWMutex
+	pins  map[string]bool
+}
+
+// NewSnapshotPins loads the pins stored at filePath, a missing file holds no pins
+func NewSnapshotPins(filePath string) (*SnapshotPins, error) {
+	p := &SnapshotPins{
+		filePath: filePath,
+		pins:     make(map[string]bool),
+	}
+
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return nil, err
+	}
+
+	var pins []string
+	err = json.Unmarshal(raw, &pins)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pin := range pins {
+		p.pins[pin] = true
+	}
+
+	return p, nil
+}
+
+// NormalizeSnapshotRef returns the reference in the form Docker lists image tags and digests in,
+// e.g. ubuntu becomes ubuntu:latest
+func NormalizeSnapshotRef(snapshot string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	return reference.FamiliarString(reference.TagNameOnly(named)), nil
+}
+
+// Pin adds the normalized snapshot reference to the pins
+func (p *SnapshotPins) Pin(snapshot string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.pins[snapshot] {
+		return nil
+	}
+
+	p.pins[snapshot] = true
+	err := p.persist()
+	if err != nil {
+		delete(p.pins, snapshot)
+		return err
+	}
+
+	return nil
+}
+
+// Unpin removes the normalized snapshot reference from the pins and reports whether it was pinned
+func (p *SnapshotPins) Unpin(snapshot string) (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.pins[snapshot] {
+		return false, nil
+	}
+
+	delete(p.pins, snapshot)
+	err := p.persist()
+	if err != nil {
+		p.pins[snapshot] = true
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *SnapshotPins) IsPinned(snapshot string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.pins[snapshot]
+}
+
+// List returns the pinned snapshot references sorted by name
+func (p *SnapshotPins) List() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	pins := make([]string, 0, len(p.pins))
+	for pin := range p.pins {
+		pins = append(pins, pin)
+	}
+	slices.Sort(pins)
+
+	return pins
+}
+
+// persist writes the pins to the file, the caller must hold the write lock
+func (p *SnapshotPins) persist() error {
+	pins := make([]string, 0, len(p.pins))
+	for pin := range p.pins {
+		pins = append(pins, pin)
+	}
+	slices.Sort(pins)
+
+	raw, err := json.Marshal(pins)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(p.filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(p.filePath, raw, 0600)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/daytonaio/runner/pkg/events"
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"golang.org/x/crypto/ssh"
+)
+
+type SshAccess struct {
+	Id string `json:"id"`
+	// PublicKey is in the authorized_keys format
+	PublicKey   string    `json:"publicKey"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+type SshAccessServiceConfig struct {
+	// FilePath persists the access keys so they survive runner restarts
+	FilePath string
+	Events   *events.Broker
+}
+
+// SshAccessService keeps the public keys allowed to open SSH sessions into each sandbox
+type SshAccessService struct {
+	filePath string
+	events   *events.Broker
+
+	mutex    sync.RWMutex
+	accesses map[string][]SshAccess
+}
+
+func NewSshAccessService(config SshAccessServiceConfig) (*SshAccessService, error) {
+	s := &SshAccessService{
+		filePath: config.FilePath,
+		events:   config.Events,
+		accesses: make(map[string][]SshAccess),
+	}
+
+	raw, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, &s.accesses)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// StartCleanup drops the access keys of sandboxes once they are destroyed
+func (s *SshAccessService) StartCleanup(ctx context.Context) {
+	eventChan, unsubscribe := s.events.Subscribe()
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-eventChan:
+				if !ok {
+					return
+				}
+				if event.Type != enums.EventTypeSandboxStateChanged || event.State != enums.SandboxStateDestroyed.String() {
+					continue
+				}
+
+				err := s.RemoveSandbox(event.SandboxId)
+				if err != nil {
+					log.Warnf("Failed to remove SSH access of sandbox %s: %v", event.SandboxId, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Create allows the key to open SSH sessions into the sandbox until the access expires. Expired accesses
+// of the sandbox are dropped on the way.
+func (s *SshAccessService) Create(sandboxId string, publicKey ssh.PublicKey, ttl time.Duration) (SshAccess, error) {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return SshAccess{}, err
+	}
+
+	now := time.Now().UTC()
+	access := SshAccess{
+		Id:          hex.EncodeToString(id),
+		PublicKey:   string(ssh.MarshalAuthorizedKey(publicKey)),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.accesses[sandboxId]
+	accesses := slices.DeleteFunc(slices.Clone(previous), func(existing SshAccess) bool {
+		return now.After(existing.ExpiresAt)
+	})
+	s.accesses[sandboxId] = append(accesses, access)
+
+	err = s.persist()
+	if err != nil {
+		s.accesses[sandboxId] = previous
+		return SshAccess{}, err
+	}
+
+	return access, nil
+}
+
+// Authorize returns the unexpired access of the sandbox the key belongs to
+func (s *SshAccessService) Authorize(sandboxId string, publicKey ssh.PublicKey) (SshAccess, bool) {
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+	now := time.Now()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, access := range s.accesses[sandboxId] {
+		if access.Fingerprint == fingerprint && now.Before(access.ExpiresAt) {
+			return access, true
+		}
+	}
+
+	return SshAccess{}, false
+}
+
+// Revoke removes the access and reports whether it existed, sessions opened with it are not closed
+func (s *SshAccessService) Revoke(sandboxId string, accessId string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.accesses[sandboxId]
+	index := slices.IndexFunc(previous, func(access SshAccess) bool {
+		return access.Id == accessId
+	})
+	if index < 0 {
+		return false, nil
+	}
+
+	s.accesses[sandboxId] = slices.Delete(slices.Clone(previous), index, index+1)
+	if len(s.accesses[sandboxId]) == 0 {
+		delete(s.accesses, sandboxId)
+	}
+
+	err := s.persist()
+	if err != nil {
+		s.accesses[sandboxId] = previous
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *SshAccessService) RemoveSandbox(sandboxId string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, ok := s.accesses[sandboxId]
+	if !ok {
+		return nil
+	}
+
+	delete(s.accesses, sandboxId)
+
+	err := s.persist()
+	if err != nil {
+		s.accesses[sandboxId] = previous
+		return err
+	}
+
+	return nil
+}
+
+// persist writes the accesses to the file, the caller must hold the write lock
+func (s *SshAccessService) persist() error {
+	raw, err := json.Marshal(s.accesses)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(s.filePath, raw, 0600)
+}
@@ -0,0 +1,364 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/events"
+)
+
+const (
+	USAGE_PUSH_EVENT = "usage.records"
+
+	maxUsagePushBatch = 500
+)
+
+type UsageMeterConfig struct {
+	// RecordInterval is the period a usage record covers, defaults to 5 minutes
+	RecordInterval time.Duration
+	// Retention is how long records are kept for GetUsageRecords, defaults to 24 hours
+	Retention time.Duration
+	// PushUrl receives the records as signed JSON once they are closed, records are only pulled if it is empty
+	PushUrl    string
+	PushSecret string
+	// AllowInsecure permits a plain HTTP push URL, intended for development only
+	AllowInsecure bool
+}
+
+// UsageMeter turns the resource samples of the sandbox metrics collector into usage records for billing. Counters are
+// integrated between samples so a sandbox is metered for what it used instead of how long it existed. Usage before
+// the first sample of a sandbox and after its last one is not metered, samples are 20 seconds apart.
+type UsageMeter struct {
+	recordInterval time.Duration
+	retention      time.Duration
+	pushUrl        string
+	pushSecret     []byte
+	client         *http.Client
+
+	mutex        sync.Mutex
+	periodStart  time.Time
+	accumulators map[string]*usageAccumulator
+	records      []dto.UsageRecordDTO
+	lastSequence uint64
+	// pushedSequence is the sequence of the last record the push endpoint accepted
+	pushedSequence uint64
+	pushPending    chan struct{}
+}
+
+type usageAccumulator struct {
+	organizationId    string
+	periodStart       time.Time
+	sampledAt         time.Time
+	last              sandboxStats
+	cpuSeconds        float64
+	memoryByteSeconds float64
+	diskByteSeconds   float64
+	diskPeak          float64
+	networkRx         float64
+	networkTx         float64
+}
+
+func NewUsageMeter(config UsageMeterConfig) (*UsageMeter, error) {
+	recordInterval := config.RecordInterval
+	if recordInterval <= 0 {
+		recordInterval = 5 * time.Minute
+	}
+
+	retention := config.Retention
+	if retention <= 0 {
+		retention = 24 * time.Hour
+	}
+
+	meter := &UsageMeter{
+		recordInterval: recordInterval,
+		retention:      retention,
+		client:         &http.Client{Timeout: 30 * time.Second},
+		periodStart:    time.Now(),
+		accumulators:   make(map[string]*usageAccumulator),
+		pushPending:    make(chan struct{}, 1),
+	}
+
+	if config.PushUrl != "" {
+		parsedUrl, err := url.Parse(config.PushUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid usage push URL: %w", err)
+		}
+
+		if parsedUrl.Scheme != "https" && !(config.AllowInsecure && parsedUrl.Scheme == "http") {
+			return nil, errors.New("usage push URL must use https")
+		}
+
+		if config.PushSecret == "" {
+			return nil, errors.New("usage push secret is required")
+		}
+
+		meter.pushUrl = parsedUrl.String()
+		meter.pushSecret = []byte(config.PushSecret)
+	}
+
+	return meter, nil
+}
+
+// StartPushing delivers closed records to the push endpoint until the context is cancelled, records that failed to
+// be delivered are sent again with the next ones
+func (m *UsageMeter) StartPushing(ctx context.Context) {
+	if m == nil || m.pushUrl == "" {
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case <-m.pushPending:
+				m.push(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// GetRecords returns up to limit records with a sequence after the given one, optionally of a single sandbox, and
+// the sequence to read the following records after
+func (m *UsageMeter) GetRecords(after uint64, sandboxId string, limit int) ([]dto.UsageRecordDTO, uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	records := make([]dto.UsageRecordDTO, 0)
+	cursor := after
+
+	start := sort.Search(len(m.records), func(i int) bool {
+		return m.records[i].Sequence > after
+	})
+
+	for _, record := range m.records[start:] {
+		if len(records) >= limit {
+			break
+		}
+
+		// The cursor moves past skipped records so filtered reads don't scan them again
+		cursor = record.Sequence
+		if sandboxId != "" && record.SandboxId != sandboxId {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, cursor
+}
+
+// observe integrates a sample of all running sandboxes, sandboxes missing from it stopped and their record is closed
+func (m *UsageMeter) observe(sampledAt time.Time, stats map[string]sandboxStats) {
+	if m == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for sandboxId, s := range stats {
+		accumulator, ok := m.accumulators[sandboxId]
+		if !ok {
+			// The first sample is the baseline of the counters
+			m.accumulators[sandboxId] = &usageAccumulator{
+				organizationId: s.organizationId,
+				periodStart:    sampledAt,
+				sampledAt:      sampledAt,
+				last:           s,
+				diskPeak:       s.diskUsage,
+			}
+			continue
+		}
+
+		elapsed := sampledAt.Sub(accumulator.sampledAt).Seconds()
+
+		accumulator.cpuSeconds += counterDelta(accumulator.last.cpuSeconds, s.cpuSeconds)
+		accumulator.networkRx += counterDelta(accumulator.last.networkRx, s.networkRx)
+		accumulator.networkTx += counterDelta(accumulator.last.networkTx, s.networkTx)
+		accumulator.memoryByteSeconds += s.memoryUsage * elapsed
+		accumulator.diskByteSeconds += s.diskUsage * elapsed
+		accumulator.diskPeak = max(accumulator.diskPeak, s.diskUsage)
+		accumulator.organizationId = s.organizationId
+		accumulator.sampledAt = sampledAt
+		accumulator.last = s
+	}
+
+	closed := false
+	for sandboxId, accumulator := range m.accumulators {
+		if _, ok := stats[sandboxId]; !ok {
+			m.closeRecord(sandboxId, accumulator, accumulator.sampledAt)
+			delete(m.accumulators, sandboxId)
+			closed = true
+		}
+	}
+
+	if sampledAt.Sub(m.periodStart) >= m.recordInterval {
+		for sandboxId, accumulator := range m.accumulators {
+			m.closeRecord(sandboxId, accumulator, sampledAt)
+			*accumulator = usageAccumulator{
+				organizationId: accumulator.organizationId,
+				periodStart:    sampledAt,
+				sampledAt:      sampledAt,
+				last:           accumulator.last,
+				diskPeak:       accumulator.last.diskUsage,
+			}
+		}
+		m.periodStart = sampledAt
+		closed = true
+	}
+
+	if closed {
+		m.trimRecords(sampledAt)
+
+		select {
+		case m.pushPending <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// closeRecord must be called with the mutex held, periods without a second sample have no usage and are dropped
+func (m *UsageMeter) closeRecord(sandboxId string, accumulator *usageAccumulator, periodEnd time.Time) {
+	if !periodEnd.After(accumulator.periodStart) {
+		return
+	}
+
+	m.lastSequence++
+	m.records = append(m.records, dto.UsageRecordDTO{
+		Sequence:        m.lastSequence,
+		SandboxId:       sandboxId,
+		OrganizationId:  accumulator.organizationId,
+		PeriodStart:     accumulator.periodStart.UTC().Format(time.RFC3339),
+		PeriodEnd:       periodEnd.UTC().Format(time.RFC3339),
+		CpuSeconds:      accumulator.cpuSeconds,
+		MemoryByteHours: accumulator.memoryByteSeconds / time.Hour.Seconds(),
+		DiskBytes:       int64(accumulator.diskPeak),
+		DiskByteHours:   accumulator.diskByteSeconds / time.Hour.Seconds(),
+		NetworkRxBytes:  int64(accumulator.networkRx),
+		NetworkTxBytes:  int64(accumulator.networkTx),
+	})
+}
+
+// trimRecords must be called with the mutex held
+func (m *UsageMeter) trimRecords(now time.Time) {
+	cutoff := now.Add(-m.retention).UTC().Format(time.RFC3339)
+
+	expired := 0
+	for expired < len(m.records) && m.records[expired].PeriodEnd < cutoff {
+		expired++
+	}
+	if expired == 0 {
+		return
+	}
+
+	if m.pushUrl != "" && m.records[expired-1].Sequence > m.pushedSequence {
+		log.Warnf("Dropping usage records up to sequence %d that were never delivered to the push endpoint", m.records[expired-1].Sequence)
+		m.pushedSequence = m.records[expired-1].Sequence
+	}
+
+	m.records = append([]dto.UsageRecordDTO(nil), m.records[expired:]...)
+}
+
+func (m *UsageMeter) push(ctx context.Context) {
+	for {
+		m.mutex.Lock()
+		batch := m.getUnpushed()
+		m.mutex.Unlock()
+
+		if len(batch.Records) == 0 {
+			return
+		}
+
+		err := m.send(ctx, batch)
+		if err != nil {
+			log.Warnf("Failed to push %d usage records, they are sent again with the next records: %v", len(batch.Records), err)
+			return
+		}
+
+		m.mutex.Lock()
+		m.pushedSequence = max(m.pushedSequence, batch.Cursor)
+		m.mutex.Unlock()
+	}
+}
+
+// getUnpushed must be called with the mutex held
+func (m *UsageMeter) getUnpushed() dto.UsageRecordsDTO {
+	batch := dto.UsageRecordsDTO{
+		Cursor: m.pushedSequence,
+	}
+
+	start := sort.Search(len(m.records), func(i int) bool {
+		return m.records[i].Sequence > m.pushedSequence
+	})
+	end := min(start+maxUsagePushBatch, len(m.records))
+
+	batch.Records = append([]dto.UsageRecordDTO(nil), m.records[start:end]...)
+	if len(batch.Records) > 0 {
+		batch.Cursor = batch.Records[len(batch.Records)-1].Sequence
+	}
+
+	return batch
+}
+
+// send posts the batch signed like the event webhooks, the receiver deduplicates records by their sequence
+func (m *UsageMeter) send(ctx context.Context, batch dto.UsageRecordsDTO) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, m.pushSecret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.pushUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(events.WEBHOOK_EVENT_HEADER, USAGE_PUSH_EVENT)
+	req.Header.Set(events.WEBHOOK_TIMESTAMP_HEADER, timestamp)
+	req.Header.Set(events.WEBHOOK_SIGNATURE_HEADER, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("usage push endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// counterDelta is the increase of a cumulative counter, counters restart from 0 when the sandbox restarts
+func counterDelta(previous, current float64) float64 {
+	if current < previous {
+		return current
+	}
+
+	return current - previous
+}
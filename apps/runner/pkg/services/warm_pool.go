@@ -0,0 +1,389 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/api/dto"
+	"github.com/daytonaio/runner/pkg/common"
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/models/enums"
+)
+
+var warmPoolTemplateName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// WarmPoolTemplate describes the sandboxes of a pool, a create is served from the pool when it asks for
+// exactly these values
+type WarmPoolTemplate struct {
+	Name           string `json:"name"`
+	OrganizationId string `json:"organizationId"`
+	Snapshot       string `json:"snapshot"`
+	OsUser         string `json:"osUser"`
+	Cpu            int64  `json:"cpu"`
+	Memory         int64  `json:"memory"`
+	Storage        int64  `json:"storage"`
+	// Size is the number of sandboxes kept ready
+	Size int `json:"size"`
+	// State is stopped or paused, paused sandboxes are claimed faster but keep holding their memory
+	State enums.SandboxState `json:"state"`
+}
+
+// LoadWarmPoolTemplates reads the JSON list of templates at filePath, an empty path configures no pool.
+// Paused sandboxes can't move to the network of the sandbox claiming them, so paused pools are refused with
+// network isolation.
+func LoadWarmPoolTemplates(filePath string, networkIsolation bool) ([]WarmPoolTemplate, error) {
+	if filePath == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []WarmPoolTemplate
+	err = json.Unmarshal(raw, &templates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warm pool file: %w", err)
+	}
+
+	names := make(map[string]bool, len(templates))
+	for i := range templates {
+		template := &templates[i]
+
+		if !warmPoolTemplateName.MatchString(template.Name) {
+			return nil, fmt.Errorf("invalid warm pool template name %q, use up to 32 lower case letters, digits and dashes", template.Name)
+		}
+		if names[template.Name] {
+			return nil, fmt.Errorf("duplicate warm pool template %s", template.Name)
+		}
+		names[template.Name] = true
+
+		if template.OrganizationId == "" || template.Snapshot == "" || template.OsUser == "" {
+			return nil, fmt.Errorf("warm pool template %s needs an organizationId, a snapshot and an osUser", template.Name)
+		}
+		if template.Cpu < 1 || template.Memory < 1 || template.Storage < 1 || template.Size < 0 {
+			return nil, fmt.Errorf("warm pool template %s has invalid resources or size", template.Name)
+		}
+
+		switch template.State {
+		case "":
+			template.State = enums.SandboxStateStopped
+		case enums.SandboxStateStopped:
+		case enums.SandboxStatePaused:
+			if networkIsolation {
+				return nil, fmt.Errorf("warm pool template %s: paused pools can't be used with sandbox network isolation", template.Name)
+			}
+		default:
+			return nil, fmt.Errorf("warm pool template %s has state %s, only stopped and paused are supported", template.Name, template.State)
+		}
+	}
+
+	return templates, nil
+}
+
+// version identifies the values of the template so pool sandboxes created from an older version are replaced
+func (t *WarmPoolTemplate) version() string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		t.OrganizationId,
+		t.Snapshot,
+		t.OsUser,
+		fmt.Sprint(t.Cpu),
+		fmt.Sprint(t.Memory),
+		fmt.Sprint(t.Storage),
+		t.State.String(),
+	}, "\x00")))
+
+	return hex.EncodeToString(hash[:4])
+}
+
+// matches reports whether the sandbox can be created from a pool sandbox of the template. Anything that has to
+// be set when the container is created rules the pool out, env, secrets and policies are applied on claim. Hooks
+// are only run when a stopped sandbox starts.
+func (t *WarmPoolTemplate) matches(sandboxDto dto.CreateSandboxDTO) bool {
+	if sandboxDto.UserId != t.OrganizationId || sandboxDto.Snapshot != t.Snapshot || sandboxDto.OsUser != t.OsUser {
+		return false
+	}
+
+	if sandboxDto.CpuQuota != t.Cpu || sandboxDto.MemoryQuota != t.Memory || sandboxDto.StorageQuota != t.Storage {
+		return false
+	}
+
+	if sandboxDto.GpuQuota > 0 || len(sandboxDto.GpuDeviceIds) > 0 || len(sandboxDto.Entrypoint) > 0 {
+		return false
+	}
+
+	if len(sandboxDto.Volumes) > 0 || sandboxDto.FromVolumeId != "" || len(sandboxDto.Sidecars) > 0 {
+		return false
+	}
+
+	if sandboxDto.IdleTimeoutMinutes > 0 || sandboxDto.IngressBandwidthMbps > 0 || sandboxDto.EgressBandwidthMbps > 0 {
+		return false
+	}
+
+	if sandboxDto.ScanSeverityThreshold != "" || sandboxDto.Runtime != "" {
+		return false
+	}
+
+	if sandboxDto.Devcontainer != nil || sandboxDto.Repository != nil || sandboxDto.RestartPolicy != nil {
+		return false
+	}
+
+	if sandboxDto.Security != nil {
+		return false
+	}
+
+	return sandboxDto.Hooks == nil || t.State == enums.SandboxStateStopped
+}
+
+type WarmPoolConfig struct {
+	Docker    *docker.DockerClient
+	Drain     *DrainService
+	Templates []WarmPoolTemplate
+	// Interval between checks for missing pool sandboxes, claims trigger a check right away
+	Interval time.Duration
+}
+
+// WarmPool keeps stopped or paused sandboxes of each template ready, a create matching a template only has to
+// claim and start one
+type WarmPool struct {
+	docker    *docker.DockerClient
+	drain     *DrainService
+	templates []WarmPoolTemplate
+	interval  time.Duration
+	refill    chan struct{}
+
+	mutex sync.Mutex
+	// ready holds the pool sandboxes of each template that can be claimed
+	ready map[string][]string
+}
+
+func NewWarmPool(config WarmPoolConfig) *WarmPool {
+	return &WarmPool{
+		docker:    config.Docker,
+		drain:     config.Drain,
+		templates: config.Templates,
+		interval:  config.Interval,
+		refill:    make(chan struct{}, 1),
+		ready:     make(map[string][]string),
+	}
+}
+
+// StartRefill adopts the pool sandboxes a previous run of the runner left, removes outdated ones and keeps the
+// pools filled from then on
+func (p *WarmPool) StartRefill(ctx context.Context) {
+	if len(p.templates) == 0 {
+		return
+	}
+
+	go func() {
+		p.adopt(ctx)
+		p.fill(ctx)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-p.refill:
+			case <-ctx.Done():
+				return
+			}
+
+			p.fill(ctx)
+		}
+	}()
+}
+
+// Claim creates the sandbox from a ready pool sandbox if the request matches a template and returns the container
+// ID. An empty ID means no pool sandbox was used and the sandbox has to be created as usual.
+func (p *WarmPool) Claim(ctx context.Context, sandboxDto dto.CreateSandboxDTO) (string, error) {
+	template := p.match(sandboxDto)
+	if template == nil {
+		return "", nil
+	}
+
+	// The regular create handles requests for sandboxes that exist already
+	_, err := p.docker.ContainerInspect(ctx, sandboxDto.Id)
+	if err == nil {
+		return "", nil
+	}
+
+	defer p.triggerRefill()
+
+	for {
+		poolSandboxId, ok := p.take(template.Name)
+		if !ok {
+			common.WarmPoolClaimCount.WithLabelValues(template.Name, "missed").Inc()
+			return "", nil
+		}
+
+		containerId, err := p.docker.ClaimPoolSandbox(ctx, poolSandboxId, sandboxDto)
+		if containerId != "" {
+			common.WarmPoolClaimCount.WithLabelValues(template.Name, "claimed").Inc()
+			return containerId, err
+		}
+
+		if errors.Is(err, docker.ErrPoolSandboxNotClaimable) {
+			p.put(template.Name, poolSandboxId)
+			common.WarmPoolClaimCount.WithLabelValues(template.Name, "missed").Inc()
+			return "", nil
+		}
+
+		log.Warnf("Removing pool sandbox %s that could not be claimed: %v", poolSandboxId, err)
+		go func() {
+			err := p.docker.RemovePoolSandbox(context.Background(), poolSandboxId)
+			if err != nil {
+				log.Warnf("Failed to remove pool sandbox %s: %v", poolSandboxId, err)
+			}
+		}()
+	}
+}
+
+func (p *WarmPool) match(sandboxDto dto.CreateSandboxDTO) *WarmPoolTemplate {
+	for i := range p.templates {
+		if p.templates[i].Size > 0 && p.templates[i].matches(sandboxDto) {
+			return &p.templates[i]
+		}
+	}
+
+	return nil
+}
+
+func (p *WarmPool) adopt(ctx context.Context) {
+	sandboxes, err := p.docker.ListPoolSandboxes(ctx)
+	if err != nil {
+		log.Errorf("Failed to list pool sandboxes: %v", err)
+		return
+	}
+
+	for poolSandboxId, state := range sandboxes {
+		template := p.getTemplate(poolSandboxId)
+		if template != nil && state == template.State && p.count(template.Name) < template.Size {
+			p.put(template.Name, poolSandboxId)
+			continue
+		}
+
+		log.Infof("Removing outdated pool sandbox %s", poolSandboxId)
+		err := p.docker.RemovePoolSandbox(ctx, poolSandboxId)
+		if err != nil {
+			log.Warnf("Failed to remove pool sandbox %s: %v", poolSandboxId, err)
+		}
+	}
+}
+
+// fill creates the missing pool sandboxes one at a time, a template that fails is retried on the next check
+func (p *WarmPool) fill(ctx context.Context) {
+	for i := range p.templates {
+		template := &p.templates[i]
+
+		for p.count(template.Name) < template.Size {
+			if ctx.Err() != nil || p.drain.IsDraining() {
+				return
+			}
+
+			poolSandboxId, err := getPoolSandboxName(template)
+			if err != nil {
+				log.Errorf("Failed to name a sandbox of warm pool %s: %v", template.Name, err)
+				break
+			}
+
+			err = p.docker.CreatePoolSandbox(ctx, dto.CreateSandboxDTO{
+				Id:           poolSandboxId,
+				UserId:       template.OrganizationId,
+				Snapshot:     template.Snapshot,
+				OsUser:       template.OsUser,
+				CpuQuota:     template.Cpu,
+				MemoryQuota:  template.Memory,
+				StorageQuota: template.Storage,
+			}, template.State)
+			if err != nil {
+				log.Errorf("Failed to create a sandbox of warm pool %s: %v", template.Name, err)
+				break
+			}
+
+			p.put(template.Name, poolSandboxId)
+		}
+	}
+}
+
+// getTemplate returns the template of the pool sandbox if it was created from its current version
+func (p *WarmPool) getTemplate(poolSandboxId string) *WarmPoolTemplate {
+	for i := range p.templates {
+		template := &p.templates[i]
+
+		suffix, ok := strings.CutPrefix(poolSandboxId, getPoolSandboxPrefix(template))
+		if ok && !strings.Contains(suffix, "-") {
+			return template
+		}
+	}
+
+	return nil
+}
+
+func (p *WarmPool) take(templateName string) (string, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	ready := p.ready[templateName]
+	if len(ready) == 0 {
+		return "", false
+	}
+
+	// The oldest pool sandbox goes first
+	poolSandboxId := ready[0]
+	p.ready[templateName] = ready[1:]
+	common.WarmPoolReadyCount.WithLabelValues(templateName).Set(float64(len(ready) - 1))
+
+	return poolSandboxId, true
+}
+
+func (p *WarmPool) put(templateName string, poolSandboxId string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.ready[templateName] = append(p.ready[templateName], poolSandboxId)
+	common.WarmPoolReadyCount.WithLabelValues(templateName).Set(float64(len(p.ready[templateName])))
+}
+
+func (p *WarmPool) count(templateName string) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return len(p.ready[templateName])
+}
+
+func (p *WarmPool) triggerRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+func getPoolSandboxPrefix(template *WarmPoolTemplate) string {
+	return fmt.Sprintf("%s%s-%s-", docker.POOL_SANDBOX_PREFIX, template.Name, template.version())
+}
+
+func getPoolSandboxName(template *WarmPoolTemplate) (string, error) {
+	suffix := make([]byte, 4)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return "", err
+	}
+
+	return getPoolSandboxPrefix(template) + hex.EncodeToString(suffix), nil
+}
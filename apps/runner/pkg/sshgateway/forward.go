@@ -0,0 +1,108 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sshgateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/sandboxnet"
+	"golang.org/x/crypto/ssh"
+)
+
+type directTcpipRequest struct {
+	DestAddr   string
+	DestPort   uint32
+	OriginAddr string
+	OriginPort uint32
+}
+
+// handleDirectTcpip forwards a local port of the client to a port of the sandbox. Only the sandbox itself is
+// a valid destination so the gateway can't be used to reach other hosts from the runner. The connection is made
+// from within the network namespace of the sandbox, ports it only listens on at localhost are reachable too.
+func (s *Server) handleDirectTcpip(ctx context.Context, sandboxId string, newChannel ssh.NewChannel) {
+	var request directTcpipRequest
+	err := ssh.Unmarshal(newChannel.ExtraData(), &request)
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, "invalid direct-tcpip request")
+		return
+	}
+
+	if !isSandboxAddress(request.DestAddr, sandboxId) {
+		newChannel.Reject(ssh.Prohibited, "only ports of the sandbox can be forwarded, use localhost as the destination")
+		return
+	}
+
+	// Sandbox ports other than the daemon port are only reachable through the network namespace of a rootless engine
+	if s.docker.Rootless() {
+		newChannel.Reject(ssh.Prohibited, "port forwarding is not supported with a rootless container engine")
+		return
+	}
+
+	ct, err := s.docker.ContainerInspect(ctx, sandboxId)
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	if ct.State == nil || !ct.State.Running || ct.State.Pid == 0 {
+		newChannel.Reject(ssh.ConnectionFailed, "the sandbox is not running")
+		return
+	}
+
+	host := "127.0.0.1"
+	if request.DestAddr == "::1" {
+		host = "::1"
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	backendConn, err := sandboxnet.DialInNamespace(dialCtx, ct.State.Pid, net.JoinHostPort(host, strconv.Itoa(int(request.DestPort))))
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, fmt.Sprintf("failed to connect to sandbox port %d: %v", request.DestPort, err))
+		return
+	}
+	defer backendConn.Close()
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(backendConn, channel)
+		if tcpConn, ok := backendConn.(*net.TCPConn); ok {
+			tcpConn.CloseWrite()
+		}
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(channel, backendConn)
+		channel.CloseWrite()
+		done <- struct{}{}
+	}()
+
+	<-done
+	<-done
+
+	log.Debugf("SSH forward to sandbox %s port %d closed", sandboxId, request.DestPort)
+}
+
+func isSandboxAddress(address string, sandboxId string) bool {
+	switch strings.ToLower(address) {
+	case "localhost", "127.0.0.1", "::1", "0.0.0.0", strings.ToLower(sandboxId):
+		return true
+	}
+
+	return false
+}
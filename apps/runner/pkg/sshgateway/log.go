@@ -0,0 +1,8 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sshgateway
+
+import "github.com/daytonaio/runner/pkg/logging"
+
+var log = logging.Module("sshgateway")
@@ -0,0 +1,198 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sshgateway
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/docker"
+	"github.com/daytonaio/runner/pkg/services"
+	"golang.org/x/crypto/ssh"
+)
+
+const sandboxIdExtension = "sandbox-id"
+
+const handshakeTimeout = 30 * time.Second
+
+type ServerConfig struct {
+	ListenAddress string
+	// HostKeyPath holds the ed25519 host key, it is generated on the first start
+	HostKeyPath string
+	Docker      *docker.DockerClient
+	Access      *services.SshAccessService
+}
+
+// Server is an SSH server that authenticates with the keys of the SSH accesses of a sandbox, the user name is
+// the sandbox ID. Sessions are bridged to docker exec in the sandbox container.
+type Server struct {
+	listenAddress string
+	docker        *docker.DockerClient
+	access        *services.SshAccessService
+	sshConfig     *ssh.ServerConfig
+
+	mutex    sync.Mutex
+	listener net.Listener
+	conns    map[*ssh.ServerConn]struct{}
+}
+
+func NewServer(config ServerConfig) (*Server, error) {
+	hostKey, err := getHostKey(config.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the SSH host key: %w", err)
+	}
+
+	s := &Server{
+		listenAddress: config.ListenAddress,
+		docker:        config.Docker,
+		access:        config.Access,
+		conns:         make(map[*ssh.ServerConn]struct{}),
+	}
+
+	s.sshConfig = &ssh.ServerConfig{
+		PublicKeyCallback: s.authenticate,
+	}
+	s.sshConfig.AddHostKey(hostKey)
+
+	return s, nil
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.listener = listener
+	s.mutex.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+// Stop closes the listener and every open connection
+func (s *Server) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *Server) authenticate(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	_, ok := s.access.Authorize(meta.User(), key)
+	if !ok {
+		return nil, fmt.Errorf("no SSH access to sandbox %s for key %s", meta.User(), ssh.FingerprintSHA256(key))
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{sandboxIdExtension: meta.User()},
+	}, nil
+}
+
+func (s *Server) handleConn(netConn net.Conn) {
+	defer netConn.Close()
+
+	err := netConn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err != nil {
+		return
+	}
+
+	conn, channels, requests, err := ssh.NewServerConn(netConn, s.sshConfig)
+	if err != nil {
+		log.Debugf("SSH handshake with %s failed: %v", netConn.RemoteAddr(), err)
+		return
+	}
+	defer conn.Close()
+
+	err = netConn.SetDeadline(time.Time{})
+	if err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.conns[conn] = struct{}{}
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+	}()
+
+	sandboxId := conn.Permissions.Extensions[sandboxIdExtension]
+	log.Infof("SSH connection to sandbox %s from %s", sandboxId, conn.RemoteAddr())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Remote forwarding would need a listener inside the sandbox, global requests are refused
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go s.handleSession(ctx, sandboxId, newChannel)
+		case "direct-tcpip":
+			go s.handleDirectTcpip(ctx, sandboxId, newChannel)
+		default:
+			newChannel.Reject(ssh.UnknownChannelType, fmt.Sprintf("unsupported channel type %s", newChannel.ChannelType()))
+		}
+	}
+}
+
+// getHostKey reads the host key or generates one so clients see the same host key across restarts
+func getHostKey(path string) (ssh.Signer, error) {
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		return ssh.ParsePrivateKey(raw)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "daytona-runner")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(path, pem.EncodeToMemory(block), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.NewSignerFromKey(privateKey)
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package sshgateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"golang.org/x/crypto/ssh"
+)
+
+// The login shell of the user isn't known outside the sandbox, bash is preferred over sh
+var shellCommand = []string{"sh", "-c", `if command -v bash >/dev/null 2>&1; then exec bash -l; fi; exec sh -l`}
+
+// Images don't ship a common sftp-server path, the usual locations of the OpenSSH sftp-server are tried
+var sftpCommand = []string{"sh", "-c", `for path in /usr/lib/openssh/sftp-server /usr/libexec/openssh/sftp-server /usr/lib/ssh/sftp-server /usr/libexec/sftp-server; do
+	[ -x "$path" ] && exec "$path"
+done
+echo "sftp-server is not installed in the sandbox" >&2
+exit 127`}
+
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type envRequest struct {
+	Name  string
+	Value string
+}
+
+type execRequest struct {
+	Command string
+}
+
+type subsystemRequest struct {
+	Name string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+type session struct {
+	server    *Server
+	sandboxId string
+	channel   ssh.Channel
+
+	mutex   sync.Mutex
+	env     []string
+	pty     *ptyRequest
+	execId  string
+	started bool
+}
+
+func (s *Server) handleSession(ctx context.Context, sandboxId string, newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		log.Debugf("Failed to accept SSH session: %v", err)
+		return
+	}
+	defer channel.Close()
+
+	session := &session{
+		server:    s,
+		sandboxId: sandboxId,
+		channel:   channel,
+	}
+
+	for request := range requests {
+		ok := session.handleRequest(ctx, request)
+		if request.WantReply {
+			request.Reply(ok, nil)
+		}
+	}
+}
+
+func (s *session) handleRequest(ctx context.Context, request *ssh.Request) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch request.Type {
+	case "pty-req":
+		var pty ptyRequest
+		if ssh.Unmarshal(request.Payload, &pty) != nil || s.started {
+			return false
+		}
+		s.pty = &pty
+		return true
+	case "env":
+		var env envRequest
+		if ssh.Unmarshal(request.Payload, &env) != nil || s.started {
+			return false
+		}
+		s.env = append(s.env, fmt.Sprintf("%s=%s", env.Name, env.Value))
+		return true
+	case "window-change":
+		var window windowChangeRequest
+		if ssh.Unmarshal(request.Payload, &window) != nil || s.pty == nil {
+			return false
+		}
+		s.pty.Columns, s.pty.Rows = window.Columns, window.Rows
+		if s.execId != "" {
+			err := s.server.docker.ApiClient().ContainerExecResize(ctx, s.execId, container.ResizeOptions{
+				Height: uint(window.Rows),
+				Width:  uint(window.Columns),
+			})
+			if err != nil {
+				log.Debugf("Failed to resize SSH session of sandbox %s: %v", s.sandboxId, err)
+			}
+		}
+		return true
+	case "shell":
+		return s.start(ctx, shellCommand)
+	case "exec":
+		var exec execRequest
+		if ssh.Unmarshal(request.Payload, &exec) != nil {
+			return false
+		}
+		return s.start(ctx, []string{"sh", "-c", exec.Command})
+	case "subsystem":
+		var subsystem subsystemRequest
+		if ssh.Unmarshal(request.Payload, &subsystem) != nil || subsystem.Name != "sftp" {
+			return false
+		}
+		return s.start(ctx, sftpCommand)
+	default:
+		return false
+	}
+}
+
+// start creates the exec, the caller must hold the mutex
+func (s *session) start(ctx context.Context, cmd []string) bool {
+	if s.started {
+		return false
+	}
+
+	tty := s.pty != nil
+	env := s.env
+	var consoleSize *[2]uint
+	if tty {
+		env = append(env, "TERM="+s.pty.Term)
+		consoleSize = &[2]uint{uint(s.pty.Rows), uint(s.pty.Columns)}
+	}
+
+	// The exec holds a slot of the exec limit of the runner for as long as the session runs
+	release, err := s.server.docker.AcquireExec()
+	if err != nil {
+		fmt.Fprintf(s.channel.Stderr(), "Failed to start a session in sandbox %s: %v\r\n", s.sandboxId, err)
+		return false
+	}
+
+	apiClient := s.server.docker.ApiClient()
+
+	response, err := apiClient.ContainerExecCreate(ctx, s.sandboxId, container.ExecOptions{
+		Cmd:          cmd,
+		Env:          env,
+		Tty:          tty,
+		ConsoleSize:  consoleSize,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		log.Debugf("Failed to create SSH exec in sandbox %s: %v", s.sandboxId, err)
+		fmt.Fprintf(s.channel.Stderr(), "Failed to start a session in sandbox %s: %v\r\n", s.sandboxId, err)
+		release()
+		return false
+	}
+
+	attach, err := apiClient.ContainerExecAttach(ctx, response.ID, container.ExecStartOptions{
+		Tty:         tty,
+		ConsoleSize: consoleSize,
+	})
+	if err != nil {
+		log.Debugf("Failed to attach SSH exec in sandbox %s: %v", s.sandboxId, err)
+		release()
+		return false
+	}
+
+	s.started = true
+	s.execId = response.ID
+
+	go s.run(ctx, response.ID, attach, tty, release)
+
+	return true
+}
+
+// run pipes the exec streams through the channel and reports the exit status once the exec ends
+func (s *session) run(ctx context.Context, execId string, attach types.HijackedResponse, tty bool, release func()) {
+	defer release()
+	defer attach.Close()
+
+	go func() {
+		_, err := io.Copy(attach.Conn, s.channel)
+		if err != nil {
+			log.Debugf("SSH session stdin of sandbox %s closed: %v", s.sandboxId, err)
+		}
+		attach.CloseWrite()
+	}()
+
+	var err error
+	if tty {
+		_, err = io.Copy(s.channel, attach.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(s.channel, s.channel.Stderr(), attach.Reader)
+	}
+	if err != nil {
+		log.Debugf("SSH session output of sandbox %s closed: %v", s.sandboxId, err)
+	}
+
+	status := uint32(255)
+	inspect, err := s.server.docker.ApiClient().ContainerExecInspect(ctx, execId)
+	if err == nil {
+		status = uint32(inspect.ExitCode)
+	}
+
+	_, err = s.channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: status}))
+	if err != nil {
+		log.Debugf("Failed to send the exit status of SSH session of sandbox %s: %v", s.sandboxId, err)
+	}
+
+	s.channel.Close()
+}
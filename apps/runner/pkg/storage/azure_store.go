@@ -0,0 +1,284 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+)
+
+const (
+	azureApiVersion = "2021-08-06"
+	// Blobs larger than a block are uploaded in blocks since the size of streams is not known upfront
+	azureBlockSize = 8 * 1024 * 1024
+)
+
+// azureStore talks to the Azure Blob Storage REST API authenticated with the storage account shared key
+type azureStore struct {
+	client     *http.Client
+	endpoint   *url.URL
+	account    string
+	accountKey []byte
+}
+
+func newAzureStore(cfg *config.Config) (*azureStore, error) {
+	if cfg.AzureAccountName == "" || cfg.AzureAccountKey == "" {
+		return nil, errors.New("Azure storage account name and key are required")
+	}
+
+	accountKey, err := base64.StdEncoding.DecodeString(cfg.AzureAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure storage account key: %w", err)
+	}
+
+	rawEndpoint := cfg.AzureEndpointUrl
+	if rawEndpoint == "" {
+		rawEndpoint = fmt.Sprintf("https://%s.blob.core.windows.net", cfg.AzureAccountName)
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(rawEndpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure storage endpoint: %w", err)
+	}
+
+	return &azureStore{
+		client:     &http.Client{},
+		endpoint:   endpoint,
+		account:    cfg.AzureAccountName,
+		accountKey: accountKey,
+	}, nil
+}
+
+func (s *azureStore) PutObject(ctx context.Context, bucket string, key string, reader io.Reader, size int64) (string, error) {
+	block := make([]byte, azureBlockSize)
+
+	n, err := io.ReadFull(reader, block)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	// Small blobs are uploaded at once
+	if n < azureBlockSize {
+		header := http.Header{}
+		header.Set("x-ms-blob-type", "BlockBlob")
+
+		resp, err := s.do(ctx, http.MethodPut, s.blobUrl(bucket, key, nil), header, block[:n])
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+
+		return strings.Trim(resp.Header.Get("ETag"), `"`), nil
+	}
+
+	blockIds := make([]string, 0)
+	for n > 0 {
+		blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%010d", len(blockIds))))
+
+		resp, err := s.do(ctx, http.MethodPut, s.blobUrl(bucket, key, url.Values{"comp": {"block"}, "blockid": {blockId}}), http.Header{}, block[:n])
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+
+		blockIds = append(blockIds, blockId)
+
+		n, err = io.ReadFull(reader, block)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+	}
+
+	var blockList bytes.Buffer
+	blockList.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, blockId := range blockIds {
+		blockList.WriteString("<Latest>" + blockId + "</Latest>")
+	}
+	blockList.WriteString("</BlockList>")
+
+	resp, err := s.do(ctx, http.MethodPut, s.blobUrl(bucket, key, url.Values{"comp": {"blocklist"}}), http.Header{}, blockList.Bytes())
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	return strings.Trim(resp.Header.Get("ETag"), `"`), nil
+}
+
+func (s *azureStore) GetObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.blobUrl(bucket, key, nil), http.Header{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+type azureListBlobsResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			Etag          string `xml:"Etag"`
+			ContentLength int64  `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (s *azureStore) ListObjects(ctx context.Context, bucket string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		resp, err := s.do(ctx, http.MethodGet, s.blobUrl(bucket, "", query), http.Header{}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result azureListBlobsResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blob list: %w", err)
+		}
+
+		for _, blob := range result.Blobs {
+			lastModified, _ := time.Parse(http.TimeFormat, blob.Properties.LastModified)
+			objects = append(objects, ObjectInfo{
+				Key:          blob.Name,
+				Size:         blob.Properties.ContentLength,
+				ETag:         strings.Trim(blob.Properties.Etag, `"`),
+				LastModified: lastModified,
+			})
+		}
+
+		if result.NextMarker == "" {
+			return objects, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+func (s *azureStore) RemoveObject(ctx context.Context, bucket string, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.blobUrl(bucket, key, nil), http.Header{}, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *azureStore) blobUrl(container string, key string, query url.Values) *url.URL {
+	blobUrl := *s.endpoint
+	blobUrl.Path = s.endpoint.Path + "/" + container
+	if key != "" {
+		blobUrl.Path += "/" + key
+	}
+	blobUrl.RawQuery = query.Encode()
+
+	return &blobUrl
+}
+
+// do signs and sends the request, responses with an error status are turned into errors
+func (s *azureStore) do(ctx context.Context, method string, requestUrl *url.URL, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = header
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureApiVersion)
+	req.ContentLength = int64(len(body))
+
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", s.account, s.sign(req)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("azure blob storage request failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return resp, nil
+}
+
+// sign computes the shared key signature of the request
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (s *azureStore) sign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	msHeaders := make([]string, 0)
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name+":"+strings.Join(values, ","))
+		}
+	}
+	slices.Sort(msHeaders)
+
+	resource := "/" + s.account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	slices.Sort(params)
+	for _, name := range params {
+		values := query[name]
+		slices.Sort(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		strings.Join(msHeaders, "\n"),
+		resource,
+	}, "\n")
+
+	mac := hmac.New(sha256.New, s.accountKey)
+	mac.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
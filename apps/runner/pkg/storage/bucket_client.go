@@ -0,0 +1,83 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+)
+
+const CONTEXT_TAR_FILE_NAME = "context.tar"
+
+// bucketClient stores objects in the default bucket of the configured object store
+type bucketClient struct {
+	store      ObjectStore
+	bucketName string
+}
+
+var instance ObjectStorageClient
+
+func GetObjectStorageClient() (ObjectStorageClient, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	runnerConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if runnerConfig.StorageBucket == "" {
+		return nil, fmt.Errorf("missing object storage configuration - bucket name not provided")
+	}
+
+	store, err := NewObjectStore(runnerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	instance = &bucketClient{
+		store:      store,
+		bucketName: runnerConfig.StorageBucket,
+	}
+
+	return instance, nil
+}
+
+func (c *bucketClient) GetObject(ctx context.Context, organizationId, hash string) ([]byte, error) {
+	objectPath := fmt.Sprintf("%s/%s/%s", organizationId, hash, CONTEXT_TAR_FILE_NAME)
+	obj, err := c.store.GetObject(ctx, c.bucketName, objectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object from storage: %w", err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object data: %w", err)
+	}
+
+	return data, nil
+}
+
+func (c *bucketClient) PutObjectStream(ctx context.Context, objectPath string, reader io.Reader, size int64) error {
+	_, err := c.store.PutObject(ctx, c.bucketName, objectPath, reader, size)
+	if err != nil {
+		return fmt.Errorf("failed to put object to storage: %w", err)
+	}
+
+	return nil
+}
+
+func (c *bucketClient) GetObjectStream(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	obj, err := c.store.GetObject(ctx, c.bucketName, objectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object from storage: %w", err)
+	}
+
+	return obj, nil
+}
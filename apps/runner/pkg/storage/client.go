@@ -5,9 +5,64 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+)
+
+const (
+	ProviderS3    = "s3"
+	ProviderGCS   = "gcs"
+	ProviderAzure = "azure"
 )
 
+var ErrObjectStorageNotConfigured = errors.New("object storage is not configured")
+
 // ObjectStorageClient defines the interface for object storage operations
 type ObjectStorageClient interface {
 	GetObject(ctx context.Context, organizationId, hash string) ([]byte, error)
+	// PutObjectStream uploads the reader to the given object path, size can be -1 if unknown
+	PutObjectStream(ctx context.Context, objectPath string, reader io.Reader, size int64) error
+	GetObjectStream(ctx context.Context, objectPath string) (io.ReadCloser, error)
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// ObjectStore is implemented by every object storage backend. Buckets map to GCS buckets and Azure Blob containers.
+type ObjectStore interface {
+	// PutObject uploads the reader and returns the ETag of the object, size can be -1 if unknown
+	PutObject(ctx context.Context, bucket string, key string, reader io.Reader, size int64) (string, error)
+	GetObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	ListObjects(ctx context.Context, bucket string) ([]ObjectInfo, error)
+	RemoveObject(ctx context.Context, bucket string, key string) error
+}
+
+// NewObjectStore creates the object store of the configured provider
+func NewObjectStore(cfg *config.Config) (ObjectStore, error) {
+	switch cfg.StorageProvider {
+	case ProviderS3:
+		if cfg.AWSEndpointUrl == "" && cfg.AWSRegion == "" {
+			return nil, ErrObjectStorageNotConfigured
+		}
+		return newS3Store(s3StoreConfig{
+			endpoint:        cfg.AWSEndpointUrl,
+			region:          cfg.AWSRegion,
+			accessKeyId:     cfg.AWSAccessKeyId,
+			secretAccessKey: cfg.AWSSecretAccessKey,
+		})
+	case ProviderGCS:
+		return newGCSStore(cfg)
+	case ProviderAzure:
+		return newAzureStore(cfg)
+	}
+
+	return nil, fmt.Errorf("unknown object storage provider %s", cfg.StorageProvider)
 }
@@ -0,0 +1,127 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/daytonaio/runner/cmd/runner/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const defaultGCSEndpoint = "https://storage.googleapis.com"
+
+type s3StoreConfig struct {
+	endpoint        string
+	region          string
+	accessKeyId     string
+	secretAccessKey string
+}
+
+// s3Store works with AWS S3 and every MinIO compatible storage
+type s3Store struct {
+	client *minio.Client
+}
+
+func newS3Store(config s3StoreConfig) (*s3Store, error) {
+	endpoint := config.endpoint
+	useSSL := !strings.HasPrefix(endpoint, "http://")
+	endpoint = strings.TrimPrefix(endpoint, "http://")
+	endpoint = strings.TrimPrefix(endpoint, "https://")
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	if endpoint == "" {
+		if config.region == "" {
+			return nil, errors.New("either an S3 endpoint or an AWS region is required")
+		}
+		endpoint = fmt.Sprintf("s3.%s.amazonaws.com", config.region)
+	}
+
+	creds := credentials.NewIAM("")
+	if config.accessKeyId != "" && config.secretAccessKey != "" {
+		creds = credentials.NewStaticV4(config.accessKeyId, config.secretAccessKey, "")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: useSSL,
+		Region: config.region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return &s3Store{client: client}, nil
+}
+
+// newGCSStore uses the S3 interoperability API of Google Cloud Storage, authenticated with HMAC keys
+func newGCSStore(cfg *config.Config) (*s3Store, error) {
+	if cfg.GCSAccessKeyId == "" || cfg.GCSSecretAccessKey == "" {
+		return nil, errors.New("GCS HMAC access key ID and secret are required")
+	}
+
+	endpoint := cfg.GCSEndpointUrl
+	if endpoint == "" {
+		endpoint = defaultGCSEndpoint
+	}
+
+	return newS3Store(s3StoreConfig{
+		endpoint:        endpoint,
+		region:          "auto",
+		accessKeyId:     cfg.GCSAccessKeyId,
+		secretAccessKey: cfg.GCSSecretAccessKey,
+	})
+}
+
+func (s *s3Store) PutObject(ctx context.Context, bucket string, key string, reader io.Reader, size int64) (string, error) {
+	info, err := s.client.PutObject(ctx, bucket, key, reader, size, minio.PutObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return info.ETag, nil
+}
+
+func (s *s3Store) GetObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// GetObject is lazy, stat the object so missing objects fail here
+	_, err = obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *s3Store) ListObjects(ctx context.Context, bucket string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+	for object := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+
+		objects = append(objects, ObjectInfo{
+			Key:          object.Key,
+			Size:         object.Size,
+			ETag:         object.ETag,
+			LastModified: object.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
+func (s *s3Store) RemoveObject(ctx context.Context, bucket string, key string) error {
+	return s.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
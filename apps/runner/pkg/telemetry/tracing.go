@@ -0,0 +1,83 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/daytonaio/runner"
+
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+}
+
+// InitTracing registers a global OTLP tracer provider and W3C trace context propagation.
+// The exporter honours the standard OTEL_EXPORTER_OTLP_* environment variables for headers, TLS and timeouts.
+// The returned function flushes pending spans and must be called on shutdown.
+func InitTracing(ctx context.Context, config TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// StartSpan starts a span under the runner tracer, tagged with the sandbox ID when one is given
+func StartSpan(ctx context.Context, name string, sandboxId string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+	if sandboxId != "" {
+		span.SetAttributes(SandboxIdAttribute(sandboxId))
+	}
+
+	return ctx, span
+}
+
+func SandboxIdAttribute(sandboxId string) attribute.KeyValue {
+	return attribute.String("sandbox.id", sandboxId)
+}
+
+// RecordError marks the span as failed when err is set
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package volumesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daytonaio/runner/pkg/models/enums"
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+type SyncerConfig struct {
+	Store storage.ObjectStore
+}
+
+type SyncStatus struct {
+	State            enums.VolumeSyncState
+	Direction        enums.VolumeSyncDirection
+	TotalObjects     int
+	SyncedObjects    int
+	TransferredBytes int64
+	Error            string
+	StartedAt        time.Time
+	FinishedAt       *time.Time
+}
+
+// Syncer materializes volume buckets into local directories and syncs local changes back.
+// Every volume bucket is mirrored at its root, the same layout mount-s3 exposes for mounted volumes.
+type Syncer struct {
+	store storage.ObjectStore
+
+	statusMutex sync.RWMutex
+	statuses    map[string]*SyncStatus
+
+	volumeMutexesMutex sync.Mutex
+	volumeMutexes      map[string]*sync.Mutex
+}
+
+func NewSyncer(config SyncerConfig) (*Syncer, error) {
+	if config.Store == nil {
+		return nil, errors.New("an object store is required for volume sync")
+	}
+
+	return &Syncer{
+		store:         config.Store,
+		statuses:      make(map[string]*SyncStatus),
+		volumeMutexes: make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// Sync runs a sync of the bucket in the given direction and blocks until it finishes
+func (s *Syncer) Sync(ctx context.Context, bucket string, localDir string, direction enums.VolumeSyncDirection) error {
+	volumeMutex := s.getVolumeMutex(bucket)
+	volumeMutex.Lock()
+	defer volumeMutex.Unlock()
+
+	s.setStatus(bucket, &SyncStatus{
+		State:     enums.VolumeSyncStateInProgress,
+		Direction: direction,
+		StartedAt: time.Now(),
+	})
+
+	log.Infof("Syncing volume %s (%s) at %s", bucket, direction, localDir)
+
+	var err error
+	switch direction {
+	case enums.VolumeSyncDirectionPull:
+		err = s.pull(ctx, bucket, localDir)
+	case enums.VolumeSyncDirectionPush:
+		err = s.push(ctx, bucket, localDir)
+	default:
+		err = fmt.Errorf("invalid sync direction %s", direction)
+	}
+
+	s.updateStatus(bucket, func(status *SyncStatus) {
+		finishedAt := time.Now()
+		status.FinishedAt = &finishedAt
+		status.State = enums.VolumeSyncStateCompleted
+		if err != nil {
+			status.State = enums.VolumeSyncStateFailed
+			status.Error = err.Error()
+		}
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to sync volume %s: %w", bucket, err)
+	}
+
+	log.Infof("Volume %s synced (%s)", bucket, direction)
+
+	return nil
+}
+
+func (s *Syncer) GetStatus(bucket string) *SyncStatus {
+	s.statusMutex.RLock()
+	defer s.statusMutex.RUnlock()
+
+	status, ok := s.statuses[bucket]
+	if !ok {
+		return &SyncStatus{State: enums.VolumeSyncStateNone}
+	}
+
+	statusCopy := *status
+	return &statusCopy
+}
+
+func (s *Syncer) setStatus(bucket string, status *SyncStatus) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	s.statuses[bucket] = status
+}
+
+func (s *Syncer) updateStatus(bucket string, update func(status *SyncStatus)) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	if status, ok := s.statuses[bucket]; ok {
+		update(status)
+	}
+}
+
+func (s *Syncer) getVolumeMutex(bucket string) *sync.Mutex {
+	s.volumeMutexesMutex.Lock()
+	defer s.volumeMutexesMutex.Unlock()
+
+	volumeMutex, exists := s.volumeMutexes[bucket]
+	if !exists {
+		volumeMutex = &sync.Mutex{}
+		s.volumeMutexes[bucket] = volumeMutex
+	}
+
+	return volumeMutex
+}
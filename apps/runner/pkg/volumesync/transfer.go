@@ -0,0 +1,279 @@
+// Copyright 2025 Daytona Platforms Inc.
+// SPDX-License-Identifier: AGPL-3.0
+
+package volumesync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daytonaio/runner/internal/util"
+	"github.com/daytonaio/runner/pkg/storage"
+)
+
+// manifestEntry records the state of a file after its last successful sync.
+// It lets pulls keep local changes that were not pushed yet and lets pushes skip unchanged files.
+type manifestEntry struct {
+	ETag    string    `json:"etag"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+type manifest map[string]manifestEntry
+
+// The manifest lives next to the volume directory so it is never visible inside the sandbox
+func manifestPath(localDir string) string {
+	return filepath.Clean(localDir) + ".sync-manifest.json"
+}
+
+func readManifest(localDir string) (manifest, error) {
+	m := make(manifest)
+
+	raw, err := os.ReadFile(manifestPath(localDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sync manifest: %w", err)
+	}
+
+	return m, nil
+}
+
+func writeManifest(localDir string, m manifest) error {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(manifestPath(localDir), raw, 0600)
+}
+
+func (s *Syncer) pull(ctx context.Context, bucket string, localDir string) error {
+	err := os.MkdirAll(localDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	m, err := readManifest(localDir)
+	if err != nil {
+		return err
+	}
+
+	listedObjects, err := s.store.ListObjects(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	objects := make([]storage.ObjectInfo, 0, len(listedObjects))
+	for _, object := range listedObjects {
+		// Skip directory markers
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+		objects = append(objects, object)
+	}
+
+	s.updateStatus(bucket, func(status *SyncStatus) {
+		status.TotalObjects = len(objects)
+	})
+
+	remoteKeys := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		remoteKeys[object.Key] = true
+
+		localPath, err := resolveLocalPath(localDir, object.Key)
+		if err != nil {
+			return err
+		}
+
+		entry, tracked := m[object.Key]
+		if tracked && entry.ETag == object.ETag && fileExists(localPath) {
+			s.markSynced(bucket, 0)
+			continue
+		}
+
+		err = s.downloadFile(ctx, bucket, object.Key, localPath)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", object.Key, err)
+		}
+
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return err
+		}
+
+		m[object.Key] = manifestEntry{
+			ETag:    object.ETag,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}
+		s.markSynced(bucket, object.Size)
+	}
+
+	// Files that were synced before but no longer exist remotely were deleted upstream
+	for key := range m {
+		if remoteKeys[key] {
+			continue
+		}
+
+		localPath, err := resolveLocalPath(localDir, key)
+		if err == nil {
+			err = os.Remove(localPath)
+			if err != nil && !os.IsNotExist(err) {
+				log.Warnf("Failed to remove %s deleted from volume %s: %v", localPath, bucket, err)
+			}
+		}
+		delete(m, key)
+	}
+
+	return writeManifest(localDir, m)
+}
+
+func (s *Syncer) push(ctx context.Context, bucket string, localDir string) error {
+	m, err := readManifest(localDir)
+	if err != nil {
+		return err
+	}
+
+	localFiles := make(map[string]fs.FileInfo)
+	err = filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+
+		localFiles[filepath.ToSlash(relPath)] = info
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk volume directory: %w", err)
+	}
+
+	s.updateStatus(bucket, func(status *SyncStatus) {
+		status.TotalObjects = len(localFiles)
+	})
+
+	for key, info := range localFiles {
+		entry, tracked := m[key]
+		if tracked && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()) {
+			s.markSynced(bucket, 0)
+			continue
+		}
+
+		etag, err := s.uploadFile(ctx, bucket, key, filepath.Join(localDir, filepath.FromSlash(key)), info.Size())
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", key, err)
+		}
+
+		m[key] = manifestEntry{
+			ETag:    etag,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}
+		s.markSynced(bucket, info.Size())
+	}
+
+	// Files that were synced before but were deleted inside the sandbox
+	for key := range m {
+		if _, ok := localFiles[key]; ok {
+			continue
+		}
+
+		err := s.store.RemoveObject(ctx, bucket, key)
+		if err != nil {
+			return fmt.Errorf("failed to remove %s: %w", key, err)
+		}
+		delete(m, key)
+	}
+
+	return writeManifest(localDir, m)
+}
+
+// downloadFile writes the object next to the local path first so an interrupted download never replaces the file
+func (s *Syncer) downloadFile(ctx context.Context, bucket string, key string, localPath string) error {
+	reader, err := s.store.GetObject(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	err = os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	partPath := localPath + ".part"
+	file, err := os.Create(partPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, reader)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partPath)
+		return err
+	}
+
+	return os.Rename(partPath, localPath)
+}
+
+func (s *Syncer) uploadFile(ctx context.Context, bucket string, key string, localPath string, size int64) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return s.store.PutObject(ctx, bucket, key, file, size)
+}
+
+func (s *Syncer) markSynced(bucket string, transferredBytes int64) {
+	s.updateStatus(bucket, func(status *SyncStatus) {
+		status.SyncedObjects++
+		status.TransferredBytes += transferredBytes
+	})
+}
+
+// resolveLocalPath maps an object key into localDir, rejecting keys that would escape it
+func resolveLocalPath(localDir string, key string) (string, error) {
+	localPath := filepath.Join(localDir, filepath.FromSlash(key))
+	if !strings.HasPrefix(localPath, filepath.Clean(localDir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid object key %s", key)
+	}
+
+	return localPath, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}